@@ -8,15 +8,21 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/go-redis/redis/v8"
 	_ "github.com/lib/pq"
 	"go.uber.org/zap"
 
 	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/api"
 	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/config"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/notify"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
 	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/services"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/stream"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/pkg/firebase"
 	"github.com/gmsas95/blytz-mvp/shared/pkg/utils"
 )
 
@@ -42,11 +48,41 @@ func main() {
 	defer db.Close()
 
 	// Initialize services
-	auctionService := services.NewAuctionService(db, logger, cfg)
+	repo := repository.NewPostgresRepo(db, logger)
+	auctionService := services.NewAuctionService(repo, logger, cfg)
+	firebaseClient := firebase.NewClient(cfg.FirebaseFunctionsURL, logger)
+
+	// Notifications are queued in Postgres and delivered through the driver
+	driver, err := notify.New(cfg.NotificationDriver, firebaseClient, logger)
+	if err != nil {
+		logger.Fatal("Invalid notification driver", zap.Error(err))
+	}
+	notificationQueue := services.NewNotificationQueue(repo, driver, logger, cfg)
+
+	// Relay lifecycle events and run the scheduler in the background
+	eventRelay := services.NewEventRelay(repo, logger)
+	eventRelay.Subscribe(services.NewSettlementNotifier(notificationQueue, logger))
+	eventRelay.Subscribe(services.NewEndTimeBroadcaster(notificationQueue))
+	eventRelay.Subscribe(services.NewBidNotifier(notificationQueue))
+	eventRelay.Subscribe(services.NewWatcherAlerter(repo, notificationQueue, logger))
+	scheduler := services.NewLifecycleScheduler(auctionService, eventRelay, notificationQueue, logger, cfg)
+
+	// Fan stream events out across replicas through Redis
+	redisClient, err := newRedisClient(cfg)
+	if err != nil {
+		logger.Fatal("Invalid Redis configuration", zap.Error(err))
+	}
+	defer redisClient.Close()
+	streamHub := stream.NewHub(redisClient, logger)
+	eventRelay.Subscribe(streamHub.Publish)
+
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	go scheduler.Run(backgroundCtx)
+	go streamHub.Run(backgroundCtx)
 
 	// Create a new Gin router
 	fmt.Println("DEBUG: About to setup router")
-	router := api.SetupRouter(auctionService, logger, cfg)
+	router := api.SetupRouter(auctionService, streamHub, logger, cfg)
 	fmt.Println("DEBUG: Router setup completed")
 
 	// Create HTTP server
@@ -69,6 +105,8 @@ func main() {
 	<-quit
 
 	logger.Info("Shutting down server...")
+	// Stopping the hub also closes open streams so Shutdown can drain
+	stopBackground()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -79,3 +117,22 @@ func main() {
 
 	logger.Info("Server exited")
 }
+
+// newRedisClient accepts REDIS_URL either as a redis:// URL or a bare host:port
+func newRedisClient(cfg *config.Config) (*redis.Client, error) {
+	if strings.HasPrefix(cfg.RedisURL, "redis://") || strings.HasPrefix(cfg.RedisURL, "rediss://") {
+		opts, err := redis.ParseURL(cfg.RedisURL)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Password == "" {
+			opts.Password = cfg.RedisPassword
+		}
+		return redis.NewClient(opts), nil
+	}
+	return redis.NewClient(&redis.Options{
+		Addr:     cfg.RedisURL,
+		Password: cfg.RedisPassword,
+		DB:       0,
+	}), nil
+}
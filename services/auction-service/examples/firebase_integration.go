@@ -16,7 +16,7 @@ func main() {
 	ctx := context.Background()
 
 	// Create Firebase client
-	firebaseClient := firebase.NewClient(logger)
+	firebaseClient := firebase.NewClient("http://localhost:5001/demo-blytz-mvp/us-central1", logger)
 
 	fmt.Println("🚀 Firebase Integration Example")
 	fmt.Println("================================")
@@ -118,7 +118,7 @@ func main() {
 	fmt.Println("\n🎉 Integration example completed!")
 	fmt.Println("\n💡 Next steps:")
 	fmt.Println("1. Import firebase package in your handlers")
-	fmt.Println("2. Create firebase.NewClient(logger) in your service constructors")
+	fmt.Println("2. Create firebase.NewClient(cfg.FirebaseFunctionsURL, logger) in your service constructors")
 	fmt.Println("3. Call Firebase functions when Redis operations need persistence")
 	fmt.Println("4. Test with your existing auction service")
 }
\ No newline at end of file
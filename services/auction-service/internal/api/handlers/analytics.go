@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/utils"
+)
+
+// GetAuctionAnalytics reports bidding on one of the caller's auctions, as
+// JSON or, with format=csv, as a per-minute CSV
+func (h *AuctionHandler) GetAuctionAnalytics(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	window, err := parseAnalyticsRange(c)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	analytics, err := h.auctionService.GetAuctionAnalytics(c.Request.Context(), c.Param("id"), userID, window)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	if c.Query("format") != "csv" {
+		utils.SendSuccessResponse(c, http.StatusOK, analytics)
+		return
+	}
+
+	rows := [][]string{{"minute", "bids", "unique_bidders", "high_price"}}
+	for _, rate := range analytics.BidsPerMinute {
+		rows = append(rows, []string{
+			rate.Minute.Format(time.RFC3339),
+			strconv.Itoa(rate.Bids),
+			strconv.Itoa(rate.UniqueBidders),
+			rate.HighPrice.String(),
+		})
+	}
+	sendCSV(c, "auction-"+analytics.AuctionID+"-analytics.csv", rows)
+}
+
+// GetSellerAnalytics rolls up the caller's auctions ending in the date range,
+// as JSON or, with format=csv, as one CSV row per auction
+func (h *AuctionHandler) GetSellerAnalytics(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	window, err := parseAnalyticsRange(c)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	analytics, err := h.auctionService.GetSellerAnalytics(c.Request.Context(), userID, window)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	if c.Query("format") != "csv" {
+		utils.SendSuccessResponse(c, http.StatusOK, analytics)
+		return
+	}
+
+	rows := [][]string{{
+		"auction_id", "title", "type", "status", "outcome", "currency", "start_time", "end_time",
+		"starting_price", "reserve_price", "highest_bid", "hammer_price", "units_sold",
+		"total_bids", "unique_bidders", "reserve_met", "hammer_ratio",
+	}}
+	for _, a := range analytics.Auctions {
+		rows = append(rows, []string{
+			a.AuctionID, a.Title, a.Type, a.Status, a.Outcome, a.Currency,
+			a.StartTime.Format(time.RFC3339), a.EndTime.Format(time.RFC3339),
+			a.StartingPrice.String(), a.ReservePrice.String(), a.HighestBid.String(),
+			a.HammerPrice.String(), strconv.Itoa(a.UnitsSold),
+			strconv.Itoa(a.TotalBids), strconv.Itoa(a.UniqueBidders),
+			strconv.FormatBool(a.ReserveMet), strconv.FormatFloat(a.HammerRatio, 'f', -1, 64),
+		})
+	}
+	sendCSV(c, "seller-analytics.csv", rows)
+}
+
+// parseAnalyticsRange reads the from/to query parameters, as RFC 3339
+// timestamps or YYYY-MM-DD dates. A date-only "to" includes that whole day.
+func parseAnalyticsRange(c *gin.Context) (models.AnalyticsRange, error) {
+	var window models.AnalyticsRange
+	for param, target := range map[string]**time.Time{"from": &window.From, "to": &window.To} {
+		v := c.Query(param)
+		if v == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			if parsed, err = time.Parse("2006-01-02", v); err != nil {
+				return window, shared_errors.ValidationError("INVALID_DATE", fmt.Sprintf("%s must be a date (YYYY-MM-DD) or an RFC 3339 timestamp", param))
+			}
+			if param == "to" {
+				parsed = parsed.AddDate(0, 0, 1)
+			}
+		}
+		*target = &parsed
+	}
+	return window, nil
+}
+
+func sendCSV(c *gin.Context, filename string, rows [][]string) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	w.WriteAll(rows)
+}
@@ -1,15 +1,18 @@
 package handlers
 
 import (
-	"context"
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
 	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
 	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/services"
-	"github.com/gmsas95/blytz-mvp/services/auction-service/pkg/firebase"
 	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
 	"github.com/gmsas95/blytz-mvp/shared/pkg/utils"
 )
@@ -17,20 +20,54 @@ import (
 type AuctionHandler struct {
 	auctionService *services.AuctionService
 	logger         *zap.Logger
-	firebaseApp    firebase.FirebaseApp
 }
 
-func NewAuctionHandler(auctionService *services.AuctionService, logger *zap.Logger, firebaseApp firebase.FirebaseApp) *AuctionHandler {
-	return &AuctionHandler{auctionService: auctionService, logger: logger, firebaseApp: firebaseApp}
+func NewAuctionHandler(auctionService *services.AuctionService, logger *zap.Logger) *AuctionHandler {
+	return &AuctionHandler{auctionService: auctionService, logger: logger}
 }
 
 func (h *AuctionHandler) CreateAuction(c *gin.Context) {
-	var auction models.Auction
-	if err := c.ShouldBindJSON(&auction); err != nil {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	var req models.CreateAuctionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.SendErrorResponse(c, shared_errors.ErrInvalidRequestBody)
 		return
 	}
 
+	auction := models.Auction{
+		ProductID:       req.ProductID,
+		SellerID:        userID,
+		Title:           req.Title,
+		Description:     req.Description,
+		Currency:        req.Currency,
+		StartingPrice:   req.StartingPrice,
+		ReservePrice:    req.ReservePrice,
+		MinBidIncrement: req.MinBidIncrement,
+		BuyNowPrice:     req.BuyNowPrice,
+		PricingRule:     req.PricingRule,
+		StartTime:       req.StartTime,
+		EndTime:         req.EndTime,
+		Type:            req.Type,
+
+		SoftClose:          req.SoftClose,
+		SoftCloseWindow:    req.SoftCloseWindow,
+		SoftCloseExtension: req.SoftCloseExtension,
+		MaxExtensions:      req.MaxExtensions,
+
+		FloorPrice:   req.FloorPrice,
+		DropStep:     req.DropStep,
+		DropInterval: req.DropInterval,
+		Quantity:     req.Quantity,
+
+		DepositAmount: req.DepositAmount,
+		Images:        imagesFromRequest(req.Images),
+	}
+
 	if err := h.auctionService.CreateAuction(c.Request.Context(), &auction); err != nil {
 		utils.SendErrorResponse(c, err)
 		return
@@ -39,6 +76,15 @@ func (h *AuctionHandler) CreateAuction(c *gin.Context) {
 	utils.SendSuccessResponse(c, http.StatusCreated, auction)
 }
 
+// imagesFromRequest converts request images to a gallery in the order given
+func imagesFromRequest(images []models.ImageRequest) []models.AuctionImage {
+	gallery := make([]models.AuctionImage, len(images))
+	for i, image := range images {
+		gallery[i] = models.AuctionImage{ImageURL: image.ImageURL, AltText: image.AltText}
+	}
+	return gallery
+}
+
 func (h *AuctionHandler) GetAuction(c *gin.Context) {
 	id := c.Param("id")
 	auction, err := h.auctionService.GetAuction(c.Request.Context(), id)
@@ -50,45 +96,493 @@ func (h *AuctionHandler) GetAuction(c *gin.Context) {
 	utils.SendSuccessResponse(c, http.StatusOK, auction)
 }
 
+func (h *AuctionHandler) UpdateAuction(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	var req models.UpdateAuctionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, shared_errors.ErrInvalidRequestBody)
+		return
+	}
+
+	auction, err := h.auctionService.UpdateAuction(c.Request.Context(), c.Param("id"), userID, &req)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, auction)
+}
+
+// RelistAuction puts an unsold auction up again. The body is optional; by
+// default the new auction starts now.
+func (h *AuctionHandler) RelistAuction(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	var req models.RelistAuctionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.SendErrorResponse(c, shared_errors.ErrInvalidRequestBody)
+		return
+	}
+
+	auction, err := h.auctionService.RelistAuction(c.Request.Context(), c.Param("id"), userID, req.StartTime)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusCreated, auction)
+}
+
+// CancelAuction lets a seller withdraw their auction, optionally saying why
+func (h *AuctionHandler) CancelAuction(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	// The body is optional so existing clients can keep sending a bare DELETE
+	var req models.CancelAuctionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.SendErrorResponse(c, shared_errors.ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.auctionService.CancelAuction(c.Request.Context(), c.Param("id"), userID, req.Reason, false); err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, gin.H{"message": "Auction cancelled successfully"})
+}
+
+// AdminCancelAuction withdraws any seller's auction; a reason is required
+func (h *AuctionHandler) AdminCancelAuction(c *gin.Context) {
+	var req models.CancelAuctionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, shared_errors.ErrInvalidRequestBody)
+		return
+	}
+
+	if err := h.auctionService.CancelAuction(c.Request.Context(), c.Param("id"), c.GetString("userID"), req.Reason, true); err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, gin.H{"message": "Auction cancelled successfully"})
+}
+
+// GetAuditLog shows a seller the history of their auction
+func (h *AuctionHandler) GetAuditLog(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	entries, err := h.auctionService.GetAuditLog(c.Request.Context(), c.Param("id"), userID, false)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, models.AuditLogResponse{Entries: entries})
+}
+
+// AdminGetAuditLog shows the history of any auction
+func (h *AuctionHandler) AdminGetAuditLog(c *gin.Context) {
+	entries, err := h.auctionService.GetAuditLog(c.Request.Context(), c.Param("id"), c.GetString("userID"), true)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, models.AuditLogResponse{Entries: entries})
+}
+
+// RetractBid withdraws one of the caller's bids, subject to the retraction rules
+func (h *AuctionHandler) RetractBid(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	var req models.RetractBidRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, shared_errors.ErrInvalidRequestBody)
+		return
+	}
+
+	auction, err := h.auctionService.RetractBid(c.Request.Context(), c.Param("id"), c.Param("bidId"), userID, req.Reason)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, models.AuctionResponse{Auction: *auction})
+}
+
+func (h *AuctionHandler) GetAuctionStatus(c *gin.Context) {
+	status, err := h.auctionService.GetAuctionStatus(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, status)
+}
+
+func (h *AuctionHandler) GetBids(c *gin.Context) {
+	bids, err := h.auctionService.GetBids(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, models.BidsResponse{Bids: bids})
+}
+
+func (h *AuctionHandler) GetMyBids(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	bids, err := h.auctionService.GetMyBids(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, models.BidsResponse{Bids: bids})
+}
+
+// GetAuctionOrders shows the seller the orders created for their auction's sales
+func (h *AuctionHandler) GetAuctionOrders(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	sales, err := h.auctionService.GetAuctionOrders(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, gin.H{"orders": sales})
+}
+
+// GetSecondChanceOffer returns the second-chance offer the caller received, if any
+func (h *AuctionHandler) GetSecondChanceOffer(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	offer, err := h.auctionService.GetSecondChanceOffer(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, offer)
+}
+
+func (h *AuctionHandler) AcceptSecondChanceOffer(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	auction, err := h.auctionService.AcceptSecondChanceOffer(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, models.AuctionResponse{Auction: *auction})
+}
+
+func (h *AuctionHandler) DeclineSecondChanceOffer(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	if err := h.auctionService.DeclineSecondChanceOffer(c.Request.Context(), c.Param("id"), userID); err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, gin.H{"message": "Offer declined"})
+}
+
 func (h *AuctionHandler) PlaceBid(c *gin.Context) {
-	var bid models.Bid
-	if err := c.ShouldBindJSON(&bid); err != nil {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	var req models.PlaceBidRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.SendErrorResponse(c, shared_errors.ErrInvalidRequestBody)
 		return
 	}
 
-	bid.AuctionID = c.Param("id")
+	bid := models.Bid{
+		AuctionID: c.Param("id"),
+		BidderID:  userID,
+		Amount:    req.Amount,
+	}
 
 	if err := h.auctionService.PlaceBid(c.Request.Context(), &bid); err != nil {
 		utils.SendErrorResponse(c, err)
 		return
 	}
 
-	// Notify via Firebase asynchronously
-	go func() {
-		// Use a background context for the async operation
-		if err := h.firebaseApp.SendBidNotification(context.Background(), &bid); err != nil {
-			h.logger.Error("Failed to send bid notification", zap.Error(err))
+	utils.SendSuccessResponse(c, http.StatusCreated, bid)
+}
+
+func (h *AuctionHandler) BuyNow(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	bid, err := h.auctionService.BuyNow(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusCreated, bid)
+}
+
+func (h *AuctionHandler) ClaimDrop(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	var req models.ClaimDropRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.SendErrorResponse(c, shared_errors.ErrInvalidRequestBody)
+			return
 		}
-	}()
+	}
+
+	bid, err := h.auctionService.ClaimDrop(c.Request.Context(), c.Param("id"), userID, req.Quantity)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
 
 	utils.SendSuccessResponse(c, http.StatusCreated, bid)
 }
 
+func (h *AuctionHandler) SetProxyBid(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	var req models.SetProxyBidRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, shared_errors.ErrInvalidRequestBody)
+		return
+	}
+
+	resp, err := h.auctionService.SetProxyBid(c.Request.Context(), c.Param("id"), userID, req.MaxAmount)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, resp)
+}
+
+func (h *AuctionHandler) GetMyProxyBid(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	proxy, err := h.auctionService.GetProxyBid(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, proxy)
+}
+
+// PlaceDeposit holds the refundable deposit a flagged auction requires
+// before the caller can bid
+func (h *AuctionHandler) PlaceDeposit(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	var req models.PlaceDepositRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, shared_errors.ErrInvalidRequestBody)
+		return
+	}
+
+	deposit, err := h.auctionService.PlaceDeposit(c.Request.Context(), c.Param("id"), userID, &req)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, deposit)
+}
+
+func (h *AuctionHandler) GetMyDeposit(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	deposit, err := h.auctionService.GetBidDeposit(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, deposit)
+}
+
+func (h *AuctionHandler) WatchAuction(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	resp, err := h.auctionService.WatchAuction(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, resp)
+}
+
+func (h *AuctionHandler) UnwatchAuction(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	resp, err := h.auctionService.UnwatchAuction(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, resp)
+}
+
+func (h *AuctionHandler) GetWatchedAuctions(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	auctions, err := h.auctionService.GetWatchedAuctions(c.Request.Context(), userID)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, auctions)
+}
+
+// ListAuctions lists auctions with optional filters, a sort order and
+// cursor pagination
 func (h *AuctionHandler) ListAuctions(c *gin.Context) {
-	auctions, err := h.auctionService.ListAuctions(c.Request.Context())
+	filter := &models.AuctionFilter{
+		SellerID: c.Query("seller_id"),
+		Status:   c.Query("status"),
+		Type:     c.Query("type"),
+		Category: c.Query("category"),
+		Currency: strings.ToUpper(c.Query("currency")),
+		Sort:     c.Query("sort"),
+	}
+
+	if l := c.Query("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed < 1 {
+			utils.SendErrorResponse(c, shared_errors.ValidationError("INVALID_LIMIT", "Limit must be a positive number"))
+			return
+		}
+		filter.Limit = parsed
+	}
+
+	// Parse price range
+	for param, target := range map[string]*models.Money{"min_price": &filter.MinPrice, "max_price": &filter.MaxPrice} {
+		if v := c.Query(param); v != "" {
+			parsed, err := models.ParseMoney(v)
+			if err != nil || parsed < 0 {
+				utils.SendErrorResponse(c, shared_errors.ValidationError("INVALID_PRICE", param+" must be a non-negative number"))
+				return
+			}
+			*target = parsed
+		}
+	}
+
+	// Parse start/end window (RFC 3339)
+	for param, target := range map[string]**time.Time{
+		"starts_after":  &filter.StartsAfter,
+		"starts_before": &filter.StartsBefore,
+		"ends_after":    &filter.EndsAfter,
+		"ends_before":   &filter.EndsBefore,
+	} {
+		if v := c.Query(param); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				utils.SendErrorResponse(c, shared_errors.ValidationError("INVALID_TIME", param+" must be an RFC 3339 timestamp"))
+				return
+			}
+			*target = &parsed
+		}
+	}
+
+	resp, err := h.auctionService.ListAuctions(c.Request.Context(), filter, c.Query("cursor"))
 	if err != nil {
 		utils.SendErrorResponse(c, err)
 		return
 	}
 
-	utils.SendSuccessResponse(c, http.StatusOK, gin.H{
-		"items":      auctions,
-		"total":      len(auctions),
-		"page":       1,
-		"limit":      20,
-		"totalPages": 1,
-	})
+	utils.SendSuccessResponse(c, http.StatusOK, resp)
 }
 
 func (h *AuctionHandler) GetActiveAuctions(c *gin.Context) {
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/utils"
+)
+
+// DeviceIDHeader carries the client's device identifier
+const DeviceIDHeader = "X-Device-ID"
+
+// BidOriginMiddleware records the caller's IP address and device on the
+// request context, so bids and auctions they create keep it for fraud review.
+// It must run after the auth middleware.
+func BidOriginMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := models.WithBidOrigin(c.Request.Context(), models.BidOrigin{
+			UserID:    c.GetString("userID"),
+			IPAddress: c.ClientIP(),
+			DeviceID:  c.GetHeader(DeviceIDHeader),
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// GetFraudFlags lists the moderation queue, open flags by default
+func (h *AuctionHandler) GetFraudFlags(c *gin.Context) {
+	flags, err := h.auctionService.GetFraudFlags(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, models.FraudFlagsResponse{Flags: flags})
+}
+
+// ReviewFraudFlag dismisses or confirms a flag
+func (h *AuctionHandler) ReviewFraudFlag(c *gin.Context) {
+	var req models.ReviewFraudFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, shared_errors.ErrInvalidRequestBody)
+		return
+	}
+
+	flag, err := h.auctionService.ReviewFraudFlag(c.Request.Context(), c.Param("id"), c.GetString("userID"), req.Status, req.Note)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, flag)
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/utils"
+)
+
+// GetNotifications lists queued notifications, failed ones by default,
+// optionally for one user
+func (h *AuctionHandler) GetNotifications(c *gin.Context) {
+	notifications, err := h.auctionService.GetNotifications(c.Request.Context(), c.Query("status"), c.Query("user_id"))
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, models.NotificationsResponse{Notifications: notifications})
+}
+
+// RetryNotification queues a failed notification for delivery again
+func (h *AuctionHandler) RetryNotification(c *gin.Context) {
+	notificationID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.SendErrorResponse(c, shared_errors.ValidationError("INVALID_NOTIFICATION_ID", "Notification ID must be a number"))
+		return
+	}
+
+	if err := h.auctionService.RetryNotification(c.Request.Context(), notificationID); err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, gin.H{"message": "Notification queued for retry"})
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/services"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/utils"
+)
+
+type ShowHandler struct {
+	auctionService *services.AuctionService
+	logger         *zap.Logger
+}
+
+func NewShowHandler(auctionService *services.AuctionService, logger *zap.Logger) *ShowHandler {
+	return &ShowHandler{auctionService: auctionService, logger: logger}
+}
+
+// CreateShow opens a show hosted by the caller
+func (h *ShowHandler) CreateShow(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	var req models.CreateShowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, shared_errors.ErrInvalidRequestBody)
+		return
+	}
+
+	show := &models.Show{
+		HostID:      userID,
+		Title:       req.Title,
+		Description: req.Description,
+		LotDuration: req.LotDuration,
+		Currency:    req.Currency,
+	}
+	if err := h.auctionService.CreateShow(c.Request.Context(), show); err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusCreated, models.ShowResponse{Show: *show})
+}
+
+// GetShow returns a show with its lot queue and current lot
+func (h *ShowHandler) GetShow(c *gin.Context) {
+	show, err := h.auctionService.GetShow(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, models.ShowResponse{Show: *show})
+}
+
+// AddLot appends a lot to the show's queue
+func (h *ShowHandler) AddLot(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	var req models.AddLotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, shared_errors.ErrInvalidRequestBody)
+		return
+	}
+
+	lot := &models.ShowLot{
+		ProductID:       req.ProductID,
+		Title:           req.Title,
+		Description:     req.Description,
+		StartingPrice:   req.StartingPrice,
+		ReservePrice:    req.ReservePrice,
+		MinBidIncrement: req.MinBidIncrement,
+		BuyNowPrice:     req.BuyNowPrice,
+		Duration:        req.Duration,
+	}
+	show, err := h.auctionService.AddLot(c.Request.Context(), c.Param("id"), userID, lot)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusCreated, models.ShowResponse{Show: *show})
+}
+
+// RemoveLot takes a queued lot out of the show
+func (h *ShowHandler) RemoveLot(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	show, err := h.auctionService.RemoveLot(c.Request.Context(), c.Param("id"), c.Param("lotId"), userID)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, models.ShowResponse{Show: *show})
+}
+
+// AdvanceShow brings up the next lot, starting or ending the show as needed
+func (h *ShowHandler) AdvanceShow(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	show, err := h.auctionService.AdvanceShow(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, models.ShowResponse{Show: *show})
+}
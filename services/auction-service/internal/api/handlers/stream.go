@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/services"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/stream"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/utils"
+)
+
+const (
+	streamHeartbeat    = 25 * time.Second
+	streamWriteTimeout = 10 * time.Second
+	// showPollInterval is how often a show stream checks whether the host
+	// has brought up the first lot
+	showPollInterval = 3 * time.Second
+)
+
+type StreamHandler struct {
+	auctionService *services.AuctionService
+	hub            *stream.Hub
+	logger         *zap.Logger
+	upgrader       websocket.Upgrader
+}
+
+func NewStreamHandler(auctionService *services.AuctionService, hub *stream.Hub, logger *zap.Logger) *StreamHandler {
+	return &StreamHandler{
+		auctionService: auctionService,
+		hub:            hub,
+		logger:         logger,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				return true // Allow all origins for now
+			},
+		},
+	}
+}
+
+// eventSink writes events and keep-alives to one connected client
+type eventSink interface {
+	send(event *models.AuctionEvent) error
+	heartbeat() error
+	closed() <-chan struct{}
+}
+
+// Stream pushes an auction's events over WebSocket, or Server-Sent Events for
+// plain HTTP clients. Clients resume by passing the last event ID they saw in
+// the Last-Event-ID header or the last_event_id query parameter.
+func (h *StreamHandler) Stream(c *gin.Context) {
+	auctionID := c.Param("id")
+	if _, err := h.auctionService.GetAuction(c.Request.Context(), auctionID); err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	cursor, err := parseStreamCursor(c)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+	if cursor.AuctionID != "" && cursor.AuctionID != auctionID {
+		utils.SendErrorResponse(c, shared_errors.ValidationError("INVALID_LAST_EVENT_ID", "Last event ID belongs to another auction"))
+		return
+	}
+
+	// Subscribe before replaying so nothing committed in between is missed;
+	// duplicates are filtered by sequence
+	sub := h.hub.Subscribe(auctionID)
+	defer sub.Close()
+
+	var sink eventSink
+	if websocket.IsWebSocketUpgrade(c.Request) {
+		conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			h.logger.Error("Failed to upgrade auction stream", zap.Error(err))
+			return
+		}
+		defer conn.Close()
+		sink = newWebSocketSink(conn)
+	} else {
+		sink = newSSESink(c)
+	}
+
+	if _, err := h.serve(c, sink, sub, auctionID, cursor.Sequence, false); err != nil {
+		h.logger.Debug("Auction stream closed", zap.String("auction_id", auctionID), zap.Error(err))
+	}
+}
+
+// ShowStream follows a show from lot to lot. It streams the current lot
+// auction's events and moves to the next lot's auction on lot_advanced.
+// Event IDs name their auction, so a resumed stream picks up on the lot the
+// client last saw and follows the chain from there.
+func (h *StreamHandler) ShowStream(c *gin.Context) {
+	showID := c.Param("id")
+	if _, err := h.auctionService.GetShow(c.Request.Context(), showID); err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	cursor, err := parseStreamCursor(c)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+	if cursor.AuctionID != "" {
+		auction, err := h.auctionService.GetAuction(c.Request.Context(), cursor.AuctionID)
+		if err != nil || auction.ShowID != showID {
+			utils.SendErrorResponse(c, shared_errors.ValidationError("INVALID_LAST_EVENT_ID", "Last event ID belongs to another show"))
+			return
+		}
+	}
+
+	var sink eventSink
+	if websocket.IsWebSocketUpgrade(c.Request) {
+		conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			h.logger.Error("Failed to upgrade show stream", zap.Error(err))
+			return
+		}
+		defer conn.Close()
+		sink = newWebSocketSink(conn)
+	} else {
+		sink = newSSESink(c)
+	}
+
+	if err := h.followShow(c, sink, showID, cursor); err != nil {
+		h.logger.Debug("Show stream closed", zap.String("show_id", showID), zap.Error(err))
+	}
+}
+
+func (h *StreamHandler) followShow(c *gin.Context, sink eventSink, showID string, cursor streamCursor) error {
+	ctx := c.Request.Context()
+
+	// Resume on the lot the client last saw, or wait for the host to bring
+	// up the first lot
+	auctionID, lastSequence := cursor.AuctionID, cursor.Sequence
+	for auctionID == "" {
+		show, err := h.auctionService.GetShow(ctx, showID)
+		if err != nil {
+			return err
+		}
+		if show.Status == models.ShowEnded {
+			return nil
+		}
+		if auctionID = show.CurrentAuctionID; auctionID != "" {
+			break
+		}
+		if err := sink.heartbeat(); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sink.closed():
+			return nil
+		case <-time.After(showPollInterval):
+		}
+	}
+
+	for {
+		sub := h.hub.Subscribe(auctionID)
+		advanced, err := h.serve(c, sink, sub, auctionID, lastSequence, true)
+		sub.Close()
+		if err != nil || advanced == nil {
+			return err
+		}
+
+		var payload models.LotAdvancedPayload
+		if err := json.Unmarshal(advanced.Payload, &payload); err != nil {
+			return err
+		}
+		if payload.ShowEnded || payload.NextAuctionID == "" {
+			return nil
+		}
+		auctionID, lastSequence = payload.NextAuctionID, 0
+	}
+}
+
+// serve streams an auction's events until the client goes away. When
+// followLots is set it also returns after sending a lot_advanced event, and
+// returns that event.
+func (h *StreamHandler) serve(c *gin.Context, sink eventSink, sub *stream.Subscription, auctionID string, lastSequence int64, followLots bool) (*models.AuctionEvent, error) {
+	ctx := c.Request.Context()
+
+	for {
+		backlog, err := h.auctionService.GetEventsAfter(ctx, auctionID, lastSequence)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range backlog {
+			if stream.Streamed(event.Type) {
+				if err := sink.send(event); err != nil {
+					return nil, err
+				}
+			}
+			lastSequence = event.Sequence
+			if followLots && event.Type == models.EventLotAdvanced {
+				return event, nil
+			}
+		}
+		if len(backlog) == 0 {
+			break
+		}
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, nil
+		case <-sink.closed():
+			return nil, nil
+		case event, ok := <-sub.Events:
+			if !ok {
+				// Fell behind or shutting down; the client reconnects and resumes
+				return nil, nil
+			}
+			// The relay publishes an auction's events in sequence order, so
+			// anything at or below the cursor was already replayed
+			if event.Sequence <= lastSequence {
+				continue
+			}
+			if err := sink.send(event); err != nil {
+				return nil, err
+			}
+			lastSequence = event.Sequence
+			if followLots && event.Type == models.EventLotAdvanced {
+				return event, nil
+			}
+		case <-heartbeat.C:
+			if err := sink.heartbeat(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// streamCursor is the last event a client saw: its auction and sequence.
+// Event IDs on the wire are <auction_id>:<sequence>; a bare sequence refers to
+// the auction being streamed.
+type streamCursor struct {
+	AuctionID string
+	Sequence  int64
+}
+
+func parseStreamCursor(c *gin.Context) (streamCursor, error) {
+	raw := c.GetHeader("Last-Event-ID")
+	if raw == "" {
+		raw = c.Query("last_event_id")
+	}
+	if raw == "" {
+		return streamCursor{}, nil
+	}
+
+	var cursor streamCursor
+	sequence := raw
+	if i := strings.LastIndex(raw, ":"); i >= 0 {
+		cursor.AuctionID, sequence = raw[:i], raw[i+1:]
+	}
+	seq, err := strconv.ParseInt(sequence, 10, 64)
+	if err != nil || seq < 0 || (cursor.AuctionID == "" && sequence != raw) {
+		return streamCursor{}, shared_errors.ValidationError("INVALID_LAST_EVENT_ID", "Last event ID must be <auction_id>:<sequence> or a sequence number")
+	}
+	cursor.Sequence = seq
+	return cursor, nil
+}
+
+// streamEventID is the SSE event ID clients hand back to resume
+func streamEventID(event *models.AuctionEvent) string {
+	return fmt.Sprintf("%s:%d", event.AuctionID, event.Sequence)
+}
+
+type sseSink struct {
+	c *gin.Context
+}
+
+func newSSESink(c *gin.Context) *sseSink {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	fmt.Fprint(c.Writer, "retry: 3000\n\n")
+	c.Writer.Flush()
+	return &sseSink{c: c}
+}
+
+func (s *sseSink) send(event *models.AuctionEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(s.c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", streamEventID(event), event.Type, data); err != nil {
+		return err
+	}
+	s.c.Writer.Flush()
+	return nil
+}
+
+func (s *sseSink) heartbeat() error {
+	if _, err := fmt.Fprint(s.c.Writer, ": ping\n\n"); err != nil {
+		return err
+	}
+	s.c.Writer.Flush()
+	return nil
+}
+
+func (s *sseSink) closed() <-chan struct{} {
+	return s.c.Request.Context().Done()
+}
+
+type webSocketSink struct {
+	conn *websocket.Conn
+	done chan struct{}
+}
+
+func newWebSocketSink(conn *websocket.Conn) *webSocketSink {
+	sink := &webSocketSink{conn: conn, done: make(chan struct{})}
+
+	// The stream is one-way; reading only notices the client going away
+	go func() {
+		defer close(sink.done)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+	return sink
+}
+
+func (s *webSocketSink) send(event *models.AuctionEvent) error {
+	s.conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	return s.conn.WriteJSON(event)
+}
+
+func (s *webSocketSink) heartbeat() error {
+	return s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteTimeout))
+}
+
+func (s *webSocketSink) closed() <-chan struct{} {
+	return s.done
+}
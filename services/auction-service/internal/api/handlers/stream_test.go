@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/config"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/services"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/stream"
+)
+
+func TestParseStreamCursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name    string
+		header  string
+		query   string
+		want    streamCursor
+		wantErr bool
+	}{
+		{name: "none"},
+		{name: "header", header: "auction-1:7", want: streamCursor{AuctionID: "auction-1", Sequence: 7}},
+		{name: "query", query: "auction-1:7", want: streamCursor{AuctionID: "auction-1", Sequence: 7}},
+		{name: "header wins", header: "auction-1:7", query: "auction-1:3", want: streamCursor{AuctionID: "auction-1", Sequence: 7}},
+		{name: "bare sequence", header: "12", want: streamCursor{Sequence: 12}},
+		{name: "empty auction", header: ":12", wantErr: true},
+		{name: "negative sequence", header: "auction-1:-1", wantErr: true},
+		{name: "not a number", header: "auction-1:latest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/stream?last_event_id="+tt.query, nil)
+			if tt.header != "" {
+				c.Request.Header.Set("Last-Event-ID", tt.header)
+			}
+			got, err := parseStreamCursor(c)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("cursor %+v, want error", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("cursor %+v, %v, want %+v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamReplaysFromLastEventID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	cfg, _ := config.Load()
+	repo := repository.NewMemoryRepo()
+	service := services.NewAuctionService(repo, zap.NewNop(), cfg)
+	auction := &models.Auction{
+		SellerID:      "seller-1",
+		ProductID:     "product-1",
+		Title:         "Lamp",
+		Category:      "home",
+		Images:        []models.AuctionImage{{ImageURL: "https://example.com/lamp.jpg"}},
+		StartingPrice: 1000,
+	}
+	if err := service.CreateAuction(ctx, auction); err != nil {
+		t.Fatalf("create auction: %v", err)
+	}
+	for _, bid := range []*models.Bid{
+		{AuctionID: auction.AuctionID, BidderID: "alice", Amount: 1000},
+		{AuctionID: auction.AuctionID, BidderID: "bob", Amount: 1500},
+	} {
+		if err := service.PlaceBid(ctx, bid); err != nil {
+			t.Fatalf("bid: %v", err)
+		}
+	}
+	events, err := repo.GetEventsAfter(ctx, auction.AuctionID, 0, 100)
+	if err != nil || len(events) < 2 {
+		t.Fatalf("recorded %d events: %v", len(events), err)
+	}
+	cursor := events[0]
+
+	router := gin.New()
+	router.GET("/auctions/:id/stream", NewStreamHandler(service, stream.NewHub(nil, zap.NewNop()), zap.NewNop()).Stream)
+	reqCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/auctions/"+auction.AuctionID+"/stream", nil).WithContext(reqCtx)
+	req.Header.Set("Last-Event-ID", streamEventID(cursor))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("content type %q", ct)
+	}
+	body := w.Body.String()
+	if strings.Contains(body, fmt.Sprintf("id: %s\n", streamEventID(cursor))) {
+		t.Errorf("replayed the event the client already saw:\n%s", body)
+	}
+	for _, event := range events[1:] {
+		id := fmt.Sprintf("id: %s\nevent: %s\n", streamEventID(event), event.Type)
+		if stream.Streamed(event.Type) != strings.Contains(body, id) {
+			t.Errorf("%s event %s streamed %v:\n%s", event.Type, streamEventID(event), !stream.Streamed(event.Type), body)
+		}
+	}
+
+	// A cursor from another auction is rejected
+	req = httptest.NewRequest(http.MethodGet, "/auctions/"+auction.AuctionID+"/stream?last_event_id=other:3", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("foreign cursor answered %d, want 400", w.Code)
+	}
+}
@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/services"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/utils"
+)
+
+type TemplateHandler struct {
+	auctionService *services.AuctionService
+	logger         *zap.Logger
+}
+
+func NewTemplateHandler(auctionService *services.AuctionService, logger *zap.Logger) *TemplateHandler {
+	return &TemplateHandler{auctionService: auctionService, logger: logger}
+}
+
+// CreateTemplate saves auction settings the caller can list again later
+func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	var req models.SaveTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, shared_errors.ErrInvalidRequestBody)
+		return
+	}
+
+	template := templateFromRequest(userID, &req)
+	if err := h.auctionService.CreateTemplate(c.Request.Context(), template); err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusCreated, models.TemplateResponse{Template: *template})
+}
+
+// GetTemplates lists the caller's templates
+func (h *TemplateHandler) GetTemplates(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	templates, err := h.auctionService.GetSellerTemplates(c.Request.Context(), userID)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, models.TemplatesResponse{Templates: templates})
+}
+
+// GetTemplate returns one of the caller's templates
+func (h *TemplateHandler) GetTemplate(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	template, err := h.auctionService.GetTemplate(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, models.TemplateResponse{Template: *template})
+}
+
+// UpdateTemplate replaces a template's settings
+func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	var req models.SaveTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, shared_errors.ErrInvalidRequestBody)
+		return
+	}
+
+	template, err := h.auctionService.UpdateTemplate(c.Request.Context(), c.Param("id"), userID, templateFromRequest(userID, &req))
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, models.TemplateResponse{Template: *template})
+}
+
+// DeleteTemplate removes a template and stops its schedules
+func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	if err := h.auctionService.DeleteTemplate(c.Request.Context(), c.Param("id"), userID); err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, gin.H{"message": "Template deleted"})
+}
+
+// CreateAuction lists an auction from a template. The body is optional.
+func (h *TemplateHandler) CreateAuction(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	var req models.CreateFromTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.SendErrorResponse(c, shared_errors.ErrInvalidRequestBody)
+		return
+	}
+
+	auction, err := h.auctionService.CreateAuctionFromTemplate(c.Request.Context(), c.Param("id"), userID, req.StartTime)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusCreated, auction)
+}
+
+// CreateSchedule repeats a template on a weekly schedule
+func (h *TemplateHandler) CreateSchedule(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	var req models.CreateScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, shared_errors.ErrInvalidRequestBody)
+		return
+	}
+
+	schedule, err := h.auctionService.CreateSchedule(c.Request.Context(), c.Param("id"), userID, &req)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusCreated, models.ScheduleResponse{Schedule: *schedule})
+}
+
+// GetSchedules lists a template's schedules
+func (h *TemplateHandler) GetSchedules(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	schedules, err := h.auctionService.GetTemplateSchedules(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, models.SchedulesResponse{Schedules: schedules})
+}
+
+// DeleteSchedule stops a schedule
+func (h *TemplateHandler) DeleteSchedule(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.SendErrorResponse(c, shared_errors.ErrUnauthorized)
+		return
+	}
+
+	if err := h.auctionService.DeleteSchedule(c.Request.Context(), c.Param("id"), c.Param("scheduleId"), userID); err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, gin.H{"message": "Schedule deleted"})
+}
+
+func templateFromRequest(sellerID string, req *models.SaveTemplateRequest) *models.AuctionTemplate {
+	return &models.AuctionTemplate{
+		SellerID:        sellerID,
+		Name:            req.Name,
+		ProductID:       req.ProductID,
+		Title:           req.Title,
+		Description:     req.Description,
+		Currency:        req.Currency,
+		Type:            req.Type,
+		PricingRule:     req.PricingRule,
+		StartingPrice:   req.StartingPrice,
+		ReservePrice:    req.ReservePrice,
+		MinBidIncrement: req.MinBidIncrement,
+		BuyNowPrice:     req.BuyNowPrice,
+		DepositAmount:   req.DepositAmount,
+		Duration:        req.Duration,
+
+		SoftClose:          req.SoftClose,
+		SoftCloseWindow:    req.SoftCloseWindow,
+		SoftCloseExtension: req.SoftCloseExtension,
+		MaxExtensions:      req.MaxExtensions,
+
+		FloorPrice:   req.FloorPrice,
+		DropStep:     req.DropStep,
+		DropInterval: req.DropInterval,
+		Quantity:     req.Quantity,
+
+		Images: imagesFromRequest(req.Images),
+	}
+}
@@ -1,16 +1,22 @@
 package api
 
 import (
+	"context"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/api/handlers"
 	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/config"
 	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/services"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/stream"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/auth"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
 )
 
-func SetupRouter(auctionService *services.AuctionService, logger *zap.Logger, cfg *config.Config) *gin.Engine {
+func SetupRouter(auctionService *services.AuctionService, streamHub *stream.Hub, logger *zap.Logger, cfg *config.Config) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 
@@ -18,7 +24,7 @@ func SetupRouter(auctionService *services.AuctionService, logger *zap.Logger, cf
 	router.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, Last-Event-ID, X-Device-ID")
 
 		if c.Request.Method == "OPTIONS" {
 			c.Status(200)
@@ -33,52 +39,14 @@ func SetupRouter(auctionService *services.AuctionService, logger *zap.Logger, cf
 	}))
 	router.Use(gin.Recovery())
 
-	// Mock auctions endpoint for frontend
-	router.GET("/", func(c *gin.Context) {
-		auctions := []gin.H{
-			{
-				"id":          1,
-				"title":       "Vintage Leather Handbag",
-				"description": "Authentic vintage leather handbag with gold hardware. Perfect condition with original dust bag.",
-				"image":       "https://images.unsplash.com/photo-1553062407-98eeb64c6a62?w=400&h=400&fit=crop",
-				"current_bid": 275.00,
-				"status":      "live",
-				"end_time":    "2024-12-31T23:59:59Z",
-				"bids_count":  12,
-				"watchers":    8,
-				"min_bid":     10.00,
-				"seller": gin.H{
-					"name":   "JStyle Boutique",
-					"rating": 4.8,
-					"avatar": "S",
-				},
-			},
-			{
-				"id":          2,
-				"title":       "Smart Home Security Camera",
-				"description": "4K wireless security camera with night vision, motion detection, and cloud storage.",
-				"image":       "https://images.unsplash.com/photo-1558089687-f282ffcbc126?w=400&h=400&fit=crop",
-				"current_bid": 185.50,
-				"status":      "scheduled",
-				"end_time":    "2024-12-31T23:59:59Z",
-				"bids_count":  0,
-				"watchers":    0,
-				"min_bid":     5.00,
-				"seller": gin.H{
-					"name":   "TechHub Deals",
-					"rating": 4.6,
-					"avatar": "M",
-				},
-			},
-		}
+	// Initialize auth client
+	authClient := auth.NewAuthClient(cfg.AuthServiceURL)
 
-		c.JSON(200, gin.H{
-			"auctions": auctions,
-			"total":    len(auctions),
-			"page":     1,
-			"limit":    10,
-		})
-	})
+	// Initialize handlers
+	auctionHandler := handlers.NewAuctionHandler(auctionService, logger)
+	streamHandler := handlers.NewStreamHandler(auctionService, streamHub, logger)
+	showHandler := handlers.NewShowHandler(auctionService, logger)
+	templateHandler := handlers.NewTemplateHandler(auctionService, logger)
 
 	// Comprehensive health check endpoint
 	router.GET("/health", func(c *gin.Context) {
@@ -87,13 +55,119 @@ func SetupRouter(auctionService *services.AuctionService, logger *zap.Logger, cf
 			"service":   "auction",
 			"timestamp": time.Now().Unix(),
 			"version":   "v1.0.0",
-			"checks": gin.H{
-				"database": "connected",
-				"redis":    "connected",
-			},
 		}
-		c.JSON(200, health)
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
+		defer cancel()
+		if err := auctionService.Ping(ctx); err != nil {
+			logger.Error("Database health check failed", zap.Error(err))
+			health["status"] = "degraded"
+			health["checks"] = gin.H{"database": "disconnected"}
+			c.JSON(http.StatusServiceUnavailable, health)
+			return
+		}
+
+		health["checks"] = gin.H{"database": "connected"}
+		c.JSON(http.StatusOK, health)
 	})
 
+	// API routes
+	api := router.Group("/api/v1")
+	{
+		// Public routes (no authentication required)
+		public := api.Group("/auctions")
+		{
+			public.GET("", auctionHandler.ListAuctions)
+			public.GET("/active", auctionHandler.GetActiveAuctions)
+			public.GET("/:id", auctionHandler.GetAuction)
+			public.GET("/:id/status", auctionHandler.GetAuctionStatus)
+			public.GET("/:id/bids", auctionHandler.GetBids)
+			public.GET("/:id/stream", streamHandler.Stream)
+		}
+
+		// Protected routes (authentication required)
+		protected := api.Group("/auctions")
+		protected.Use(auth.GinAuthMiddleware(authClient), handlers.BidOriginMiddleware())
+		{
+			protected.POST("", auctionHandler.CreateAuction)
+			protected.GET("/watched", auctionHandler.GetWatchedAuctions)
+			protected.GET("/analytics", auctionHandler.GetSellerAnalytics)
+			protected.PUT("/:id", auctionHandler.UpdateAuction)
+			protected.DELETE("/:id", auctionHandler.CancelAuction)
+			protected.POST("/:id/relist", auctionHandler.RelistAuction)
+			protected.POST("/:id/bids", auctionHandler.PlaceBid)
+			protected.GET("/:id/bids/me", auctionHandler.GetMyBids)
+			protected.GET("/:id/orders", auctionHandler.GetAuctionOrders)
+			protected.GET("/:id/second-chance", auctionHandler.GetSecondChanceOffer)
+			protected.POST("/:id/second-chance/accept", auctionHandler.AcceptSecondChanceOffer)
+			protected.POST("/:id/second-chance/decline", auctionHandler.DeclineSecondChanceOffer)
+			protected.POST("/:id/proxy-bids", auctionHandler.SetProxyBid)
+			protected.POST("/:id/claim", auctionHandler.ClaimDrop)
+			protected.POST("/:id/buy-now", auctionHandler.BuyNow)
+			protected.POST("/:id/watch", auctionHandler.WatchAuction)
+			protected.DELETE("/:id/watch", auctionHandler.UnwatchAuction)
+			protected.GET("/:id/proxy-bids/me", auctionHandler.GetMyProxyBid)
+			protected.POST("/:id/deposit", auctionHandler.PlaceDeposit)
+			protected.GET("/:id/deposit", auctionHandler.GetMyDeposit)
+			protected.POST("/:id/bids/:bidId/retract", auctionHandler.RetractBid)
+			protected.GET("/:id/audit", auctionHandler.GetAuditLog)
+			protected.GET("/:id/analytics", auctionHandler.GetAuctionAnalytics)
+		}
+
+		// Live shows: a host runs a queue of lots as back-to-back auctions
+		publicShows := api.Group("/shows")
+		{
+			publicShows.GET("/:id", showHandler.GetShow)
+			publicShows.GET("/:id/stream", streamHandler.ShowStream)
+		}
+
+		shows := api.Group("/shows")
+		shows.Use(auth.GinAuthMiddleware(authClient), handlers.BidOriginMiddleware())
+		{
+			shows.POST("", showHandler.CreateShow)
+			shows.POST("/:id/lots", showHandler.AddLot)
+			shows.DELETE("/:id/lots/:lotId", showHandler.RemoveLot)
+			shows.POST("/:id/advance", showHandler.AdvanceShow)
+		}
+
+		// Templates: saved auction settings a seller lists again by hand or
+		// on a recurring schedule
+		templates := api.Group("/auction-templates")
+		templates.Use(auth.GinAuthMiddleware(authClient), handlers.BidOriginMiddleware())
+		{
+			templates.POST("", templateHandler.CreateTemplate)
+			templates.GET("", templateHandler.GetTemplates)
+			templates.GET("/:id", templateHandler.GetTemplate)
+			templates.PUT("/:id", templateHandler.UpdateTemplate)
+			templates.DELETE("/:id", templateHandler.DeleteTemplate)
+			templates.POST("/:id/auctions", templateHandler.CreateAuction)
+			templates.POST("/:id/schedules", templateHandler.CreateSchedule)
+			templates.GET("/:id/schedules", templateHandler.GetSchedules)
+			templates.DELETE("/:id/schedules/:scheduleId", templateHandler.DeleteSchedule)
+		}
+
+		// Admin routes (moderation)
+		admin := api.Group("/admin/auctions")
+		admin.Use(auth.GinAuthMiddleware(authClient), auth.GinRequireRole(authClient, constants.RoleAdmin))
+		{
+			admin.POST("/:id/cancel", auctionHandler.AdminCancelAuction)
+			admin.GET("/:id/audit", auctionHandler.AdminGetAuditLog)
+		}
+
+		fraud := api.Group("/admin/fraud-flags")
+		fraud.Use(auth.GinAuthMiddleware(authClient), auth.GinRequireRole(authClient, constants.RoleAdmin))
+		{
+			fraud.GET("", auctionHandler.GetFraudFlags)
+			fraud.POST("/:id/review", auctionHandler.ReviewFraudFlag)
+		}
+
+		notifications := api.Group("/admin/notifications")
+		notifications.Use(auth.GinAuthMiddleware(authClient), auth.GinRequireRole(authClient, constants.RoleAdmin))
+		{
+			notifications.GET("", auctionHandler.GetNotifications)
+			notifications.POST("/:id/retry", auctionHandler.RetryNotification)
+		}
+	}
+
 	return router
 }
@@ -4,25 +4,99 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
 )
 
+// IncrementStep is one rung of the bid increment ladder: bids on prices below
+// Below must raise by at least Increment. A zero Below matches any price.
+type IncrementStep struct {
+	Below     models.Money
+	Increment models.Money
+}
+
+// defaultIncrementLadder is used when BID_INCREMENT_LADDER is unset or invalid
+var defaultIncrementLadder = []IncrementStep{
+	{Below: 5000, Increment: 100},
+	{Below: 50000, Increment: 500},
+	{Below: 100000, Increment: 1000},
+	{Below: 500000, Increment: 2500},
+	{Below: 0, Increment: 5000},
+}
+
 type Config struct {
-	Port                   string
-	Environment            string
-	LogLevel               string
-	DatabaseURL            string
-	PostgresUser           string `env:"POSTGRES_USER"`
-	PostgresPassword       string `env:"POSTGRES_PASSWORD"`
-	PostgresHost           string `env:"POSTGRES_HOST"`
-	PostgresPort           string `env:"POSTGRES_PORT"`
-	PostgresDB             string `env:"POSTGRES_DB"`
-	RedisURL               string
-	JWTSecret              string
-	MetricsPort            string
-	ServiceName            string
+	Port              string
+	Environment       string
+	LogLevel          string
+	DatabaseURL       string
+	PostgresUser      string `env:"POSTGRES_USER"`
+	PostgresPassword  string `env:"POSTGRES_PASSWORD"`
+	PostgresHost      string `env:"POSTGRES_HOST"`
+	PostgresPort      string `env:"POSTGRES_PORT"`
+	PostgresDB        string `env:"POSTGRES_DB"`
+	RedisURL          string
+	RedisPassword     string
+	AuthServiceURL    string
+	OrderServiceURL   string
+	ProductServiceURL string
+	PaymentServiceURL string
+	// FirebaseFunctionsURL is the base URL of the Firebase functions, e.g.
+	// https://us-central1-<project>.cloudfunctions.net
+	FirebaseFunctionsURL string
+	// ServiceToken authenticates calls to other services' internal endpoints
+	ServiceToken string
+	JWTSecret    string
+	MetricsPort  string
+	ServiceName  string
+	// DefaultCurrency prices auctions and shows created without a currency
+	DefaultCurrency        string
 	DefaultAuctionDuration time.Duration
+	LifecycleInterval      time.Duration
+	BidIncrementLadder     []IncrementStep
+	SoftCloseWindow        time.Duration
+	SoftCloseExtension     time.Duration
+	SoftCloseMaxExtensions int
+	// BuyNowThreshold disables buy-now once the current bid reaches this
+	// fraction of the buy-now price; 0 disables it on the first bid
+	BuyNowThreshold float64
+	// EndingSoonLead is how long before EndTime watchers are alerted
+	EndingSoonLead time.Duration
+	// PaymentWindow is how long a winner has to pay for their order
+	PaymentWindow time.Duration
+	// SecondChanceWindow is how long a runner-up has to accept an offer after
+	// the winner fails to pay
+	SecondChanceWindow time.Duration
+	// NonPaymentStrikeLimit is how many strikes make a bidder ineligible for
+	// second-chance offers
+	NonPaymentStrikeLimit int
+	// Bids may be retracted within BidRetractionWindow of being placed, but
+	// not once the auction is within BidRetractionCutoff of its end
+	BidRetractionWindow time.Duration
+	BidRetractionCutoff time.Duration
+	// ShowLotDuration is how long a show lot runs when neither the lot nor
+	// the show sets a duration
+	ShowLotDuration time.Duration
+	// Shill detection looks back FraudLookback over a bidder's history with
+	// the seller, and queues auctions scoring FraudFlagThreshold or more
+	FraudLookback      time.Duration
+	FraudFlagThreshold int
+	// Bids above VerifiedBidThreshold need an identity-verified account, and
+	// a bidder's leading bids across active auctions may not total more than
+	// OpenCommitmentLimit; 0 disables either check
+	VerifiedBidThreshold models.Money
+	OpenCommitmentLimit  models.Money
+	// ScheduleLead is how far ahead of its start a recurring schedule creates
+	// the next auction, so it can be listed and watched before it opens
+	ScheduleLead time.Duration
+	// NotificationDriver delivers queued notifications: firebase, or log to
+	// only log them. A notification that still fails after
+	// NotificationAttempts tries is marked failed.
+	NotificationDriver   string
+	NotificationAttempts int
 }
 
 func Load() (*Config, error) {
@@ -36,10 +110,38 @@ func Load() (*Config, error) {
 		PostgresPort:           getEnv("POSTGRES_PORT", "5432"),
 		PostgresDB:             getEnv("POSTGRES_DB", "blytz_prod"),
 		RedisURL:               getEnv("REDIS_URL", "localhost:6379"),
+		RedisPassword:          getEnv("REDIS_PASSWORD", ""),
+		AuthServiceURL:         getEnv("AUTH_SERVICE_URL", "http://auth-service:8084"),
+		OrderServiceURL:        getEnv("ORDER_SERVICE_URL", "http://order-service:8085"),
+		ProductServiceURL:      getEnv("PRODUCT_SERVICE_URL", "http://product-service:8082"),
+		PaymentServiceURL:      getEnv("PAYMENT_SERVICE_URL", "http://payment-service:8086"),
+		FirebaseFunctionsURL:   getEnv("FIREBASE_FUNCTIONS_URL", "http://localhost:5001/demo-blytz-mvp/us-central1"),
+		ServiceToken:           getEnv("SERVICE_TOKEN", ""),
 		JWTSecret:              getEnv("JWT_SECRET", "your-secret-key"),
 		MetricsPort:            getEnv("METRICS_PORT", "9083"),
 		ServiceName:            getEnv("SERVICE_NAME", "auction-service"),
+		DefaultCurrency:        strings.ToUpper(getEnv("DEFAULT_CURRENCY", "USD")),
 		DefaultAuctionDuration: getEnvAsDuration("DEFAULT_AUCTION_DURATION", 24*time.Hour),
+		LifecycleInterval:      getEnvAsDuration("AUCTION_LIFECYCLE_INTERVAL", time.Second),
+		BidIncrementLadder:     getEnvAsIncrementLadder("BID_INCREMENT_LADDER", defaultIncrementLadder),
+		SoftCloseWindow:        getEnvAsDuration("SOFT_CLOSE_WINDOW", 30*time.Second),
+		SoftCloseExtension:     getEnvAsDuration("SOFT_CLOSE_EXTENSION", 30*time.Second),
+		SoftCloseMaxExtensions: getEnvAsInt("SOFT_CLOSE_MAX_EXTENSIONS", 10),
+		BuyNowThreshold:        getEnvAsFloat("BUY_NOW_THRESHOLD", 0.5),
+		EndingSoonLead:         getEnvAsDuration("AUCTION_ENDING_SOON_LEAD", 15*time.Minute),
+		PaymentWindow:          getEnvAsDuration("AUCTION_PAYMENT_WINDOW", 48*time.Hour),
+		SecondChanceWindow:     getEnvAsDuration("SECOND_CHANCE_WINDOW", 24*time.Hour),
+		NonPaymentStrikeLimit:  getEnvAsInt("NON_PAYMENT_STRIKE_LIMIT", 3),
+		BidRetractionWindow:    getEnvAsDuration("BID_RETRACTION_WINDOW", 5*time.Minute),
+		BidRetractionCutoff:    getEnvAsDuration("BID_RETRACTION_CUTOFF", time.Hour),
+		ShowLotDuration:        getEnvAsDuration("SHOW_LOT_DURATION", time.Minute),
+		FraudLookback:          getEnvAsDuration("FRAUD_LOOKBACK", 90*24*time.Hour),
+		FraudFlagThreshold:     getEnvAsInt("FRAUD_FLAG_THRESHOLD", 50),
+		VerifiedBidThreshold:   getEnvAsMoney("VERIFIED_BID_THRESHOLD", 100000),
+		OpenCommitmentLimit:    getEnvAsMoney("OPEN_COMMITMENT_LIMIT", 1000000),
+		ScheduleLead:           getEnvAsDuration("AUCTION_SCHEDULE_LEAD", 24*time.Hour),
+		NotificationDriver:     getEnv("NOTIFICATION_DRIVER", "firebase"),
+		NotificationAttempts:   getEnvAsInt("NOTIFICATION_MAX_ATTEMPTS", 10),
 	}
 
 	// Construct the database URL
@@ -66,6 +168,26 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsMoney reads an amount written in major units, such as "1000" or
+// "12.50"
+func getEnvAsMoney(key string, defaultValue models.Money) models.Money {
+	if value := os.Getenv(key); value != "" {
+		if amount, err := models.ParseMoney(value); err == nil {
+			return amount
+		}
+	}
+	return defaultValue
+}
+
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -73,4 +195,42 @@ func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 		}
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
+
+// getEnvAsIncrementLadder parses a ladder such as "50:1,500:5,*:10", where
+// each entry is "<below>:<increment>" in major units and "*" marks the
+// open-ended top rung
+func getEnvAsIncrementLadder(key string, defaultValue []IncrementStep) []IncrementStep {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var ladder []IncrementStep
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), ":", 2)
+		if len(parts) != 2 {
+			return defaultValue
+		}
+		increment, err := models.ParseMoney(parts[1])
+		if err != nil || increment <= 0 {
+			return defaultValue
+		}
+		step := IncrementStep{Increment: increment}
+		if parts[0] != "*" {
+			if step.Below, err = models.ParseMoney(parts[0]); err != nil || step.Below <= 0 {
+				return defaultValue
+			}
+		}
+		ladder = append(ladder, step)
+	}
+
+	// Bounded rungs ascending, open-ended rung last
+	sort.SliceStable(ladder, func(i, j int) bool {
+		if ladder[i].Below == 0 || ladder[j].Below == 0 {
+			return ladder[j].Below == 0 && ladder[i].Below != 0
+		}
+		return ladder[i].Below < ladder[j].Below
+	})
+	return ladder
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetEnvAsIncrementLadder(t *testing.T) {
+	fallback := []IncrementStep{{Below: 0, Increment: 1}}
+	tests := []struct {
+		name  string
+		value string
+		want  []IncrementStep
+	}{
+		{name: "unset", value: "", want: fallback},
+		{
+			name:  "major units",
+			value: "50:1,500:5,*:10",
+			want:  []IncrementStep{{Below: 5000, Increment: 100}, {Below: 50000, Increment: 500}, {Below: 0, Increment: 1000}},
+		},
+		{
+			name:  "sorted with the open rung last",
+			value: "*:10, 500:5, 50:0.5",
+			want:  []IncrementStep{{Below: 5000, Increment: 50}, {Below: 50000, Increment: 500}, {Below: 0, Increment: 1000}},
+		},
+		{name: "missing increment", value: "50,*:10", want: fallback},
+		{name: "zero increment", value: "50:0,*:10", want: fallback},
+		{name: "invalid bound", value: "fifty:1,*:10", want: fallback},
+		{name: "negative bound", value: "-50:1,*:10", want: fallback},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_INCREMENT_LADDER", tt.value)
+			if got := getEnvAsIncrementLadder("TEST_INCREMENT_LADDER", fallback); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ladder %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
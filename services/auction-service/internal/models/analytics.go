@@ -0,0 +1,88 @@
+package models
+
+import "time"
+
+// AnalyticsRange limits analytics to [From, To). A nil bound is open.
+type AnalyticsRange struct {
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+}
+
+// BidRate is one minute of bidding on an auction
+type BidRate struct {
+	Minute        time.Time `json:"minute"`
+	Bids          int       `json:"bids"`
+	UniqueBidders int       `json:"unique_bidders"`
+	HighPrice     Money     `json:"high_price"`
+}
+
+// PricePoint is the auction price right after a bid
+type PricePoint struct {
+	Time  time.Time `json:"time"`
+	Price Money     `json:"price"`
+}
+
+// AuctionAnalytics describes how bidding went on one auction
+type AuctionAnalytics struct {
+	AuctionID        string         `json:"auction_id"`
+	Title            string         `json:"title"`
+	Type             string         `json:"type"`
+	Status           string         `json:"status"`
+	Outcome          string         `json:"outcome,omitempty"` // sold, unsold, cancelled; empty while running
+	Currency         string         `json:"currency"`
+	Range            AnalyticsRange `json:"range"`
+	TotalBids        int            `json:"total_bids"`
+	UniqueBidders    int            `json:"unique_bidders"`
+	AvgBidsPerMinute float64        `json:"avg_bids_per_minute"` // over the minutes from first to last bid
+	BidsPerMinute    []BidRate      `json:"bids_per_minute"`
+	PriceCurve       []PricePoint   `json:"price_curve"`
+	StartingPrice    Money          `json:"starting_price"`
+	ReservePrice     Money          `json:"reserve_price"`
+	HammerPrice      Money          `json:"hammer_price,omitempty"`
+	ReserveMet       bool           `json:"reserve_met"`
+	HammerRatio      float64        `json:"hammer_ratio,omitempty"` // hammer price over starting price, for sales
+}
+
+// AuctionSummary is one auction's line in a seller's analytics. Drop
+// auctions sell per claim, so their hammer price is the average claim price.
+type AuctionSummary struct {
+	AuctionID     string    `json:"auction_id"`
+	Title         string    `json:"title"`
+	Type          string    `json:"type"`
+	Status        string    `json:"status"`
+	Outcome       string    `json:"outcome,omitempty"`
+	Currency      string    `json:"currency"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+	StartingPrice Money     `json:"starting_price"`
+	ReservePrice  Money     `json:"reserve_price"`
+	HighestBid    Money     `json:"highest_bid"`
+	HammerPrice   Money     `json:"hammer_price,omitempty"`
+	UnitsSold     int       `json:"units_sold"`
+	TotalBids     int       `json:"total_bids"`
+	UniqueBidders int       `json:"unique_bidders"`
+	ReserveMet    bool      `json:"reserve_met"`
+	HammerRatio   float64   `json:"hammer_ratio,omitempty"`
+}
+
+// SellerAnalytics rolls up a seller's auctions that end within the range
+type SellerAnalytics struct {
+	SellerID        string         `json:"seller_id"`
+	Range           AnalyticsRange `json:"range"`
+	TotalAuctions   int            `json:"total_auctions"`
+	Finished        int            `json:"finished"` // ended sold or unsold; cancelled auctions are left out
+	Sold            int            `json:"sold"`
+	SellThroughRate float64        `json:"sell_through_rate"`
+	WithReserve     int            `json:"with_reserve"`
+	ReserveMet      int            `json:"reserve_met"`
+	ReserveMetRate  float64        `json:"reserve_met_rate"`
+	TotalBids       int            `json:"total_bids"`
+	UniqueBidders   int            `json:"unique_bidders"`
+	AvgHammerRatio  float64        `json:"avg_hammer_ratio"`
+	// GrossSales and Currency are set when every sale was in one currency;
+	// GrossSalesByCurrency always breaks the total down
+	GrossSales           Money            `json:"gross_sales"`
+	Currency             string           `json:"currency,omitempty"`
+	GrossSalesByCurrency map[string]Money `json:"gross_sales_by_currency"`
+	Auctions             []AuctionSummary `json:"auctions"`
+}
@@ -1,40 +1,167 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 )
 
 type Auction struct {
-	AuctionID       string          `json:"auction_id" gorm:"primaryKey"`
-	ProductID       string          `json:"product_id"`
-	SellerID        string          `json:"seller_id"`
-	Title           string          `json:"title"`
-	Description     string          `json:"description"`
-	StartingPrice   float64         `json:"starting_price"`
-	CurrentPrice    float64         `json:"current_price"`
-	ReservePrice    float64         `json:"reserve_price"`
-	MinBidIncrement float64         `json:"min_bid_increment"`
-	StartTime       time.Time       `json:"start_time"`
-	EndTime         time.Time       `json:"end_time"`
-	Status          string          `json:"status"` // scheduled, active, ended, cancelled
-	Type            string          `json:"type"`   // live, scheduled
-	IsActive        bool            `json:"is_active"`
-	CreatedAt       time.Time       `json:"created_at"`
-	UpdatedAt       time.Time       `json:"updated_at"`
-	Bids            []Bid           `json:"bids,omitempty" gorm:"foreignKey:AuctionID"`
-	Images          []AuctionImage  `json:"images,omitempty" gorm:"foreignKey:AuctionID"`
+	AuctionID       string    `json:"auction_id" gorm:"primaryKey"`
+	ProductID       string    `json:"product_id"`
+	Category        string    `json:"category,omitempty"`
+	ShowID          string    `json:"show_id,omitempty"`       // set on a show's lot auctions
+	TemplateID      string    `json:"template_id,omitempty"`   // set on auctions made from a template
+	RelistedFrom    string    `json:"relisted_from,omitempty"` // the unsold auction this one relists
+	SellerID        string    `json:"seller_id"`
+	Title           string    `json:"title"`
+	Description     string    `json:"description"`
+	Currency        string    `json:"currency"` // ISO 4217; every price on the auction is in its minor units
+	StartingPrice   Money     `json:"starting_price"`
+	CurrentPrice    Money     `json:"current_price"`
+	ReservePrice    Money     `json:"reserve_price"`
+	MinBidIncrement Money     `json:"min_bid_increment"`
+	BuyNowPrice     Money     `json:"buy_now_price,omitempty"`
+	PricingRule     string    `json:"pricing_rule,omitempty"` // sealed auctions: first_price, second_price
+	StartTime       time.Time `json:"start_time"`
+	EndTime         time.Time `json:"end_time"`
+	Status          string    `json:"status"` // scheduled, active, ended, cancelled
+	Type            string    `json:"type"`   // live, scheduled, drop, sealed
+	IsActive        bool      `json:"is_active"`
+	// Soft close: a bid within SoftCloseWindow seconds of EndTime extends it
+	// by SoftCloseExtension seconds, at most MaxExtensions times
+	SoftClose          bool `json:"soft_close"`
+	SoftCloseWindow    int  `json:"soft_close_window_seconds,omitempty"`
+	SoftCloseExtension int  `json:"soft_close_extension_seconds,omitempty"`
+	MaxExtensions      int  `json:"max_extensions,omitempty"`
+	ExtensionCount     int  `json:"extension_count"`
+	WatcherCount       int  `json:"watchers"`
+	BidCount           int  `json:"bid_count"`
+	// Drop auctions: the price falls by DropStep every DropInterval seconds
+	// from StartingPrice down to FloorPrice, and claims buy at the current price
+	FloorPrice         Money      `json:"floor_price,omitempty"`
+	DropStep           Money      `json:"drop_step,omitempty"`
+	DropInterval       int        `json:"drop_interval_seconds,omitempty"`
+	NextDropAt         *time.Time `json:"next_drop_at,omitempty"`
+	Quantity           int        `json:"quantity"`
+	QuantityRemaining  int        `json:"quantity_remaining"`
+	WinnerID           string     `json:"winner_id,omitempty"`
+	WinningBidID       string     `json:"winning_bid_id,omitempty"`
+	SettledAt          *time.Time `json:"settled_at,omitempty"`
+	OrderID            string     `json:"order_id,omitempty"`
+	CancellationReason string     `json:"cancellation_reason,omitempty"`
+	PaymentDueAt       *time.Time `json:"payment_due_at,omitempty"`
+	// DepositAmount flags a high-value auction: bidders must hold a
+	// refundable deposit of this amount before bidding
+	DepositAmount Money `json:"deposit_amount,omitempty"`
+	// Where the seller created the auction from, for fraud review
+	SellerIP       string         `json:"-"`
+	SellerDeviceID string         `json:"-"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	Bids           []Bid          `json:"bids,omitempty" gorm:"foreignKey:AuctionID"`
+	Images         []AuctionImage `json:"images" gorm:"foreignKey:AuctionID"`
 }
 
 type Bid struct {
-	BidID      string    `json:"bid_id" gorm:"primaryKey"`
-	AuctionID  string    `json:"auction_id"`
-	BidderID   string    `json:"bidder_id"`
-	Amount     float64   `json:"amount"`
-	IsWinning  bool      `json:"is_winning"`
-	BidTime    time.Time `json:"bid_time"`
-	CreatedAt  time.Time `json:"created_at"`
+	BidID     string `json:"bid_id" gorm:"primaryKey"`
+	AuctionID string `json:"auction_id"`
+	BidderID  string `json:"bidder_id"`
+	Amount    Money  `json:"amount"`
+	IsWinning bool   `json:"is_winning"`
+	IsProxy   bool   `json:"is_proxy"`
+	IsBuyNow  bool   `json:"is_buy_now"`
+	Quantity  int    `json:"quantity"`
+	Defaulted bool   `json:"defaulted,omitempty"` // the bidder failed to pay for this win
+	Retracted bool   `json:"retracted,omitempty"`
+	// Request origin, kept for fraud review and never returned to clients
+	IPAddress string    `json:"-"`
+	DeviceID  string    `json:"-"`
+	BidTime   time.Time `json:"bid_time"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
+// Auction types
+const (
+	AuctionTypeLive      = "live"
+	AuctionTypeScheduled = "scheduled"
+	AuctionTypeDrop      = "drop"
+	AuctionTypeSealed    = "sealed"
+)
+
+// Sealed-bid pricing rules: the winner pays their own bid, or the runner-up's
+// (Vickrey)
+const (
+	PricingFirstPrice  = "first_price"
+	PricingSecondPrice = "second_price"
+)
+
+// Auction event types written to the auction_events outbox
+const (
+	EventAuctionStarted = "auction_started"
+	EventAuctionEnded   = "auction_ended"
+	EventAuctionSettled = "auction_settled"
+	EventTimeExtended   = "time_extended"
+	EventBidPlaced      = "bid_placed"
+	EventPriceChanged   = "price_changed"
+	EventDropClaimed    = "drop_claimed"
+	EventEndingSoon     = "auction_ending_soon"
+	EventOutbid         = "outbid"
+	EventOrderCreated   = "order_created"
+	// Non-payment: the winner defaulted, and the runner-up chain either
+	// produced an offer or ran out of eligible bidders
+	EventPaymentDefaulted      = "payment_defaulted"
+	EventSecondChanceOffered   = "second_chance_offered"
+	EventSecondChanceExhausted = "second_chance_exhausted"
+	EventBidRetracted          = "bid_retracted"
+)
+
+// OutcomeCancelled marks an EventAuctionEnded for an auction its seller
+// withdrew; sold and unsold outcomes come from settlement
+const OutcomeCancelled = "cancelled"
+
+// AuctionEvent is a lifecycle event recorded alongside the state change that
+// produced it and relayed to subscribers once committed. Sequence numbers an
+// auction's events in commit order without gaps; EventID is unique across
+// auctions but can commit out of order.
+type AuctionEvent struct {
+	EventID   int64           `json:"event_id"`
+	AuctionID string          `json:"auction_id"`
+	Sequence  int64           `json:"sequence"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+	Attempts  int             `json:"-"` // failed relay attempts
+}
+
+// AuctionSettledPayload is the payload of an EventAuctionSettled event
+type AuctionSettledPayload struct {
+	SellerID     string `json:"seller_id"`
+	Outcome      string `json:"outcome"` // sold, unsold
+	WinnerID     string `json:"winner_id,omitempty"`
+	WinningBidID string `json:"winning_bid_id,omitempty"`
+	HammerPrice  Money  `json:"hammer_price"`
+	BuyNow       bool   `json:"buy_now,omitempty"`
+	SecondChance bool   `json:"second_chance,omitempty"` // a runner-up accepted after the winner defaulted
+}
+
+// DropClaimedPayload is the payload of an EventDropClaimed event. Every claim
+// on a drop auction is a sale in its own right.
+type DropClaimedPayload struct {
+	SellerID          string `json:"seller_id"`
+	BidID             string `json:"bid_id"`
+	BidderID          string `json:"bidder_id"`
+	Price             Money  `json:"price"`
+	Quantity          int    `json:"quantity"`
+	QuantityRemaining int    `json:"quantity_remaining"`
+}
+
+// Settlement outcomes
+const (
+	OutcomeSold   = "sold"
+	OutcomeUnsold = "unsold"
+)
+
+// AuctionImage is one image in an auction's gallery, shown in Order from 0
 type AuctionImage struct {
 	ImageID   string `json:"image_id" gorm:"primaryKey"`
 	AuctionID string `json:"auction_id"`
@@ -43,31 +170,136 @@ type AuctionImage struct {
 	Order     int    `json:"order"`
 }
 
+// ImageRequest is an image on an auction or template request. A bare URL
+// string is accepted too, as images were sent before they had alt text.
+type ImageRequest struct {
+	ImageURL string `json:"image_url" binding:"required,url"`
+	AltText  string `json:"alt_text" binding:"max=255"`
+}
+
+// UnmarshalJSON reads an image object or a bare image URL
+func (r *ImageRequest) UnmarshalJSON(data []byte) error {
+	type image ImageRequest
+	if url, ok := imageURL(data); ok {
+		*r = ImageRequest{ImageURL: url}
+		return nil
+	}
+	return json.Unmarshal(data, (*image)(r))
+}
+
+// ImageUpdate is an image in an auction update: one already in the gallery
+// by ImageID, or a new one by ImageURL. AltText replaces an existing image's
+// alt text when given. A bare URL string is a new image without alt text.
+type ImageUpdate struct {
+	ImageID  string `json:"image_id"`
+	ImageURL string `json:"image_url" binding:"omitempty,url"`
+	AltText  string `json:"alt_text" binding:"max=255"`
+}
+
+// UnmarshalJSON reads an image object or a bare image URL
+func (u *ImageUpdate) UnmarshalJSON(data []byte) error {
+	type image ImageUpdate
+	if url, ok := imageURL(data); ok {
+		*u = ImageUpdate{ImageURL: url}
+		return nil
+	}
+	return json.Unmarshal(data, (*image)(u))
+}
+
+// imageURL reads data as a bare image URL if it is a JSON string
+func imageURL(data []byte) (string, bool) {
+	var url string
+	if err := json.Unmarshal(data, &url); err != nil {
+		return "", false
+	}
+	return url, true
+}
+
 type CreateAuctionRequest struct {
 	ProductID       string    `json:"product_id" binding:"required"`
 	Title           string    `json:"title" binding:"required"`
 	Description     string    `json:"description"`
-	StartingPrice   float64   `json:"starting_price" binding:"required,min=0"`
-	ReservePrice    float64   `json:"reserve_price"`
-	MinBidIncrement float64   `json:"min_bid_increment" binding:"required,min=0"`
+	Currency        string    `json:"currency" binding:"omitempty,len=3"` // defaults to the service currency
+	StartingPrice   Money     `json:"starting_price" binding:"required,min=0"`
+	ReservePrice    Money     `json:"reserve_price"`
+	MinBidIncrement Money     `json:"min_bid_increment" binding:"required,min=0"`
+	BuyNowPrice     Money     `json:"buy_now_price" binding:"min=0"`
+	PricingRule     string    `json:"pricing_rule" binding:"omitempty,oneof=first_price second_price"`
 	StartTime       time.Time `json:"start_time" binding:"required"`
 	EndTime         time.Time `json:"end_time" binding:"required"`
-	Type            string    `json:"type" binding:"required,oneof=live scheduled"`
-	Images          []string  `json:"images"`
+	Type            string    `json:"type" binding:"required,oneof=live scheduled drop sealed"`
+	// Images default to the product's images when none are given
+	Images []ImageRequest `json:"images" binding:"max=20,dive"`
+	// Soft close settings; zero values fall back to the service defaults
+	SoftClose          bool `json:"soft_close"`
+	SoftCloseWindow    int  `json:"soft_close_window_seconds" binding:"min=0"`
+	SoftCloseExtension int  `json:"soft_close_extension_seconds" binding:"min=0"`
+	MaxExtensions      int  `json:"max_extensions" binding:"min=0"`
+	// Drop auction settings, required when type is drop
+	FloorPrice   Money `json:"floor_price" binding:"min=0"`
+	DropStep     Money `json:"drop_step" binding:"min=0"`
+	DropInterval int   `json:"drop_interval_seconds" binding:"min=0"`
+	Quantity     int   `json:"quantity" binding:"min=0"`
+	// DepositAmount requires bidders to hold a refundable deposit first
+	DepositAmount Money `json:"deposit_amount" binding:"min=0"`
 }
 
 type UpdateAuctionRequest struct {
 	Title           string    `json:"title"`
 	Description     string    `json:"description"`
-	ReservePrice    float64   `json:"reserve_price"`
-	MinBidIncrement float64   `json:"min_bid_increment"`
+	ReservePrice    Money     `json:"reserve_price"`
+	MinBidIncrement Money     `json:"min_bid_increment"`
 	StartTime       time.Time `json:"start_time"`
 	EndTime         time.Time `json:"end_time"`
-	Images          []string  `json:"images"`
+	// Images, when present, replaces the gallery in the order given: images
+	// left out are removed and an empty list removes them all
+	Images []ImageUpdate `json:"images" binding:"max=20,dive"`
+}
+
+// Watch is a user's interest in an auction; watchers get start, ending-soon
+// and outbid alerts
+type Watch struct {
+	AuctionID string    `json:"auction_id"`
+	UserID    string    `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WatchResponse struct {
+	AuctionID string `json:"auction_id"`
+	Watching  bool   `json:"watching"`
+	Watchers  int    `json:"watchers"`
 }
 
 type PlaceBidRequest struct {
-	Amount float64 `json:"amount" binding:"required,min=0"`
+	Amount Money `json:"amount" binding:"required,min=0"`
+}
+
+// ProxyBid is a bidder's hidden maximum; the service bids on their behalf
+// up to MaxAmount
+type ProxyBid struct {
+	AuctionID string    `json:"auction_id"`
+	BidderID  string    `json:"bidder_id"`
+	MaxAmount Money     `json:"max_amount"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ClaimDropRequest buys units of a drop auction at the current price;
+// Quantity defaults to 1
+type ClaimDropRequest struct {
+	Quantity int `json:"quantity" binding:"min=0"`
+}
+
+type SetProxyBidRequest struct {
+	MaxAmount Money `json:"max_amount" binding:"required,gt=0"`
+}
+
+// ProxyBidResponse reports a bidder's maximum and where they stand after the
+// proxy engine has run
+type ProxyBidResponse struct {
+	ProxyBid     ProxyBid `json:"proxy_bid"`
+	CurrentPrice Money    `json:"current_price"`
+	IsLeading    bool     `json:"is_leading"`
 }
 
 type AuctionResponse struct {
@@ -75,7 +307,7 @@ type AuctionResponse struct {
 }
 
 type BidResponse struct {
-	Bid   Bid   `json:"bid"`
+	Bid Bid `json:"bid"`
 }
 
 type BidsResponse struct {
@@ -83,18 +315,65 @@ type BidsResponse struct {
 }
 
 type AuctionsResponse struct {
-	Auctions []Auction `json:"auctions"`
-	Total    int64     `json:"total"`
-	Page     int       `json:"page"`
-	Limit    int       `json:"limit"`
+	Auctions   []Auction `json:"auctions"`
+	Total      int64     `json:"total"`
+	Page       int       `json:"page"`
+	Limit      int       `json:"limit"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
+
+// Listing sort orders
+const (
+	SortNewest     = "newest"
+	SortEndingSoon = "ending_soon"
+	SortPriceAsc   = "price_asc"
+	SortPriceDesc  = "price_desc"
+	SortMostBids   = "most_bids"
+)
+
+// AuctionFilter represents auction listing filters, sort order and page
+type AuctionFilter struct {
+	SellerID     string
+	Status       string
+	Type         string
+	Category     string
+	Currency     string
+	MinPrice     Money
+	MaxPrice     Money
+	StartsAfter  *time.Time
+	StartsBefore *time.Time
+	EndsAfter    *time.Time
+	EndsBefore   *time.Time
+	Sort         string
+	Limit        int
+	After        *AuctionCursor
+}
+
+// AuctionCursor marks the last auction of a listing page. Listings resume
+// strictly after it in the filter's sort order, so pages stay stable while
+// new auctions are created.
+type AuctionCursor struct {
+	Sort      string     `json:"s"`
+	Time      *time.Time `json:"t,omitempty"` // newest, ending_soon
+	Value     int64      `json:"v,omitempty"` // price in minor units, or bid count
+	AuctionID string     `json:"id"`
+	Page      int        `json:"p"`
 }
 
 type AuctionStatus struct {
-	AuctionID     string    `json:"auction_id"`
-	Status        string    `json:"status"`
-	CurrentPrice  float64   `json:"current_price"`
-	WinningBidID  string    `json:"winning_bid_id,omitempty"`
-	TotalBids     int       `json:"total_bids"`
-	TimeRemaining string    `json:"time_remaining"`
-	UpdatedAt     time.Time `json:"updated_at"`
-}
\ No newline at end of file
+	AuctionID    string `json:"auction_id"`
+	Status       string `json:"status"`
+	CurrentPrice Money  `json:"current_price"`
+	WinningBidID string `json:"winning_bid_id,omitempty"`
+	TotalBids    int    `json:"total_bids"`
+	MinimumBid   Money  `json:"minimum_bid"`
+	// BuyNowAvailable is false once bidding has passed the buy-now threshold
+	// or met the reserve
+	BuyNowPrice     Money `json:"buy_now_price,omitempty"`
+	BuyNowAvailable bool  `json:"buy_now_available"`
+	// Drop auctions only
+	NextDropAt        *time.Time `json:"next_drop_at,omitempty"`
+	QuantityRemaining int        `json:"quantity_remaining,omitempty"`
+	TimeRemaining     string     `json:"time_remaining"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestImageRequestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []ImageRequest
+		wantErr bool
+	}{
+		{
+			name:  "objects",
+			input: `[{"image_url":"https://example.com/a.jpg","alt_text":"Front"},{"image_url":"https://example.com/b.jpg"}]`,
+			want:  []ImageRequest{{ImageURL: "https://example.com/a.jpg", AltText: "Front"}, {ImageURL: "https://example.com/b.jpg"}},
+		},
+		{
+			name:  "strings",
+			input: `["https://example.com/a.jpg","https://example.com/b.jpg"]`,
+			want:  []ImageRequest{{ImageURL: "https://example.com/a.jpg"}, {ImageURL: "https://example.com/b.jpg"}},
+		},
+		{
+			name:  "mixed",
+			input: `["https://example.com/a.jpg",{"image_url":"https://example.com/b.jpg","alt_text":"Back"}]`,
+			want:  []ImageRequest{{ImageURL: "https://example.com/a.jpg"}, {ImageURL: "https://example.com/b.jpg", AltText: "Back"}},
+		},
+		{name: "number", input: `[12]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []ImageRequest
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("unmarshal %s = %+v, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unmarshal %s = %+v, %v, want %+v", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateAuctionRequestImages(t *testing.T) {
+	var req UpdateAuctionRequest
+	input := `{"images":[{"image_id":"image-1","alt_text":"Front"},"https://example.com/new.jpg"]}`
+	if err := json.Unmarshal([]byte(input), &req); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	want := []ImageUpdate{{ImageID: "image-1", AltText: "Front"}, {ImageURL: "https://example.com/new.jpg"}}
+	if !reflect.DeepEqual(req.Images, want) {
+		t.Errorf("images %+v, want %+v", req.Images, want)
+	}
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEntry is one row of an auction's audit log
+type AuditEntry struct {
+	EntryID    int64           `json:"entry_id"`
+	AuctionID  string          `json:"auction_id"`
+	ActorID    string          `json:"actor_id,omitempty"`
+	ActorRole  string          `json:"actor_role"` // seller, bidder, admin, system
+	Action     string          `json:"action"`
+	FromStatus string          `json:"from_status,omitempty"`
+	ToStatus   string          `json:"to_status,omitempty"`
+	Reason     string          `json:"reason,omitempty"`
+	Details    json.RawMessage `json:"details,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// Audit actor roles
+const (
+	ActorSeller = "seller"
+	ActorBidder = "bidder"
+	ActorAdmin  = "admin"
+	ActorSystem = "system"
+)
+
+// Audit actions
+const (
+	AuditActivated            = "activated"
+	AuditSettled              = "settled"
+	AuditCancelled            = "cancelled"
+	AuditBidRetracted         = "bid_retracted"
+	AuditPaymentDefaulted     = "payment_defaulted"
+	AuditSecondChanceAccepted = "second_chance_accepted"
+)
+
+// AuditLogResponse lists an auction's audit entries, oldest first
+type AuditLogResponse struct {
+	Entries []*AuditEntry `json:"entries"`
+}
+
+// CancelAuctionRequest gives the reason an auction is being withdrawn
+type CancelAuctionRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// RetractBidRequest explains why a bid is being withdrawn
+type RetractBidRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// BidDeposit is a bidder's refundable deposit on an auction that requires
+// one. The funds are held by payment-service; this row tracks what happens
+// to them once the auction is decided.
+type BidDeposit struct {
+	AuctionID        string    `json:"auction_id"`
+	BidderID         string    `json:"bidder_id"`
+	Amount           Money     `json:"amount"`
+	PaymentDepositID string    `json:"payment_deposit_id"`
+	Status           string    `json:"status"`
+	Attempts         int       `json:"-"`
+	NextAttemptAt    time.Time `json:"-"`
+	LastError        string    `json:"-"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// Deposit statuses. Losing bidders' deposits are released when the auction
+// ends; the winner's is released once they pay or captured if they default.
+const (
+	DepositHeld           = "held"
+	DepositReleasePending = "release_pending"
+	DepositReleased       = "released"
+	DepositCapturePending = "capture_pending"
+	DepositCaptured       = "captured"
+)
+
+// PlaceDepositRequest carries the payment method the deposit is held on
+type PlaceDepositRequest struct {
+	PaymentMethod string `json:"payment_method" binding:"required"`
+	Provider      string `json:"provider" binding:"required"`
+	Token         string `json:"token" binding:"required"`
+}
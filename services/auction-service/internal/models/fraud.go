@@ -0,0 +1,86 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// BidOrigin is where a request came from. It travels in the request context
+// so every bid the user places records it, whichever path placed the bid.
+type BidOrigin struct {
+	UserID    string
+	IPAddress string
+	DeviceID  string
+}
+
+type bidOriginKey struct{}
+
+// WithBidOrigin attaches the caller's origin to a request context
+func WithBidOrigin(ctx context.Context, origin BidOrigin) context.Context {
+	return context.WithValue(ctx, bidOriginKey{}, origin)
+}
+
+// BidOriginFor returns the request origin if it belongs to userID. Proxy
+// counter-bids for other users run in the same request and must not inherit
+// the caller's origin.
+func BidOriginFor(ctx context.Context, userID string) (BidOrigin, bool) {
+	origin, ok := ctx.Value(bidOriginKey{}).(BidOrigin)
+	if !ok || origin.UserID == "" || origin.UserID != userID {
+		return BidOrigin{}, false
+	}
+	return origin, true
+}
+
+// FraudSignal is one suspicious pattern found in a bidder's activity
+type FraudSignal struct {
+	BidderID string `json:"bidder_id"`
+	Signal   string `json:"signal"`
+	Score    int    `json:"score"`
+	Detail   string `json:"detail"`
+}
+
+// Fraud signals
+const (
+	SignalSharedWithSeller = "shared_origin_with_seller"
+	SignalSharedWithBidder = "shared_origin_with_bidder"
+	SignalRepeatNonWinner  = "repeat_non_winning_bidder"
+	SignalReservePushing   = "reserve_pushing"
+)
+
+// FraudFlag puts an auction in the moderation queue. Score is the highest
+// total of any one bidder's signals, capped at 100.
+type FraudFlag struct {
+	FlagID     string        `json:"flag_id"`
+	AuctionID  string        `json:"auction_id"`
+	SellerID   string        `json:"seller_id"`
+	Score      int           `json:"score"`
+	Signals    []FraudSignal `json:"signals"`
+	Status     string        `json:"status"` // open, dismissed, confirmed
+	ReviewerID string        `json:"reviewer_id,omitempty"`
+	ReviewNote string        `json:"review_note,omitempty"`
+	ReviewedAt *time.Time    `json:"reviewed_at,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// Fraud flag statuses
+const (
+	FlagOpen      = "open"
+	FlagDismissed = "dismissed"
+	FlagConfirmed = "confirmed"
+)
+
+// SellerBidderStats is a bidder's history with one seller
+type SellerBidderStats struct {
+	BidderID string
+	Auctions int
+	Wins     int
+}
+
+type ReviewFraudFlagRequest struct {
+	Status string `json:"status" binding:"required,oneof=dismissed confirmed"`
+	Note   string `json:"note" binding:"max=1000"`
+}
+
+type FraudFlagsResponse struct {
+	Flags []*FraudFlag `json:"flags"`
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in the minor units (cents) of its auction's currency.
+// Amounts are integers so sums and comparisons never drift, but they are
+// read from and written to JSON as decimals in major units, so clients keep
+// sending and receiving 12.50.
+type Money int64
+
+// SupportedCurrencies are the ISO 4217 codes auctions can be priced in. All
+// of them have two minor-unit digits, which Money assumes.
+var SupportedCurrencies = map[string]bool{
+	"USD": true,
+	"MYR": true,
+	"SGD": true,
+	"EUR": true,
+	"GBP": true,
+}
+
+// maxMoneyUnits is the largest whole amount whose cents, rounded up, still
+// fit in a Money
+const maxMoneyUnits = (math.MaxInt64 - 99) / 100
+
+// ParseMoney reads a decimal amount in major units, such as "12.5", rounding
+// anything past the cent half away from zero
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if strings.ContainsAny(s, "eE") {
+		// Exponent notation is valid JSON; fall back to float parsing
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid amount %q", s)
+		}
+		if math.Abs(math.Round(f*100)) >= math.MaxInt64 {
+			return 0, fmt.Errorf("amount %q is too large", s)
+		}
+		return MoneyFromFloat(f), nil
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	digits := strings.TrimPrefix(s, "-")
+	whole, frac, _ := strings.Cut(digits, ".")
+	if whole == "" && frac == "" {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil && !errors.Is(err, strconv.ErrRange) || strings.ContainsAny(whole, "+-") {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if err != nil || units > maxMoneyUnits {
+		return 0, fmt.Errorf("amount %q is too large", s)
+	}
+	for _, r := range frac {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("invalid amount %q", s)
+		}
+	}
+	frac += "000"
+	cents, _ := strconv.ParseInt(frac[:2], 10, 64)
+	amount := units*100 + cents
+	if frac[2] >= '5' {
+		amount++
+	}
+	if negative {
+		amount = -amount
+	}
+	return Money(amount), nil
+}
+
+// MoneyFromFloat converts a major-unit float, for example from Firebase, to
+// the nearest cent
+func MoneyFromFloat(amount float64) Money {
+	return Money(math.Round(amount * 100))
+}
+
+// Float64 is the amount in major units, for boundaries such as Firebase
+// that expect a plain number
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+// String formats the amount in major units with two decimals, e.g. "12.50"
+func (m Money) String() string {
+	sign := ""
+	value := int64(m)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, value/100, value%100)
+}
+
+// MarshalJSON writes the amount as a major-unit number without trailing
+// zeros, the same way the float prices it replaced were written
+func (m Money) MarshalJSON() ([]byte, error) {
+	s := strings.TrimRight(m.String(), "0")
+	return []byte(strings.TrimSuffix(s, ".")), nil
+}
+
+// UnmarshalJSON reads a major-unit number. A quoted number is accepted too.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(bytes.TrimSpace(data), `"`)
+	if string(data) == "null" {
+		return nil
+	}
+	amount, err := ParseMoney(string(data))
+	if err != nil {
+		return err
+	}
+	*m = amount
+	return nil
+}
+
+// Times is the amount for quantity units
+func (m Money) Times(quantity int) Money {
+	return m * Money(quantity)
+}
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Money
+		wantErr bool
+	}{
+		{name: "whole", input: "12", want: 1200},
+		{name: "one decimal", input: "12.5", want: 1250},
+		{name: "two decimals", input: "12.34", want: 1234},
+		{name: "rounds down below half", input: "12.344", want: 1234},
+		{name: "rounds half up", input: "12.345", want: 1235},
+		{name: "ignores digits past the third", input: "12.3449", want: 1234},
+		{name: "rounds into the next unit", input: "0.995", want: 100},
+		{name: "leading dot", input: ".5", want: 50},
+		{name: "negative", input: "-12.5", want: -1250},
+		{name: "negative rounds away from zero", input: "-12.345", want: -1235},
+		{name: "negative leading dot", input: "-.05", want: -5},
+		{name: "surrounding space", input: " 3.10 ", want: 310},
+		{name: "exponent", input: "1.5e2", want: 15000},
+		{name: "largest amount", input: "92233720368547757.99", want: 9223372036854775799},
+		{name: "empty", input: "", wantErr: true},
+		{name: "lone dot", input: ".", wantErr: true},
+		{name: "letters", input: "abc", wantErr: true},
+		{name: "letters in fraction", input: "1.2x", wantErr: true},
+		{name: "two dots", input: "1.2.3", wantErr: true},
+		{name: "explicit plus", input: "+1", wantErr: true},
+		{name: "double minus", input: "--1", wantErr: true},
+		{name: "overflow", input: "92233720368547758", wantErr: true},
+		{name: "overflow past int64", input: "99999999999999999999", wantErr: true},
+		{name: "negative overflow", input: "-92233720368547758", wantErr: true},
+		{name: "exponent overflow", input: "1e20", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseMoney(%q) = %d, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseMoney(%q) = %d, %v, want %d", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Money
+		wantErr bool
+	}{
+		{name: "number", input: `12.5`, want: 1250},
+		{name: "quoted number", input: `"12.5"`, want: 1250},
+		{name: "rounded number", input: `0.125`, want: 13},
+		{name: "null keeps the value", input: `null`, want: 700},
+		{name: "invalid string", input: `"twelve"`, wantErr: true},
+		{name: "overflow", input: `1e30`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Money(700)
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("unmarshal %s = %d, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("unmarshal %s = %d, %v, want %d", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyMarshalJSON(t *testing.T) {
+	tests := []struct {
+		amount Money
+		want   string
+	}{
+		{amount: 1250, want: `12.5`},
+		{amount: 1234, want: `12.34`},
+		{amount: 1200, want: `12`},
+		{amount: 5, want: `0.05`},
+		{amount: 0, want: `0`},
+		{amount: -150, want: `-1.5`},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.amount)
+		if err != nil || string(got) != tt.want {
+			t.Errorf("marshal %d = %s, %v, want %s", int64(tt.amount), got, err, tt.want)
+		}
+	}
+}
+
+func TestMoneyFromFloat(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   Money
+	}{
+		{amount: 12.5, want: 1250},
+		{amount: 19.99, want: 1999},
+		{amount: 0.1 + 0.2, want: 30},
+		{amount: 0.125, want: 13},
+		{amount: -2.5, want: -250},
+		{amount: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := MoneyFromFloat(tt.amount); got != tt.want {
+			t.Errorf("MoneyFromFloat(%v) = %d, want %d", tt.amount, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Notification is a message queued for delivery through the configured
+// notification driver. Failed deliveries are retried with backoff until
+// they succeed or run out of attempts.
+type Notification struct {
+	NotificationID int64  `json:"notification_id"`
+	DedupeKey      string `json:"-"`
+	Kind           string `json:"kind"`
+	UserID         string `json:"user_id,omitempty"`
+	AuctionID      string `json:"auction_id,omitempty"`
+	// Push notifications carry a title, body and data; bid and auction
+	// updates carry a Payload instead
+	Title         string            `json:"title,omitempty"`
+	Body          string            `json:"body,omitempty"`
+	Data          map[string]string `json:"data,omitempty"`
+	Payload       json.RawMessage   `json:"payload,omitempty"`
+	Status        string            `json:"status"`           // pending, sending, sent, failed
+	Driver        string            `json:"driver,omitempty"` // the driver that delivered it
+	Attempts      int               `json:"attempts"`
+	NextAttemptAt time.Time         `json:"next_attempt_at"`
+	LastError     string            `json:"last_error,omitempty"`
+	SentAt        *time.Time        `json:"sent_at,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+// Notification kinds
+const (
+	// NotificationPush is a push notification to one user
+	NotificationPush = "push"
+	// NotificationBid announces a new bid on an auction
+	NotificationBid = "bid"
+	// NotificationAuctionUpdate updates the auction document viewers follow
+	NotificationAuctionUpdate = "auction_update"
+)
+
+// Notification delivery statuses
+const (
+	NotificationPending = "pending"
+	NotificationSending = "sending" // claimed by a replica until its lease runs out
+	NotificationSent    = "sent"
+	NotificationFailed  = "failed"
+)
+
+type NotificationsResponse struct {
+	Notifications []*Notification `json:"notifications"`
+}
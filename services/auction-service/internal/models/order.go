@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// OrderHandoff is a sale waiting to be turned into an order in order-service.
+// Each winning bid, or each drop claim, gets exactly one.
+type OrderHandoff struct {
+	BidID         string    `json:"bid_id"`
+	AuctionID     string    `json:"auction_id"`
+	BuyerID       string    `json:"buyer_id"`
+	Quantity      int       `json:"quantity"`
+	Price         Money     `json:"price"` // Per unit
+	PaymentDueAt  time.Time `json:"payment_due_at"`
+	OrderID       string    `json:"order_id,omitempty"`
+	Attempts      int       `json:"attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	LastError     string    `json:"last_error,omitempty"`
+	Resolution    string    `json:"resolution,omitempty"` // paid or defaulted, once the deadline has been checked
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Order hand-off resolutions
+const (
+	HandoffPaid      = "paid"
+	HandoffDefaulted = "defaulted"
+)
+
+// OrderCreatedPayload is the payload of an EventOrderCreated event
+type OrderCreatedPayload struct {
+	OrderID      string    `json:"order_id"`
+	BidID        string    `json:"bid_id"`
+	BuyerID      string    `json:"buyer_id"`
+	Quantity     int       `json:"quantity"`
+	Price        Money     `json:"price"`
+	PaymentDueAt time.Time `json:"payment_due_at"`
+}
+
+// AuctionOrder is the seller's view of one sale and how far its order has got
+type AuctionOrder struct {
+	OrderID       string    `json:"order_id,omitempty"`
+	BidID         string    `json:"bid_id"`
+	BuyerID       string    `json:"buyer_id"`
+	Quantity      int       `json:"quantity"`
+	Price         Money     `json:"price"`
+	PaymentDueAt  time.Time `json:"payment_due_at"`
+	Status        string    `json:"status"` // order status, or awaiting_order until order-service accepts it
+	PaymentStatus string    `json:"payment_status,omitempty"`
+}
+
+// AwaitingOrder is the status of a sale order-service has not accepted yet
+const AwaitingOrder = "awaiting_order"
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// SecondChanceOffer offers an auction to a runner-up at their own last bid
+// after the winner failed to pay
+type SecondChanceOffer struct {
+	OfferID     string     `json:"offer_id"`
+	AuctionID   string     `json:"auction_id"`
+	BidID       string     `json:"bid_id"`
+	BidderID    string     `json:"bidder_id"`
+	Amount      Money      `json:"amount"`
+	Status      string     `json:"status"` // pending, accepted, declined, expired
+	ExpiresAt   time.Time  `json:"expires_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// Second-chance offer statuses
+const (
+	OfferPending  = "pending"
+	OfferAccepted = "accepted"
+	OfferDeclined = "declined"
+	OfferExpired  = "expired"
+)
+
+// NonPaymentStrike records a user failing to pay for a sale they won
+type NonPaymentStrike struct {
+	BidID     string    `json:"bid_id"`
+	UserID    string    `json:"user_id"`
+	AuctionID string    `json:"auction_id"`
+	OrderID   string    `json:"order_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PaymentDefaultedPayload is the payload of an EventPaymentDefaulted event
+type PaymentDefaultedPayload struct {
+	SellerID string `json:"seller_id"`
+	BidID    string `json:"bid_id"`
+	BidderID string `json:"bidder_id"`
+	OrderID  string `json:"order_id"`
+}
+
+// SecondChanceOfferedPayload is the payload of an EventSecondChanceOffered event
+type SecondChanceOfferedPayload struct {
+	SellerID  string    `json:"seller_id"`
+	OfferID   string    `json:"offer_id"`
+	BidderID  string    `json:"bidder_id"`
+	Amount    Money     `json:"amount"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
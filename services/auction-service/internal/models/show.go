@@ -0,0 +1,102 @@
+package models
+
+import "time"
+
+// Show is a live stream selling a queue of lots back-to-back. The host
+// advances the queue and each lot runs as its own short live auction.
+type Show struct {
+	ShowID           string     `json:"show_id"`
+	HostID           string     `json:"host_id"`
+	Title            string     `json:"title"`
+	Description      string     `json:"description"`
+	Status           string     `json:"status"` // scheduled, live, ended
+	LotDuration      int        `json:"lot_duration_seconds"`
+	Currency         string     `json:"currency"` // lots are priced and auctioned in this currency
+	CurrentLotID     string     `json:"current_lot_id,omitempty"`
+	CurrentAuctionID string     `json:"current_auction_id,omitempty"`
+	StartedAt        *time.Time `json:"started_at,omitempty"`
+	EndedAt          *time.Time `json:"ended_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	Lots             []ShowLot  `json:"lots"`
+}
+
+// ShowLot is one item in a show's queue. AuctionID is set once the host
+// brings the lot up.
+type ShowLot struct {
+	LotID           string     `json:"lot_id"`
+	ShowID          string     `json:"show_id"`
+	Position        int        `json:"position"`
+	ProductID       string     `json:"product_id"`
+	Category        string     `json:"category,omitempty"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	StartingPrice   Money      `json:"starting_price"`
+	ReservePrice    Money      `json:"reserve_price"`
+	MinBidIncrement Money      `json:"min_bid_increment"`
+	BuyNowPrice     Money      `json:"buy_now_price,omitempty"`
+	Duration        int        `json:"duration_seconds,omitempty"` // 0 uses the show's lot duration
+	Status          string     `json:"status"`                     // queued, live, closed
+	AuctionID       string     `json:"auction_id,omitempty"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// Show statuses
+const (
+	ShowScheduled = "scheduled"
+	ShowLive      = "live"
+	ShowEnded     = "ended"
+)
+
+// Lot statuses
+const (
+	LotQueued = "queued"
+	LotLive   = "live"
+	LotClosed = "closed"
+)
+
+// Show events, recorded on the lot auctions so they reach the auction stream
+const (
+	EventLotStarted  = "lot_started"
+	EventLotAdvanced = "lot_advanced"
+)
+
+// LotStartedPayload is the payload of an EventLotStarted event
+type LotStartedPayload struct {
+	ShowID   string    `json:"show_id"`
+	LotID    string    `json:"lot_id"`
+	Position int       `json:"position"`
+	EndTime  time.Time `json:"end_time"`
+}
+
+// LotAdvancedPayload is the payload of an EventLotAdvanced event, recorded on
+// the outgoing lot's auction. Viewers follow NextAuctionID to the next lot.
+type LotAdvancedPayload struct {
+	ShowID        string `json:"show_id"`
+	NextLotID     string `json:"next_lot_id,omitempty"`
+	NextAuctionID string `json:"next_auction_id,omitempty"`
+	ShowEnded     bool   `json:"show_ended,omitempty"`
+}
+
+type CreateShowRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+	LotDuration int    `json:"lot_duration_seconds" binding:"min=0"`
+	Currency    string `json:"currency" binding:"omitempty,len=3"`
+}
+
+type AddLotRequest struct {
+	ProductID       string `json:"product_id" binding:"required"`
+	Title           string `json:"title" binding:"required"`
+	Description     string `json:"description"`
+	StartingPrice   Money  `json:"starting_price" binding:"required,min=0"`
+	ReservePrice    Money  `json:"reserve_price" binding:"min=0"`
+	MinBidIncrement Money  `json:"min_bid_increment" binding:"required,min=0"`
+	BuyNowPrice     Money  `json:"buy_now_price" binding:"min=0"`
+	Duration        int    `json:"duration_seconds" binding:"min=0"`
+}
+
+type ShowResponse struct {
+	Show Show `json:"show"`
+}
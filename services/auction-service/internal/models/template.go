@@ -0,0 +1,122 @@
+package models
+
+import "time"
+
+// AuctionTemplate is a seller's saved auction settings. Auctions made from it
+// copy everything but the timing: each one runs for Duration seconds from
+// its own start time.
+type AuctionTemplate struct {
+	TemplateID      string `json:"template_id"`
+	SellerID        string `json:"seller_id"`
+	Name            string `json:"name"`
+	ProductID       string `json:"product_id"`
+	Title           string `json:"title"`
+	Description     string `json:"description"`
+	Currency        string `json:"currency"`
+	Type            string `json:"type"`
+	PricingRule     string `json:"pricing_rule,omitempty"`
+	StartingPrice   Money  `json:"starting_price"`
+	ReservePrice    Money  `json:"reserve_price"`
+	MinBidIncrement Money  `json:"min_bid_increment"`
+	BuyNowPrice     Money  `json:"buy_now_price,omitempty"`
+	DepositAmount   Money  `json:"deposit_amount,omitempty"`
+	Duration        int    `json:"duration_seconds"`
+	// Soft close and drop settings, as on CreateAuctionRequest
+	SoftClose          bool           `json:"soft_close"`
+	SoftCloseWindow    int            `json:"soft_close_window_seconds,omitempty"`
+	SoftCloseExtension int            `json:"soft_close_extension_seconds,omitempty"`
+	MaxExtensions      int            `json:"max_extensions,omitempty"`
+	FloorPrice         Money          `json:"floor_price,omitempty"`
+	DropStep           Money          `json:"drop_step,omitempty"`
+	DropInterval       int            `json:"drop_interval_seconds,omitempty"`
+	Quantity           int            `json:"quantity,omitempty"`
+	Images             []AuctionImage `json:"images"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+}
+
+// AuctionSchedule creates an auction from a template at TimeOfDay in
+// Timezone on each of Weekdays (0 = Sunday; empty means every day). Auctions
+// are created as scheduled ahead of their start, and an occurrence missed
+// while the schedule could not run is skipped rather than started late.
+type AuctionSchedule struct {
+	ScheduleID    string    `json:"schedule_id"`
+	TemplateID    string    `json:"template_id"`
+	SellerID      string    `json:"seller_id"`
+	Weekdays      []int     `json:"weekdays"`
+	TimeOfDay     string    `json:"time"` // HH:MM, 24-hour
+	Timezone      string    `json:"timezone"`
+	Active        bool      `json:"active"`
+	NextRunAt     time.Time `json:"next_run_at"`
+	LastAuctionID string    `json:"last_auction_id,omitempty"`
+	// LastError explains why the schedule was switched off, for example
+	// because the template no longer makes a valid auction
+	LastError string    `json:"last_error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SaveTemplateRequest creates or replaces a template
+type SaveTemplateRequest struct {
+	Name            string `json:"name" binding:"required,max=255"`
+	ProductID       string `json:"product_id" binding:"required"`
+	Title           string `json:"title" binding:"required"`
+	Description     string `json:"description"`
+	Currency        string `json:"currency" binding:"omitempty,len=3"`
+	Type            string `json:"type" binding:"required,oneof=live scheduled drop sealed"`
+	PricingRule     string `json:"pricing_rule" binding:"omitempty,oneof=first_price second_price"`
+	StartingPrice   Money  `json:"starting_price" binding:"required,min=0"`
+	ReservePrice    Money  `json:"reserve_price" binding:"min=0"`
+	MinBidIncrement Money  `json:"min_bid_increment" binding:"required,min=0"`
+	BuyNowPrice     Money  `json:"buy_now_price" binding:"min=0"`
+	DepositAmount   Money  `json:"deposit_amount" binding:"min=0"`
+	Duration        int    `json:"duration_seconds" binding:"required,gt=0"`
+
+	SoftClose          bool `json:"soft_close"`
+	SoftCloseWindow    int  `json:"soft_close_window_seconds" binding:"min=0"`
+	SoftCloseExtension int  `json:"soft_close_extension_seconds" binding:"min=0"`
+	MaxExtensions      int  `json:"max_extensions" binding:"min=0"`
+
+	FloorPrice   Money `json:"floor_price" binding:"min=0"`
+	DropStep     Money `json:"drop_step" binding:"min=0"`
+	DropInterval int   `json:"drop_interval_seconds" binding:"min=0"`
+	Quantity     int   `json:"quantity" binding:"min=0"`
+
+	Images []ImageRequest `json:"images" binding:"max=20,dive"`
+}
+
+// CreateFromTemplateRequest starts an auction from a template. A zero
+// StartTime starts it now.
+type CreateFromTemplateRequest struct {
+	StartTime time.Time `json:"start_time"`
+}
+
+// RelistAuctionRequest puts an unsold auction up again with the same
+// settings and duration. A zero StartTime starts it now.
+type RelistAuctionRequest struct {
+	StartTime time.Time `json:"start_time"`
+}
+
+// CreateScheduleRequest repeats a template, e.g. every Friday at 21:00 in
+// Asia/Kuala_Lumpur
+type CreateScheduleRequest struct {
+	Weekdays []int  `json:"weekdays" binding:"max=7,dive,min=0,max=6"`
+	Time     string `json:"time" binding:"required"`
+	Timezone string `json:"timezone"` // IANA name; defaults to UTC
+}
+
+type TemplateResponse struct {
+	Template AuctionTemplate `json:"template"`
+}
+
+type TemplatesResponse struct {
+	Templates []*AuctionTemplate `json:"templates"`
+}
+
+type ScheduleResponse struct {
+	Schedule AuctionSchedule `json:"schedule"`
+}
+
+type SchedulesResponse struct {
+	Schedules []*AuctionSchedule `json:"schedules"`
+}
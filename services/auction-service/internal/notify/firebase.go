@@ -0,0 +1,41 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/pkg/firebase"
+)
+
+// FirebaseDriver delivers notifications through the Firebase functions
+type FirebaseDriver struct {
+	app firebase.FirebaseApp
+}
+
+func NewFirebaseDriver(app firebase.FirebaseApp) *FirebaseDriver {
+	return &FirebaseDriver{app: app}
+}
+
+func (d *FirebaseDriver) Name() string {
+	return DriverFirebase
+}
+
+func (d *FirebaseDriver) Deliver(ctx context.Context, notification *models.Notification) error {
+	switch notification.Kind {
+	case models.NotificationPush:
+		_, err := d.app.SendNotification(ctx, notification.UserID, notification.Title, notification.Body, notification.Data)
+		return err
+	case models.NotificationBid:
+		return d.app.SendBidNotification(ctx, notification.Payload)
+	case models.NotificationAuctionUpdate:
+		var update map[string]interface{}
+		if err := json.Unmarshal(notification.Payload, &update); err != nil {
+			return fmt.Errorf("failed to decode auction update: %w", err)
+		}
+		return d.app.UpdateAuction(ctx, notification.AuctionID, update)
+	default:
+		return fmt.Errorf("unknown notification kind %q", notification.Kind)
+	}
+}
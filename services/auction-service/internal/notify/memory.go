@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// MemoryDriver logs notifications and keeps them in memory instead of
+// sending them, so tests can see what would have gone out
+type MemoryDriver struct {
+	logger    *zap.Logger
+	mu        sync.Mutex
+	delivered []models.Notification
+	err       error
+}
+
+func NewMemoryDriver(logger *zap.Logger) *MemoryDriver {
+	return &MemoryDriver{logger: logger}
+}
+
+func (d *MemoryDriver) Name() string {
+	return DriverLog
+}
+
+func (d *MemoryDriver) Deliver(ctx context.Context, notification *models.Notification) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.err != nil {
+		return d.err
+	}
+	d.delivered = append(d.delivered, *notification)
+
+	d.logger.Info("Notification delivered",
+		zap.String("kind", notification.Kind),
+		zap.String("user_id", notification.UserID),
+		zap.String("auction_id", notification.AuctionID),
+		zap.String("title", notification.Title))
+	return nil
+}
+
+// Delivered returns the notifications delivered so far, oldest first
+func (d *MemoryDriver) Delivered() []models.Notification {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]models.Notification(nil), d.delivered...)
+}
+
+// FailWith makes every delivery fail with err until it is called with nil,
+// to simulate an outage
+func (d *MemoryDriver) FailWith(err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.err = err
+}
@@ -0,0 +1,40 @@
+// Package notify holds the drivers queued notifications are delivered
+// through. The queue itself lives in Postgres; a driver only has to make one
+// delivery attempt and report whether it worked.
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/pkg/firebase"
+)
+
+// Driver delivers a notification. Returning an error leaves the
+// notification queued to be retried later.
+type Driver interface {
+	Name() string
+	Deliver(ctx context.Context, notification *models.Notification) error
+}
+
+// Driver names accepted by New
+const (
+	DriverFirebase = "firebase"
+	DriverLog      = "log"
+)
+
+// New returns the driver called name. The log driver delivers nothing, so
+// it is only for local development and tests.
+func New(name string, app firebase.FirebaseApp, logger *zap.Logger) (Driver, error) {
+	switch name {
+	case DriverFirebase:
+		return NewFirebaseDriver(app), nil
+	case DriverLog:
+		return NewMemoryDriver(logger), nil
+	default:
+		return nil, fmt.Errorf("unknown notification driver %q", name)
+	}
+}
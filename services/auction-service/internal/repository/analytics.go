@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// Analytics count every unretracted bid, including bids whose bidder later
+// defaulted; they were real demand at the time.
+
+// GetBidRates buckets an auction's bids by minute within the range
+func (r *PostgresRepo) GetBidRates(ctx context.Context, auctionID string, window models.AnalyticsRange) ([]models.BidRate, error) {
+	query := `
+		SELECT date_trunc('minute', bid_time), COUNT(*), COUNT(DISTINCT bidder_id), MAX(amount)
+		FROM bids
+		WHERE auction_id = $1 AND retracted_at IS NULL
+			AND ($2::timestamp IS NULL OR bid_time >= $2)
+			AND ($3::timestamp IS NULL OR bid_time < $3)
+		GROUP BY 1
+		ORDER BY 1
+	`
+	rows, err := r.db.QueryContext(ctx, query, auctionID, window.From, window.To)
+	if err != nil {
+		r.logger.Error("Failed to query bid rates", zap.String("auction_id", auctionID), zap.Error(err))
+		return nil, fmt.Errorf("failed to query bid rates: %w", err)
+	}
+	defer rows.Close()
+
+	rates := []models.BidRate{}
+	for rows.Next() {
+		var rate models.BidRate
+		if err := rows.Scan(&rate.Minute, &rate.Bids, &rate.UniqueBidders, &rate.HighPrice); err != nil {
+			return nil, fmt.Errorf("failed to scan bid rate: %w", err)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, rows.Err()
+}
+
+// GetBidHistory returns an auction's bids within the range, oldest first
+func (r *PostgresRepo) GetBidHistory(ctx context.Context, auctionID string, window models.AnalyticsRange) ([]*models.Bid, error) {
+	query := `
+		SELECT ` + bidColumns + `
+		FROM bids
+		WHERE auction_id = $1 AND retracted_at IS NULL
+			AND ($2::timestamp IS NULL OR bid_time >= $2)
+			AND ($3::timestamp IS NULL OR bid_time < $3)
+		ORDER BY bid_time ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, auctionID, window.From, window.To)
+	if err != nil {
+		r.logger.Error("Failed to query bid history", zap.String("auction_id", auctionID), zap.Error(err))
+		return nil, fmt.Errorf("failed to query bid history: %w", err)
+	}
+	defer rows.Close()
+
+	var bids []*models.Bid
+	for rows.Next() {
+		bid, err := scanBid(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bid: %w", err)
+		}
+		bids = append(bids, bid)
+	}
+	return bids, rows.Err()
+}
+
+// GetSellerAuctionSummaries returns per-auction bid totals for a seller's
+// auctions ending within the range. Outcome and ratios are left to the caller.
+func (r *PostgresRepo) GetSellerAuctionSummaries(ctx context.Context, sellerID string, window models.AnalyticsRange) ([]models.AuctionSummary, error) {
+	query := `
+		SELECT a.auction_id, a.title, a.type, a.status, a.currency, a.start_time, a.end_time,
+			a.starting_price, COALESCE(a.reserve_price, 0), COALESCE(MAX(b.amount), 0),
+			CASE
+				WHEN a.type = 'drop' THEN COALESCE(ROUND(SUM(b.amount * b.quantity)::numeric / NULLIF(SUM(b.quantity), 0))::BIGINT, 0)
+				WHEN a.winner_id IS NOT NULL THEN a.current_price
+				ELSE 0
+			END,
+			CASE
+				WHEN a.type = 'drop' THEN a.quantity - a.quantity_remaining
+				WHEN a.winner_id IS NOT NULL THEN 1
+				ELSE 0
+			END,
+			COUNT(b.bid_id), COUNT(DISTINCT b.bidder_id)
+		FROM auctions a
+		LEFT JOIN bids b ON b.auction_id = a.auction_id AND b.retracted_at IS NULL
+		WHERE a.seller_id = $1
+			AND ($2::timestamp IS NULL OR a.end_time >= $2)
+			AND ($3::timestamp IS NULL OR a.end_time < $3)
+		GROUP BY a.auction_id
+		ORDER BY a.end_time, a.auction_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, sellerID, window.From, window.To)
+	if err != nil {
+		r.logger.Error("Failed to query seller auction summaries", zap.String("seller_id", sellerID), zap.Error(err))
+		return nil, fmt.Errorf("failed to query seller auction summaries: %w", err)
+	}
+	defer rows.Close()
+
+	summaries := []models.AuctionSummary{}
+	for rows.Next() {
+		var s models.AuctionSummary
+		if err := rows.Scan(&s.AuctionID, &s.Title, &s.Type, &s.Status, &s.Currency, &s.StartTime, &s.EndTime,
+			&s.StartingPrice, &s.ReservePrice, &s.HighestBid, &s.HammerPrice, &s.UnitsSold,
+			&s.TotalBids, &s.UniqueBidders); err != nil {
+			return nil, fmt.Errorf("failed to scan auction summary: %w", err)
+		}
+		summaries = append(summaries, s)
+	}
+	return summaries, rows.Err()
+}
+
+// CountSellerBidders counts the distinct users who bid on a seller's
+// auctions ending within the range
+func (r *PostgresRepo) CountSellerBidders(ctx context.Context, sellerID string, window models.AnalyticsRange) (int, error) {
+	query := `
+		SELECT COUNT(DISTINCT b.bidder_id)
+		FROM bids b
+		JOIN auctions a ON a.auction_id = b.auction_id
+		WHERE a.seller_id = $1 AND b.retracted_at IS NULL
+			AND ($2::timestamp IS NULL OR a.end_time >= $2)
+			AND ($3::timestamp IS NULL OR a.end_time < $3)
+	`
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, sellerID, window.From, window.To).Scan(&count); err != nil {
+		r.logger.Error("Failed to count seller bidders", zap.String("seller_id", sellerID), zap.Error(err))
+		return 0, fmt.Errorf("failed to count seller bidders: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// CreateAuditEntry appends to an auction's audit log. Call it with the
+// transaction that makes the change so the history cannot drift from it.
+func (r *PostgresRepo) CreateAuditEntry(ctx context.Context, entry *models.AuditEntry) error {
+	if len(entry.Details) == 0 {
+		entry.Details = []byte("{}")
+	}
+
+	query := `INSERT INTO auction_audit_log (auction_id, actor_id, actor_role, action, from_status, to_status, reason, details, created_at)
+		VALUES ($1, NULLIF($2, ''), $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), $8, $9)
+		RETURNING entry_id`
+	err := r.db.QueryRowContext(ctx, query, entry.AuctionID, entry.ActorID, entry.ActorRole, entry.Action,
+		entry.FromStatus, entry.ToStatus, entry.Reason, []byte(entry.Details), entry.CreatedAt).Scan(&entry.EntryID)
+	if err != nil {
+		r.logger.Error("Failed to write audit entry", zap.String("auction_id", entry.AuctionID), zap.String("action", entry.Action), zap.Error(err))
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
+// GetAuditLog returns an auction's audit entries in the order they happened
+func (r *PostgresRepo) GetAuditLog(ctx context.Context, auctionID string) ([]*models.AuditEntry, error) {
+	query := `SELECT entry_id, auction_id, COALESCE(actor_id, ''), actor_role, action,
+			COALESCE(from_status, ''), COALESCE(to_status, ''), COALESCE(reason, ''), details, created_at
+		FROM auction_audit_log
+		WHERE auction_id = $1
+		ORDER BY entry_id`
+
+	rows, err := r.db.QueryContext(ctx, query, auctionID)
+	if err != nil {
+		r.logger.Error("Failed to query audit log", zap.String("auction_id", auctionID), zap.Error(err))
+		return nil, fmt.Errorf("failed to query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*models.AuditEntry{}
+	for rows.Next() {
+		var entry models.AuditEntry
+		var details []byte
+		if err := rows.Scan(&entry.EntryID, &entry.AuctionID, &entry.ActorID, &entry.ActorRole, &entry.Action,
+			&entry.FromStatus, &entry.ToStatus, &entry.Reason, &details, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		entry.Details = details
+		entries = append(entries, &entry)
+	}
+	return entries, rows.Err()
+}
+
+// CancelAuction withdraws an auction and records why
+func (r *PostgresRepo) CancelAuction(ctx context.Context, auctionID, reason string, at time.Time) error {
+	query := `UPDATE auctions SET status = 'cancelled', cancellation_reason = NULLIF($2, ''), updated_at = $3 WHERE auction_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, auctionID, reason, at); err != nil {
+		r.logger.Error("Failed to cancel auction", zap.String("auction_id", auctionID), zap.Error(err))
+		return fmt.Errorf("failed to cancel auction: %w", err)
+	}
+	return nil
+}
+
+// GetBid returns one bid, including retracted ones
+func (r *PostgresRepo) GetBid(ctx context.Context, bidID string) (*models.Bid, error) {
+	bid, err := scanBid(r.db.QueryRowContext(ctx, `SELECT `+bidColumns+` FROM bids WHERE bid_id = $1`, bidID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return bid, err
+}
+
+// RetractBid withdraws a bid. It stays on record for the audit trail but no
+// longer counts towards the price or the winner.
+func (r *PostgresRepo) RetractBid(ctx context.Context, bidID, reason string, at time.Time) error {
+	query := `WITH retracted AS (
+			UPDATE bids SET retracted_at = $2, retraction_reason = $3, is_winning = false
+			WHERE bid_id = $1 AND retracted_at IS NULL
+			RETURNING auction_id
+		)
+		UPDATE auctions SET bid_count = GREATEST(bid_count - 1, 0) WHERE auction_id = (SELECT auction_id FROM retracted)`
+	if _, err := r.db.ExecContext(ctx, query, bidID, at, reason); err != nil {
+		r.logger.Error("Failed to retract bid", zap.String("bid_id", bidID), zap.Error(err))
+		return fmt.Errorf("failed to retract bid: %w", err)
+	}
+	return nil
+}
+
+// ResetAuctionPrice sets the current price without the monotonic guard of
+// UpdateAuctionPrice; only a retraction may lower the price
+func (r *PostgresRepo) ResetAuctionPrice(ctx context.Context, auctionID string, price models.Money) error {
+	query := `UPDATE auctions SET current_price = $2, updated_at = $3 WHERE auction_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, auctionID, price, time.Now()); err != nil {
+		r.logger.Error("Failed to reset auction price", zap.String("auction_id", auctionID), zap.Error(err))
+		return fmt.Errorf("failed to reset auction price: %w", err)
+	}
+	return nil
+}
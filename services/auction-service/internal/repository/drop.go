@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// ClaimNextDropDue locks one active drop auction whose next price step is due.
+// Rows locked by another replica are skipped.
+func (r *PostgresRepo) ClaimNextDropDue(ctx context.Context, now time.Time) (*models.Auction, error) {
+	query := `SELECT ` + auctionColumns + ` FROM auctions
+		WHERE status = 'active' AND next_drop_at IS NOT NULL AND next_drop_at <= $1
+		ORDER BY next_drop_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`
+	auction, err := scanAuction(r.db.QueryRowContext(ctx, query, now))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return auction, err
+}
+
+// UpdateDropState stores a drop auction's current price, next step time and
+// remaining quantity. Unlike UpdateAuctionPrice it may lower the price.
+func (r *PostgresRepo) UpdateDropState(ctx context.Context, auction *models.Auction) error {
+	query := `UPDATE auctions SET current_price = $2, next_drop_at = $3, quantity_remaining = $4, updated_at = $5 WHERE auction_id = $1`
+	_, err := r.db.ExecContext(ctx, query, auction.AuctionID, auction.CurrentPrice, auction.NextDropAt, auction.QuantityRemaining, time.Now())
+	if err != nil {
+		r.logger.Error("Failed to update drop auction", zap.String("auction_id", auction.AuctionID), zap.Error(err))
+		return fmt.Errorf("failed to update drop auction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// GetOpenCommitment totals what a bidder stands to pay across the active
+// auctions they are currently leading, other than excludeAuctionID. A leading
+// bid backed by a higher proxy maximum counts at the maximum, and every
+// sealed bid counts because it may yet win.
+func (r *PostgresRepo) GetOpenCommitment(ctx context.Context, bidderID, excludeAuctionID string) (models.Money, error) {
+	query := `SELECT COALESCE(SUM(GREATEST(b.amount, COALESCE(p.max_amount, 0))), 0)::BIGINT
+		FROM bids b
+		JOIN auctions a ON a.auction_id = b.auction_id
+		LEFT JOIN proxy_bids p ON p.auction_id = b.auction_id AND p.bidder_id = b.bidder_id
+		WHERE b.bidder_id = $1 AND b.retracted_at IS NULL
+			AND (b.is_winning OR a.type = 'sealed')
+			AND a.status = 'active' AND a.auction_id <> $2`
+	var total models.Money
+	if err := r.db.QueryRowContext(ctx, query, bidderID, excludeAuctionID).Scan(&total); err != nil {
+		r.logger.Error("Failed to sum open commitment", zap.String("bidder_id", bidderID), zap.Error(err))
+		return 0, fmt.Errorf("failed to sum open commitment: %w", err)
+	}
+	return total, nil
+}
+
+// bidDepositColumns is the column list read by scanBidDeposit
+const bidDepositColumns = `auction_id, bidder_id, amount, payment_deposit_id, status,
+			attempts, next_attempt_at, COALESCE(last_error, ''), created_at, updated_at`
+
+func scanBidDeposit(row rowScanner) (*models.BidDeposit, error) {
+	deposit := &models.BidDeposit{}
+	err := row.Scan(&deposit.AuctionID, &deposit.BidderID, &deposit.Amount, &deposit.PaymentDepositID, &deposit.Status,
+		&deposit.Attempts, &deposit.NextAttemptAt, &deposit.LastError, &deposit.CreatedAt, &deposit.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return deposit, nil
+}
+
+// GetBidDeposit returns a bidder's deposit on an auction, or nil if they have
+// not placed one
+func (r *PostgresRepo) GetBidDeposit(ctx context.Context, auctionID, bidderID string) (*models.BidDeposit, error) {
+	query := `SELECT ` + bidDepositColumns + ` FROM bid_deposits WHERE auction_id = $1 AND bidder_id = $2`
+	deposit, err := scanBidDeposit(r.db.QueryRowContext(ctx, query, auctionID, bidderID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.Error("Failed to get bid deposit", zap.String("auction_id", auctionID), zap.Error(err))
+		return nil, fmt.Errorf("failed to get bid deposit: %w", err)
+	}
+	return deposit, nil
+}
+
+// SaveBidDeposit records a deposit payment-service has authorised. A bidder
+// whose earlier deposit was already released can hold a new one.
+func (r *PostgresRepo) SaveBidDeposit(ctx context.Context, deposit *models.BidDeposit) error {
+	query := `INSERT INTO bid_deposits (auction_id, bidder_id, amount, payment_deposit_id, status, next_attempt_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6, $6)
+		ON CONFLICT (auction_id, bidder_id) DO UPDATE SET
+			amount = EXCLUDED.amount, payment_deposit_id = EXCLUDED.payment_deposit_id, status = EXCLUDED.status,
+			attempts = 0, next_attempt_at = EXCLUDED.next_attempt_at, last_error = NULL, updated_at = EXCLUDED.updated_at`
+	_, err := r.db.ExecContext(ctx, query, deposit.AuctionID, deposit.BidderID, deposit.Amount, deposit.PaymentDepositID,
+		deposit.Status, deposit.CreatedAt)
+	if err != nil {
+		r.logger.Error("Failed to save bid deposit", zap.String("auction_id", deposit.AuctionID), zap.Error(err))
+		return fmt.Errorf("failed to save bid deposit: %w", err)
+	}
+	return nil
+}
+
+// ReleaseBidDeposits queues every deposit still held on an auction for
+// release, except keepBidderID's
+func (r *PostgresRepo) ReleaseBidDeposits(ctx context.Context, auctionID, keepBidderID string, at time.Time) error {
+	query := `UPDATE bid_deposits SET status = $3, next_attempt_at = $4, updated_at = $4
+		WHERE auction_id = $1 AND status = $2 AND bidder_id <> $5`
+	if _, err := r.db.ExecContext(ctx, query, auctionID, models.DepositHeld, models.DepositReleasePending, at, keepBidderID); err != nil {
+		r.logger.Error("Failed to queue deposit releases", zap.String("auction_id", auctionID), zap.Error(err))
+		return fmt.Errorf("failed to queue deposit releases: %w", err)
+	}
+	return nil
+}
+
+// QueueBidDeposit moves one bidder's held deposit to release_pending or
+// capture_pending. It does nothing if the bidder holds no deposit.
+func (r *PostgresRepo) QueueBidDeposit(ctx context.Context, auctionID, bidderID, status string, at time.Time) error {
+	query := `UPDATE bid_deposits SET status = $4, next_attempt_at = $5, updated_at = $5
+		WHERE auction_id = $1 AND bidder_id = $2 AND status = $3`
+	if _, err := r.db.ExecContext(ctx, query, auctionID, bidderID, models.DepositHeld, status, at); err != nil {
+		r.logger.Error("Failed to queue bid deposit", zap.String("auction_id", auctionID), zap.Error(err))
+		return fmt.Errorf("failed to queue bid deposit: %w", err)
+	}
+	return nil
+}
+
+// ClaimNextDepositSettlement leases the next deposit waiting to be released
+// or captured until leaseUntil and returns it. Deposits leased by another
+// replica are not due again until their lease runs out. Run it outside a
+// transaction so the lease commits before payment-service is called.
+func (r *PostgresRepo) ClaimNextDepositSettlement(ctx context.Context, now, leaseUntil time.Time) (*models.BidDeposit, error) {
+	query := `UPDATE bid_deposits SET next_attempt_at = $4
+		WHERE (auction_id, bidder_id) = (
+			SELECT auction_id, bidder_id FROM bid_deposits
+			WHERE status IN ($2, $3) AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + bidDepositColumns
+	deposit, err := scanBidDeposit(r.db.QueryRowContext(ctx, query, now, models.DepositReleasePending, models.DepositCapturePending, leaseUntil))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return deposit, err
+}
+
+// CompleteDepositSettlement records that payment-service released or
+// captured a deposit. Like a retry it only applies while the deposit is still
+// waiting to be settled.
+func (r *PostgresRepo) CompleteDepositSettlement(ctx context.Context, auctionID, bidderID, status string, at time.Time) error {
+	query := `UPDATE bid_deposits SET status = $3, attempts = attempts + 1, last_error = NULL, updated_at = $4
+		WHERE auction_id = $1 AND bidder_id = $2 AND status IN ($5, $6)`
+	if _, err := r.db.ExecContext(ctx, query, auctionID, bidderID, status, at, models.DepositReleasePending, models.DepositCapturePending); err != nil {
+		r.logger.Error("Failed to complete deposit settlement", zap.String("auction_id", auctionID), zap.Error(err))
+		return fmt.Errorf("failed to complete deposit settlement: %w", err)
+	}
+	return nil
+}
+
+// RetryDepositSettlement records a failed attempt and when to try again
+func (r *PostgresRepo) RetryDepositSettlement(ctx context.Context, auctionID, bidderID string, nextAttemptAt time.Time, lastError string) error {
+	query := `UPDATE bid_deposits SET attempts = attempts + 1, next_attempt_at = $3, last_error = $4
+		WHERE auction_id = $1 AND bidder_id = $2 AND status IN ($5, $6)`
+	if _, err := r.db.ExecContext(ctx, query, auctionID, bidderID, nextAttemptAt, lastError, models.DepositReleasePending, models.DepositCapturePending); err != nil {
+		r.logger.Error("Failed to reschedule deposit settlement", zap.String("auction_id", auctionID), zap.Error(err))
+		return fmt.Errorf("failed to reschedule deposit settlement: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// CreateEvent appends an event to the auction_events outbox. Call it with the
+// transaction that performs the state change so both commit together.
+//
+// The event is numbered after the auction's last event while holding the
+// auction row lock, which makes an auction's sequences gap-free and in commit
+// order. Writers normally hold the lock already; taking it here covers the
+// ones that do not.
+func (r *PostgresRepo) CreateEvent(ctx context.Context, event *models.AuctionEvent) error {
+	if len(event.Payload) == 0 {
+		event.Payload = []byte("{}")
+	}
+
+	var locked string
+	if err := r.db.QueryRowContext(ctx, `SELECT auction_id FROM auctions WHERE auction_id = $1 FOR UPDATE`, event.AuctionID).Scan(&locked); err != nil {
+		r.logger.Error("Failed to lock auction for event", zap.String("auction_id", event.AuctionID), zap.Error(err))
+		return fmt.Errorf("failed to lock auction for event: %w", err)
+	}
+
+	// A separate statement, so the MAX sees everything committed before the
+	// lock was granted
+	query := `
+		INSERT INTO auction_events (auction_id, sequence, event_type, payload, created_at)
+		VALUES ($1, (SELECT COALESCE(MAX(sequence), 0) + 1 FROM auction_events WHERE auction_id = $1), $2, $3, $4)
+		RETURNING event_id, sequence
+	`
+	err := r.db.QueryRowContext(ctx, query, event.AuctionID, event.Type, []byte(event.Payload), event.CreatedAt).Scan(&event.EventID, &event.Sequence)
+	if err != nil {
+		r.logger.Error("Failed to create auction event", zap.String("auction_id", event.AuctionID), zap.String("type", event.Type), zap.Error(err))
+		return fmt.Errorf("failed to create auction event: %w", err)
+	}
+	return nil
+}
+
+// ClaimPendingEvents claims the undispatched events of up to limit auctions,
+// oldest first, and returns each auction's events together in sequence order.
+// Auctions with an event waiting out its retry backoff are left until it is
+// due.
+// An auction is claimed with a transaction-level advisory lock, so while one
+// replica relays it the others skip all of its events and cannot publish a
+// later one first.
+func (r *PostgresRepo) ClaimPendingEvents(ctx context.Context, now time.Time, limit int) ([]*models.AuctionEvent, error) {
+	// The lock is taken in the outer query so LIMIT stops it at limit
+	// claimed auctions, skipping ones another replica holds
+	query := `
+		SELECT auction_id
+		FROM (
+			SELECT auction_id
+			FROM auction_events
+			WHERE dispatched_at IS NULL
+			GROUP BY auction_id
+			HAVING MAX(next_attempt_at) IS NULL OR MAX(next_attempt_at) <= $2
+			ORDER BY MIN(event_id)
+		) pending
+		WHERE pg_try_advisory_xact_lock(hashtextextended('auction_events:' || auction_id, 0))
+		LIMIT $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, now)
+	if err != nil {
+		r.logger.Error("Failed to claim pending events", zap.Error(err))
+		return nil, fmt.Errorf("failed to claim pending events: %w", err)
+	}
+	var claimed []string
+	for rows.Next() {
+		var auctionID string
+		if err := rows.Scan(&auctionID); err != nil {
+			rows.Close()
+			r.logger.Error("Failed to scan pending event auction", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan pending event auction: %w", err)
+		}
+		claimed = append(claimed, auctionID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(claimed) == 0 {
+		return nil, nil
+	}
+
+	// Read after locking, so events another replica relayed meanwhile are
+	// seen as dispatched
+	query = `
+		SELECT event_id, auction_id, sequence, event_type, payload, created_at, attempts
+		FROM auction_events
+		WHERE auction_id = ANY($1) AND dispatched_at IS NULL
+		ORDER BY auction_id, sequence
+	`
+	return r.queryEvents(ctx, query, pq.Array(claimed))
+}
+
+// GetEventsAfter returns up to limit events for one auction with sequences
+// above afterSequence, oldest first
+func (r *PostgresRepo) GetEventsAfter(ctx context.Context, auctionID string, afterSequence int64, limit int) ([]*models.AuctionEvent, error) {
+	query := `
+		SELECT event_id, auction_id, sequence, event_type, payload, created_at, attempts
+		FROM auction_events
+		WHERE auction_id = $1 AND sequence > $2
+		ORDER BY sequence
+		LIMIT $3
+	`
+	return r.queryEvents(ctx, query, auctionID, afterSequence, limit)
+}
+
+func (r *PostgresRepo) queryEvents(ctx context.Context, query string, args ...interface{}) ([]*models.AuctionEvent, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Failed to query auction events", zap.Error(err))
+		return nil, fmt.Errorf("failed to query auction events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*models.AuctionEvent
+	for rows.Next() {
+		var event models.AuctionEvent
+		var payload []byte
+		if err := rows.Scan(&event.EventID, &event.AuctionID, &event.Sequence, &event.Type, &payload, &event.CreatedAt, &event.Attempts); err != nil {
+			r.logger.Error("Failed to scan auction event", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan auction event: %w", err)
+		}
+		event.Payload = payload
+		events = append(events, &event)
+	}
+
+	return events, rows.Err()
+}
+
+// MarkEventDispatched records that an event has been handed to subscribers
+func (r *PostgresRepo) MarkEventDispatched(ctx context.Context, eventID int64) error {
+	query := `UPDATE auction_events SET dispatched_at = $2 WHERE event_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, eventID, time.Now()); err != nil {
+		r.logger.Error("Failed to mark event dispatched", zap.Int64("event_id", eventID), zap.Error(err))
+		return fmt.Errorf("failed to mark event dispatched: %w", err)
+	}
+	return nil
+}
+
+// RetryEvent leaves an event pending after a handler failed and sets when the
+// relay may try it again
+func (r *PostgresRepo) RetryEvent(ctx context.Context, eventID int64, nextAttemptAt time.Time, lastError string) error {
+	query := `UPDATE auction_events SET attempts = attempts + 1, next_attempt_at = $2, last_error = $3 WHERE event_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, eventID, nextAttemptAt, lastError); err != nil {
+		r.logger.Error("Failed to schedule event retry", zap.Int64("event_id", eventID), zap.Error(err))
+		return fmt.Errorf("failed to schedule event retry: %w", err)
+	}
+	return nil
+}
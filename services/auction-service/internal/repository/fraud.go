@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// fraudFlagColumns is the column list read by scanFraudFlag
+const fraudFlagColumns = `flag_id, auction_id, seller_id, score, signals, status,
+	COALESCE(reviewer_id, ''), COALESCE(review_note, ''), reviewed_at, created_at`
+
+func scanFraudFlag(row rowScanner) (*models.FraudFlag, error) {
+	flag := &models.FraudFlag{}
+	var signals []byte
+	var reviewedAt sql.NullTime
+	err := row.Scan(&flag.FlagID, &flag.AuctionID, &flag.SellerID, &flag.Score, &signals, &flag.Status,
+		&flag.ReviewerID, &flag.ReviewNote, &reviewedAt, &flag.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(signals, &flag.Signals); err != nil {
+		return nil, fmt.Errorf("failed to decode fraud signals: %w", err)
+	}
+	if reviewedAt.Valid {
+		flag.ReviewedAt = &reviewedAt.Time
+	}
+	return flag, nil
+}
+
+// ClaimNextFraudScan locks the next ended auction the detection job has not
+// scored yet. Auctions locked by another replica are skipped.
+func (r *PostgresRepo) ClaimNextFraudScan(ctx context.Context) (*models.Auction, error) {
+	query := `SELECT ` + auctionColumns + ` FROM auctions
+		WHERE status = 'ended' AND fraud_scanned_at IS NULL
+		ORDER BY end_time
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`
+	auction, err := scanAuction(r.db.QueryRowContext(ctx, query))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return auction, err
+}
+
+// MarkFraudScanned records that the detection job has scored an auction
+func (r *PostgresRepo) MarkFraudScanned(ctx context.Context, auctionID string, at time.Time) error {
+	query := `UPDATE auctions SET fraud_scanned_at = $2 WHERE auction_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, auctionID, at); err != nil {
+		r.logger.Error("Failed to mark auction fraud-scanned", zap.String("auction_id", auctionID), zap.Error(err))
+		return fmt.Errorf("failed to mark auction fraud-scanned: %w", err)
+	}
+	return nil
+}
+
+// GetSellerBidderStats counts, for each bidder, the seller's ended auctions
+// since the given time that they bid on and won
+func (r *PostgresRepo) GetSellerBidderStats(ctx context.Context, sellerID string, bidderIDs []string, since time.Time) ([]models.SellerBidderStats, error) {
+	query := `
+		SELECT b.bidder_id, COUNT(DISTINCT a.auction_id),
+			COUNT(DISTINCT a.auction_id) FILTER (WHERE a.winner_id = b.bidder_id)
+		FROM bids b
+		JOIN auctions a ON a.auction_id = b.auction_id
+		WHERE a.seller_id = $1 AND a.status = 'ended' AND a.end_time >= $2
+			AND b.bidder_id = ANY($3) AND b.retracted_at IS NULL
+		GROUP BY b.bidder_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, sellerID, since, pq.Array(bidderIDs))
+	if err != nil {
+		r.logger.Error("Failed to query seller bidder stats", zap.String("seller_id", sellerID), zap.Error(err))
+		return nil, fmt.Errorf("failed to query seller bidder stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []models.SellerBidderStats
+	for rows.Next() {
+		var s models.SellerBidderStats
+		if err := rows.Scan(&s.BidderID, &s.Auctions, &s.Wins); err != nil {
+			return nil, fmt.Errorf("failed to scan seller bidder stats: %w", err)
+		}
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
+
+// CreateFraudFlag adds an auction to the moderation queue; an auction is
+// flagged at most once
+func (r *PostgresRepo) CreateFraudFlag(ctx context.Context, flag *models.FraudFlag) error {
+	signals, err := json.Marshal(flag.Signals)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO fraud_flags (flag_id, auction_id, seller_id, score, signals, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (auction_id) DO NOTHING`
+	if _, err := r.db.ExecContext(ctx, query, flag.FlagID, flag.AuctionID, flag.SellerID, flag.Score, signals, flag.Status, flag.CreatedAt); err != nil {
+		r.logger.Error("Failed to create fraud flag", zap.String("auction_id", flag.AuctionID), zap.Error(err))
+		return fmt.Errorf("failed to create fraud flag: %w", err)
+	}
+	return nil
+}
+
+// GetFraudFlags lists flags with the given status, highest score first
+func (r *PostgresRepo) GetFraudFlags(ctx context.Context, status string, limit int) ([]*models.FraudFlag, error) {
+	query := `SELECT ` + fraudFlagColumns + ` FROM fraud_flags
+		WHERE status = $1
+		ORDER BY score DESC, created_at
+		LIMIT $2`
+	rows, err := r.db.QueryContext(ctx, query, status, limit)
+	if err != nil {
+		r.logger.Error("Failed to query fraud flags", zap.Error(err))
+		return nil, fmt.Errorf("failed to query fraud flags: %w", err)
+	}
+	defer rows.Close()
+
+	flags := []*models.FraudFlag{}
+	for rows.Next() {
+		flag, err := scanFraudFlag(rows)
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, flag)
+	}
+	return flags, rows.Err()
+}
+
+func (r *PostgresRepo) GetFraudFlag(ctx context.Context, flagID string) (*models.FraudFlag, error) {
+	query := `SELECT ` + fraudFlagColumns + ` FROM fraud_flags WHERE flag_id = $1`
+	return scanFraudFlag(r.db.QueryRowContext(ctx, query, flagID))
+}
+
+// ReviewFraudFlag records a moderator's decision on an open flag. It reports
+// false if the flag was not open.
+func (r *PostgresRepo) ReviewFraudFlag(ctx context.Context, flagID, status, reviewerID, note string, at time.Time) (bool, error) {
+	query := `UPDATE fraud_flags SET status = $2, reviewer_id = $3, review_note = $4, reviewed_at = $5
+		WHERE flag_id = $1 AND status = 'open'`
+	result, err := r.db.ExecContext(ctx, query, flagID, status, reviewerID, note, at)
+	if err != nil {
+		r.logger.Error("Failed to review fraud flag", zap.String("flag_id", flagID), zap.Error(err))
+		return false, fmt.Errorf("failed to review fraud flag: %w", err)
+	}
+	reviewed, err := result.RowsAffected()
+	return reviewed > 0, err
+}
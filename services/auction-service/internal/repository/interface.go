@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
+
 	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
 )
 
@@ -13,13 +15,128 @@ type DBTX interface {
 	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
 }
 
+// Tx is a transaction begun by AuctionRepo.BeginTx. Rollback after Commit
+// is a no-op, so callers can always defer it.
+type Tx interface {
+	Commit() error
+	Rollback() error
+}
+
 type AuctionRepo interface {
 	Create(ctx context.Context, auction *models.Auction) error
 	GetByID(ctx context.Context, id string) (*models.Auction, error)
-	List(ctx context.Context) ([]*models.Auction, error)
+	GetByIDForUpdate(ctx context.Context, id string) (*models.Auction, error)
+	Update(ctx context.Context, auction *models.Auction) error
+	List(ctx context.Context, filter *models.AuctionFilter) ([]*models.Auction, int64, error)
 	GetActive(ctx context.Context) ([]*models.Auction, error)
-	UpdateAuctionPrice(ctx context.Context, id string, price float64) error
+	UpdateAuctionPrice(ctx context.Context, id string, price models.Money) error
+	ExtendEndTime(ctx context.Context, id string, endTime time.Time, extensionCount int) error
 	CreateBid(ctx context.Context, bid *models.Bid) error
-	BeginTx(ctx context.Context) (*sql.Tx, error)
-	WithTx(tx *sql.Tx) AuctionRepo
+	GetBids(ctx context.Context, auctionID string) (*models.BidsResponse, error)
+	CountBids(ctx context.Context, auctionID string) (int, error)
+	GetWinningBid(ctx context.Context, auctionID string) (*models.Bid, error)
+	UpdateAuctionStatus(ctx context.Context, auctionID string, status string) error
+	ClaimNextToStart(ctx context.Context, now time.Time) (*models.Auction, error)
+	ClaimNextToEnd(ctx context.Context, now time.Time) (*models.Auction, error)
+	ClaimNextDropDue(ctx context.Context, now time.Time) (*models.Auction, error)
+	UpdateDropState(ctx context.Context, auction *models.Auction) error
+	GetHighestBid(ctx context.Context, auctionID string) (*models.Bid, error)
+	GetTopBids(ctx context.Context, auctionID string, limit int) ([]*models.Bid, error)
+	GetBidsByBidder(ctx context.Context, auctionID, bidderID string) ([]*models.Bid, error)
+	ReviseBid(ctx context.Context, bid *models.Bid) error
+	AddWatch(ctx context.Context, auctionID, userID string) (bool, error)
+	RemoveWatch(ctx context.Context, auctionID, userID string) (bool, error)
+	IsWatching(ctx context.Context, auctionID, userID string) (bool, error)
+	GetWatcherIDs(ctx context.Context, auctionID string) ([]string, error)
+	GetWatchedAuctions(ctx context.Context, userID string) ([]*models.Auction, error)
+	ClaimNextEndingSoon(ctx context.Context, cutoff time.Time) (*models.Auction, error)
+	MarkEndingSoon(ctx context.Context, auctionID string, at time.Time) error
+	SetWinningBid(ctx context.Context, auctionID, bidID string) error
+	SettleAuction(ctx context.Context, auction *models.Auction) error
+	CreateOrderHandoff(ctx context.Context, handoff *models.OrderHandoff) error
+	ClaimNextOrderHandoff(ctx context.Context, now, leaseUntil time.Time) (*models.OrderHandoff, error)
+	CompleteOrderHandoff(ctx context.Context, handoff *models.OrderHandoff) (bool, error)
+	RetryOrderHandoff(ctx context.Context, bidID string, nextAttemptAt time.Time, lastError string) error
+	GetOrderHandoffs(ctx context.Context, auctionID string) ([]*models.OrderHandoff, error)
+	ClaimNextLapsedPayment(ctx context.Context, now, leaseUntil time.Time) (*models.OrderHandoff, error)
+	ResolveOrderHandoff(ctx context.Context, bidID, resolution string, at time.Time) (bool, error)
+	MarkBidDefaulted(ctx context.Context, bidID string, at time.Time) error
+	ClearAuctionWinner(ctx context.Context, auctionID string) error
+	CreateStrike(ctx context.Context, strike *models.NonPaymentStrike) error
+	CountStrikes(ctx context.Context, userID string) (int, error)
+	GetNextSecondChanceBid(ctx context.Context, auctionID string, minAmount models.Money, strikeLimit int) (*models.Bid, error)
+	CreateSecondChanceOffer(ctx context.Context, offer *models.SecondChanceOffer) error
+	GetPendingOffer(ctx context.Context, auctionID string) (*models.SecondChanceOffer, error)
+	GetOfferForBidder(ctx context.Context, auctionID, bidderID string) (*models.SecondChanceOffer, error)
+	UpdateOfferStatus(ctx context.Context, offerID, status string, at time.Time) error
+	ClaimNextExpiredOffer(ctx context.Context, now time.Time) (*models.Auction, error)
+	CreateAuditEntry(ctx context.Context, entry *models.AuditEntry) error
+	GetAuditLog(ctx context.Context, auctionID string) ([]*models.AuditEntry, error)
+	CancelAuction(ctx context.Context, auctionID, reason string, at time.Time) error
+	GetBid(ctx context.Context, bidID string) (*models.Bid, error)
+	RetractBid(ctx context.Context, bidID, reason string, at time.Time) error
+	ResetAuctionPrice(ctx context.Context, auctionID string, price models.Money) error
+	DeleteProxyBid(ctx context.Context, auctionID, bidderID string) error
+	GetBidRates(ctx context.Context, auctionID string, window models.AnalyticsRange) ([]models.BidRate, error)
+	GetBidHistory(ctx context.Context, auctionID string, window models.AnalyticsRange) ([]*models.Bid, error)
+	GetSellerAuctionSummaries(ctx context.Context, sellerID string, window models.AnalyticsRange) ([]models.AuctionSummary, error)
+	CountSellerBidders(ctx context.Context, sellerID string, window models.AnalyticsRange) (int, error)
+	ClaimNextFraudScan(ctx context.Context) (*models.Auction, error)
+	MarkFraudScanned(ctx context.Context, auctionID string, at time.Time) error
+	GetSellerBidderStats(ctx context.Context, sellerID string, bidderIDs []string, since time.Time) ([]models.SellerBidderStats, error)
+	CreateFraudFlag(ctx context.Context, flag *models.FraudFlag) error
+	GetFraudFlags(ctx context.Context, status string, limit int) ([]*models.FraudFlag, error)
+	GetFraudFlag(ctx context.Context, flagID string) (*models.FraudFlag, error)
+	ReviewFraudFlag(ctx context.Context, flagID, status, reviewerID, note string, at time.Time) (bool, error)
+	GetOpenCommitment(ctx context.Context, bidderID, excludeAuctionID string) (models.Money, error)
+	GetBidDeposit(ctx context.Context, auctionID, bidderID string) (*models.BidDeposit, error)
+	SaveBidDeposit(ctx context.Context, deposit *models.BidDeposit) error
+	ReleaseBidDeposits(ctx context.Context, auctionID, keepBidderID string, at time.Time) error
+	QueueBidDeposit(ctx context.Context, auctionID, bidderID, status string, at time.Time) error
+	ClaimNextDepositSettlement(ctx context.Context, now, leaseUntil time.Time) (*models.BidDeposit, error)
+	CompleteDepositSettlement(ctx context.Context, auctionID, bidderID, status string, at time.Time) error
+	RetryDepositSettlement(ctx context.Context, auctionID, bidderID string, nextAttemptAt time.Time, lastError string) error
+	CreateShow(ctx context.Context, show *models.Show) error
+	GetShow(ctx context.Context, showID string) (*models.Show, error)
+	GetShowForUpdate(ctx context.Context, showID string) (*models.Show, error)
+	GetShowLots(ctx context.Context, showID string) ([]models.ShowLot, error)
+	CreateShowLot(ctx context.Context, lot *models.ShowLot) error
+	DeleteQueuedLot(ctx context.Context, showID, lotID string) (bool, error)
+	GetNextQueuedLot(ctx context.Context, showID string) (*models.ShowLot, error)
+	StartShowLot(ctx context.Context, lotID, auctionID string, at time.Time) error
+	CloseShowLot(ctx context.Context, lotID string) error
+	SetShowCurrentLot(ctx context.Context, showID, lotID, auctionID string, at time.Time) error
+	EndShow(ctx context.Context, showID string, at time.Time) error
+	CreateTemplate(ctx context.Context, template *models.AuctionTemplate) error
+	UpdateTemplate(ctx context.Context, template *models.AuctionTemplate) error
+	GetTemplate(ctx context.Context, templateID string) (*models.AuctionTemplate, error)
+	GetSellerTemplates(ctx context.Context, sellerID string) ([]*models.AuctionTemplate, error)
+	DeleteTemplate(ctx context.Context, templateID string) error
+	CreateSchedule(ctx context.Context, schedule *models.AuctionSchedule) error
+	GetTemplateSchedules(ctx context.Context, templateID string) ([]*models.AuctionSchedule, error)
+	DeleteSchedule(ctx context.Context, templateID, scheduleID string) (bool, error)
+	ClaimNextDueSchedule(ctx context.Context, cutoff time.Time) (*models.AuctionSchedule, error)
+	AdvanceSchedule(ctx context.Context, scheduleID string, nextRunAt time.Time, auctionID string, at time.Time) error
+	DeactivateSchedule(ctx context.Context, scheduleID, lastError string, at time.Time) error
+	GetRelisting(ctx context.Context, auctionID string) (*models.Auction, error)
+	EnqueueNotification(ctx context.Context, notification *models.Notification) (bool, error)
+	ClaimNextNotification(ctx context.Context, now, leaseUntil time.Time) (*models.Notification, error)
+	CompleteNotification(ctx context.Context, notificationID int64, driver string, at time.Time) error
+	RetryNotification(ctx context.Context, notificationID int64, nextAttemptAt time.Time, lastError string, at time.Time) error
+	FailNotification(ctx context.Context, notificationID int64, lastError string, at time.Time) error
+	RequeueNotification(ctx context.Context, notificationID int64, at time.Time) (bool, error)
+	GetNotifications(ctx context.Context, status, userID string, limit int) ([]*models.Notification, error)
+	UpsertProxyBid(ctx context.Context, proxy *models.ProxyBid) error
+	GetProxyBid(ctx context.Context, auctionID, bidderID string) (*models.ProxyBid, error)
+	GetProxyBids(ctx context.Context, auctionID string) ([]*models.ProxyBid, error)
+	CreateEvent(ctx context.Context, event *models.AuctionEvent) error
+	ClaimPendingEvents(ctx context.Context, now time.Time, limit int) ([]*models.AuctionEvent, error)
+	GetEventsAfter(ctx context.Context, auctionID string, afterSequence int64, limit int) ([]*models.AuctionEvent, error)
+	MarkEventDispatched(ctx context.Context, eventID int64) error
+	RetryEvent(ctx context.Context, eventID int64, nextAttemptAt time.Time, lastError string) error
+	Ping(ctx context.Context) error
+	BeginTx(ctx context.Context) (Tx, error)
+	// WithTx returns a repository bound to tx, which must have been begun by
+	// the same implementation
+	WithTx(tx Tx) AuctionRepo
 }
@@ -0,0 +1,745 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// MemoryRepo is an AuctionRepo that keeps its tables in memory, so the
+// services can be tested without Postgres. It mirrors PostgresRepo query by
+// query, constraints included.
+//
+// Transactions keep an undo log and roll their writes back on Rollback.
+// Writes and FOR UPDATE reads take row locks that are held until the
+// transaction ends, and claims skip rows another transaction has locked, so
+// concurrent bids and SKIP LOCKED claims behave as they do in Postgres.
+// Reads never wait, and unlike Postgres they see other transactions'
+// uncommitted writes.
+type MemoryRepo struct {
+	store *memoryStore
+	tx    *memoryTx
+}
+
+func NewMemoryRepo() *MemoryRepo {
+	store := &memoryStore{
+		locks:         make(map[string]*memoryTx),
+		serials:       make(map[string]int64),
+		auctions:      make(map[string]*memoryAuction),
+		bids:          make(map[string]*memoryBid),
+		proxyBids:     make(map[memoryPair]*models.ProxyBid),
+		watches:       make(map[memoryPair]*memoryWatch),
+		events:        make(map[int64]*memoryEvent),
+		handoffs:      make(map[string]*memoryHandoff),
+		strikes:       make(map[string]*models.NonPaymentStrike),
+		offers:        make(map[string]*models.SecondChanceOffer),
+		audit:         make(map[int64]*models.AuditEntry),
+		fraudFlags:    make(map[string]*models.FraudFlag),
+		deposits:      make(map[memoryPair]*models.BidDeposit),
+		shows:         make(map[string]*models.Show),
+		lots:          make(map[string]*models.ShowLot),
+		templates:     make(map[string]*models.AuctionTemplate),
+		schedules:     make(map[string]*models.AuctionSchedule),
+		notifications: make(map[int64]*models.Notification),
+	}
+	store.unlocked = sync.NewCond(&store.mu)
+	return &MemoryRepo{store: store}
+}
+
+// memoryStore holds the tables. mu guards everything and is held for the
+// length of each statement; row locks outlive it until their transaction
+// ends.
+type memoryStore struct {
+	mu       sync.Mutex
+	unlocked *sync.Cond // broadcast whenever a transaction releases its locks
+	locks    map[string]*memoryTx
+	serials  map[string]int64
+
+	auctions      map[string]*memoryAuction
+	bids          map[string]*memoryBid
+	proxyBids     map[memoryPair]*models.ProxyBid
+	watches       map[memoryPair]*memoryWatch
+	events        map[int64]*memoryEvent
+	handoffs      map[string]*memoryHandoff
+	strikes       map[string]*models.NonPaymentStrike
+	offers        map[string]*models.SecondChanceOffer
+	audit         map[int64]*models.AuditEntry
+	fraudFlags    map[string]*models.FraudFlag
+	deposits      map[memoryPair]*models.BidDeposit
+	shows         map[string]*models.Show
+	lots          map[string]*models.ShowLot
+	templates     map[string]*models.AuctionTemplate
+	schedules     map[string]*models.AuctionSchedule
+	notifications map[int64]*models.Notification
+}
+
+// memoryPair keys the tables whose primary key is (auction_id, user)
+type memoryPair struct {
+	AuctionID string
+	UserID    string
+}
+
+// memoryAuction carries the auction columns the model does not expose
+type memoryAuction struct {
+	models.Auction
+	endingSoonAt   *time.Time
+	fraudScannedAt *time.Time
+}
+
+// memoryBid remembers insertion order, which breaks ties on bid_time
+type memoryBid struct {
+	models.Bid
+	seq int64
+}
+
+type memoryWatch struct {
+	createdAt time.Time
+	seq       int64
+}
+
+type memoryEvent struct {
+	models.AuctionEvent
+	dispatched    bool
+	nextAttemptAt *time.Time
+	lastError     string
+}
+
+type memoryHandoff struct {
+	models.OrderHandoff
+	resolved bool
+}
+
+// memoryTx is the Tx returned by MemoryRepo.BeginTx
+type memoryTx struct {
+	store *memoryStore
+	undo  []func()
+	locks []string
+	done  bool
+}
+
+func (tx *memoryTx) Commit() error {
+	tx.store.mu.Lock()
+	defer tx.store.mu.Unlock()
+	if tx.done {
+		return sql.ErrTxDone
+	}
+	tx.finish()
+	return nil
+}
+
+func (tx *memoryTx) Rollback() error {
+	tx.store.mu.Lock()
+	defer tx.store.mu.Unlock()
+	if tx.done {
+		return sql.ErrTxDone
+	}
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		tx.undo[i]()
+	}
+	tx.finish()
+	return nil
+}
+
+func (tx *memoryTx) finish() {
+	tx.done = true
+	for _, key := range tx.locks {
+		delete(tx.store.locks, key)
+	}
+	tx.locks = nil
+	tx.undo = nil
+	tx.store.unlocked.Broadcast()
+}
+
+// Ping always succeeds
+func (r *MemoryRepo) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (r *MemoryRepo) BeginTx(ctx context.Context) (Tx, error) {
+	if r.tx != nil {
+		return nil, fmt.Errorf("cannot begin transaction with a transaction")
+	}
+	return &memoryTx{store: r.store}, nil
+}
+
+func (r *MemoryRepo) WithTx(tx Tx) AuctionRepo {
+	return &MemoryRepo{store: r.store, tx: tx.(*memoryTx)}
+}
+
+// open starts a statement; call the returned func when it is done
+func (r *MemoryRepo) open() (*memoryStore, func()) {
+	r.store.mu.Lock()
+	return r.store, r.store.mu.Unlock
+}
+
+// lockRow takes the lock on a row for the rest of the transaction, waiting
+// while another transaction holds it. Outside a transaction the statement
+// commits at once, so it only waits.
+func (r *MemoryRepo) lockRow(table string, key any) {
+	s := r.store
+	name := fmt.Sprintf("%s/%v", table, key)
+	for {
+		owner, held := s.locks[name]
+		if !held || owner == r.tx {
+			break
+		}
+		s.unlocked.Wait()
+	}
+	if r.tx != nil && s.locks[name] == nil {
+		s.locks[name] = r.tx
+		r.tx.locks = append(r.tx.locks, name)
+	}
+}
+
+// tryLockRow is lockRow for SKIP LOCKED: it reports false instead of
+// waiting when another transaction holds the row
+func (r *MemoryRepo) tryLockRow(table string, key any) bool {
+	name := fmt.Sprintf("%s/%v", table, key)
+	if owner, held := r.store.locks[name]; held && owner != r.tx {
+		return false
+	}
+	r.lockRow(table, key)
+	return true
+}
+
+// logUndo records how to reverse a write if the transaction rolls back
+func (r *MemoryRepo) logUndo(undo func()) {
+	if r.tx != nil {
+		r.tx.undo = append(r.tx.undo, undo)
+	}
+}
+
+// nextSerial hands out BIGSERIAL values. Like Postgres sequences they are
+// not rolled back.
+func (s *memoryStore) nextSerial(table string) int64 {
+	s.serials[table]++
+	return s.serials[table]
+}
+
+// memoryPut stores row under key, logging the row it replaces. Rows are
+// never changed in place, so the logged pointer is the old row as it was.
+func memoryPut[K comparable, V any](r *MemoryRepo, rows map[K]*V, key K, row *V) {
+	old, existed := rows[key]
+	rows[key] = row
+	r.logUndo(func() {
+		if existed {
+			rows[key] = old
+		} else {
+			delete(rows, key)
+		}
+	})
+}
+
+// memoryInsert locks and adds a row. It reports false, and changes nothing,
+// if the key is taken.
+func memoryInsert[K comparable, V any](r *MemoryRepo, table string, rows map[K]*V, key K, row *V) bool {
+	r.lockRow(table, key)
+	if _, exists := rows[key]; exists {
+		return false
+	}
+	memoryPut(r, rows, key, row)
+	return true
+}
+
+// memoryUpdate locks a row and replaces it with a changed copy. change
+// returns false to leave the row alone, like a WHERE clause that does not
+// match; memoryUpdate reports whether the row was changed.
+func memoryUpdate[K comparable, V any](r *MemoryRepo, table string, rows map[K]*V, key K, change func(row *V) bool) bool {
+	r.lockRow(table, key)
+	row, ok := rows[key]
+	if !ok {
+		return false
+	}
+	updated := *row
+	if !change(&updated) {
+		return false
+	}
+	memoryPut(r, rows, key, &updated)
+	return true
+}
+
+// memoryDelete locks and removes a row if match accepts it, reporting
+// whether it did
+func memoryDelete[K comparable, V any](r *MemoryRepo, table string, rows map[K]*V, key K, match func(row *V) bool) bool {
+	r.lockRow(table, key)
+	old, ok := rows[key]
+	if !ok || !match(old) {
+		return false
+	}
+	delete(rows, key)
+	r.logUndo(func() { rows[key] = old })
+	return true
+}
+
+// memoryClaim locks and returns the first row in order that matches and is
+// not locked by another transaction, as FOR UPDATE SKIP LOCKED does
+func memoryClaim[K comparable, V any](r *MemoryRepo, table string, rows map[K]*V, match func(row *V) bool, order func(a, b *V) int, key func(row *V) K) *V {
+	var candidates []*V
+	for _, row := range rows {
+		if match(row) {
+			candidates = append(candidates, row)
+		}
+	}
+	slices.SortFunc(candidates, order)
+	for _, row := range candidates {
+		if r.tryLockRow(table, key(row)) {
+			return row
+		}
+	}
+	return nil
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
+
+// model returns a copy of the auction as PostgresRepo would scan it
+func (a *memoryAuction) model() *models.Auction {
+	auction := a.Auction
+	auction.SettledAt = copyTime(a.SettledAt)
+	auction.NextDropAt = copyTime(a.NextDropAt)
+	auction.PaymentDueAt = copyTime(a.PaymentDueAt)
+	auction.Images = slices.Clone(a.Images)
+	if auction.Images == nil {
+		auction.Images = []models.AuctionImage{}
+	}
+	return &auction
+}
+
+func (b *memoryBid) model() *models.Bid {
+	bid := b.Bid
+	return &bid
+}
+
+// auctionRows returns the auctions matching match, in no particular order
+func (s *memoryStore) auctionRows(match func(a *memoryAuction) bool) []*memoryAuction {
+	var rows []*memoryAuction
+	for _, auction := range s.auctions {
+		if match(auction) {
+			rows = append(rows, auction)
+		}
+	}
+	return rows
+}
+
+// bidRows returns the bids matching match in insertion order
+func (s *memoryStore) bidRows(match func(b *memoryBid) bool) []*memoryBid {
+	var rows []*memoryBid
+	for _, bid := range s.bids {
+		if match(bid) {
+			rows = append(rows, bid)
+		}
+	}
+	slices.SortFunc(rows, func(a, b *memoryBid) int { return cmp.Compare(a.seq, b.seq) })
+	return rows
+}
+
+// byAmountThenTime orders bids highest first with earlier bids winning ties
+func byAmountThenTime(a, b *memoryBid) int {
+	if c := cmp.Compare(b.Amount, a.Amount); c != 0 {
+		return c
+	}
+	return a.BidTime.Compare(b.BidTime)
+}
+
+// newestBidFirst orders bids by bid_time, latest first
+func newestBidFirst(a, b *memoryBid) int {
+	return b.BidTime.Compare(a.BidTime)
+}
+
+func bidModels(rows []*memoryBid) []*models.Bid {
+	var bids []*models.Bid
+	for _, row := range rows {
+		bids = append(bids, row.model())
+	}
+	return bids
+}
+
+func (r *MemoryRepo) Create(ctx context.Context, auction *models.Auction) error {
+	s, done := r.open()
+	defer done()
+
+	if origin, ok := models.BidOriginFor(ctx, auction.SellerID); ok {
+		auction.SellerIP, auction.SellerDeviceID = origin.IPAddress, origin.DeviceID
+	}
+	if auction.RelistedFrom != "" {
+		for _, existing := range s.auctions {
+			if existing.RelistedFrom == auction.RelistedFrom {
+				return fmt.Errorf("auction %s is already relisted", auction.RelistedFrom)
+			}
+		}
+	}
+
+	row := &memoryAuction{Auction: *auction}
+	row.Bids, row.Images = nil, slices.Clone(auction.Images)
+	row.WinnerID, row.WinningBidID, row.OrderID, row.CancellationReason = "", "", "", ""
+	row.SettledAt, row.PaymentDueAt = nil, nil
+	row.ExtensionCount, row.WatcherCount, row.BidCount = 0, 0, 0
+	row.NextDropAt = copyTime(auction.NextDropAt)
+	if !memoryInsert(r, "auctions", s.auctions, auction.AuctionID, row) {
+		return fmt.Errorf("auction %s already exists", auction.AuctionID)
+	}
+	return nil
+}
+
+func (r *MemoryRepo) Update(ctx context.Context, auction *models.Auction) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "auctions", s.auctions, auction.AuctionID, func(row *memoryAuction) bool {
+		row.Title, row.Description = auction.Title, auction.Description
+		row.ReservePrice, row.MinBidIncrement = auction.ReservePrice, auction.MinBidIncrement
+		row.StartTime, row.EndTime, row.UpdatedAt = auction.StartTime, auction.EndTime, auction.UpdatedAt
+		row.Images = slices.Clone(auction.Images)
+		return true
+	})
+	return nil
+}
+
+func (r *MemoryRepo) GetByID(ctx context.Context, id string) (*models.Auction, error) {
+	s, done := r.open()
+	defer done()
+	auction, ok := s.auctions[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return auction.model(), nil
+}
+
+// GetByIDForUpdate reads an auction and locks it until the surrounding
+// transaction ends
+func (r *MemoryRepo) GetByIDForUpdate(ctx context.Context, id string) (*models.Auction, error) {
+	s, done := r.open()
+	defer done()
+	r.lockRow("auctions", id)
+	auction, ok := s.auctions[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return auction.model(), nil
+}
+
+// listPosition is where an auction falls in a listing sort, in the form
+// the listing cursor records it
+func listPosition(sort string, auction *models.Auction) models.AuctionCursor {
+	position := models.AuctionCursor{Sort: sort, AuctionID: auction.AuctionID}
+	switch sort {
+	case models.SortNewest:
+		position.Time = &auction.CreatedAt
+	case models.SortEndingSoon:
+		position.Time = &auction.EndTime
+	case models.SortPriceAsc, models.SortPriceDesc:
+		position.Value = int64(auction.CurrentPrice)
+	case models.SortMostBids:
+		// Sealed auctions' counts stay hidden until their bids are revealed
+		sealed := auction.Type == models.AuctionTypeSealed && auction.Status != "ended" &&
+			(auction.Status == "cancelled" || time.Now().Before(auction.EndTime))
+		if !sealed {
+			position.Value = int64(auction.BidCount)
+		}
+	}
+	return position
+}
+
+// compareListPositions orders two positions by the sort column, then
+// auction ID, ascending
+func compareListPositions(a, b models.AuctionCursor) int {
+	c := cmp.Compare(a.Value, b.Value)
+	if a.Time != nil && b.Time != nil {
+		c = a.Time.Compare(*b.Time)
+	}
+	if c != 0 {
+		return c
+	}
+	return strings.Compare(a.AuctionID, b.AuctionID)
+}
+
+// List returns one page of auctions matching the filter, plus the number of
+// matching auctions across all pages
+func (r *MemoryRepo) List(ctx context.Context, filter *models.AuctionFilter) ([]*models.Auction, int64, error) {
+	sort, ok := listSortColumns[filter.Sort]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown sort %q", filter.Sort)
+	}
+
+	s, done := r.open()
+	defer done()
+
+	rows := s.auctionRows(func(a *memoryAuction) bool {
+		return (filter.SellerID == "" || a.SellerID == filter.SellerID) &&
+			(filter.Status == "" || a.Status == filter.Status) &&
+			(filter.Type == "" || a.Type == filter.Type) &&
+			(filter.Category == "" || a.Category == filter.Category) &&
+			(filter.Currency == "" || a.Currency == filter.Currency) &&
+			(filter.MinPrice <= 0 || a.CurrentPrice >= filter.MinPrice) &&
+			(filter.MaxPrice <= 0 || a.CurrentPrice <= filter.MaxPrice) &&
+			(filter.StartsAfter == nil || !a.StartTime.Before(*filter.StartsAfter)) &&
+			(filter.StartsBefore == nil || !a.StartTime.After(*filter.StartsBefore)) &&
+			(filter.EndsAfter == nil || !a.EndTime.Before(*filter.EndsAfter)) &&
+			(filter.EndsBefore == nil || !a.EndTime.After(*filter.EndsBefore))
+	})
+	total := int64(len(rows))
+
+	direction := 1
+	if sort.desc {
+		direction = -1
+	}
+	slices.SortFunc(rows, func(a, b *memoryAuction) int {
+		return direction * compareListPositions(listPosition(filter.Sort, &a.Auction), listPosition(filter.Sort, &b.Auction))
+	})
+
+	var auctions []*models.Auction
+	for _, row := range rows {
+		if len(auctions) == filter.Limit {
+			break
+		}
+		if filter.After != nil && direction*compareListPositions(listPosition(filter.Sort, &row.Auction), *filter.After) <= 0 {
+			continue
+		}
+		auctions = append(auctions, row.model())
+	}
+	return auctions, total, nil
+}
+
+func (r *MemoryRepo) UpdateAuctionPrice(ctx context.Context, id string, price models.Money) error {
+	s, done := r.open()
+	defer done()
+	updated := memoryUpdate(r, "auctions", s.auctions, id, func(row *memoryAuction) bool {
+		if row.CurrentPrice > price {
+			return false
+		}
+		row.CurrentPrice = price
+		return true
+	})
+	if !updated {
+		return ErrPriceRegression
+	}
+	return nil
+}
+
+// ExtendEndTime moves an auction's end time for a soft-close extension
+func (r *MemoryRepo) ExtendEndTime(ctx context.Context, id string, endTime time.Time, extensionCount int) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "auctions", s.auctions, id, func(row *memoryAuction) bool {
+		row.EndTime, row.ExtensionCount, row.UpdatedAt = endTime, extensionCount, time.Now()
+		return true
+	})
+	return nil
+}
+
+func (r *MemoryRepo) CreateBid(ctx context.Context, bid *models.Bid) error {
+	s, done := r.open()
+	defer done()
+
+	if bid.Quantity == 0 {
+		bid.Quantity = 1
+	}
+	if origin, ok := models.BidOriginFor(ctx, bid.BidderID); ok {
+		bid.IPAddress, bid.DeviceID = origin.IPAddress, origin.DeviceID
+	}
+	if _, ok := s.auctions[bid.AuctionID]; !ok {
+		return fmt.Errorf("auction %s does not exist", bid.AuctionID)
+	}
+
+	row := &memoryBid{Bid: *bid, seq: s.nextSerial("bids")}
+	row.Defaulted, row.Retracted = false, false
+	if !memoryInsert(r, "bids", s.bids, bid.BidID, row) {
+		return fmt.Errorf("bid %s already exists", bid.BidID)
+	}
+	memoryUpdate(r, "auctions", s.auctions, bid.AuctionID, func(auction *memoryAuction) bool {
+		auction.BidCount++
+		return true
+	})
+	return nil
+}
+
+func (r *MemoryRepo) GetBids(ctx context.Context, auctionID string) (*models.BidsResponse, error) {
+	s, done := r.open()
+	defer done()
+
+	rows := s.bidRows(func(b *memoryBid) bool { return b.AuctionID == auctionID && !b.Retracted })
+	slices.SortStableFunc(rows, newestBidFirst)
+
+	var bids []models.Bid
+	for _, row := range rows {
+		bids = append(bids, row.Bid)
+	}
+	return &models.BidsResponse{Bids: bids}, nil
+}
+
+func (r *MemoryRepo) CountBids(ctx context.Context, auctionID string) (int, error) {
+	s, done := r.open()
+	defer done()
+	return len(s.bidRows(func(b *memoryBid) bool { return b.AuctionID == auctionID && !b.Retracted })), nil
+}
+
+func (r *MemoryRepo) GetWinningBid(ctx context.Context, auctionID string) (*models.Bid, error) {
+	s, done := r.open()
+	defer done()
+
+	rows := s.bidRows(func(b *memoryBid) bool { return b.AuctionID == auctionID && b.IsWinning && !b.Retracted })
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no winning bid found for auction: %s", auctionID)
+	}
+	slices.SortStableFunc(rows, newestBidFirst)
+	return rows[0].model(), nil
+}
+
+func (r *MemoryRepo) UpdateAuctionStatus(ctx context.Context, auctionID string, status string) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "auctions", s.auctions, auctionID, func(row *memoryAuction) bool {
+		row.Status, row.UpdatedAt = status, time.Now()
+		return true
+	})
+	return nil
+}
+
+// claimAuction locks the first auction by order that matches and is not
+// locked by another transaction, or returns nil
+func (r *MemoryRepo) claimAuction(match func(a *memoryAuction) bool, order func(a, b *memoryAuction) int) (*models.Auction, error) {
+	s, done := r.open()
+	defer done()
+	auction := memoryClaim(r, "auctions", s.auctions, match, order, func(a *memoryAuction) string { return a.AuctionID })
+	if auction == nil {
+		return nil, nil
+	}
+	return auction.model(), nil
+}
+
+// ClaimNextToStart locks the next scheduled auction whose start time has
+// passed
+func (r *MemoryRepo) ClaimNextToStart(ctx context.Context, now time.Time) (*models.Auction, error) {
+	return r.claimAuction(func(a *memoryAuction) bool {
+		return a.Status == "scheduled" && !a.StartTime.After(now)
+	}, func(a, b *memoryAuction) int { return a.StartTime.Compare(b.StartTime) })
+}
+
+// ClaimNextToEnd locks the next active auction whose end time has passed
+func (r *MemoryRepo) ClaimNextToEnd(ctx context.Context, now time.Time) (*models.Auction, error) {
+	return r.claimAuction(func(a *memoryAuction) bool {
+		return a.Status == "active" && !a.EndTime.After(now)
+	}, func(a, b *memoryAuction) int { return a.EndTime.Compare(b.EndTime) })
+}
+
+// GetHighestBid returns the highest bid on an auction, earliest first on
+// ties, or nil when the auction has no bids
+func (r *MemoryRepo) GetHighestBid(ctx context.Context, auctionID string) (*models.Bid, error) {
+	s, done := r.open()
+	defer done()
+
+	rows := s.bidRows(func(b *memoryBid) bool { return b.AuctionID == auctionID && !b.Retracted })
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	slices.SortStableFunc(rows, byAmountThenTime)
+	return rows[0].model(), nil
+}
+
+// GetTopBids returns an auction's highest bids, best first with earlier
+// bids winning ties
+func (r *MemoryRepo) GetTopBids(ctx context.Context, auctionID string, limit int) ([]*models.Bid, error) {
+	s, done := r.open()
+	defer done()
+
+	rows := s.bidRows(func(b *memoryBid) bool { return b.AuctionID == auctionID && !b.Retracted })
+	slices.SortStableFunc(rows, byAmountThenTime)
+	return bidModels(rows[:min(limit, len(rows))]), nil
+}
+
+// GetBidsByBidder returns one bidder's bids on an auction, newest first
+func (r *MemoryRepo) GetBidsByBidder(ctx context.Context, auctionID, bidderID string) ([]*models.Bid, error) {
+	s, done := r.open()
+	defer done()
+
+	rows := s.bidRows(func(b *memoryBid) bool { return b.AuctionID == auctionID && b.BidderID == bidderID })
+	slices.SortStableFunc(rows, newestBidFirst)
+	return bidModels(rows), nil
+}
+
+// ReviseBid replaces the amount and time of an existing bid
+func (r *MemoryRepo) ReviseBid(ctx context.Context, bid *models.Bid) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "bids", s.bids, bid.BidID, func(row *memoryBid) bool {
+		row.Amount, row.BidTime = bid.Amount, bid.BidTime
+		return true
+	})
+	return nil
+}
+
+// SetWinningBid marks bidID as the only winning bid of an auction. An empty
+// bidID clears the flag on every bid.
+func (r *MemoryRepo) SetWinningBid(ctx context.Context, auctionID, bidID string) error {
+	s, done := r.open()
+	defer done()
+	for _, row := range s.bidRows(func(b *memoryBid) bool { return b.AuctionID == auctionID }) {
+		memoryUpdate(r, "bids", s.bids, row.BidID, func(bid *memoryBid) bool {
+			bid.IsWinning = bid.BidID == bidID
+			return true
+		})
+	}
+	return nil
+}
+
+// SettleAuction records the outcome of an auction and marks it ended
+func (r *MemoryRepo) SettleAuction(ctx context.Context, auction *models.Auction) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "auctions", s.auctions, auction.AuctionID, func(row *memoryAuction) bool {
+		row.Status, row.CurrentPrice = auction.Status, auction.CurrentPrice
+		row.WinnerID, row.WinningBidID = auction.WinnerID, auction.WinningBidID
+		row.SettledAt = copyTime(auction.SettledAt)
+		if auction.SettledAt != nil {
+			row.UpdatedAt = *auction.SettledAt
+		}
+		return true
+	})
+	return nil
+}
+
+func (r *MemoryRepo) GetActive(ctx context.Context) ([]*models.Auction, error) {
+	s, done := r.open()
+	defer done()
+
+	now := time.Now()
+	rows := s.auctionRows(func(a *memoryAuction) bool { return a.Status == "active" && a.EndTime.After(now) })
+	slices.SortFunc(rows, func(a, b *memoryAuction) int { return b.CreatedAt.Compare(a.CreatedAt) })
+
+	var auctions []*models.Auction
+	for _, row := range rows {
+		auctions = append(auctions, row.model())
+	}
+	return auctions, nil
+}
+
+// ClaimNextDropDue locks one active drop auction whose next price step is due
+func (r *MemoryRepo) ClaimNextDropDue(ctx context.Context, now time.Time) (*models.Auction, error) {
+	return r.claimAuction(func(a *memoryAuction) bool {
+		return a.Status == "active" && a.NextDropAt != nil && !a.NextDropAt.After(now)
+	}, func(a, b *memoryAuction) int { return a.NextDropAt.Compare(*b.NextDropAt) })
+}
+
+// UpdateDropState stores a drop auction's current price, next step time and
+// remaining quantity
+func (r *MemoryRepo) UpdateDropState(ctx context.Context, auction *models.Auction) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "auctions", s.auctions, auction.AuctionID, func(row *memoryAuction) bool {
+		row.CurrentPrice, row.NextDropAt = auction.CurrentPrice, copyTime(auction.NextDropAt)
+		row.QuantityRemaining, row.UpdatedAt = auction.QuantityRemaining, time.Now()
+		return true
+	})
+	return nil
+}
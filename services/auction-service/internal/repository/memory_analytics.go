@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// inRange reports whether t falls within the half-open analytics range
+func inRange(t time.Time, window models.AnalyticsRange) bool {
+	return (window.From == nil || !t.Before(*window.From)) && (window.To == nil || t.Before(*window.To))
+}
+
+// GetBidRates buckets an auction's bids by minute within the range
+func (r *MemoryRepo) GetBidRates(ctx context.Context, auctionID string, window models.AnalyticsRange) ([]models.BidRate, error) {
+	s, done := r.open()
+	defer done()
+
+	buckets := make(map[time.Time]*models.BidRate)
+	bidders := make(map[time.Time]map[string]bool)
+	for _, bid := range s.bidRows(func(b *memoryBid) bool {
+		return b.AuctionID == auctionID && !b.Retracted && inRange(b.BidTime, window)
+	}) {
+		minute := bid.BidTime.Truncate(time.Minute)
+		rate, ok := buckets[minute]
+		if !ok {
+			rate = &models.BidRate{Minute: minute}
+			buckets[minute] = rate
+			bidders[minute] = make(map[string]bool)
+		}
+		rate.Bids++
+		rate.HighPrice = max(rate.HighPrice, bid.Amount)
+		bidders[minute][bid.BidderID] = true
+		rate.UniqueBidders = len(bidders[minute])
+	}
+
+	rates := []models.BidRate{}
+	for _, rate := range buckets {
+		rates = append(rates, *rate)
+	}
+	slices.SortFunc(rates, func(a, b models.BidRate) int { return a.Minute.Compare(b.Minute) })
+	return rates, nil
+}
+
+// GetBidHistory returns an auction's bids within the range, oldest first
+func (r *MemoryRepo) GetBidHistory(ctx context.Context, auctionID string, window models.AnalyticsRange) ([]*models.Bid, error) {
+	s, done := r.open()
+	defer done()
+
+	rows := s.bidRows(func(b *memoryBid) bool {
+		return b.AuctionID == auctionID && !b.Retracted && inRange(b.BidTime, window)
+	})
+	slices.SortStableFunc(rows, func(a, b *memoryBid) int { return a.BidTime.Compare(b.BidTime) })
+	return bidModels(rows), nil
+}
+
+// GetSellerAuctionSummaries returns per-auction bid totals for a seller's
+// auctions ending within the range. Outcome and ratios are left to the caller.
+func (r *MemoryRepo) GetSellerAuctionSummaries(ctx context.Context, sellerID string, window models.AnalyticsRange) ([]models.AuctionSummary, error) {
+	s, done := r.open()
+	defer done()
+
+	auctions := s.auctionRows(func(a *memoryAuction) bool { return a.SellerID == sellerID && inRange(a.EndTime, window) })
+	slices.SortFunc(auctions, func(a, b *memoryAuction) int {
+		if c := a.EndTime.Compare(b.EndTime); c != 0 {
+			return c
+		}
+		return strings.Compare(a.AuctionID, b.AuctionID)
+	})
+
+	summaries := []models.AuctionSummary{}
+	for _, a := range auctions {
+		summary := models.AuctionSummary{
+			AuctionID: a.AuctionID, Title: a.Title, Type: a.Type, Status: a.Status, Currency: a.Currency,
+			StartTime: a.StartTime, EndTime: a.EndTime, StartingPrice: a.StartingPrice, ReservePrice: a.ReservePrice,
+		}
+
+		var value models.Money
+		var units int
+		bidders := make(map[string]bool)
+		for _, bid := range s.bidRows(func(b *memoryBid) bool { return b.AuctionID == a.AuctionID && !b.Retracted }) {
+			summary.HighestBid = max(summary.HighestBid, bid.Amount)
+			summary.TotalBids++
+			bidders[bid.BidderID] = true
+			value += bid.Amount * models.Money(bid.Quantity)
+			units += bid.Quantity
+		}
+		summary.UniqueBidders = len(bidders)
+
+		switch {
+		case a.Type == models.AuctionTypeDrop:
+			if units > 0 {
+				// Rounded half away from zero, as Postgres rounds numerics
+				summary.HammerPrice = (2*value + models.Money(units)) / (2 * models.Money(units))
+			}
+			summary.UnitsSold = a.Quantity - a.QuantityRemaining
+		case a.WinnerID != "":
+			summary.HammerPrice, summary.UnitsSold = a.CurrentPrice, 1
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// CountSellerBidders counts the distinct users who bid on a seller's
+// auctions ending within the range
+func (r *MemoryRepo) CountSellerBidders(ctx context.Context, sellerID string, window models.AnalyticsRange) (int, error) {
+	s, done := r.open()
+	defer done()
+
+	bidders := make(map[string]bool)
+	for _, bid := range s.bidRows(func(b *memoryBid) bool {
+		a, ok := s.auctions[b.AuctionID]
+		return ok && a.SellerID == sellerID && !b.Retracted && inRange(a.EndTime, window)
+	}) {
+		bidders[bid.BidderID] = true
+	}
+	return len(bidders), nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// CreateAuditEntry appends to an auction's audit log. Call it with the
+// transaction that makes the change so the history cannot drift from it.
+func (r *MemoryRepo) CreateAuditEntry(ctx context.Context, entry *models.AuditEntry) error {
+	s, done := r.open()
+	defer done()
+
+	if len(entry.Details) == 0 {
+		entry.Details = []byte("{}")
+	}
+	entry.EntryID = s.nextSerial("auction_audit_log")
+
+	row := *entry
+	row.Details = slices.Clone(entry.Details)
+	memoryInsert(r, "auction_audit_log", s.audit, entry.EntryID, &row)
+	return nil
+}
+
+// GetAuditLog returns an auction's audit entries in the order they happened
+func (r *MemoryRepo) GetAuditLog(ctx context.Context, auctionID string) ([]*models.AuditEntry, error) {
+	s, done := r.open()
+	defer done()
+
+	entries := []*models.AuditEntry{}
+	for _, row := range s.audit {
+		if row.AuctionID == auctionID {
+			entry := *row
+			entry.Details = slices.Clone(row.Details)
+			entries = append(entries, &entry)
+		}
+	}
+	slices.SortFunc(entries, func(a, b *models.AuditEntry) int { return cmp.Compare(a.EntryID, b.EntryID) })
+	return entries, nil
+}
+
+// CancelAuction withdraws an auction and records why
+func (r *MemoryRepo) CancelAuction(ctx context.Context, auctionID, reason string, at time.Time) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "auctions", s.auctions, auctionID, func(auction *memoryAuction) bool {
+		auction.Status, auction.CancellationReason, auction.UpdatedAt = "cancelled", reason, at
+		return true
+	})
+	return nil
+}
+
+// GetBid returns one bid, including retracted ones
+func (r *MemoryRepo) GetBid(ctx context.Context, bidID string) (*models.Bid, error) {
+	s, done := r.open()
+	defer done()
+	bid, ok := s.bids[bidID]
+	if !ok {
+		return nil, nil
+	}
+	return bid.model(), nil
+}
+
+// RetractBid withdraws a bid. It stays on record for the audit trail but no
+// longer counts towards the price or the winner.
+func (r *MemoryRepo) RetractBid(ctx context.Context, bidID, reason string, at time.Time) error {
+	s, done := r.open()
+	defer done()
+
+	var auctionID string
+	retracted := memoryUpdate(r, "bids", s.bids, bidID, func(bid *memoryBid) bool {
+		if bid.Retracted {
+			return false
+		}
+		bid.Retracted, bid.IsWinning = true, false
+		auctionID = bid.AuctionID
+		return true
+	})
+	if retracted {
+		memoryUpdate(r, "auctions", s.auctions, auctionID, func(auction *memoryAuction) bool {
+			auction.BidCount = max(auction.BidCount-1, 0)
+			return true
+		})
+	}
+	return nil
+}
+
+// ResetAuctionPrice sets the current price without the monotonic guard of
+// UpdateAuctionPrice; only a retraction may lower the price
+func (r *MemoryRepo) ResetAuctionPrice(ctx context.Context, auctionID string, price models.Money) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "auctions", s.auctions, auctionID, func(auction *memoryAuction) bool {
+		auction.CurrentPrice, auction.UpdatedAt = price, time.Now()
+		return true
+	})
+	return nil
+}
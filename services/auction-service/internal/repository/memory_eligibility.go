@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// GetOpenCommitment totals what a bidder stands to pay across the active
+// auctions they are currently leading, other than excludeAuctionID. A leading
+// bid backed by a higher proxy maximum counts at the maximum, and every
+// sealed bid counts because it may yet win.
+func (r *MemoryRepo) GetOpenCommitment(ctx context.Context, bidderID, excludeAuctionID string) (models.Money, error) {
+	s, done := r.open()
+	defer done()
+
+	var total models.Money
+	for _, bid := range s.bidRows(func(b *memoryBid) bool { return b.BidderID == bidderID && !b.Retracted }) {
+		a, ok := s.auctions[bid.AuctionID]
+		if !ok || !(bid.IsWinning || a.Type == models.AuctionTypeSealed) || a.Status != "active" || a.AuctionID == excludeAuctionID {
+			continue
+		}
+		commitment := bid.Amount
+		if proxy, ok := s.proxyBids[memoryPair{bid.AuctionID, bidderID}]; ok {
+			commitment = max(commitment, proxy.MaxAmount)
+		}
+		total += commitment
+	}
+	return total, nil
+}
+
+// GetBidDeposit returns a bidder's deposit on an auction, or nil if they have
+// not placed one
+func (r *MemoryRepo) GetBidDeposit(ctx context.Context, auctionID, bidderID string) (*models.BidDeposit, error) {
+	s, done := r.open()
+	defer done()
+	deposit, ok := s.deposits[memoryPair{auctionID, bidderID}]
+	if !ok {
+		return nil, nil
+	}
+	found := *deposit
+	return &found, nil
+}
+
+// SaveBidDeposit records a deposit payment-service has authorised. A bidder
+// whose earlier deposit was already released can hold a new one.
+func (r *MemoryRepo) SaveBidDeposit(ctx context.Context, deposit *models.BidDeposit) error {
+	s, done := r.open()
+	defer done()
+
+	key := memoryPair{deposit.AuctionID, deposit.BidderID}
+	row := *deposit
+	row.Attempts, row.LastError = 0, ""
+	row.NextAttemptAt, row.UpdatedAt = deposit.CreatedAt, deposit.CreatedAt
+	if !memoryInsert(r, "bid_deposits", s.deposits, key, &row) {
+		memoryUpdate(r, "bid_deposits", s.deposits, key, func(existing *models.BidDeposit) bool {
+			existing.Amount, existing.PaymentDepositID, existing.Status = deposit.Amount, deposit.PaymentDepositID, deposit.Status
+			existing.Attempts, existing.LastError = 0, ""
+			existing.NextAttemptAt, existing.UpdatedAt = deposit.CreatedAt, deposit.CreatedAt
+			return true
+		})
+	}
+	return nil
+}
+
+// ReleaseBidDeposits queues every deposit still held on an auction for
+// release, except keepBidderID's
+func (r *MemoryRepo) ReleaseBidDeposits(ctx context.Context, auctionID, keepBidderID string, at time.Time) error {
+	s, done := r.open()
+	defer done()
+
+	var keys []memoryPair
+	for key, deposit := range s.deposits {
+		if key.AuctionID == auctionID && deposit.Status == models.DepositHeld && key.UserID != keepBidderID {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range keys {
+		memoryUpdate(r, "bid_deposits", s.deposits, key, func(deposit *models.BidDeposit) bool {
+			if deposit.Status != models.DepositHeld {
+				return false
+			}
+			deposit.Status, deposit.NextAttemptAt, deposit.UpdatedAt = models.DepositReleasePending, at, at
+			return true
+		})
+	}
+	return nil
+}
+
+// QueueBidDeposit moves one bidder's held deposit to release_pending or
+// capture_pending. It does nothing if the bidder holds no deposit.
+func (r *MemoryRepo) QueueBidDeposit(ctx context.Context, auctionID, bidderID, status string, at time.Time) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "bid_deposits", s.deposits, memoryPair{auctionID, bidderID}, func(deposit *models.BidDeposit) bool {
+		if deposit.Status != models.DepositHeld {
+			return false
+		}
+		deposit.Status, deposit.NextAttemptAt, deposit.UpdatedAt = status, at, at
+		return true
+	})
+	return nil
+}
+
+// ClaimNextDepositSettlement leases the next deposit waiting to be released
+// or captured until leaseUntil and returns it. Deposits leased by another
+// replica are not due again until their lease runs out.
+func (r *MemoryRepo) ClaimNextDepositSettlement(ctx context.Context, now, leaseUntil time.Time) (*models.BidDeposit, error) {
+	s, done := r.open()
+	defer done()
+
+	deposit := memoryClaim(r, "bid_deposits", s.deposits, func(d *models.BidDeposit) bool {
+		return settlementPending(d) && !d.NextAttemptAt.After(now)
+	}, func(a, b *models.BidDeposit) int {
+		return a.NextAttemptAt.Compare(b.NextAttemptAt)
+	}, func(d *models.BidDeposit) memoryPair { return memoryPair{d.AuctionID, d.BidderID} })
+	if deposit == nil {
+		return nil, nil
+	}
+	key := memoryPair{deposit.AuctionID, deposit.BidderID}
+	memoryUpdate(r, "bid_deposits", s.deposits, key, func(d *models.BidDeposit) bool {
+		d.NextAttemptAt = leaseUntil
+		return true
+	})
+	found := *s.deposits[key]
+	return &found, nil
+}
+
+// CompleteDepositSettlement records that payment-service released or
+// captured a deposit. Like a retry it only applies while the deposit is still
+// waiting to be settled.
+func (r *MemoryRepo) CompleteDepositSettlement(ctx context.Context, auctionID, bidderID, status string, at time.Time) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "bid_deposits", s.deposits, memoryPair{auctionID, bidderID}, func(deposit *models.BidDeposit) bool {
+		if !settlementPending(deposit) {
+			return false
+		}
+		deposit.Status, deposit.LastError, deposit.UpdatedAt = status, "", at
+		deposit.Attempts++
+		return true
+	})
+	return nil
+}
+
+// RetryDepositSettlement records a failed attempt and when to try again
+func (r *MemoryRepo) RetryDepositSettlement(ctx context.Context, auctionID, bidderID string, nextAttemptAt time.Time, lastError string) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "bid_deposits", s.deposits, memoryPair{auctionID, bidderID}, func(deposit *models.BidDeposit) bool {
+		if !settlementPending(deposit) {
+			return false
+		}
+		deposit.Attempts++
+		deposit.NextAttemptAt, deposit.LastError = nextAttemptAt, lastError
+		return true
+	})
+	return nil
+}
+
+// settlementPending reports whether a deposit is waiting to be released or
+// captured
+func settlementPending(deposit *models.BidDeposit) bool {
+	return deposit.Status == models.DepositReleasePending || deposit.Status == models.DepositCapturePending
+}
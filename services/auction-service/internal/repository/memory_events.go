@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+func (e *memoryEvent) model() *models.AuctionEvent {
+	event := e.AuctionEvent
+	event.Payload = slices.Clone(e.Payload)
+	return &event
+}
+
+// CreateEvent appends an event to the outbox. Call it with the transaction
+// that performs the state change so both commit together. The event is
+// numbered after the auction's last event under the auction row lock.
+func (r *MemoryRepo) CreateEvent(ctx context.Context, event *models.AuctionEvent) error {
+	s, done := r.open()
+	defer done()
+
+	r.lockRow("auctions", event.AuctionID)
+	if _, ok := s.auctions[event.AuctionID]; !ok {
+		return fmt.Errorf("failed to lock auction for event: %w", sql.ErrNoRows)
+	}
+	if len(event.Payload) == 0 {
+		event.Payload = []byte("{}")
+	}
+	event.EventID = s.nextSerial("auction_events")
+	event.Sequence = 1
+	for _, row := range s.events {
+		if row.AuctionID == event.AuctionID && row.Sequence >= event.Sequence {
+			event.Sequence = row.Sequence + 1
+		}
+	}
+
+	row := &memoryEvent{AuctionEvent: *event}
+	row.Payload = slices.Clone(event.Payload)
+	memoryInsert(r, "auction_events", s.events, event.EventID, row)
+	return nil
+}
+
+// eventRows returns the events matching match, ordered by auction and
+// sequence
+func (s *memoryStore) eventRows(match func(e *memoryEvent) bool) []*memoryEvent {
+	var rows []*memoryEvent
+	for _, event := range s.events {
+		if match(event) {
+			rows = append(rows, event)
+		}
+	}
+	slices.SortFunc(rows, func(a, b *memoryEvent) int {
+		return cmp.Or(cmp.Compare(a.AuctionID, b.AuctionID), cmp.Compare(a.Sequence, b.Sequence))
+	})
+	return rows
+}
+
+// ClaimPendingEvents claims the undispatched events of up to limit auctions,
+// oldest first, and returns each auction's events together in sequence order.
+// Auctions another transaction has claimed, or with an event waiting out its
+// retry backoff, are skipped.
+func (r *MemoryRepo) ClaimPendingEvents(ctx context.Context, now time.Time, limit int) ([]*models.AuctionEvent, error) {
+	s, done := r.open()
+	defer done()
+
+	oldest := map[string]int64{}
+	backingOff := map[string]bool{}
+	for _, row := range s.events {
+		if row.dispatched {
+			continue
+		}
+		if first, ok := oldest[row.AuctionID]; !ok || row.EventID < first {
+			oldest[row.AuctionID] = row.EventID
+		}
+		if row.nextAttemptAt != nil && row.nextAttemptAt.After(now) {
+			backingOff[row.AuctionID] = true
+		}
+	}
+	maps.DeleteFunc(oldest, func(auctionID string, _ int64) bool { return backingOff[auctionID] })
+	pending := slices.Collect(maps.Keys(oldest))
+	slices.SortFunc(pending, func(a, b string) int { return cmp.Compare(oldest[a], oldest[b]) })
+
+	claimed := map[string]bool{}
+	for _, auctionID := range pending {
+		if len(claimed) == limit {
+			break
+		}
+		if r.tryLockRow("auction_event_relay", auctionID) {
+			claimed[auctionID] = true
+		}
+	}
+
+	var events []*models.AuctionEvent
+	for _, row := range s.eventRows(func(e *memoryEvent) bool { return !e.dispatched && claimed[e.AuctionID] }) {
+		events = append(events, row.model())
+	}
+	return events, nil
+}
+
+// GetEventsAfter returns up to limit events for one auction with sequences
+// above afterSequence, oldest first
+func (r *MemoryRepo) GetEventsAfter(ctx context.Context, auctionID string, afterSequence int64, limit int) ([]*models.AuctionEvent, error) {
+	s, done := r.open()
+	defer done()
+
+	rows := s.eventRows(func(e *memoryEvent) bool { return e.AuctionID == auctionID && e.Sequence > afterSequence })
+	var events []*models.AuctionEvent
+	for _, row := range rows[:min(limit, len(rows))] {
+		events = append(events, row.model())
+	}
+	return events, nil
+}
+
+// MarkEventDispatched records that an event has been handed to subscribers
+func (r *MemoryRepo) MarkEventDispatched(ctx context.Context, eventID int64) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "auction_events", s.events, eventID, func(event *memoryEvent) bool {
+		event.dispatched = true
+		return true
+	})
+	return nil
+}
+
+// RetryEvent leaves an event pending after a handler failed and sets when the
+// relay may try it again
+func (r *MemoryRepo) RetryEvent(ctx context.Context, eventID int64, nextAttemptAt time.Time, lastError string) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "auction_events", s.events, eventID, func(event *memoryEvent) bool {
+		event.Attempts++
+		event.nextAttemptAt, event.lastError = &nextAttemptAt, lastError
+		return true
+	})
+	return nil
+}
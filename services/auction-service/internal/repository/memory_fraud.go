@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+func cloneFraudFlag(flag *models.FraudFlag) *models.FraudFlag {
+	c := *flag
+	c.Signals = slices.Clone(flag.Signals)
+	c.ReviewedAt = copyTime(flag.ReviewedAt)
+	return &c
+}
+
+// ClaimNextFraudScan locks the next ended auction the detection job has not
+// scored yet. Auctions locked by another transaction are skipped.
+func (r *MemoryRepo) ClaimNextFraudScan(ctx context.Context) (*models.Auction, error) {
+	return r.claimAuction(func(a *memoryAuction) bool {
+		return a.Status == "ended" && a.fraudScannedAt == nil
+	}, func(a, b *memoryAuction) int { return a.EndTime.Compare(b.EndTime) })
+}
+
+// MarkFraudScanned records that the detection job has scored an auction
+func (r *MemoryRepo) MarkFraudScanned(ctx context.Context, auctionID string, at time.Time) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "auctions", s.auctions, auctionID, func(auction *memoryAuction) bool {
+		auction.fraudScannedAt = &at
+		return true
+	})
+	return nil
+}
+
+// GetSellerBidderStats counts, for each bidder, the seller's ended auctions
+// since the given time that they bid on and won
+func (r *MemoryRepo) GetSellerBidderStats(ctx context.Context, sellerID string, bidderIDs []string, since time.Time) ([]models.SellerBidderStats, error) {
+	s, done := r.open()
+	defer done()
+
+	type seen struct{ bidderID, auctionID string }
+	counted := make(map[seen]bool)
+	byBidder := make(map[string]*models.SellerBidderStats)
+	var stats []*models.SellerBidderStats
+	for _, bid := range s.bidRows(func(b *memoryBid) bool {
+		a, ok := s.auctions[b.AuctionID]
+		return ok && a.SellerID == sellerID && a.Status == "ended" && !a.EndTime.Before(since) &&
+			slices.Contains(bidderIDs, b.BidderID) && !b.Retracted
+	}) {
+		if counted[seen{bid.BidderID, bid.AuctionID}] {
+			continue
+		}
+		counted[seen{bid.BidderID, bid.AuctionID}] = true
+
+		stat, ok := byBidder[bid.BidderID]
+		if !ok {
+			stat = &models.SellerBidderStats{BidderID: bid.BidderID}
+			byBidder[bid.BidderID] = stat
+			stats = append(stats, stat)
+		}
+		stat.Auctions++
+		if s.auctions[bid.AuctionID].WinnerID == bid.BidderID {
+			stat.Wins++
+		}
+	}
+
+	var result []models.SellerBidderStats
+	for _, stat := range stats {
+		result = append(result, *stat)
+	}
+	return result, nil
+}
+
+// CreateFraudFlag adds an auction to the moderation queue; an auction is
+// flagged at most once
+func (r *MemoryRepo) CreateFraudFlag(ctx context.Context, flag *models.FraudFlag) error {
+	s, done := r.open()
+	defer done()
+
+	// The auction lock stands in for the unique index on auction_id
+	r.lockRow("auctions", flag.AuctionID)
+	for _, existing := range s.fraudFlags {
+		if existing.AuctionID == flag.AuctionID {
+			return nil
+		}
+	}
+	memoryInsert(r, "fraud_flags", s.fraudFlags, flag.FlagID, cloneFraudFlag(flag))
+	return nil
+}
+
+// GetFraudFlags lists flags with the given status, highest score first
+func (r *MemoryRepo) GetFraudFlags(ctx context.Context, status string, limit int) ([]*models.FraudFlag, error) {
+	s, done := r.open()
+	defer done()
+
+	flags := []*models.FraudFlag{}
+	for _, flag := range s.fraudFlags {
+		if flag.Status == status {
+			flags = append(flags, cloneFraudFlag(flag))
+		}
+	}
+	slices.SortFunc(flags, func(a, b *models.FraudFlag) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return flags[:min(limit, len(flags))], nil
+}
+
+func (r *MemoryRepo) GetFraudFlag(ctx context.Context, flagID string) (*models.FraudFlag, error) {
+	s, done := r.open()
+	defer done()
+	flag, ok := s.fraudFlags[flagID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return cloneFraudFlag(flag), nil
+}
+
+// ReviewFraudFlag records a moderator's decision on an open flag. It reports
+// false if the flag was not open.
+func (r *MemoryRepo) ReviewFraudFlag(ctx context.Context, flagID, status, reviewerID, note string, at time.Time) (bool, error) {
+	s, done := r.open()
+	defer done()
+	return memoryUpdate(r, "fraud_flags", s.fraudFlags, flagID, func(flag *models.FraudFlag) bool {
+		if flag.Status != models.FlagOpen {
+			return false
+		}
+		flag.Status, flag.ReviewerID, flag.ReviewNote, flag.ReviewedAt = status, reviewerID, note, &at
+		return true
+	}), nil
+}
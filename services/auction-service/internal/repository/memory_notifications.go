@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// cloneNotification copies a notification as PostgresRepo would scan it,
+// without its dedupe key
+func cloneNotification(notification *models.Notification) *models.Notification {
+	c := *notification
+	c.DedupeKey = ""
+	c.Data = maps.Clone(notification.Data)
+	if c.Data == nil {
+		c.Data = map[string]string{}
+	}
+	c.Payload = slices.Clone(notification.Payload)
+	c.SentAt = copyTime(notification.SentAt)
+	return &c
+}
+
+// EnqueueNotification queues a notification for delivery. A notification
+// whose dedupe key is already queued is skipped; it reports whether this one
+// was added.
+func (r *MemoryRepo) EnqueueNotification(ctx context.Context, notification *models.Notification) (bool, error) {
+	s, done := r.open()
+	defer done()
+
+	if notification.DedupeKey != "" {
+		r.lockRow("notifications.dedupe_key", notification.DedupeKey)
+		for _, existing := range s.notifications {
+			if existing.DedupeKey == notification.DedupeKey {
+				return false, nil
+			}
+		}
+	}
+
+	notification.NotificationID = s.nextSerial("notifications")
+	row := cloneNotification(notification)
+	row.DedupeKey = notification.DedupeKey
+	row.Driver, row.Attempts, row.LastError, row.SentAt = "", 0, "", nil
+	memoryInsert(r, "notifications", s.notifications, notification.NotificationID, row)
+	return true, nil
+}
+
+// ClaimNextNotification marks the next due notification as sending, leased
+// until leaseUntil, and returns it. Due notifications are pending ones and
+// sending ones whose lease has run out. Rows locked by another transaction
+// are skipped.
+func (r *MemoryRepo) ClaimNextNotification(ctx context.Context, now, leaseUntil time.Time) (*models.Notification, error) {
+	s, done := r.open()
+	defer done()
+
+	notification := memoryClaim(r, "notifications", s.notifications, func(n *models.Notification) bool {
+		return (n.Status == models.NotificationPending || n.Status == models.NotificationSending) && !n.NextAttemptAt.After(now)
+	}, func(a, b *models.Notification) int {
+		if c := a.NextAttemptAt.Compare(b.NextAttemptAt); c != 0 {
+			return c
+		}
+		return int(a.NotificationID - b.NotificationID)
+	}, func(n *models.Notification) int64 { return n.NotificationID })
+	if notification == nil {
+		return nil, nil
+	}
+	memoryUpdate(r, "notifications", s.notifications, notification.NotificationID, func(n *models.Notification) bool {
+		n.Status, n.NextAttemptAt = models.NotificationSending, leaseUntil
+		return true
+	})
+	return cloneNotification(s.notifications[notification.NotificationID]), nil
+}
+
+// CompleteNotification records that a driver delivered a notification. Like
+// the other delivery results it only applies while the notification is still
+// claimed as sending.
+func (r *MemoryRepo) CompleteNotification(ctx context.Context, notificationID int64, driver string, at time.Time) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "notifications", s.notifications, notificationID, func(n *models.Notification) bool {
+		if n.Status != models.NotificationSending {
+			return false
+		}
+		n.Status, n.Driver, n.LastError, n.SentAt = models.NotificationSent, driver, "", &at
+		n.Attempts++
+		return true
+	})
+	return nil
+}
+
+// RetryNotification records a failed delivery and when to try again
+func (r *MemoryRepo) RetryNotification(ctx context.Context, notificationID int64, nextAttemptAt time.Time, lastError string, at time.Time) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "notifications", s.notifications, notificationID, func(n *models.Notification) bool {
+		if n.Status != models.NotificationSending {
+			return false
+		}
+		n.Status = models.NotificationPending
+		n.Attempts++
+		n.NextAttemptAt, n.LastError = nextAttemptAt, lastError
+		return true
+	})
+	return nil
+}
+
+// FailNotification gives up on a notification after its last failed attempt
+func (r *MemoryRepo) FailNotification(ctx context.Context, notificationID int64, lastError string, at time.Time) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "notifications", s.notifications, notificationID, func(n *models.Notification) bool {
+		if n.Status != models.NotificationSending {
+			return false
+		}
+		n.Status, n.LastError = models.NotificationFailed, lastError
+		n.Attempts++
+		return true
+	})
+	return nil
+}
+
+// RequeueNotification puts a failed notification back in the queue with
+// its attempts reset. It reports false if there was no such failed
+// notification.
+func (r *MemoryRepo) RequeueNotification(ctx context.Context, notificationID int64, at time.Time) (bool, error) {
+	s, done := r.open()
+	defer done()
+	return memoryUpdate(r, "notifications", s.notifications, notificationID, func(n *models.Notification) bool {
+		if n.Status != models.NotificationFailed {
+			return false
+		}
+		n.Status, n.Attempts, n.NextAttemptAt = models.NotificationPending, 0, at
+		return true
+	}), nil
+}
+
+// GetNotifications lists notifications in a delivery status, newest first,
+// optionally for one user
+func (r *MemoryRepo) GetNotifications(ctx context.Context, status, userID string, limit int) ([]*models.Notification, error) {
+	s, done := r.open()
+	defer done()
+
+	notifications := []*models.Notification{}
+	for _, n := range s.notifications {
+		if n.Status == status && (userID == "" || n.UserID == userID) {
+			notifications = append(notifications, cloneNotification(n))
+		}
+	}
+	slices.SortFunc(notifications, func(a, b *models.Notification) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return int(b.NotificationID - a.NotificationID)
+	})
+	return notifications[:min(limit, len(notifications))], nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+func (h *memoryHandoff) model() *models.OrderHandoff {
+	handoff := h.OrderHandoff
+	return &handoff
+}
+
+// CreateOrderHandoff queues a sale for order-service. A winning bid's payment
+// deadline is also copied onto its auction.
+func (r *MemoryRepo) CreateOrderHandoff(ctx context.Context, handoff *models.OrderHandoff) error {
+	s, done := r.open()
+	defer done()
+
+	row := &memoryHandoff{OrderHandoff: *handoff}
+	row.OrderID, row.Attempts, row.LastError, row.Resolution = "", 0, "", ""
+	row.NextAttemptAt = handoff.CreatedAt
+	memoryInsert(r, "order_handoffs", s.handoffs, handoff.BidID, row)
+
+	memoryUpdate(r, "auctions", s.auctions, handoff.AuctionID, func(auction *memoryAuction) bool {
+		if auction.WinningBidID != handoff.BidID {
+			return false
+		}
+		dueAt := handoff.PaymentDueAt
+		auction.PaymentDueAt = &dueAt
+		return true
+	})
+	return nil
+}
+
+// claimHandoff leases the first hand-off by order that matches and is not
+// locked by another transaction until leaseUntil, or returns nil
+func (r *MemoryRepo) claimHandoff(match func(h *memoryHandoff) bool, order func(a, b *memoryHandoff) int, leaseUntil time.Time) *models.OrderHandoff {
+	s, done := r.open()
+	defer done()
+	handoff := memoryClaim(r, "order_handoffs", s.handoffs, match, order, func(h *memoryHandoff) string { return h.BidID })
+	if handoff == nil {
+		return nil
+	}
+	memoryUpdate(r, "order_handoffs", s.handoffs, handoff.BidID, func(row *memoryHandoff) bool {
+		row.NextAttemptAt = leaseUntil
+		return true
+	})
+	return s.handoffs[handoff.BidID].model()
+}
+
+// ClaimNextOrderHandoff leases the next hand-off that is due to be sent until
+// leaseUntil and returns it
+func (r *MemoryRepo) ClaimNextOrderHandoff(ctx context.Context, now, leaseUntil time.Time) (*models.OrderHandoff, error) {
+	return r.claimHandoff(func(h *memoryHandoff) bool {
+		return h.OrderID == "" && !h.NextAttemptAt.After(now)
+	}, func(a, b *memoryHandoff) int { return a.NextAttemptAt.Compare(b.NextAttemptAt) }, leaseUntil), nil
+}
+
+// CompleteOrderHandoff records the order order-service created for a sale, on
+// the auction too when the sale was its winning bid. It reports false if the
+// hand-off already had its order recorded.
+func (r *MemoryRepo) CompleteOrderHandoff(ctx context.Context, handoff *models.OrderHandoff) (bool, error) {
+	s, done := r.open()
+	defer done()
+
+	completed := memoryUpdate(r, "order_handoffs", s.handoffs, handoff.BidID, func(row *memoryHandoff) bool {
+		if row.OrderID != "" {
+			return false
+		}
+		row.OrderID, row.LastError = handoff.OrderID, ""
+		row.Attempts++
+		return true
+	})
+	if !completed {
+		return false, nil
+	}
+	memoryUpdate(r, "auctions", s.auctions, handoff.AuctionID, func(auction *memoryAuction) bool {
+		if auction.WinningBidID != handoff.BidID {
+			return false
+		}
+		auction.OrderID, auction.UpdatedAt = handoff.OrderID, time.Now()
+		return true
+	})
+	return true, nil
+}
+
+// RetryOrderHandoff records a failed attempt and when to try again. It has no
+// effect once the hand-off is resolved.
+func (r *MemoryRepo) RetryOrderHandoff(ctx context.Context, bidID string, nextAttemptAt time.Time, lastError string) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "order_handoffs", s.handoffs, bidID, func(row *memoryHandoff) bool {
+		if row.resolved {
+			return false
+		}
+		row.Attempts++
+		row.NextAttemptAt, row.LastError = nextAttemptAt, lastError
+		return true
+	})
+	return nil
+}
+
+// ClaimNextLapsedPayment leases one handed-off order whose payment deadline
+// has passed and whose outcome has not been checked yet until leaseUntil and
+// returns it
+func (r *MemoryRepo) ClaimNextLapsedPayment(ctx context.Context, now, leaseUntil time.Time) (*models.OrderHandoff, error) {
+	return r.claimHandoff(func(h *memoryHandoff) bool {
+		return h.OrderID != "" && !h.resolved && !h.PaymentDueAt.After(now) && !h.NextAttemptAt.After(now)
+	}, func(a, b *memoryHandoff) int { return a.PaymentDueAt.Compare(b.PaymentDueAt) }, leaseUntil), nil
+}
+
+// ResolveOrderHandoff records whether a handed-off order was paid in time. It
+// reports false if the hand-off was already resolved.
+func (r *MemoryRepo) ResolveOrderHandoff(ctx context.Context, bidID, resolution string, at time.Time) (bool, error) {
+	s, done := r.open()
+	defer done()
+	return memoryUpdate(r, "order_handoffs", s.handoffs, bidID, func(row *memoryHandoff) bool {
+		if row.resolved {
+			return false
+		}
+		row.Resolution, row.LastError, row.resolved = resolution, "", true
+		return true
+	}), nil
+}
+
+// GetOrderHandoffs returns an auction's sales in the order they were made
+func (r *MemoryRepo) GetOrderHandoffs(ctx context.Context, auctionID string) ([]*models.OrderHandoff, error) {
+	s, done := r.open()
+	defer done()
+
+	var handoffs []*models.OrderHandoff
+	for _, row := range s.handoffs {
+		if row.AuctionID == auctionID {
+			handoffs = append(handoffs, row.model())
+		}
+	}
+	slices.SortFunc(handoffs, func(a, b *models.OrderHandoff) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.BidID, b.BidID)
+	})
+	return handoffs, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// UpsertProxyBid records or replaces a bidder's maximum for an auction
+func (r *MemoryRepo) UpsertProxyBid(ctx context.Context, proxy *models.ProxyBid) error {
+	s, done := r.open()
+	defer done()
+
+	key := memoryPair{proxy.AuctionID, proxy.BidderID}
+	row := *proxy
+	if !memoryInsert(r, "proxy_bids", s.proxyBids, key, &row) {
+		memoryUpdate(r, "proxy_bids", s.proxyBids, key, func(existing *models.ProxyBid) bool {
+			existing.MaxAmount, existing.UpdatedAt = proxy.MaxAmount, proxy.UpdatedAt
+			return true
+		})
+	}
+	return nil
+}
+
+// GetProxyBid returns a bidder's maximum for an auction, or nil if none is set
+func (r *MemoryRepo) GetProxyBid(ctx context.Context, auctionID, bidderID string) (*models.ProxyBid, error) {
+	s, done := r.open()
+	defer done()
+
+	proxy, ok := s.proxyBids[memoryPair{auctionID, bidderID}]
+	if !ok {
+		return nil, nil
+	}
+	found := *proxy
+	return &found, nil
+}
+
+// GetProxyBids returns every maximum on an auction, strongest first. Equal
+// maximums are ordered by when they were set, so the earlier one wins.
+func (r *MemoryRepo) GetProxyBids(ctx context.Context, auctionID string) ([]*models.ProxyBid, error) {
+	s, done := r.open()
+	defer done()
+
+	var proxies []*models.ProxyBid
+	for key, proxy := range s.proxyBids {
+		if key.AuctionID == auctionID {
+			found := *proxy
+			proxies = append(proxies, &found)
+		}
+	}
+	slices.SortFunc(proxies, func(a, b *models.ProxyBid) int {
+		if c := cmp.Compare(b.MaxAmount, a.MaxAmount); c != 0 {
+			return c
+		}
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	})
+	return proxies, nil
+}
+
+// DeleteProxyBid removes a bidder's maximum for an auction
+func (r *MemoryRepo) DeleteProxyBid(ctx context.Context, auctionID, bidderID string) error {
+	s, done := r.open()
+	defer done()
+	memoryDelete(r, "proxy_bids", s.proxyBids, memoryPair{auctionID, bidderID}, func(*models.ProxyBid) bool { return true })
+	return nil
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+func cloneOffer(offer *models.SecondChanceOffer) *models.SecondChanceOffer {
+	c := *offer
+	c.RespondedAt = copyTime(offer.RespondedAt)
+	return &c
+}
+
+// MarkBidDefaulted records that a winning bid's bidder did not pay. The bid
+// stops being the winning bid.
+func (r *MemoryRepo) MarkBidDefaulted(ctx context.Context, bidID string, at time.Time) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "bids", s.bids, bidID, func(bid *memoryBid) bool {
+		bid.Defaulted, bid.IsWinning = true, false
+		return true
+	})
+	return nil
+}
+
+// ClearAuctionWinner removes a defaulted winner, and their order, from an
+// ended auction
+func (r *MemoryRepo) ClearAuctionWinner(ctx context.Context, auctionID string) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "auctions", s.auctions, auctionID, func(auction *memoryAuction) bool {
+		auction.WinnerID, auction.WinningBidID, auction.OrderID = "", "", ""
+		auction.PaymentDueAt, auction.UpdatedAt = nil, time.Now()
+		return true
+	})
+	return nil
+}
+
+// CreateStrike records a non-payment strike; one per defaulted bid
+func (r *MemoryRepo) CreateStrike(ctx context.Context, strike *models.NonPaymentStrike) error {
+	s, done := r.open()
+	defer done()
+	row := *strike
+	memoryInsert(r, "non_payment_strikes", s.strikes, strike.BidID, &row)
+	return nil
+}
+
+// countStrikes is CountStrikes for callers that already hold the store
+func (s *memoryStore) countStrikes(userID string) int {
+	count := 0
+	for _, strike := range s.strikes {
+		if strike.UserID == userID {
+			count++
+		}
+	}
+	return count
+}
+
+// CountStrikes returns how many times a user has failed to pay for a win
+func (r *MemoryRepo) CountStrikes(ctx context.Context, userID string) (int, error) {
+	s, done := r.open()
+	defer done()
+	return s.countStrikes(userID), nil
+}
+
+// GetNextSecondChanceBid returns the highest bid of at least minAmount from a
+// bidder who has not defaulted on or been offered this auction and has fewer
+// than strikeLimit strikes, or nil if nobody is left
+func (r *MemoryRepo) GetNextSecondChanceBid(ctx context.Context, auctionID string, minAmount models.Money, strikeLimit int) (*models.Bid, error) {
+	s, done := r.open()
+	defer done()
+
+	defaulted := make(map[string]bool)
+	for _, bid := range s.bidRows(func(b *memoryBid) bool { return b.AuctionID == auctionID && b.Defaulted }) {
+		defaulted[bid.BidderID] = true
+	}
+	offered := make(map[string]bool)
+	for _, offer := range s.offers {
+		if offer.AuctionID == auctionID {
+			offered[offer.BidderID] = true
+		}
+	}
+
+	rows := s.bidRows(func(b *memoryBid) bool {
+		return b.AuctionID == auctionID && b.Amount >= minAmount && !b.Retracted &&
+			!defaulted[b.BidderID] && !offered[b.BidderID] && s.countStrikes(b.BidderID) < strikeLimit
+	})
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	slices.SortStableFunc(rows, byAmountThenTime)
+	return rows[0].model(), nil
+}
+
+// CreateSecondChanceOffer records an offer to a runner-up
+func (r *MemoryRepo) CreateSecondChanceOffer(ctx context.Context, offer *models.SecondChanceOffer) error {
+	s, done := r.open()
+	defer done()
+
+	// The auction lock stands in for the unique indexes on the offers
+	r.lockRow("auctions", offer.AuctionID)
+	for _, existing := range s.offers {
+		if existing.AuctionID != offer.AuctionID {
+			continue
+		}
+		if existing.BidderID == offer.BidderID {
+			return fmt.Errorf("failed to create second-chance offer: bidder %s was already offered auction %s", offer.BidderID, offer.AuctionID)
+		}
+		if existing.Status == models.OfferPending && offer.Status == models.OfferPending {
+			return fmt.Errorf("failed to create second-chance offer: auction %s already has a pending offer", offer.AuctionID)
+		}
+	}
+	if !memoryInsert(r, "second_chance_offers", s.offers, offer.OfferID, cloneOffer(offer)) {
+		return fmt.Errorf("failed to create second-chance offer: offer %s already exists", offer.OfferID)
+	}
+	return nil
+}
+
+// findOffer returns a copy of the first offer that matches, or nil
+func (s *memoryStore) findOffer(match func(o *models.SecondChanceOffer) bool) *models.SecondChanceOffer {
+	var found *models.SecondChanceOffer
+	for _, offer := range s.offers {
+		if match(offer) && (found == nil || strings.Compare(offer.OfferID, found.OfferID) < 0) {
+			found = offer
+		}
+	}
+	if found == nil {
+		return nil
+	}
+	return cloneOffer(found)
+}
+
+// GetPendingOffer returns an auction's open second-chance offer, or nil
+func (r *MemoryRepo) GetPendingOffer(ctx context.Context, auctionID string) (*models.SecondChanceOffer, error) {
+	s, done := r.open()
+	defer done()
+	return s.findOffer(func(o *models.SecondChanceOffer) bool {
+		return o.AuctionID == auctionID && o.Status == models.OfferPending
+	}), nil
+}
+
+// GetOfferForBidder returns the offer a bidder received for an auction, or nil
+func (r *MemoryRepo) GetOfferForBidder(ctx context.Context, auctionID, bidderID string) (*models.SecondChanceOffer, error) {
+	s, done := r.open()
+	defer done()
+	return s.findOffer(func(o *models.SecondChanceOffer) bool {
+		return o.AuctionID == auctionID && o.BidderID == bidderID
+	}), nil
+}
+
+// UpdateOfferStatus closes a second-chance offer
+func (r *MemoryRepo) UpdateOfferStatus(ctx context.Context, offerID, status string, at time.Time) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "second_chance_offers", s.offers, offerID, func(offer *models.SecondChanceOffer) bool {
+		offer.Status, offer.RespondedAt = status, &at
+		return true
+	})
+	return nil
+}
+
+// ClaimNextExpiredOffer locks one auction whose open second-chance offer has
+// expired. The auction is locked rather than the offer so expiry and the
+// bidder's response are serialised by the same lock.
+func (r *MemoryRepo) ClaimNextExpiredOffer(ctx context.Context, now time.Time) (*models.Auction, error) {
+	r.store.mu.Lock()
+	expired := make(map[string]bool)
+	for _, offer := range r.store.offers {
+		if offer.Status == models.OfferPending && !offer.ExpiresAt.After(now) {
+			expired[offer.AuctionID] = true
+		}
+	}
+	r.store.mu.Unlock()
+
+	return r.claimAuction(func(a *memoryAuction) bool {
+		return expired[a.AuctionID]
+	}, func(a, b *memoryAuction) int { return strings.Compare(a.AuctionID, b.AuctionID) })
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// cloneShow copies a show as PostgresRepo would scan it, without its lots
+func cloneShow(show *models.Show) *models.Show {
+	c := *show
+	c.StartedAt, c.EndedAt = copyTime(show.StartedAt), copyTime(show.EndedAt)
+	c.Lots = nil
+	return &c
+}
+
+func cloneLot(lot *models.ShowLot) *models.ShowLot {
+	c := *lot
+	c.StartedAt = copyTime(lot.StartedAt)
+	return &c
+}
+
+// CreateShow saves a new show
+func (r *MemoryRepo) CreateShow(ctx context.Context, show *models.Show) error {
+	s, done := r.open()
+	defer done()
+
+	row := cloneShow(show)
+	row.CurrentLotID, row.CurrentAuctionID, row.StartedAt, row.EndedAt = "", "", nil, nil
+	if !memoryInsert(r, "shows", s.shows, show.ShowID, row) {
+		return fmt.Errorf("failed to create show: show %s already exists", show.ShowID)
+	}
+	return nil
+}
+
+func (r *MemoryRepo) GetShow(ctx context.Context, showID string) (*models.Show, error) {
+	s, done := r.open()
+	defer done()
+	show, ok := s.shows[showID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return cloneShow(show), nil
+}
+
+// GetShowForUpdate reads a show and locks it, serialising changes to its
+// queue
+func (r *MemoryRepo) GetShowForUpdate(ctx context.Context, showID string) (*models.Show, error) {
+	s, done := r.open()
+	defer done()
+	r.lockRow("shows", showID)
+	show, ok := s.shows[showID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return cloneShow(show), nil
+}
+
+// lotRows returns the lots matching match in queue order
+func (s *memoryStore) lotRows(match func(l *models.ShowLot) bool) []*models.ShowLot {
+	var rows []*models.ShowLot
+	for _, lot := range s.lots {
+		if match(lot) {
+			rows = append(rows, lot)
+		}
+	}
+	slices.SortFunc(rows, func(a, b *models.ShowLot) int { return cmp.Compare(a.Position, b.Position) })
+	return rows
+}
+
+// GetShowLots returns a show's lots in queue order
+func (r *MemoryRepo) GetShowLots(ctx context.Context, showID string) ([]models.ShowLot, error) {
+	s, done := r.open()
+	defer done()
+
+	lots := []models.ShowLot{}
+	for _, lot := range s.lotRows(func(l *models.ShowLot) bool { return l.ShowID == showID }) {
+		lots = append(lots, *cloneLot(lot))
+	}
+	return lots, nil
+}
+
+// CreateShowLot appends a lot to the end of a show's queue. Call it with the
+// show locked so positions are not handed out twice.
+func (r *MemoryRepo) CreateShowLot(ctx context.Context, lot *models.ShowLot) error {
+	s, done := r.open()
+	defer done()
+
+	lot.Position = 1
+	if queued := s.lotRows(func(l *models.ShowLot) bool { return l.ShowID == lot.ShowID }); len(queued) > 0 {
+		lot.Position = queued[len(queued)-1].Position + 1
+	}
+
+	row := cloneLot(lot)
+	row.AuctionID, row.StartedAt = "", nil
+	if !memoryInsert(r, "show_lots", s.lots, lot.LotID, row) {
+		return fmt.Errorf("failed to create show lot: lot %s already exists", lot.LotID)
+	}
+	return nil
+}
+
+// DeleteQueuedLot removes a lot that has not been brought up yet. It reports
+// false if there was no such queued lot.
+func (r *MemoryRepo) DeleteQueuedLot(ctx context.Context, showID, lotID string) (bool, error) {
+	s, done := r.open()
+	defer done()
+	return memoryDelete(r, "show_lots", s.lots, lotID, func(lot *models.ShowLot) bool {
+		return lot.ShowID == showID && lot.Status == models.LotQueued
+	}), nil
+}
+
+// GetNextQueuedLot returns the first lot still waiting in a show's queue, or nil
+func (r *MemoryRepo) GetNextQueuedLot(ctx context.Context, showID string) (*models.ShowLot, error) {
+	s, done := r.open()
+	defer done()
+
+	queued := s.lotRows(func(l *models.ShowLot) bool { return l.ShowID == showID && l.Status == models.LotQueued })
+	if len(queued) == 0 {
+		return nil, nil
+	}
+	return cloneLot(queued[0]), nil
+}
+
+// StartShowLot marks a lot live on the auction created for it
+func (r *MemoryRepo) StartShowLot(ctx context.Context, lotID, auctionID string, at time.Time) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "show_lots", s.lots, lotID, func(lot *models.ShowLot) bool {
+		lot.Status, lot.AuctionID, lot.StartedAt = models.LotLive, auctionID, &at
+		return true
+	})
+	return nil
+}
+
+// CloseShowLot marks a lot done once the host moves past it
+func (r *MemoryRepo) CloseShowLot(ctx context.Context, lotID string) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "show_lots", s.lots, lotID, func(lot *models.ShowLot) bool {
+		lot.Status = models.LotClosed
+		return true
+	})
+	return nil
+}
+
+// SetShowCurrentLot puts a show live on the given lot
+func (r *MemoryRepo) SetShowCurrentLot(ctx context.Context, showID, lotID, auctionID string, at time.Time) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "shows", s.shows, showID, func(show *models.Show) bool {
+		show.Status, show.CurrentLotID, show.CurrentAuctionID, show.UpdatedAt = models.ShowLive, lotID, auctionID, at
+		if show.StartedAt == nil {
+			show.StartedAt = &at
+		}
+		return true
+	})
+	return nil
+}
+
+// EndShow marks a show ended with no current lot
+func (r *MemoryRepo) EndShow(ctx context.Context, showID string, at time.Time) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "shows", s.shows, showID, func(show *models.Show) bool {
+		show.Status, show.CurrentLotID, show.CurrentAuctionID = models.ShowEnded, "", ""
+		show.EndedAt, show.UpdatedAt = &at, at
+		return true
+	})
+	return nil
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+func cloneTemplate(template *models.AuctionTemplate) *models.AuctionTemplate {
+	c := *template
+	c.Images = slices.Clone(template.Images)
+	return &c
+}
+
+func cloneSchedule(schedule *models.AuctionSchedule) *models.AuctionSchedule {
+	c := *schedule
+	c.Weekdays = append(make([]int, 0, len(schedule.Weekdays)), schedule.Weekdays...)
+	return &c
+}
+
+// CreateTemplate saves a new auction template
+func (r *MemoryRepo) CreateTemplate(ctx context.Context, template *models.AuctionTemplate) error {
+	s, done := r.open()
+	defer done()
+	if !memoryInsert(r, "auction_templates", s.templates, template.TemplateID, cloneTemplate(template)) {
+		return fmt.Errorf("failed to create auction template: template %s already exists", template.TemplateID)
+	}
+	return nil
+}
+
+// UpdateTemplate replaces a template's settings
+func (r *MemoryRepo) UpdateTemplate(ctx context.Context, template *models.AuctionTemplate) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "auction_templates", s.templates, template.TemplateID, func(row *models.AuctionTemplate) bool {
+		updated := cloneTemplate(template)
+		updated.SellerID, updated.CreatedAt = row.SellerID, row.CreatedAt
+		*row = *updated
+		return true
+	})
+	return nil
+}
+
+func (r *MemoryRepo) GetTemplate(ctx context.Context, templateID string) (*models.AuctionTemplate, error) {
+	s, done := r.open()
+	defer done()
+	template, ok := s.templates[templateID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return cloneTemplate(template), nil
+}
+
+// GetSellerTemplates returns a seller's templates, newest first
+func (r *MemoryRepo) GetSellerTemplates(ctx context.Context, sellerID string) ([]*models.AuctionTemplate, error) {
+	s, done := r.open()
+	defer done()
+
+	templates := []*models.AuctionTemplate{}
+	for _, template := range s.templates {
+		if template.SellerID == sellerID {
+			templates = append(templates, cloneTemplate(template))
+		}
+	}
+	slices.SortFunc(templates, func(a, b *models.AuctionTemplate) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.TemplateID, b.TemplateID)
+	})
+	return templates, nil
+}
+
+// DeleteTemplate removes a template and its schedules. Auctions already
+// made from it are unaffected.
+func (r *MemoryRepo) DeleteTemplate(ctx context.Context, templateID string) error {
+	s, done := r.open()
+	defer done()
+
+	if !memoryDelete(r, "auction_templates", s.templates, templateID, func(*models.AuctionTemplate) bool { return true }) {
+		return nil
+	}
+	var scheduleIDs []string
+	for id, schedule := range s.schedules {
+		if schedule.TemplateID == templateID {
+			scheduleIDs = append(scheduleIDs, id)
+		}
+	}
+	for _, id := range scheduleIDs {
+		memoryDelete(r, "auction_schedules", s.schedules, id, func(*models.AuctionSchedule) bool { return true })
+	}
+	return nil
+}
+
+// CreateSchedule saves a new recurring schedule
+func (r *MemoryRepo) CreateSchedule(ctx context.Context, schedule *models.AuctionSchedule) error {
+	s, done := r.open()
+	defer done()
+
+	if _, ok := s.templates[schedule.TemplateID]; !ok {
+		return fmt.Errorf("failed to create auction schedule: template %s does not exist", schedule.TemplateID)
+	}
+	row := cloneSchedule(schedule)
+	row.LastAuctionID, row.LastError = "", ""
+	if !memoryInsert(r, "auction_schedules", s.schedules, schedule.ScheduleID, row) {
+		return fmt.Errorf("failed to create auction schedule: schedule %s already exists", schedule.ScheduleID)
+	}
+	return nil
+}
+
+// GetTemplateSchedules returns a template's schedules, oldest first
+func (r *MemoryRepo) GetTemplateSchedules(ctx context.Context, templateID string) ([]*models.AuctionSchedule, error) {
+	s, done := r.open()
+	defer done()
+
+	schedules := []*models.AuctionSchedule{}
+	for _, schedule := range s.schedules {
+		if schedule.TemplateID == templateID {
+			schedules = append(schedules, cloneSchedule(schedule))
+		}
+	}
+	slices.SortFunc(schedules, func(a, b *models.AuctionSchedule) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ScheduleID, b.ScheduleID)
+	})
+	return schedules, nil
+}
+
+// DeleteSchedule removes one of a template's schedules. It reports false if
+// there was no such schedule.
+func (r *MemoryRepo) DeleteSchedule(ctx context.Context, templateID, scheduleID string) (bool, error) {
+	s, done := r.open()
+	defer done()
+	return memoryDelete(r, "auction_schedules", s.schedules, scheduleID, func(schedule *models.AuctionSchedule) bool {
+		return schedule.TemplateID == templateID
+	}), nil
+}
+
+// ClaimNextDueSchedule locks the next active schedule whose next run falls
+// before cutoff. Schedules locked by another transaction are skipped.
+func (r *MemoryRepo) ClaimNextDueSchedule(ctx context.Context, cutoff time.Time) (*models.AuctionSchedule, error) {
+	s, done := r.open()
+	defer done()
+
+	schedule := memoryClaim(r, "auction_schedules", s.schedules, func(schedule *models.AuctionSchedule) bool {
+		return schedule.Active && !schedule.NextRunAt.After(cutoff)
+	}, func(a, b *models.AuctionSchedule) int {
+		return a.NextRunAt.Compare(b.NextRunAt)
+	}, func(schedule *models.AuctionSchedule) string { return schedule.ScheduleID })
+	if schedule == nil {
+		return nil, nil
+	}
+	return cloneSchedule(schedule), nil
+}
+
+// AdvanceSchedule moves a schedule on to its next run, recording the auction
+// the last run created if there was one
+func (r *MemoryRepo) AdvanceSchedule(ctx context.Context, scheduleID string, nextRunAt time.Time, auctionID string, at time.Time) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "auction_schedules", s.schedules, scheduleID, func(schedule *models.AuctionSchedule) bool {
+		schedule.NextRunAt, schedule.LastError, schedule.UpdatedAt = nextRunAt, "", at
+		if auctionID != "" {
+			schedule.LastAuctionID = auctionID
+		}
+		return true
+	})
+	return nil
+}
+
+// DeactivateSchedule switches off a schedule that can no longer run,
+// recording why
+func (r *MemoryRepo) DeactivateSchedule(ctx context.Context, scheduleID, lastError string, at time.Time) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "auction_schedules", s.schedules, scheduleID, func(schedule *models.AuctionSchedule) bool {
+		schedule.Active, schedule.LastError, schedule.UpdatedAt = false, lastError, at
+		return true
+	})
+	return nil
+}
+
+// GetRelisting returns the auction that relists auctionID, or nil
+func (r *MemoryRepo) GetRelisting(ctx context.Context, auctionID string) (*models.Auction, error) {
+	s, done := r.open()
+	defer done()
+	for _, auction := range s.auctions {
+		if auction.RelistedFrom == auctionID {
+			return auction.model(), nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+)
+
+func newMemoryAuction(t *testing.T, repo *MemoryRepo, id, status string) *models.Auction {
+	t.Helper()
+	now := time.Now()
+	auction := &models.Auction{
+		AuctionID:     id,
+		ProductID:     "product-" + id,
+		SellerID:      "seller-1",
+		Title:         "Memory test auction",
+		Currency:      "USD",
+		StartingPrice: 1000,
+		CurrentPrice:  1000,
+		StartTime:     now.Add(-time.Minute),
+		EndTime:       now.Add(time.Hour),
+		Status:        status,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := repo.Create(context.Background(), auction); err != nil {
+		t.Fatalf("create auction: %v", err)
+	}
+	return auction
+}
+
+func TestMemoryRepoTransactions(t *testing.T) {
+	tests := []struct {
+		name       string
+		commit     bool
+		wantStatus string
+		wantBids   int
+	}{
+		{"commit keeps writes", true, constants.AuctionStatusEnded, 1},
+		{"rollback undoes writes", false, constants.AuctionStatusActive, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMemoryRepo()
+			ctx := context.Background()
+			auction := newMemoryAuction(t, repo, "auction-1", constants.AuctionStatusActive)
+
+			tx, err := repo.BeginTx(ctx)
+			if err != nil {
+				t.Fatalf("begin: %v", err)
+			}
+			txRepo := repo.WithTx(tx)
+			bid := &models.Bid{BidID: "bid-1", AuctionID: auction.AuctionID, BidderID: "alice", Amount: 1000, BidTime: time.Now()}
+			if err := txRepo.CreateBid(ctx, bid); err != nil {
+				t.Fatalf("create bid: %v", err)
+			}
+			if err := txRepo.UpdateAuctionStatus(ctx, auction.AuctionID, constants.AuctionStatusEnded); err != nil {
+				t.Fatalf("update status: %v", err)
+			}
+			if tt.commit {
+				err = tx.Commit()
+			} else {
+				err = tx.Rollback()
+			}
+			if err != nil {
+				t.Fatalf("finish: %v", err)
+			}
+			if err := tx.Rollback(); !errors.Is(err, sql.ErrTxDone) {
+				t.Errorf("second finish returned %v, want sql.ErrTxDone", err)
+			}
+
+			stored, err := repo.GetByID(ctx, auction.AuctionID)
+			if err != nil {
+				t.Fatalf("reload: %v", err)
+			}
+			if stored.Status != tt.wantStatus {
+				t.Errorf("status %s, want %s", stored.Status, tt.wantStatus)
+			}
+			if count, _ := repo.CountBids(ctx, auction.AuctionID); count != tt.wantBids {
+				t.Errorf("%d bids, want %d", count, tt.wantBids)
+			}
+		})
+	}
+}
+
+func TestMemoryRepoClaimsSkipLockedRows(t *testing.T) {
+	repo := NewMemoryRepo()
+	ctx := context.Background()
+	newMemoryAuction(t, repo, "auction-1", constants.AuctionStatusScheduled)
+	newMemoryAuction(t, repo, "auction-2", constants.AuctionStatusScheduled)
+
+	claimed := map[string]bool{}
+	var txs []Tx
+	for i := 0; i < 3; i++ {
+		tx, err := repo.BeginTx(ctx)
+		if err != nil {
+			t.Fatalf("begin: %v", err)
+		}
+		defer tx.Rollback()
+		txs = append(txs, tx)
+
+		auction, err := repo.WithTx(tx).ClaimNextToStart(ctx, time.Now())
+		if err != nil {
+			t.Fatalf("claim %d: %v", i+1, err)
+		}
+		if auction == nil {
+			continue
+		}
+		if claimed[auction.AuctionID] {
+			t.Fatalf("%s claimed twice", auction.AuctionID)
+		}
+		claimed[auction.AuctionID] = true
+	}
+	if len(claimed) != 2 {
+		t.Fatalf("claimed %d auctions, want 2", len(claimed))
+	}
+
+	// Rolling back releases the lock, so the auction can be claimed again
+	if err := txs[0].Rollback(); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	tx, err := repo.BeginTx(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer tx.Rollback()
+	if auction, err := repo.WithTx(tx).ClaimNextToStart(ctx, time.Now()); err != nil || auction == nil {
+		t.Errorf("claim after rollback: %v, %v", auction, err)
+	}
+}
+
+func TestMemoryRepoLockWaitsForCommit(t *testing.T) {
+	repo := NewMemoryRepo()
+	ctx := context.Background()
+	auction := newMemoryAuction(t, repo, "auction-1", constants.AuctionStatusActive)
+
+	first, err := repo.BeginTx(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	if _, err := repo.WithTx(first).GetByIDForUpdate(ctx, auction.AuctionID); err != nil {
+		t.Fatalf("lock: %v", err)
+	}
+	if err := repo.WithTx(first).UpdateAuctionStatus(ctx, auction.AuctionID, constants.AuctionStatusEnded); err != nil {
+		t.Fatalf("update status: %v", err)
+	}
+
+	locked := make(chan *models.Auction)
+	go func() {
+		second, err := repo.BeginTx(ctx)
+		if err != nil {
+			t.Errorf("begin: %v", err)
+			close(locked)
+			return
+		}
+		defer second.Rollback()
+		stored, err := repo.WithTx(second).GetByIDForUpdate(ctx, auction.AuctionID)
+		if err != nil {
+			t.Errorf("second lock: %v", err)
+		}
+		locked <- stored
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("second transaction locked the row before the first committed")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if err := first.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	select {
+	case stored := <-locked:
+		if stored == nil || stored.Status != constants.AuctionStatusEnded {
+			t.Errorf("second transaction read %+v, want the committed status", stored)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("second transaction still waiting after commit")
+	}
+}
+
+func TestMemoryRepoEventSequences(t *testing.T) {
+	repo := NewMemoryRepo()
+	ctx := context.Background()
+	first := newMemoryAuction(t, repo, "auction-1", constants.AuctionStatusActive)
+	second := newMemoryAuction(t, repo, "auction-2", constants.AuctionStatusActive)
+
+	record := func(repo AuctionRepo, auctionID string) *models.AuctionEvent {
+		t.Helper()
+		event := &models.AuctionEvent{AuctionID: auctionID, Type: models.EventBidPlaced, CreatedAt: time.Now()}
+		if err := repo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("create event: %v", err)
+		}
+		return event
+	}
+
+	// The first auction's event takes the lower event ID but commits last
+	tx, err := repo.BeginTx(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	early := record(repo.WithTx(tx), first.AuctionID)
+	record(repo, second.AuctionID)
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	late := record(repo, first.AuctionID)
+	if early.Sequence != 1 || late.Sequence != 2 {
+		t.Fatalf("sequences %d, %d; want 1, 2", early.Sequence, late.Sequence)
+	}
+
+	// One relay claims the whole of the oldest auction; another skips it
+	relays := make([]Tx, 2)
+	claimed := make([][]*models.AuctionEvent, 2)
+	for i := range relays {
+		if relays[i], err = repo.BeginTx(ctx); err != nil {
+			t.Fatalf("begin: %v", err)
+		}
+		defer relays[i].Rollback()
+		if claimed[i], err = repo.WithTx(relays[i]).ClaimPendingEvents(ctx, time.Now(), 1); err != nil {
+			t.Fatalf("claim: %v", err)
+		}
+	}
+	if len(claimed[0]) != 2 || claimed[0][0].EventID != early.EventID || claimed[0][1].EventID != late.EventID {
+		t.Errorf("first relay claimed %v, want %s's events in sequence order", claimed[0], first.AuctionID)
+	}
+	if len(claimed[1]) != 1 || claimed[1][0].AuctionID != second.AuctionID {
+		t.Errorf("second relay claimed %v, want only %s's event", claimed[1], second.AuctionID)
+	}
+
+	replay, err := repo.GetEventsAfter(ctx, first.AuctionID, early.Sequence, 10)
+	if err != nil || len(replay) != 1 || replay[0].EventID != late.EventID {
+		t.Errorf("events after sequence %d: %v, %v", early.Sequence, replay, err)
+	}
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// AddWatch adds an auction to a user's watchlist and bumps its watcher count.
+// It reports false if the user was already watching.
+func (r *MemoryRepo) AddWatch(ctx context.Context, auctionID, userID string) (bool, error) {
+	s, done := r.open()
+	defer done()
+
+	watch := &memoryWatch{createdAt: time.Now(), seq: s.nextSerial("watchlist")}
+	if !memoryInsert(r, "watchlist", s.watches, memoryPair{auctionID, userID}, watch) {
+		return false, nil
+	}
+	memoryUpdate(r, "auctions", s.auctions, auctionID, func(auction *memoryAuction) bool {
+		auction.WatcherCount++
+		return true
+	})
+	return true, nil
+}
+
+// RemoveWatch takes an auction off a user's watchlist. It reports false if
+// the user was not watching.
+func (r *MemoryRepo) RemoveWatch(ctx context.Context, auctionID, userID string) (bool, error) {
+	s, done := r.open()
+	defer done()
+
+	if !memoryDelete(r, "watchlist", s.watches, memoryPair{auctionID, userID}, func(*memoryWatch) bool { return true }) {
+		return false, nil
+	}
+	memoryUpdate(r, "auctions", s.auctions, auctionID, func(auction *memoryAuction) bool {
+		auction.WatcherCount = max(auction.WatcherCount-1, 0)
+		return true
+	})
+	return true, nil
+}
+
+// IsWatching reports whether a user watches an auction
+func (r *MemoryRepo) IsWatching(ctx context.Context, auctionID, userID string) (bool, error) {
+	s, done := r.open()
+	defer done()
+	_, watching := s.watches[memoryPair{auctionID, userID}]
+	return watching, nil
+}
+
+// GetWatcherIDs returns the users watching an auction
+func (r *MemoryRepo) GetWatcherIDs(ctx context.Context, auctionID string) ([]string, error) {
+	s, done := r.open()
+	defer done()
+
+	var userIDs []string
+	for key := range s.watches {
+		if key.AuctionID == auctionID {
+			userIDs = append(userIDs, key.UserID)
+		}
+	}
+	slices.Sort(userIDs)
+	return userIDs, nil
+}
+
+// GetWatchedAuctions returns the auctions a user watches, most recently
+// watched first
+func (r *MemoryRepo) GetWatchedAuctions(ctx context.Context, userID string) ([]*models.Auction, error) {
+	s, done := r.open()
+	defer done()
+
+	var keys []memoryPair
+	for key := range s.watches {
+		if key.UserID == userID {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(a, b memoryPair) int {
+		wa, wb := s.watches[a], s.watches[b]
+		if c := wb.createdAt.Compare(wa.createdAt); c != 0 {
+			return c
+		}
+		return int(wb.seq - wa.seq)
+	})
+
+	var auctions []*models.Auction
+	for _, key := range keys {
+		if auction, ok := s.auctions[key.AuctionID]; ok {
+			auctions = append(auctions, auction.model())
+		}
+	}
+	return auctions, nil
+}
+
+// ClaimNextEndingSoon locks one active auction ending by cutoff whose
+// ending-soon alert has not been scheduled yet
+func (r *MemoryRepo) ClaimNextEndingSoon(ctx context.Context, cutoff time.Time) (*models.Auction, error) {
+	return r.claimAuction(func(a *memoryAuction) bool {
+		return a.Status == "active" && a.endingSoonAt == nil && !a.EndTime.After(cutoff)
+	}, func(a, b *memoryAuction) int { return a.EndTime.Compare(b.EndTime) })
+}
+
+// MarkEndingSoon records that an auction's ending-soon alert was scheduled
+func (r *MemoryRepo) MarkEndingSoon(ctx context.Context, auctionID string, at time.Time) error {
+	s, done := r.open()
+	defer done()
+	memoryUpdate(r, "auctions", s.auctions, auctionID, func(auction *memoryAuction) bool {
+		auction.endingSoonAt = &at
+		return true
+	})
+	return nil
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// notificationColumns is the column list read by scanNotification
+const notificationColumns = `notification_id, kind, COALESCE(user_id, ''), COALESCE(auction_id, ''), title, body, data,
+	payload, status, COALESCE(driver, ''), attempts, next_attempt_at, COALESCE(last_error, ''), sent_at, created_at`
+
+func scanNotification(row rowScanner) (*models.Notification, error) {
+	notification := &models.Notification{}
+	var data, payload []byte
+	var sentAt sql.NullTime
+	err := row.Scan(&notification.NotificationID, &notification.Kind, &notification.UserID, &notification.AuctionID,
+		&notification.Title, &notification.Body, &data, &payload, &notification.Status, &notification.Driver,
+		&notification.Attempts, &notification.NextAttemptAt, &notification.LastError, &sentAt, &notification.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &notification.Data); err != nil {
+		return nil, fmt.Errorf("failed to decode notification data: %w", err)
+	}
+	if len(payload) > 0 {
+		notification.Payload = payload
+	}
+	if sentAt.Valid {
+		notification.SentAt = &sentAt.Time
+	}
+	return notification, nil
+}
+
+// EnqueueNotification queues a notification for delivery. A notification
+// whose dedupe key is already queued is skipped; it reports whether this one
+// was added.
+func (r *PostgresRepo) EnqueueNotification(ctx context.Context, notification *models.Notification) (bool, error) {
+	data, err := json.Marshal(notification.Data)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode notification data: %w", err)
+	}
+	if notification.Data == nil {
+		data = []byte("{}")
+	}
+	var payload []byte
+	if len(notification.Payload) > 0 {
+		payload = notification.Payload
+	}
+
+	query := `INSERT INTO notifications (dedupe_key, kind, user_id, auction_id, title, body, data, payload, status,
+			next_attempt_at, created_at, updated_at)
+		VALUES (NULLIF($1, ''), $2, NULLIF($3, ''), NULLIF($4, ''), $5, $6, $7, $8, $9, $10, $11, $11)
+		ON CONFLICT (dedupe_key) DO NOTHING
+		RETURNING notification_id`
+	err = r.db.QueryRowContext(ctx, query, notification.DedupeKey, notification.Kind, notification.UserID,
+		notification.AuctionID, notification.Title, notification.Body, data, payload, notification.Status,
+		notification.NextAttemptAt, notification.CreatedAt).Scan(&notification.NotificationID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		r.logger.Error("Failed to queue notification", zap.String("kind", notification.Kind), zap.Error(err))
+		return false, fmt.Errorf("failed to queue notification: %w", err)
+	}
+	return true, nil
+}
+
+// ClaimNextNotification marks the next due notification as sending, leased
+// until leaseUntil, and returns it. Due notifications are pending ones and
+// sending ones whose lease has run out. Rows locked by another replica are
+// skipped. Run it outside a transaction so the claim commits before the
+// driver is called.
+func (r *PostgresRepo) ClaimNextNotification(ctx context.Context, now, leaseUntil time.Time) (*models.Notification, error) {
+	query := `UPDATE notifications SET status = 'sending', next_attempt_at = $2, updated_at = $1
+		WHERE notification_id = (
+			SELECT notification_id FROM notifications
+			WHERE status IN ('pending', 'sending') AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + notificationColumns
+	notification, err := scanNotification(r.db.QueryRowContext(ctx, query, now, leaseUntil))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return notification, err
+}
+
+// CompleteNotification records that a driver delivered a notification. Like
+// the other delivery results it only applies while the notification is still
+// claimed as sending.
+func (r *PostgresRepo) CompleteNotification(ctx context.Context, notificationID int64, driver string, at time.Time) error {
+	query := `UPDATE notifications SET status = 'sent', driver = $2, attempts = attempts + 1, last_error = NULL,
+			sent_at = $3, updated_at = $3
+		WHERE notification_id = $1 AND status = 'sending'`
+	if _, err := r.db.ExecContext(ctx, query, notificationID, driver, at); err != nil {
+		r.logger.Error("Failed to complete notification", zap.Int64("notification_id", notificationID), zap.Error(err))
+		return fmt.Errorf("failed to complete notification: %w", err)
+	}
+	return nil
+}
+
+// RetryNotification records a failed delivery and when to try again
+func (r *PostgresRepo) RetryNotification(ctx context.Context, notificationID int64, nextAttemptAt time.Time, lastError string, at time.Time) error {
+	query := `UPDATE notifications SET status = 'pending', attempts = attempts + 1, next_attempt_at = $2, last_error = $3,
+			updated_at = $4
+		WHERE notification_id = $1 AND status = 'sending'`
+	if _, err := r.db.ExecContext(ctx, query, notificationID, nextAttemptAt, lastError, at); err != nil {
+		r.logger.Error("Failed to reschedule notification", zap.Int64("notification_id", notificationID), zap.Error(err))
+		return fmt.Errorf("failed to reschedule notification: %w", err)
+	}
+	return nil
+}
+
+// FailNotification gives up on a notification after its last failed attempt
+func (r *PostgresRepo) FailNotification(ctx context.Context, notificationID int64, lastError string, at time.Time) error {
+	query := `UPDATE notifications SET status = 'failed', attempts = attempts + 1, last_error = $2, updated_at = $3
+		WHERE notification_id = $1 AND status = 'sending'`
+	if _, err := r.db.ExecContext(ctx, query, notificationID, lastError, at); err != nil {
+		r.logger.Error("Failed to mark notification failed", zap.Int64("notification_id", notificationID), zap.Error(err))
+		return fmt.Errorf("failed to mark notification failed: %w", err)
+	}
+	return nil
+}
+
+// RequeueNotification puts a failed notification back in the queue with
+// its attempts reset. It reports false if there was no such failed
+// notification.
+func (r *PostgresRepo) RequeueNotification(ctx context.Context, notificationID int64, at time.Time) (bool, error) {
+	query := `UPDATE notifications SET status = 'pending', attempts = 0, next_attempt_at = $2, updated_at = $2
+		WHERE notification_id = $1 AND status = 'failed'`
+	result, err := r.db.ExecContext(ctx, query, notificationID, at)
+	if err != nil {
+		r.logger.Error("Failed to requeue notification", zap.Int64("notification_id", notificationID), zap.Error(err))
+		return false, fmt.Errorf("failed to requeue notification: %w", err)
+	}
+	requeued, err := result.RowsAffected()
+	return requeued > 0, err
+}
+
+// GetNotifications lists notifications in a delivery status, newest first,
+// optionally for one user
+func (r *PostgresRepo) GetNotifications(ctx context.Context, status, userID string, limit int) ([]*models.Notification, error) {
+	query := `SELECT ` + notificationColumns + ` FROM notifications
+		WHERE status = $1 AND ($2 = '' OR user_id = $2)
+		ORDER BY created_at DESC
+		LIMIT $3`
+	rows, err := r.db.QueryContext(ctx, query, status, userID, limit)
+	if err != nil {
+		r.logger.Error("Failed to query notifications", zap.Error(err))
+		return nil, fmt.Errorf("failed to query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []*models.Notification{}
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, rows.Err()
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// orderHandoffColumns is the column list read by scanOrderHandoff
+const orderHandoffColumns = `bid_id, auction_id, buyer_id, quantity, price, payment_due_at,
+			COALESCE(order_id, ''), attempts, next_attempt_at, COALESCE(last_error, ''), COALESCE(resolution, ''), created_at`
+
+func scanOrderHandoff(row rowScanner) (*models.OrderHandoff, error) {
+	handoff := &models.OrderHandoff{}
+	err := row.Scan(&handoff.BidID, &handoff.AuctionID, &handoff.BuyerID, &handoff.Quantity, &handoff.Price, &handoff.PaymentDueAt,
+		&handoff.OrderID, &handoff.Attempts, &handoff.NextAttemptAt, &handoff.LastError, &handoff.Resolution, &handoff.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return handoff, nil
+}
+
+// CreateOrderHandoff queues a sale for order-service. Call it with the
+// settling transaction so a sale is never recorded without its hand-off. A
+// winning bid's payment deadline is also copied onto its auction.
+func (r *PostgresRepo) CreateOrderHandoff(ctx context.Context, handoff *models.OrderHandoff) error {
+	query := `INSERT INTO order_handoffs (bid_id, auction_id, buyer_id, quantity, price, payment_due_at, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+		ON CONFLICT (bid_id) DO NOTHING`
+	_, err := r.db.ExecContext(ctx, query, handoff.BidID, handoff.AuctionID, handoff.BuyerID, handoff.Quantity, handoff.Price,
+		handoff.PaymentDueAt, handoff.CreatedAt)
+	if err != nil {
+		r.logger.Error("Failed to queue order handoff", zap.String("bid_id", handoff.BidID), zap.Error(err))
+		return fmt.Errorf("failed to queue order handoff: %w", err)
+	}
+
+	query = `UPDATE auctions SET payment_due_at = $3 WHERE auction_id = $1 AND winning_bid_id = $2`
+	if _, err := r.db.ExecContext(ctx, query, handoff.AuctionID, handoff.BidID, handoff.PaymentDueAt); err != nil {
+		return fmt.Errorf("failed to set payment deadline: %w", err)
+	}
+	return nil
+}
+
+// ClaimNextOrderHandoff leases the next hand-off that is due to be sent until
+// leaseUntil and returns it. Hand-offs leased by another replica are not due
+// again until their lease runs out. Run it outside a transaction so the lease
+// commits before order-service is called.
+func (r *PostgresRepo) ClaimNextOrderHandoff(ctx context.Context, now, leaseUntil time.Time) (*models.OrderHandoff, error) {
+	query := `UPDATE order_handoffs SET next_attempt_at = $2
+		WHERE bid_id = (
+			SELECT bid_id FROM order_handoffs
+			WHERE order_id IS NULL AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + orderHandoffColumns
+	handoff, err := scanOrderHandoff(r.db.QueryRowContext(ctx, query, now, leaseUntil))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return handoff, err
+}
+
+// CompleteOrderHandoff records the order order-service created for a sale, on
+// the auction too when the sale was its winning bid. It reports false if the
+// hand-off already had its order recorded.
+func (r *PostgresRepo) CompleteOrderHandoff(ctx context.Context, handoff *models.OrderHandoff) (bool, error) {
+	query := `UPDATE order_handoffs SET order_id = $2, attempts = attempts + 1, last_error = NULL
+		WHERE bid_id = $1 AND order_id IS NULL`
+	result, err := r.db.ExecContext(ctx, query, handoff.BidID, handoff.OrderID)
+	if err != nil {
+		r.logger.Error("Failed to complete order handoff", zap.String("bid_id", handoff.BidID), zap.Error(err))
+		return false, fmt.Errorf("failed to complete order handoff: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return false, err
+	}
+
+	query = `UPDATE auctions SET order_id = $3, updated_at = $4 WHERE auction_id = $1 AND winning_bid_id = $2`
+	if _, err := r.db.ExecContext(ctx, query, handoff.AuctionID, handoff.BidID, handoff.OrderID, time.Now()); err != nil {
+		return false, fmt.Errorf("failed to record auction order: %w", err)
+	}
+	return true, nil
+}
+
+// RetryOrderHandoff records a failed attempt and when to try again. It has no
+// effect once the hand-off is resolved.
+func (r *PostgresRepo) RetryOrderHandoff(ctx context.Context, bidID string, nextAttemptAt time.Time, lastError string) error {
+	query := `UPDATE order_handoffs SET attempts = attempts + 1, next_attempt_at = $2, last_error = $3
+		WHERE bid_id = $1 AND resolved_at IS NULL`
+	if _, err := r.db.ExecContext(ctx, query, bidID, nextAttemptAt, lastError); err != nil {
+		r.logger.Error("Failed to reschedule order handoff", zap.String("bid_id", bidID), zap.Error(err))
+		return fmt.Errorf("failed to reschedule order handoff: %w", err)
+	}
+	return nil
+}
+
+// ClaimNextLapsedPayment leases one handed-off order whose payment deadline
+// has passed and whose outcome has not been checked yet until leaseUntil and
+// returns it. Run it outside a transaction so the lease commits before
+// order-service is called.
+func (r *PostgresRepo) ClaimNextLapsedPayment(ctx context.Context, now, leaseUntil time.Time) (*models.OrderHandoff, error) {
+	query := `UPDATE order_handoffs SET next_attempt_at = $2
+		WHERE bid_id = (
+			SELECT bid_id FROM order_handoffs
+			WHERE order_id IS NOT NULL AND resolved_at IS NULL AND payment_due_at <= $1 AND next_attempt_at <= $1
+			ORDER BY payment_due_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + orderHandoffColumns
+	handoff, err := scanOrderHandoff(r.db.QueryRowContext(ctx, query, now, leaseUntil))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return handoff, err
+}
+
+// ResolveOrderHandoff records whether a handed-off order was paid in time. It
+// reports false if the hand-off was already resolved.
+func (r *PostgresRepo) ResolveOrderHandoff(ctx context.Context, bidID, resolution string, at time.Time) (bool, error) {
+	query := `UPDATE order_handoffs SET resolution = $2, resolved_at = $3, last_error = NULL
+		WHERE bid_id = $1 AND resolved_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, bidID, resolution, at)
+	if err != nil {
+		r.logger.Error("Failed to resolve order handoff", zap.String("bid_id", bidID), zap.Error(err))
+		return false, fmt.Errorf("failed to resolve order handoff: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// GetOrderHandoffs returns an auction's sales in the order they were made
+func (r *PostgresRepo) GetOrderHandoffs(ctx context.Context, auctionID string) ([]*models.OrderHandoff, error) {
+	query := `SELECT ` + orderHandoffColumns + ` FROM order_handoffs WHERE auction_id = $1 ORDER BY created_at, bid_id`
+	rows, err := r.db.QueryContext(ctx, query, auctionID)
+	if err != nil {
+		r.logger.Error("Failed to query order handoffs", zap.String("auction_id", auctionID), zap.Error(err))
+		return nil, fmt.Errorf("failed to query order handoffs: %w", err)
+	}
+	defer rows.Close()
+
+	var handoffs []*models.OrderHandoff
+	for rows.Next() {
+		handoff, err := scanOrderHandoff(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order handoff: %w", err)
+		}
+		handoffs = append(handoffs, handoff)
+	}
+	return handoffs, rows.Err()
+}
@@ -3,7 +3,10 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	_ "github.com/lib/pq"
@@ -12,6 +15,78 @@ import (
 	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
 )
 
+// ErrPriceRegression is returned when a price update would move an auction's
+// current price below a bid that was already accepted
+var ErrPriceRegression = errors.New("auction price would move below an accepted bid")
+
+// auctionColumns is the column list read by scanAuction
+const auctionColumns = `auction_id, product_id, seller_id, title, description,
+			starting_price, current_price, reserve_price, min_bid_increment,
+			start_time, end_time, status, type, is_active, created_at, updated_at,
+			COALESCE(winner_id, ''), COALESCE(winning_bid_id, ''), settled_at,
+			soft_close, soft_close_window_seconds, soft_close_extension_seconds, max_extensions, extension_count,
+			floor_price, drop_step, drop_interval_seconds, next_drop_at, quantity, quantity_remaining,
+			buy_now_price, pricing_rule, watcher_count, COALESCE(order_id, ''), payment_due_at,
+			COALESCE(cancellation_reason, ''), category, bid_count, COALESCE(show_id, ''),
+			COALESCE(seller_ip, ''), COALESCE(seller_device_id, ''), deposit_amount, currency,
+			COALESCE(template_id, ''), COALESCE(relisted_from, ''), images`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAuction(row rowScanner) (*models.Auction, error) {
+	auction := &models.Auction{}
+	var settledAt, nextDropAt, paymentDueAt sql.NullTime
+	var images []byte
+	err := row.Scan(
+		&auction.AuctionID, &auction.ProductID, &auction.SellerID, &auction.Title, &auction.Description,
+		&auction.StartingPrice, &auction.CurrentPrice, &auction.ReservePrice, &auction.MinBidIncrement,
+		&auction.StartTime, &auction.EndTime, &auction.Status, &auction.Type, &auction.IsActive,
+		&auction.CreatedAt, &auction.UpdatedAt,
+		&auction.WinnerID, &auction.WinningBidID, &settledAt,
+		&auction.SoftClose, &auction.SoftCloseWindow, &auction.SoftCloseExtension, &auction.MaxExtensions, &auction.ExtensionCount,
+		&auction.FloorPrice, &auction.DropStep, &auction.DropInterval, &nextDropAt, &auction.Quantity, &auction.QuantityRemaining,
+		&auction.BuyNowPrice, &auction.PricingRule, &auction.WatcherCount, &auction.OrderID, &paymentDueAt,
+		&auction.CancellationReason, &auction.Category, &auction.BidCount, &auction.ShowID,
+		&auction.SellerIP, &auction.SellerDeviceID, &auction.DepositAmount, &auction.Currency,
+		&auction.TemplateID, &auction.RelistedFrom, &images,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(images, &auction.Images); err != nil {
+		return nil, fmt.Errorf("failed to decode auction images: %w", err)
+	}
+	if auction.Images == nil {
+		auction.Images = []models.AuctionImage{}
+	}
+	if settledAt.Valid {
+		auction.SettledAt = &settledAt.Time
+	}
+	if nextDropAt.Valid {
+		auction.NextDropAt = &nextDropAt.Time
+	}
+	if paymentDueAt.Valid {
+		auction.PaymentDueAt = &paymentDueAt.Time
+	}
+	return auction, nil
+}
+
+// bidColumns is the column list read by scanBid
+const bidColumns = `bid_id, auction_id, bidder_id, amount, is_winning, is_proxy, is_buy_now, quantity, defaulted_at IS NOT NULL, retracted_at IS NOT NULL, bid_time, created_at,
+	COALESCE(ip_address, ''), COALESCE(device_id, '')`
+
+func scanBid(row rowScanner) (*models.Bid, error) {
+	bid := &models.Bid{}
+	err := row.Scan(&bid.BidID, &bid.AuctionID, &bid.BidderID, &bid.Amount, &bid.IsWinning, &bid.IsProxy, &bid.IsBuyNow, &bid.Quantity, &bid.Defaulted, &bid.Retracted, &bid.BidTime, &bid.CreatedAt,
+		&bid.IPAddress, &bid.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+	return bid, nil
+}
+
 type PostgresRepo struct {
 	db     DBTX
 	logger *zap.Logger
@@ -21,82 +96,222 @@ func NewPostgresRepo(db DBTX, logger *zap.Logger) *PostgresRepo {
 	return &PostgresRepo{db: db, logger: logger}
 }
 
-func (r *PostgresRepo) BeginTx(ctx context.Context) (*sql.Tx, error) {
+// Ping checks that the database is reachable
+func (r *PostgresRepo) Ping(ctx context.Context) error {
+	db, ok := r.db.(*sql.DB)
+	if !ok {
+		return nil
+	}
+	return db.PingContext(ctx)
+}
+
+func (r *PostgresRepo) BeginTx(ctx context.Context) (Tx, error) {
 	db, ok := r.db.(*sql.DB)
 	if !ok {
 		return nil, fmt.Errorf("cannot begin transaction with a transaction")
 	}
-	return db.BeginTx(ctx, nil)
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
 }
 
-func (r *PostgresRepo) WithTx(tx *sql.Tx) AuctionRepo {
-	return &PostgresRepo{db: tx, logger: r.logger}
+func (r *PostgresRepo) WithTx(tx Tx) AuctionRepo {
+	return &PostgresRepo{db: tx.(*sql.Tx), logger: r.logger}
 }
 
 func (r *PostgresRepo) Create(ctx context.Context, auction *models.Auction) error {
-	query := `INSERT INTO auctions (auction_id, product_id, seller_id, title, description, starting_price, reserve_price, min_bid_increment, start_time, end_time, status, type, is_active, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
-	_, err := r.db.ExecContext(ctx, query, auction.AuctionID, auction.ProductID, auction.SellerID, auction.Title, auction.Description, auction.StartingPrice, auction.ReservePrice, auction.MinBidIncrement, auction.StartTime, auction.EndTime, auction.Status, auction.Type, auction.IsActive, auction.CreatedAt, auction.UpdatedAt)
+	if origin, ok := models.BidOriginFor(ctx, auction.SellerID); ok {
+		auction.SellerIP, auction.SellerDeviceID = origin.IPAddress, origin.DeviceID
+	}
+	images, err := json.Marshal(auction.Images)
+	if err != nil {
+		return fmt.Errorf("failed to encode auction images: %w", err)
+	}
+	query := `INSERT INTO auctions (auction_id, product_id, seller_id, title, description, starting_price, current_price, reserve_price, min_bid_increment, start_time, end_time, status, type, is_active, created_at, updated_at, soft_close, soft_close_window_seconds, soft_close_extension_seconds, max_extensions, floor_price, drop_step, drop_interval_seconds, next_drop_at, quantity, quantity_remaining, buy_now_price, pricing_rule, category, show_id, seller_ip, seller_device_id, deposit_amount, currency, template_id, relisted_from, images) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, NULLIF($30, ''), NULLIF($31, ''), NULLIF($32, ''), $33, $34, NULLIF($35, ''), NULLIF($36, ''), $37)`
+	_, err = r.db.ExecContext(ctx, query, auction.AuctionID, auction.ProductID, auction.SellerID, auction.Title, auction.Description, auction.StartingPrice, auction.CurrentPrice, auction.ReservePrice, auction.MinBidIncrement, auction.StartTime, auction.EndTime, auction.Status, auction.Type, auction.IsActive, auction.CreatedAt, auction.UpdatedAt, auction.SoftClose, auction.SoftCloseWindow, auction.SoftCloseExtension, auction.MaxExtensions, auction.FloorPrice, auction.DropStep, auction.DropInterval, auction.NextDropAt, auction.Quantity, auction.QuantityRemaining, auction.BuyNowPrice, auction.PricingRule, auction.Category, auction.ShowID, auction.SellerIP, auction.SellerDeviceID, auction.DepositAmount, auction.Currency, auction.TemplateID, auction.RelistedFrom, images)
+	return err
+}
+
+func (r *PostgresRepo) Update(ctx context.Context, auction *models.Auction) error {
+	images, err := json.Marshal(auction.Images)
+	if err != nil {
+		return fmt.Errorf("failed to encode auction images: %w", err)
+	}
+	query := `UPDATE auctions SET title = $2, description = $3, reserve_price = $4, min_bid_increment = $5, start_time = $6, end_time = $7, updated_at = $8, images = $9 WHERE auction_id = $1`
+	_, err = r.db.ExecContext(ctx, query, auction.AuctionID, auction.Title, auction.Description, auction.ReservePrice, auction.MinBidIncrement, auction.StartTime, auction.EndTime, auction.UpdatedAt, images)
 	return err
 }
 
 func (r *PostgresRepo) GetByID(ctx context.Context, id string) (*models.Auction, error) {
-	query := `SELECT auction_id, product_id, seller_id, title, description, starting_price, current_price, reserve_price, min_bid_increment, start_time, end_time, status, type, is_active, created_at, updated_at FROM auctions WHERE auction_id = $1`
-	row := r.db.QueryRowContext(ctx, query, id)
+	query := `SELECT ` + auctionColumns + ` FROM auctions WHERE auction_id = $1`
+	return scanAuction(r.db.QueryRowContext(ctx, query, id))
+}
 
-	auction := &models.Auction{}
-	if err := row.Scan(&auction.AuctionID, &auction.ProductID, &auction.SellerID, &auction.Title, &auction.Description, &auction.StartingPrice, &auction.CurrentPrice, &auction.ReservePrice, &auction.MinBidIncrement, &auction.StartTime, &auction.EndTime, &auction.Status, &auction.Type, &auction.IsActive, &auction.CreatedAt, &auction.UpdatedAt); err != nil {
-		return nil, err
-	}
-	return auction, nil
+// GetByIDForUpdate reads an auction and locks its row until the surrounding
+// transaction ends, serialising bids and seller edits on the same auction
+func (r *PostgresRepo) GetByIDForUpdate(ctx context.Context, id string) (*models.Auction, error) {
+	query := `SELECT ` + auctionColumns + ` FROM auctions WHERE auction_id = $1 FOR UPDATE`
+	return scanAuction(r.db.QueryRowContext(ctx, query, id))
 }
 
-func (r *PostgresRepo) List(ctx context.Context) ([]*models.Auction, error) {
-	r.logger.Info("Getting all auctions from database")
+// listSortColumns maps each listing sort to its column and direction
+var listSortColumns = map[string]struct {
+	column string
+	desc   bool
+}{
+	models.SortNewest:     {"created_at", true},
+	models.SortEndingSoon: {"end_time", false},
+	models.SortPriceAsc:   {"current_price", false},
+	models.SortPriceDesc:  {"current_price", true},
+	models.SortMostBids:   {visibleBidCount, true},
+}
 
-	query := `
-		SELECT auction_id, product_id, seller_id, title, description,
-			starting_price, current_price, reserve_price, min_bid_increment,
-			start_time, end_time, status, type, is_active, created_at, updated_at
+// visibleBidCount is bid_count with sealed auctions' counts hidden until
+// their bids are revealed, so sorting by bids gives nothing away
+const visibleBidCount = `(CASE WHEN type = 'sealed' AND status <> 'ended'
+		AND (status = 'cancelled' OR end_time > NOW()) THEN 0 ELSE bid_count END)`
+
+// List returns one page of auctions matching the filter, plus the number of
+// matching auctions across all pages. Pages are keyed on the sort column and
+// auction_id, resuming after filter.After.
+func (r *PostgresRepo) List(ctx context.Context, filter *models.AuctionFilter) ([]*models.Auction, int64, error) {
+	sort, ok := listSortColumns[filter.Sort]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown sort %q", filter.Sort)
+	}
+
+	var conditions []string
+	var args []interface{}
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.SellerID != "" {
+		where("seller_id = $%d", filter.SellerID)
+	}
+	if filter.Status != "" {
+		where("status = $%d", filter.Status)
+	}
+	if filter.Type != "" {
+		where("type = $%d", filter.Type)
+	}
+	if filter.Category != "" {
+		where("category = $%d", filter.Category)
+	}
+	if filter.Currency != "" {
+		where("currency = $%d", filter.Currency)
+	}
+	if filter.MinPrice > 0 {
+		where("current_price >= $%d", filter.MinPrice)
+	}
+	if filter.MaxPrice > 0 {
+		where("current_price <= $%d", filter.MaxPrice)
+	}
+	if filter.StartsAfter != nil {
+		where("start_time >= $%d", *filter.StartsAfter)
+	}
+	if filter.StartsBefore != nil {
+		where("start_time <= $%d", *filter.StartsBefore)
+	}
+	if filter.EndsAfter != nil {
+		where("end_time >= $%d", *filter.EndsAfter)
+	}
+	if filter.EndsBefore != nil {
+		where("end_time <= $%d", *filter.EndsBefore)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM auctions `+whereClause, args...).Scan(&total); err != nil {
+		r.logger.Error("Failed to count auctions", zap.Error(err))
+		return nil, 0, fmt.Errorf("failed to count auctions: %w", err)
+	}
+
+	direction, comparison := "ASC", ">"
+	if sort.desc {
+		direction, comparison = "DESC", "<"
+	}
+	if filter.After != nil {
+		var value interface{} = filter.After.Value
+		if filter.After.Time != nil {
+			value = *filter.After.Time
+		}
+		args = append(args, value, filter.After.AuctionID)
+		conditions = append(conditions, fmt.Sprintf("(%s, auction_id) %s ($%d, $%d)", sort.column, comparison, len(args)-1, len(args)))
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	args = append(args, filter.Limit)
+	query := fmt.Sprintf(`SELECT `+auctionColumns+`
 		FROM auctions
-		ORDER BY created_at DESC
-		LIMIT 100
-	`
+		%s
+		ORDER BY %s %s, auction_id %s
+		LIMIT $%d`, whereClause, sort.column, direction, direction, len(args))
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		r.logger.Error("Failed to query auctions", zap.Error(err))
-		return nil, fmt.Errorf("failed to query auctions: %w", err)
+		return nil, 0, fmt.Errorf("failed to query auctions: %w", err)
 	}
 	defer rows.Close()
 
 	var auctions []*models.Auction
 	for rows.Next() {
-		var auction models.Auction
-		err := rows.Scan(
-			&auction.AuctionID, &auction.ProductID, &auction.SellerID, &auction.Title, &auction.Description,
-			&auction.StartingPrice, &auction.CurrentPrice, &auction.ReservePrice, &auction.MinBidIncrement,
-			&auction.StartTime, &auction.EndTime, &auction.Status, &auction.Type, &auction.IsActive,
-			&auction.CreatedAt, &auction.UpdatedAt,
-		)
+		auction, err := scanAuction(rows)
 		if err != nil {
 			r.logger.Error("Failed to scan auction", zap.Error(err))
-			return nil, fmt.Errorf("failed to scan auction: %w", err)
+			return nil, 0, fmt.Errorf("failed to scan auction: %w", err)
 		}
-		auctions = append(auctions, &auction)
+		auctions = append(auctions, auction)
 	}
 
-	return auctions, nil
+	return auctions, total, rows.Err()
 }
 
-func (r *PostgresRepo) UpdateAuctionPrice(ctx context.Context, id string, price float64) error {
-	query := `UPDATE auctions SET current_price = $1 WHERE auction_id = $2`
-	_, err := r.db.ExecContext(ctx, query, price, id)
+func (r *PostgresRepo) UpdateAuctionPrice(ctx context.Context, id string, price models.Money) error {
+	// The guard keeps current_price monotonic even if a caller skipped the row lock
+	query := `UPDATE auctions SET current_price = $1 WHERE auction_id = $2 AND current_price <= $1`
+	result, err := r.db.ExecContext(ctx, query, price, id)
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return ErrPriceRegression
+	}
+	return nil
+}
+
+// ExtendEndTime moves an auction's end time for a soft-close extension
+func (r *PostgresRepo) ExtendEndTime(ctx context.Context, id string, endTime time.Time, extensionCount int) error {
+	query := `UPDATE auctions SET end_time = $2, extension_count = $3, updated_at = $4 WHERE auction_id = $1`
+	_, err := r.db.ExecContext(ctx, query, id, endTime, extensionCount, time.Now())
 	return err
 }
 
 func (r *PostgresRepo) CreateBid(ctx context.Context, bid *models.Bid) error {
-	query := `INSERT INTO bids (bid_id, auction_id, bidder_id, amount, is_winning, bid_time, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`
-	_, err := r.db.ExecContext(ctx, query, bid.BidID, bid.AuctionID, bid.BidderID, bid.Amount, bid.IsWinning, bid.BidTime, bid.CreatedAt)
+	if bid.Quantity == 0 {
+		bid.Quantity = 1
+	}
+	if origin, ok := models.BidOriginFor(ctx, bid.BidderID); ok {
+		bid.IPAddress, bid.DeviceID = origin.IPAddress, origin.DeviceID
+	}
+	// One statement so the auction's bid count never drifts from its bids
+	query := `WITH inserted AS (
+			INSERT INTO bids (bid_id, auction_id, bidder_id, amount, is_winning, is_proxy, is_buy_now, quantity, bid_time, created_at, ip_address, device_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''), NULLIF($12, ''))
+			RETURNING auction_id
+		)
+		UPDATE auctions SET bid_count = bid_count + 1 WHERE auction_id = (SELECT auction_id FROM inserted)`
+	_, err := r.db.ExecContext(ctx, query, bid.BidID, bid.AuctionID, bid.BidderID, bid.Amount, bid.IsWinning, bid.IsProxy, bid.IsBuyNow, bid.Quantity, bid.BidTime, bid.CreatedAt, bid.IPAddress, bid.DeviceID)
 	return err
 }
 
@@ -104,9 +319,9 @@ func (r *PostgresRepo) GetBids(ctx context.Context, auctionID string) (*models.B
 	r.logger.Info("Getting bids from database", zap.String("auction_id", auctionID))
 
 	query := `
-		SELECT bid_id, auction_id, bidder_id, amount, is_winning, bid_time, created_at
+		SELECT ` + bidColumns + `
 		FROM bids
-		WHERE auction_id = $1
+		WHERE auction_id = $1 AND retracted_at IS NULL
 		ORDER BY bid_time DESC
 	`
 
@@ -119,35 +334,39 @@ func (r *PostgresRepo) GetBids(ctx context.Context, auctionID string) (*models.B
 
 	var bids []models.Bid
 	for rows.Next() {
-		var bid models.Bid
-		err := rows.Scan(
-			&bid.BidID, &bid.AuctionID, &bid.BidderID, &bid.Amount, &bid.IsWinning, &bid.BidTime, &bid.CreatedAt,
-		)
+		bid, err := scanBid(rows)
 		if err != nil {
 			r.logger.Error("Failed to scan bid", zap.Error(err))
 			return nil, fmt.Errorf("failed to scan bid: %w", err)
 		}
-		bids = append(bids, bid)
+		bids = append(bids, *bid)
 	}
 
 	return &models.BidsResponse{Bids: bids}, nil
 }
 
+func (r *PostgresRepo) CountBids(ctx context.Context, auctionID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM bids WHERE auction_id = $1 AND retracted_at IS NULL`, auctionID).Scan(&count)
+	if err != nil {
+		r.logger.Error("Failed to count bids", zap.String("auction_id", auctionID), zap.Error(err))
+		return 0, fmt.Errorf("failed to count bids: %w", err)
+	}
+	return count, nil
+}
+
 func (r *PostgresRepo) GetWinningBid(ctx context.Context, auctionID string) (*models.Bid, error) {
 	r.logger.Info("Getting winning bid from database", zap.String("auction_id", auctionID))
 
 	query := `
-		SELECT bid_id, auction_id, bidder_id, amount, is_winning, bid_time, created_at
+		SELECT ` + bidColumns + `
 		FROM bids
-		WHERE auction_id = $1 AND is_winning = true
+		WHERE auction_id = $1 AND is_winning = true AND retracted_at IS NULL
 		ORDER BY bid_time DESC
 		LIMIT 1
 	`
 
-	var bid models.Bid
-	err := r.db.QueryRowContext(ctx, query, auctionID).Scan(
-		&bid.BidID, &bid.AuctionID, &bid.BidderID, &bid.Amount, &bid.IsWinning, &bid.BidTime, &bid.CreatedAt,
-	)
+	bid, err := scanBid(r.db.QueryRowContext(ctx, query, auctionID))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -157,7 +376,7 @@ func (r *PostgresRepo) GetWinningBid(ctx context.Context, auctionID string) (*mo
 		return nil, fmt.Errorf("failed to get winning bid: %w", err)
 	}
 
-	return &bid, nil
+	return bid, nil
 }
 
 func (r *PostgresRepo) UpdateAuctionStatus(ctx context.Context, auctionID string, status string) error {
@@ -174,13 +393,139 @@ func (r *PostgresRepo) UpdateAuctionStatus(ctx context.Context, auctionID string
 	return nil
 }
 
+// ClaimNextToStart locks the next scheduled auction whose start time has
+// passed. Rows locked by another replica are skipped; nil means none are due.
+func (r *PostgresRepo) ClaimNextToStart(ctx context.Context, now time.Time) (*models.Auction, error) {
+	query := `SELECT ` + auctionColumns + ` FROM auctions
+		WHERE status = 'scheduled' AND start_time <= $1
+		ORDER BY start_time
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`
+	auction, err := scanAuction(r.db.QueryRowContext(ctx, query, now))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return auction, err
+}
+
+// ClaimNextToEnd locks the next active auction whose end time has passed.
+// Rows locked by another replica are skipped; nil means none are due.
+func (r *PostgresRepo) ClaimNextToEnd(ctx context.Context, now time.Time) (*models.Auction, error) {
+	query := `SELECT ` + auctionColumns + ` FROM auctions
+		WHERE status = 'active' AND end_time <= $1
+		ORDER BY end_time
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`
+	auction, err := scanAuction(r.db.QueryRowContext(ctx, query, now))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return auction, err
+}
+
+// GetHighestBid returns the highest bid on an auction, earliest first on ties,
+// or nil when the auction has no bids
+func (r *PostgresRepo) GetHighestBid(ctx context.Context, auctionID string) (*models.Bid, error) {
+	query := `
+		SELECT ` + bidColumns + `
+		FROM bids
+		WHERE auction_id = $1 AND retracted_at IS NULL
+		ORDER BY amount DESC, bid_time ASC
+		LIMIT 1
+	`
+
+	bid, err := scanBid(r.db.QueryRowContext(ctx, query, auctionID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.Error("Failed to get highest bid", zap.String("auction_id", auctionID), zap.Error(err))
+		return nil, fmt.Errorf("failed to get highest bid: %w", err)
+	}
+	return bid, nil
+}
+
+// GetTopBids returns an auction's highest bids, best first with earlier bids
+// winning ties
+func (r *PostgresRepo) GetTopBids(ctx context.Context, auctionID string, limit int) ([]*models.Bid, error) {
+	query := `
+		SELECT ` + bidColumns + `
+		FROM bids
+		WHERE auction_id = $1 AND retracted_at IS NULL
+		ORDER BY amount DESC, bid_time ASC
+		LIMIT $2
+	`
+	return r.queryBids(ctx, query, auctionID, limit)
+}
+
+// GetBidsByBidder returns one bidder's bids on an auction, newest first
+func (r *PostgresRepo) GetBidsByBidder(ctx context.Context, auctionID, bidderID string) ([]*models.Bid, error) {
+	query := `
+		SELECT ` + bidColumns + `
+		FROM bids
+		WHERE auction_id = $1 AND bidder_id = $2
+		ORDER BY bid_time DESC
+	`
+	return r.queryBids(ctx, query, auctionID, bidderID)
+}
+
+// ReviseBid replaces the amount and time of an existing bid
+func (r *PostgresRepo) ReviseBid(ctx context.Context, bid *models.Bid) error {
+	query := `UPDATE bids SET amount = $2, bid_time = $3 WHERE bid_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, bid.BidID, bid.Amount, bid.BidTime); err != nil {
+		r.logger.Error("Failed to revise bid", zap.String("bid_id", bid.BidID), zap.Error(err))
+		return fmt.Errorf("failed to revise bid: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepo) queryBids(ctx context.Context, query string, args ...interface{}) ([]*models.Bid, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Failed to query bids", zap.Error(err))
+		return nil, fmt.Errorf("failed to query bids: %w", err)
+	}
+	defer rows.Close()
+
+	var bids []*models.Bid
+	for rows.Next() {
+		bid, err := scanBid(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan bid", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan bid: %w", err)
+		}
+		bids = append(bids, bid)
+	}
+	return bids, rows.Err()
+}
+
+// SetWinningBid marks bidID as the only winning bid of an auction. An empty
+// bidID clears the flag on every bid.
+func (r *PostgresRepo) SetWinningBid(ctx context.Context, auctionID, bidID string) error {
+	query := `UPDATE bids SET is_winning = (bid_id = $2) WHERE auction_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, auctionID, bidID); err != nil {
+		r.logger.Error("Failed to set winning bid", zap.String("auction_id", auctionID), zap.Error(err))
+		return fmt.Errorf("failed to set winning bid: %w", err)
+	}
+	return nil
+}
+
+// SettleAuction records the outcome of an auction and marks it ended
+func (r *PostgresRepo) SettleAuction(ctx context.Context, auction *models.Auction) error {
+	query := `UPDATE auctions SET status = $2, current_price = $3, winner_id = NULLIF($4, ''), winning_bid_id = NULLIF($5, ''), settled_at = $6, updated_at = $6 WHERE auction_id = $1`
+	_, err := r.db.ExecContext(ctx, query, auction.AuctionID, auction.Status, auction.CurrentPrice, auction.WinnerID, auction.WinningBidID, auction.SettledAt)
+	if err != nil {
+		r.logger.Error("Failed to settle auction", zap.String("auction_id", auction.AuctionID), zap.Error(err))
+		return fmt.Errorf("failed to settle auction: %w", err)
+	}
+	return nil
+}
+
 func (r *PostgresRepo) GetActive(ctx context.Context) ([]*models.Auction, error) {
 	r.logger.Info("Getting active auctions from database")
 
 	query := `
-		SELECT auction_id, product_id, seller_id, title, description,
-			starting_price, current_price, reserve_price, min_bid_increment,
-			start_time, end_time, status, type, is_active, created_at, updated_at
+		SELECT ` + auctionColumns + `
 		FROM auctions
 		WHERE status = 'active' AND end_time > $1
 		ORDER BY created_at DESC
@@ -195,18 +540,12 @@ func (r *PostgresRepo) GetActive(ctx context.Context) ([]*models.Auction, error)
 
 	var auctions []*models.Auction
 	for rows.Next() {
-		var auction models.Auction
-		err := rows.Scan(
-			&auction.AuctionID, &auction.ProductID, &auction.SellerID, &auction.Title, &auction.Description,
-			&auction.StartingPrice, &auction.CurrentPrice, &auction.ReservePrice, &auction.MinBidIncrement,
-			&auction.StartTime, &auction.EndTime, &auction.Status, &auction.Type, &auction.IsActive,
-			&auction.CreatedAt, &auction.UpdatedAt,
-		)
+		auction, err := scanAuction(rows)
 		if err != nil {
 			r.logger.Error("Failed to scan auction", zap.Error(err))
 			return nil, fmt.Errorf("failed to scan auction: %w", err)
 		}
-		auctions = append(auctions, &auction)
+		auctions = append(auctions, auction)
 	}
 
 	return auctions, nil
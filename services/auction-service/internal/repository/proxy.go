@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// UpsertProxyBid records or replaces a bidder's maximum for an auction
+func (r *PostgresRepo) UpsertProxyBid(ctx context.Context, proxy *models.ProxyBid) error {
+	query := `
+		INSERT INTO proxy_bids (auction_id, bidder_id, max_amount, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (auction_id, bidder_id)
+		DO UPDATE SET max_amount = EXCLUDED.max_amount, updated_at = EXCLUDED.updated_at
+	`
+	_, err := r.db.ExecContext(ctx, query, proxy.AuctionID, proxy.BidderID, proxy.MaxAmount, proxy.CreatedAt, proxy.UpdatedAt)
+	if err != nil {
+		r.logger.Error("Failed to upsert proxy bid", zap.String("auction_id", proxy.AuctionID), zap.Error(err))
+		return fmt.Errorf("failed to upsert proxy bid: %w", err)
+	}
+	return nil
+}
+
+// GetProxyBid returns a bidder's maximum for an auction, or nil if none is set
+func (r *PostgresRepo) GetProxyBid(ctx context.Context, auctionID, bidderID string) (*models.ProxyBid, error) {
+	query := `SELECT auction_id, bidder_id, max_amount, created_at, updated_at FROM proxy_bids WHERE auction_id = $1 AND bidder_id = $2`
+
+	var proxy models.ProxyBid
+	err := r.db.QueryRowContext(ctx, query, auctionID, bidderID).Scan(
+		&proxy.AuctionID, &proxy.BidderID, &proxy.MaxAmount, &proxy.CreatedAt, &proxy.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.Error("Failed to get proxy bid", zap.String("auction_id", auctionID), zap.Error(err))
+		return nil, fmt.Errorf("failed to get proxy bid: %w", err)
+	}
+	return &proxy, nil
+}
+
+// GetProxyBids returns every maximum on an auction, strongest first. Equal
+// maximums are ordered by when they were set, so the earlier one wins.
+func (r *PostgresRepo) GetProxyBids(ctx context.Context, auctionID string) ([]*models.ProxyBid, error) {
+	query := `
+		SELECT auction_id, bidder_id, max_amount, created_at, updated_at
+		FROM proxy_bids
+		WHERE auction_id = $1
+		ORDER BY max_amount DESC, updated_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, auctionID)
+	if err != nil {
+		r.logger.Error("Failed to query proxy bids", zap.String("auction_id", auctionID), zap.Error(err))
+		return nil, fmt.Errorf("failed to query proxy bids: %w", err)
+	}
+	defer rows.Close()
+
+	var proxies []*models.ProxyBid
+	for rows.Next() {
+		var proxy models.ProxyBid
+		if err := rows.Scan(&proxy.AuctionID, &proxy.BidderID, &proxy.MaxAmount, &proxy.CreatedAt, &proxy.UpdatedAt); err != nil {
+			r.logger.Error("Failed to scan proxy bid", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan proxy bid: %w", err)
+		}
+		proxies = append(proxies, &proxy)
+	}
+
+	return proxies, rows.Err()
+}
+
+// DeleteProxyBid removes a bidder's maximum for an auction
+func (r *PostgresRepo) DeleteProxyBid(ctx context.Context, auctionID, bidderID string) error {
+	query := `DELETE FROM proxy_bids WHERE auction_id = $1 AND bidder_id = $2`
+	if _, err := r.db.ExecContext(ctx, query, auctionID, bidderID); err != nil {
+		r.logger.Error("Failed to delete proxy bid", zap.String("auction_id", auctionID), zap.Error(err))
+		return fmt.Errorf("failed to delete proxy bid: %w", err)
+	}
+	return nil
+}
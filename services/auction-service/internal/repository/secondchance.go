@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// offerColumns is the column list read by scanOffer
+const offerColumns = `offer_id, auction_id, bid_id, bidder_id, amount, status, expires_at, responded_at, created_at`
+
+func scanOffer(row rowScanner) (*models.SecondChanceOffer, error) {
+	offer := &models.SecondChanceOffer{}
+	var respondedAt sql.NullTime
+	err := row.Scan(&offer.OfferID, &offer.AuctionID, &offer.BidID, &offer.BidderID, &offer.Amount, &offer.Status,
+		&offer.ExpiresAt, &respondedAt, &offer.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if respondedAt.Valid {
+		offer.RespondedAt = &respondedAt.Time
+	}
+	return offer, nil
+}
+
+// MarkBidDefaulted records that a winning bid's bidder did not pay. The bid
+// stops being the winning bid.
+func (r *PostgresRepo) MarkBidDefaulted(ctx context.Context, bidID string, at time.Time) error {
+	query := `UPDATE bids SET defaulted_at = $2, is_winning = false WHERE bid_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, bidID, at); err != nil {
+		r.logger.Error("Failed to mark bid defaulted", zap.String("bid_id", bidID), zap.Error(err))
+		return fmt.Errorf("failed to mark bid defaulted: %w", err)
+	}
+	return nil
+}
+
+// ClearAuctionWinner removes a defaulted winner, and their order, from an
+// ended auction
+func (r *PostgresRepo) ClearAuctionWinner(ctx context.Context, auctionID string) error {
+	query := `UPDATE auctions SET winner_id = NULL, winning_bid_id = NULL, order_id = NULL, payment_due_at = NULL, updated_at = $2
+		WHERE auction_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, auctionID, time.Now()); err != nil {
+		r.logger.Error("Failed to clear auction winner", zap.String("auction_id", auctionID), zap.Error(err))
+		return fmt.Errorf("failed to clear auction winner: %w", err)
+	}
+	return nil
+}
+
+// CreateStrike records a non-payment strike; one per defaulted bid
+func (r *PostgresRepo) CreateStrike(ctx context.Context, strike *models.NonPaymentStrike) error {
+	query := `INSERT INTO non_payment_strikes (bid_id, user_id, auction_id, order_id, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+		ON CONFLICT (bid_id) DO NOTHING`
+	_, err := r.db.ExecContext(ctx, query, strike.BidID, strike.UserID, strike.AuctionID, strike.OrderID, strike.CreatedAt)
+	if err != nil {
+		r.logger.Error("Failed to record non-payment strike", zap.String("user_id", strike.UserID), zap.Error(err))
+		return fmt.Errorf("failed to record non-payment strike: %w", err)
+	}
+	return nil
+}
+
+// CountStrikes returns how many times a user has failed to pay for a win
+func (r *PostgresRepo) CountStrikes(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM non_payment_strikes WHERE user_id = $1`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count strikes: %w", err)
+	}
+	return count, nil
+}
+
+// GetNextSecondChanceBid returns the highest bid of at least minAmount from a
+// bidder who has not defaulted on or been offered this auction and has fewer
+// than strikeLimit strikes, or nil if nobody is left
+func (r *PostgresRepo) GetNextSecondChanceBid(ctx context.Context, auctionID string, minAmount models.Money, strikeLimit int) (*models.Bid, error) {
+	query := `
+		SELECT ` + bidColumns + `
+		FROM bids b
+		WHERE b.auction_id = $1 AND b.amount >= $2 AND b.retracted_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM bids d WHERE d.auction_id = b.auction_id AND d.bidder_id = b.bidder_id AND d.defaulted_at IS NOT NULL)
+			AND NOT EXISTS (SELECT 1 FROM second_chance_offers o WHERE o.auction_id = b.auction_id AND o.bidder_id = b.bidder_id)
+			AND (SELECT COUNT(*) FROM non_payment_strikes s WHERE s.user_id = b.bidder_id) < $3
+		ORDER BY b.amount DESC, b.bid_time ASC
+		LIMIT 1
+	`
+	bid, err := scanBid(r.db.QueryRowContext(ctx, query, auctionID, minAmount, strikeLimit))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return bid, err
+}
+
+// CreateSecondChanceOffer records an offer to a runner-up
+func (r *PostgresRepo) CreateSecondChanceOffer(ctx context.Context, offer *models.SecondChanceOffer) error {
+	query := `INSERT INTO second_chance_offers (offer_id, auction_id, bid_id, bidder_id, amount, status, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := r.db.ExecContext(ctx, query, offer.OfferID, offer.AuctionID, offer.BidID, offer.BidderID, offer.Amount,
+		offer.Status, offer.ExpiresAt, offer.CreatedAt)
+	if err != nil {
+		r.logger.Error("Failed to create second-chance offer", zap.String("auction_id", offer.AuctionID), zap.Error(err))
+		return fmt.Errorf("failed to create second-chance offer: %w", err)
+	}
+	return nil
+}
+
+// GetPendingOffer returns an auction's open second-chance offer, or nil
+func (r *PostgresRepo) GetPendingOffer(ctx context.Context, auctionID string) (*models.SecondChanceOffer, error) {
+	query := `SELECT ` + offerColumns + ` FROM second_chance_offers WHERE auction_id = $1 AND status = 'pending'`
+	offer, err := scanOffer(r.db.QueryRowContext(ctx, query, auctionID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return offer, err
+}
+
+// GetOfferForBidder returns the offer a bidder received for an auction, or nil
+func (r *PostgresRepo) GetOfferForBidder(ctx context.Context, auctionID, bidderID string) (*models.SecondChanceOffer, error) {
+	query := `SELECT ` + offerColumns + ` FROM second_chance_offers WHERE auction_id = $1 AND bidder_id = $2`
+	offer, err := scanOffer(r.db.QueryRowContext(ctx, query, auctionID, bidderID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return offer, err
+}
+
+// UpdateOfferStatus closes a second-chance offer
+func (r *PostgresRepo) UpdateOfferStatus(ctx context.Context, offerID, status string, at time.Time) error {
+	query := `UPDATE second_chance_offers SET status = $2, responded_at = $3 WHERE offer_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, offerID, status, at); err != nil {
+		r.logger.Error("Failed to update second-chance offer", zap.String("offer_id", offerID), zap.Error(err))
+		return fmt.Errorf("failed to update second-chance offer: %w", err)
+	}
+	return nil
+}
+
+// ClaimNextExpiredOffer locks one auction whose open second-chance offer has
+// expired. The auction is locked rather than the offer so expiry and the
+// bidder's response are serialised by the same lock.
+func (r *PostgresRepo) ClaimNextExpiredOffer(ctx context.Context, now time.Time) (*models.Auction, error) {
+	query := `SELECT ` + auctionColumns + ` FROM auctions
+		WHERE auction_id IN (SELECT auction_id FROM second_chance_offers WHERE status = 'pending' AND expires_at <= $1)
+		ORDER BY auction_id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`
+	auction, err := scanAuction(r.db.QueryRowContext(ctx, query, now))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return auction, err
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// showColumns is the column list read by scanShow
+const showColumns = `show_id, host_id, title, COALESCE(description, ''), status, lot_duration_seconds, currency,
+	COALESCE(current_lot_id, ''), COALESCE(current_auction_id, ''), started_at, ended_at, created_at, updated_at`
+
+// lotColumns is the column list read by scanLot
+const lotColumns = `lot_id, show_id, position, product_id, category, title, COALESCE(description, ''),
+	starting_price, reserve_price, min_bid_increment, buy_now_price, duration_seconds, status,
+	COALESCE(auction_id, ''), started_at, created_at`
+
+func scanShow(row rowScanner) (*models.Show, error) {
+	show := &models.Show{}
+	var startedAt, endedAt sql.NullTime
+	err := row.Scan(&show.ShowID, &show.HostID, &show.Title, &show.Description, &show.Status, &show.LotDuration, &show.Currency,
+		&show.CurrentLotID, &show.CurrentAuctionID, &startedAt, &endedAt, &show.CreatedAt, &show.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if startedAt.Valid {
+		show.StartedAt = &startedAt.Time
+	}
+	if endedAt.Valid {
+		show.EndedAt = &endedAt.Time
+	}
+	return show, nil
+}
+
+func scanLot(row rowScanner) (*models.ShowLot, error) {
+	lot := &models.ShowLot{}
+	var startedAt sql.NullTime
+	err := row.Scan(&lot.LotID, &lot.ShowID, &lot.Position, &lot.ProductID, &lot.Category, &lot.Title, &lot.Description,
+		&lot.StartingPrice, &lot.ReservePrice, &lot.MinBidIncrement, &lot.BuyNowPrice, &lot.Duration, &lot.Status,
+		&lot.AuctionID, &startedAt, &lot.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if startedAt.Valid {
+		lot.StartedAt = &startedAt.Time
+	}
+	return lot, nil
+}
+
+// CreateShow saves a new show
+func (r *PostgresRepo) CreateShow(ctx context.Context, show *models.Show) error {
+	query := `INSERT INTO shows (show_id, host_id, title, description, status, lot_duration_seconds, currency, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := r.db.ExecContext(ctx, query, show.ShowID, show.HostID, show.Title, show.Description, show.Status,
+		show.LotDuration, show.Currency, show.CreatedAt, show.UpdatedAt)
+	if err != nil {
+		r.logger.Error("Failed to create show", zap.String("show_id", show.ShowID), zap.Error(err))
+		return fmt.Errorf("failed to create show: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepo) GetShow(ctx context.Context, showID string) (*models.Show, error) {
+	query := `SELECT ` + showColumns + ` FROM shows WHERE show_id = $1`
+	return scanShow(r.db.QueryRowContext(ctx, query, showID))
+}
+
+// GetShowForUpdate reads a show and locks its row, serialising changes to
+// its queue
+func (r *PostgresRepo) GetShowForUpdate(ctx context.Context, showID string) (*models.Show, error) {
+	query := `SELECT ` + showColumns + ` FROM shows WHERE show_id = $1 FOR UPDATE`
+	return scanShow(r.db.QueryRowContext(ctx, query, showID))
+}
+
+// GetShowLots returns a show's lots in queue order
+func (r *PostgresRepo) GetShowLots(ctx context.Context, showID string) ([]models.ShowLot, error) {
+	query := `SELECT ` + lotColumns + ` FROM show_lots WHERE show_id = $1 ORDER BY position`
+	rows, err := r.db.QueryContext(ctx, query, showID)
+	if err != nil {
+		r.logger.Error("Failed to query show lots", zap.String("show_id", showID), zap.Error(err))
+		return nil, fmt.Errorf("failed to query show lots: %w", err)
+	}
+	defer rows.Close()
+
+	lots := []models.ShowLot{}
+	for rows.Next() {
+		lot, err := scanLot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan show lot: %w", err)
+		}
+		lots = append(lots, *lot)
+	}
+	return lots, rows.Err()
+}
+
+// CreateShowLot appends a lot to the end of a show's queue. Call it with the
+// show row locked so positions are not handed out twice.
+func (r *PostgresRepo) CreateShowLot(ctx context.Context, lot *models.ShowLot) error {
+	query := `INSERT INTO show_lots (lot_id, show_id, position, product_id, category, title, description,
+			starting_price, reserve_price, min_bid_increment, buy_now_price, duration_seconds, status, created_at)
+		SELECT $1, $2, COALESCE(MAX(position), 0) + 1, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+		FROM show_lots WHERE show_id = $2
+		RETURNING position`
+	err := r.db.QueryRowContext(ctx, query, lot.LotID, lot.ShowID, lot.ProductID, lot.Category, lot.Title, lot.Description,
+		lot.StartingPrice, lot.ReservePrice, lot.MinBidIncrement, lot.BuyNowPrice, lot.Duration, lot.Status, lot.CreatedAt).Scan(&lot.Position)
+	if err != nil {
+		r.logger.Error("Failed to create show lot", zap.String("show_id", lot.ShowID), zap.Error(err))
+		return fmt.Errorf("failed to create show lot: %w", err)
+	}
+	return nil
+}
+
+// DeleteQueuedLot removes a lot that has not been brought up yet. It reports
+// false if there was no such queued lot.
+func (r *PostgresRepo) DeleteQueuedLot(ctx context.Context, showID, lotID string) (bool, error) {
+	query := `DELETE FROM show_lots WHERE show_id = $1 AND lot_id = $2 AND status = 'queued'`
+	result, err := r.db.ExecContext(ctx, query, showID, lotID)
+	if err != nil {
+		r.logger.Error("Failed to delete show lot", zap.String("lot_id", lotID), zap.Error(err))
+		return false, fmt.Errorf("failed to delete show lot: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	return deleted > 0, err
+}
+
+// GetNextQueuedLot returns the first lot still waiting in a show's queue, or nil
+func (r *PostgresRepo) GetNextQueuedLot(ctx context.Context, showID string) (*models.ShowLot, error) {
+	query := `SELECT ` + lotColumns + ` FROM show_lots WHERE show_id = $1 AND status = 'queued' ORDER BY position LIMIT 1`
+	lot, err := scanLot(r.db.QueryRowContext(ctx, query, showID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return lot, err
+}
+
+// StartShowLot marks a lot live on the auction created for it
+func (r *PostgresRepo) StartShowLot(ctx context.Context, lotID, auctionID string, at time.Time) error {
+	query := `UPDATE show_lots SET status = 'live', auction_id = $2, started_at = $3 WHERE lot_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, lotID, auctionID, at); err != nil {
+		r.logger.Error("Failed to start show lot", zap.String("lot_id", lotID), zap.Error(err))
+		return fmt.Errorf("failed to start show lot: %w", err)
+	}
+	return nil
+}
+
+// CloseShowLot marks a lot done once the host moves past it
+func (r *PostgresRepo) CloseShowLot(ctx context.Context, lotID string) error {
+	query := `UPDATE show_lots SET status = 'closed' WHERE lot_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, lotID); err != nil {
+		r.logger.Error("Failed to close show lot", zap.String("lot_id", lotID), zap.Error(err))
+		return fmt.Errorf("failed to close show lot: %w", err)
+	}
+	return nil
+}
+
+// SetShowCurrentLot puts a show live on the given lot
+func (r *PostgresRepo) SetShowCurrentLot(ctx context.Context, showID, lotID, auctionID string, at time.Time) error {
+	query := `UPDATE shows SET status = 'live', current_lot_id = $2, current_auction_id = $3,
+			started_at = COALESCE(started_at, $4), updated_at = $4
+		WHERE show_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, showID, lotID, auctionID, at); err != nil {
+		r.logger.Error("Failed to set show current lot", zap.String("show_id", showID), zap.Error(err))
+		return fmt.Errorf("failed to set show current lot: %w", err)
+	}
+	return nil
+}
+
+// EndShow marks a show ended with no current lot
+func (r *PostgresRepo) EndShow(ctx context.Context, showID string, at time.Time) error {
+	query := `UPDATE shows SET status = 'ended', current_lot_id = NULL, current_auction_id = NULL,
+			ended_at = $2, updated_at = $2
+		WHERE show_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, showID, at); err != nil {
+		r.logger.Error("Failed to end show", zap.String("show_id", showID), zap.Error(err))
+		return fmt.Errorf("failed to end show: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// templateColumns is the column list read by scanTemplate
+const templateColumns = `template_id, seller_id, name, product_id, title, COALESCE(description, ''), currency, type,
+	pricing_rule, starting_price, reserve_price, min_bid_increment, buy_now_price, deposit_amount, duration_seconds,
+	soft_close, soft_close_window_seconds, soft_close_extension_seconds, max_extensions,
+	floor_price, drop_step, drop_interval_seconds, quantity, images, created_at, updated_at`
+
+// scheduleColumns is the column list read by scanSchedule
+const scheduleColumns = `schedule_id, template_id, seller_id, weekdays, time_of_day, timezone, active, next_run_at,
+	COALESCE(last_auction_id, ''), COALESCE(last_error, ''), created_at, updated_at`
+
+func scanTemplate(row rowScanner) (*models.AuctionTemplate, error) {
+	template := &models.AuctionTemplate{}
+	var images []byte
+	err := row.Scan(&template.TemplateID, &template.SellerID, &template.Name, &template.ProductID, &template.Title,
+		&template.Description, &template.Currency, &template.Type,
+		&template.PricingRule, &template.StartingPrice, &template.ReservePrice, &template.MinBidIncrement,
+		&template.BuyNowPrice, &template.DepositAmount, &template.Duration,
+		&template.SoftClose, &template.SoftCloseWindow, &template.SoftCloseExtension, &template.MaxExtensions,
+		&template.FloorPrice, &template.DropStep, &template.DropInterval, &template.Quantity, &images,
+		&template.CreatedAt, &template.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(images, &template.Images); err != nil {
+		return nil, fmt.Errorf("failed to decode template images: %w", err)
+	}
+	return template, nil
+}
+
+func scanSchedule(row rowScanner) (*models.AuctionSchedule, error) {
+	schedule := &models.AuctionSchedule{}
+	var weekdays pq.Int64Array
+	err := row.Scan(&schedule.ScheduleID, &schedule.TemplateID, &schedule.SellerID, &weekdays, &schedule.TimeOfDay,
+		&schedule.Timezone, &schedule.Active, &schedule.NextRunAt,
+		&schedule.LastAuctionID, &schedule.LastError, &schedule.CreatedAt, &schedule.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	schedule.Weekdays = make([]int, len(weekdays))
+	for i, day := range weekdays {
+		schedule.Weekdays[i] = int(day)
+	}
+	return schedule, nil
+}
+
+// CreateTemplate saves a new auction template
+func (r *PostgresRepo) CreateTemplate(ctx context.Context, template *models.AuctionTemplate) error {
+	images, err := json.Marshal(template.Images)
+	if err != nil {
+		return fmt.Errorf("failed to encode template images: %w", err)
+	}
+	query := `INSERT INTO auction_templates (template_id, seller_id, name, product_id, title, description, currency, type,
+			pricing_rule, starting_price, reserve_price, min_bid_increment, buy_now_price, deposit_amount, duration_seconds,
+			soft_close, soft_close_window_seconds, soft_close_extension_seconds, max_extensions,
+			floor_price, drop_step, drop_interval_seconds, quantity, images, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)`
+	_, err = r.db.ExecContext(ctx, query, template.TemplateID, template.SellerID, template.Name, template.ProductID,
+		template.Title, template.Description, template.Currency, template.Type,
+		template.PricingRule, template.StartingPrice, template.ReservePrice, template.MinBidIncrement,
+		template.BuyNowPrice, template.DepositAmount, template.Duration,
+		template.SoftClose, template.SoftCloseWindow, template.SoftCloseExtension, template.MaxExtensions,
+		template.FloorPrice, template.DropStep, template.DropInterval, template.Quantity, images,
+		template.CreatedAt, template.UpdatedAt)
+	if err != nil {
+		r.logger.Error("Failed to create auction template", zap.String("template_id", template.TemplateID), zap.Error(err))
+		return fmt.Errorf("failed to create auction template: %w", err)
+	}
+	return nil
+}
+
+// UpdateTemplate replaces a template's settings
+func (r *PostgresRepo) UpdateTemplate(ctx context.Context, template *models.AuctionTemplate) error {
+	images, err := json.Marshal(template.Images)
+	if err != nil {
+		return fmt.Errorf("failed to encode template images: %w", err)
+	}
+	query := `UPDATE auction_templates SET name = $2, product_id = $3, title = $4, description = $5, currency = $6,
+			type = $7, pricing_rule = $8, starting_price = $9, reserve_price = $10, min_bid_increment = $11,
+			buy_now_price = $12, deposit_amount = $13, duration_seconds = $14, soft_close = $15,
+			soft_close_window_seconds = $16, soft_close_extension_seconds = $17, max_extensions = $18,
+			floor_price = $19, drop_step = $20, drop_interval_seconds = $21, quantity = $22, images = $23, updated_at = $24
+		WHERE template_id = $1`
+	_, err = r.db.ExecContext(ctx, query, template.TemplateID, template.Name, template.ProductID, template.Title,
+		template.Description, template.Currency, template.Type, template.PricingRule, template.StartingPrice,
+		template.ReservePrice, template.MinBidIncrement, template.BuyNowPrice, template.DepositAmount, template.Duration,
+		template.SoftClose, template.SoftCloseWindow, template.SoftCloseExtension, template.MaxExtensions,
+		template.FloorPrice, template.DropStep, template.DropInterval, template.Quantity, images, template.UpdatedAt)
+	if err != nil {
+		r.logger.Error("Failed to update auction template", zap.String("template_id", template.TemplateID), zap.Error(err))
+		return fmt.Errorf("failed to update auction template: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepo) GetTemplate(ctx context.Context, templateID string) (*models.AuctionTemplate, error) {
+	query := `SELECT ` + templateColumns + ` FROM auction_templates WHERE template_id = $1`
+	return scanTemplate(r.db.QueryRowContext(ctx, query, templateID))
+}
+
+// GetSellerTemplates returns a seller's templates, newest first
+func (r *PostgresRepo) GetSellerTemplates(ctx context.Context, sellerID string) ([]*models.AuctionTemplate, error) {
+	query := `SELECT ` + templateColumns + ` FROM auction_templates WHERE seller_id = $1 ORDER BY created_at DESC`
+	rows, err := r.db.QueryContext(ctx, query, sellerID)
+	if err != nil {
+		r.logger.Error("Failed to query auction templates", zap.String("seller_id", sellerID), zap.Error(err))
+		return nil, fmt.Errorf("failed to query auction templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []*models.AuctionTemplate{}
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan auction template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
+
+// DeleteTemplate removes a template and its schedules. Auctions already
+// made from it are unaffected.
+func (r *PostgresRepo) DeleteTemplate(ctx context.Context, templateID string) error {
+	query := `DELETE FROM auction_templates WHERE template_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, templateID); err != nil {
+		r.logger.Error("Failed to delete auction template", zap.String("template_id", templateID), zap.Error(err))
+		return fmt.Errorf("failed to delete auction template: %w", err)
+	}
+	return nil
+}
+
+// CreateSchedule saves a new recurring schedule
+func (r *PostgresRepo) CreateSchedule(ctx context.Context, schedule *models.AuctionSchedule) error {
+	query := `INSERT INTO auction_schedules (schedule_id, template_id, seller_id, weekdays, time_of_day, timezone,
+			active, next_run_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	weekdays := make(pq.Int64Array, len(schedule.Weekdays))
+	for i, day := range schedule.Weekdays {
+		weekdays[i] = int64(day)
+	}
+	_, err := r.db.ExecContext(ctx, query, schedule.ScheduleID, schedule.TemplateID, schedule.SellerID, weekdays,
+		schedule.TimeOfDay, schedule.Timezone, schedule.Active, schedule.NextRunAt, schedule.CreatedAt, schedule.UpdatedAt)
+	if err != nil {
+		r.logger.Error("Failed to create auction schedule", zap.String("template_id", schedule.TemplateID), zap.Error(err))
+		return fmt.Errorf("failed to create auction schedule: %w", err)
+	}
+	return nil
+}
+
+// GetTemplateSchedules returns a template's schedules, oldest first
+func (r *PostgresRepo) GetTemplateSchedules(ctx context.Context, templateID string) ([]*models.AuctionSchedule, error) {
+	query := `SELECT ` + scheduleColumns + ` FROM auction_schedules WHERE template_id = $1 ORDER BY created_at`
+	rows, err := r.db.QueryContext(ctx, query, templateID)
+	if err != nil {
+		r.logger.Error("Failed to query auction schedules", zap.String("template_id", templateID), zap.Error(err))
+		return nil, fmt.Errorf("failed to query auction schedules: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := []*models.AuctionSchedule{}
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan auction schedule: %w", err)
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, rows.Err()
+}
+
+// DeleteSchedule removes one of a template's schedules. It reports false if
+// there was no such schedule.
+func (r *PostgresRepo) DeleteSchedule(ctx context.Context, templateID, scheduleID string) (bool, error) {
+	query := `DELETE FROM auction_schedules WHERE template_id = $1 AND schedule_id = $2`
+	result, err := r.db.ExecContext(ctx, query, templateID, scheduleID)
+	if err != nil {
+		r.logger.Error("Failed to delete auction schedule", zap.String("schedule_id", scheduleID), zap.Error(err))
+		return false, fmt.Errorf("failed to delete auction schedule: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	return deleted > 0, err
+}
+
+// ClaimNextDueSchedule locks the next active schedule whose next run falls
+// before cutoff. Schedules locked by another replica are skipped.
+func (r *PostgresRepo) ClaimNextDueSchedule(ctx context.Context, cutoff time.Time) (*models.AuctionSchedule, error) {
+	query := `SELECT ` + scheduleColumns + ` FROM auction_schedules
+		WHERE active AND next_run_at <= $1
+		ORDER BY next_run_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`
+	schedule, err := scanSchedule(r.db.QueryRowContext(ctx, query, cutoff))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return schedule, err
+}
+
+// AdvanceSchedule moves a schedule on to its next run, recording the auction
+// the last run created if there was one
+func (r *PostgresRepo) AdvanceSchedule(ctx context.Context, scheduleID string, nextRunAt time.Time, auctionID string, at time.Time) error {
+	query := `UPDATE auction_schedules SET next_run_at = $2,
+			last_auction_id = COALESCE(NULLIF($3, ''), last_auction_id), last_error = NULL, updated_at = $4
+		WHERE schedule_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, scheduleID, nextRunAt, auctionID, at); err != nil {
+		r.logger.Error("Failed to advance auction schedule", zap.String("schedule_id", scheduleID), zap.Error(err))
+		return fmt.Errorf("failed to advance auction schedule: %w", err)
+	}
+	return nil
+}
+
+// DeactivateSchedule switches off a schedule that can no longer run,
+// recording why
+func (r *PostgresRepo) DeactivateSchedule(ctx context.Context, scheduleID, lastError string, at time.Time) error {
+	query := `UPDATE auction_schedules SET active = FALSE, last_error = $2, updated_at = $3 WHERE schedule_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, scheduleID, lastError, at); err != nil {
+		r.logger.Error("Failed to deactivate auction schedule", zap.String("schedule_id", scheduleID), zap.Error(err))
+		return fmt.Errorf("failed to deactivate auction schedule: %w", err)
+	}
+	return nil
+}
+
+// GetRelisting returns the auction that relists auctionID, or nil
+func (r *PostgresRepo) GetRelisting(ctx context.Context, auctionID string) (*models.Auction, error) {
+	query := `SELECT ` + auctionColumns + ` FROM auctions WHERE relisted_from = $1`
+	auction, err := scanAuction(r.db.QueryRowContext(ctx, query, auctionID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return auction, err
+}
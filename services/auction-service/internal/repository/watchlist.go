@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// AddWatch adds an auction to a user's watchlist and bumps its watcher count.
+// It reports false if the user was already watching.
+func (r *PostgresRepo) AddWatch(ctx context.Context, auctionID, userID string) (bool, error) {
+	query := `INSERT INTO watchlist (auction_id, user_id, created_at) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
+	result, err := r.db.ExecContext(ctx, query, auctionID, userID, time.Now())
+	if err != nil {
+		r.logger.Error("Failed to add watch", zap.String("auction_id", auctionID), zap.Error(err))
+		return false, fmt.Errorf("failed to add watch: %w", err)
+	}
+	if added, err := result.RowsAffected(); err != nil || added == 0 {
+		return false, err
+	}
+
+	if _, err := r.db.ExecContext(ctx, `UPDATE auctions SET watcher_count = watcher_count + 1 WHERE auction_id = $1`, auctionID); err != nil {
+		return false, fmt.Errorf("failed to update watcher count: %w", err)
+	}
+	return true, nil
+}
+
+// RemoveWatch takes an auction off a user's watchlist. It reports false if
+// the user was not watching.
+func (r *PostgresRepo) RemoveWatch(ctx context.Context, auctionID, userID string) (bool, error) {
+	query := `DELETE FROM watchlist WHERE auction_id = $1 AND user_id = $2`
+	result, err := r.db.ExecContext(ctx, query, auctionID, userID)
+	if err != nil {
+		r.logger.Error("Failed to remove watch", zap.String("auction_id", auctionID), zap.Error(err))
+		return false, fmt.Errorf("failed to remove watch: %w", err)
+	}
+	if removed, err := result.RowsAffected(); err != nil || removed == 0 {
+		return false, err
+	}
+
+	if _, err := r.db.ExecContext(ctx, `UPDATE auctions SET watcher_count = GREATEST(watcher_count - 1, 0) WHERE auction_id = $1`, auctionID); err != nil {
+		return false, fmt.Errorf("failed to update watcher count: %w", err)
+	}
+	return true, nil
+}
+
+// IsWatching reports whether a user watches an auction
+func (r *PostgresRepo) IsWatching(ctx context.Context, auctionID, userID string) (bool, error) {
+	var watching bool
+	query := `SELECT EXISTS (SELECT 1 FROM watchlist WHERE auction_id = $1 AND user_id = $2)`
+	if err := r.db.QueryRowContext(ctx, query, auctionID, userID).Scan(&watching); err != nil {
+		return false, fmt.Errorf("failed to check watch: %w", err)
+	}
+	return watching, nil
+}
+
+// GetWatcherIDs returns the users watching an auction
+func (r *PostgresRepo) GetWatcherIDs(ctx context.Context, auctionID string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT user_id FROM watchlist WHERE auction_id = $1`, auctionID)
+	if err != nil {
+		r.logger.Error("Failed to query watchers", zap.String("auction_id", auctionID), zap.Error(err))
+		return nil, fmt.Errorf("failed to query watchers: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan watcher: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, rows.Err()
+}
+
+// GetWatchedAuctions returns the auctions a user watches, most recently
+// watched first
+func (r *PostgresRepo) GetWatchedAuctions(ctx context.Context, userID string) ([]*models.Auction, error) {
+	query := `
+		SELECT ` + auctionColumns + `
+		FROM auctions
+		JOIN (SELECT auction_id, created_at AS watched_at FROM watchlist WHERE user_id = $1) w USING (auction_id)
+		ORDER BY w.watched_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		r.logger.Error("Failed to query watched auctions", zap.String("user_id", userID), zap.Error(err))
+		return nil, fmt.Errorf("failed to query watched auctions: %w", err)
+	}
+	defer rows.Close()
+
+	var auctions []*models.Auction
+	for rows.Next() {
+		auction, err := scanAuction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan auction: %w", err)
+		}
+		auctions = append(auctions, auction)
+	}
+	return auctions, rows.Err()
+}
+
+// ClaimNextEndingSoon locks one active auction ending by cutoff whose
+// ending-soon alert has not been scheduled yet
+func (r *PostgresRepo) ClaimNextEndingSoon(ctx context.Context, cutoff time.Time) (*models.Auction, error) {
+	query := `SELECT ` + auctionColumns + ` FROM auctions
+		WHERE status = 'active' AND ending_soon_at IS NULL AND end_time <= $1
+		ORDER BY end_time
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`
+	auction, err := scanAuction(r.db.QueryRowContext(ctx, query, cutoff))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return auction, err
+}
+
+// MarkEndingSoon records that an auction's ending-soon alert was scheduled
+func (r *PostgresRepo) MarkEndingSoon(ctx context.Context, auctionID string, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE auctions SET ending_soon_at = $2 WHERE auction_id = $1`, auctionID, at)
+	return err
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// GetAuctionAnalytics reports bidding activity on one of the seller's
+// auctions. The range limits which bids are counted; the outcome, reserve and
+// hammer figures describe the auction as a whole.
+func (s *AuctionService) GetAuctionAnalytics(ctx context.Context, auctionID, sellerID string, window models.AnalyticsRange) (*models.AuctionAnalytics, error) {
+	if err := validateAnalyticsRange(window); err != nil {
+		return nil, err
+	}
+
+	auction, err := s.repo.GetByID(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrNotFound
+	}
+	if auction.SellerID != sellerID {
+		return nil, shared_errors.ErrForbidden
+	}
+	// Bid amounts would let the seller see into a running sealed auction
+	if sealedBidsHidden(auction, time.Now()) {
+		return nil, shared_errors.ConflictError("SEALED_BIDS_HIDDEN", "Sealed bids are revealed when the auction ends")
+	}
+
+	history, err := s.repo.GetBidHistory(ctx, auctionID, window)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	rates, err := s.repo.GetBidRates(ctx, auctionID, window)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	highest, err := s.repo.GetHighestBid(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	analytics := &models.AuctionAnalytics{
+		AuctionID:     auction.AuctionID,
+		Title:         auction.Title,
+		Type:          auction.Type,
+		Status:        auction.Status,
+		Currency:      auction.Currency,
+		Range:         window,
+		TotalBids:     len(history),
+		BidsPerMinute: rates,
+		PriceCurve:    priceCurve(auction, history),
+		StartingPrice: auction.StartingPrice,
+		ReservePrice:  auction.ReservePrice,
+		ReserveMet:    auction.ReservePrice > 0 && highest != nil && highest.Amount >= auction.ReservePrice,
+	}
+
+	bidders := make(map[string]struct{})
+	var claimed models.Money
+	var units int
+	for _, bid := range history {
+		bidders[bid.BidderID] = struct{}{}
+		claimed += bid.Amount.Times(bid.Quantity)
+		units += bid.Quantity
+	}
+	analytics.UniqueBidders = len(bidders)
+	if len(history) > 0 {
+		first := history[0].BidTime.Truncate(time.Minute)
+		last := history[len(history)-1].BidTime.Truncate(time.Minute)
+		minutes := last.Sub(first).Minutes() + 1
+		analytics.AvgBidsPerMinute = roundRatio(float64(len(history)) / minutes)
+	}
+
+	unitsSold := 0
+	if auction.Type == models.AuctionTypeDrop {
+		unitsSold = auction.Quantity - auction.QuantityRemaining
+		if units > 0 {
+			analytics.HammerPrice = models.Money(math.Round(float64(claimed) / float64(units)))
+		}
+	} else if auction.WinnerID != "" {
+		unitsSold = 1
+		analytics.HammerPrice = auction.CurrentPrice
+	}
+	analytics.Outcome = summaryOutcome(auction.Status, unitsSold)
+	if analytics.Outcome == models.OutcomeSold && auction.StartingPrice > 0 {
+		analytics.HammerRatio = roundRatio(float64(analytics.HammerPrice) / float64(auction.StartingPrice))
+	}
+	return analytics, nil
+}
+
+// GetSellerAnalytics rolls up the seller's auctions that end within the range
+func (s *AuctionService) GetSellerAnalytics(ctx context.Context, sellerID string, window models.AnalyticsRange) (*models.SellerAnalytics, error) {
+	if err := validateAnalyticsRange(window); err != nil {
+		return nil, err
+	}
+
+	summaries, err := s.repo.GetSellerAuctionSummaries(ctx, sellerID, window)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	uniqueBidders, err := s.repo.CountSellerBidders(ctx, sellerID, window)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	analytics := &models.SellerAnalytics{
+		SellerID:             sellerID,
+		Range:                window,
+		TotalAuctions:        len(summaries),
+		UniqueBidders:        uniqueBidders,
+		Auctions:             summaries,
+		GrossSalesByCurrency: map[string]models.Money{},
+	}
+	var ratioSum float64
+	var ratioCount int
+	for i := range summaries {
+		summary := &summaries[i]
+		summary.Outcome = summaryOutcome(summary.Status, summary.UnitsSold)
+		summary.ReserveMet = summary.ReservePrice > 0 && summary.HighestBid >= summary.ReservePrice
+		analytics.TotalBids += summary.TotalBids
+
+		if summary.Outcome != models.OutcomeSold && summary.Outcome != models.OutcomeUnsold {
+			continue
+		}
+		analytics.Finished++
+		if summary.ReservePrice > 0 {
+			analytics.WithReserve++
+			if summary.ReserveMet {
+				analytics.ReserveMet++
+			}
+		}
+		if summary.Outcome == models.OutcomeSold {
+			analytics.Sold++
+			analytics.GrossSalesByCurrency[summary.Currency] += summary.HammerPrice.Times(summary.UnitsSold)
+			if summary.StartingPrice > 0 {
+				summary.HammerRatio = roundRatio(float64(summary.HammerPrice) / float64(summary.StartingPrice))
+				ratioSum += summary.HammerRatio
+				ratioCount++
+			}
+		}
+	}
+	if len(analytics.GrossSalesByCurrency) == 1 {
+		for currency, total := range analytics.GrossSalesByCurrency {
+			analytics.Currency = currency
+			analytics.GrossSales = total
+		}
+	}
+	if analytics.Finished > 0 {
+		analytics.SellThroughRate = roundRatio(float64(analytics.Sold) / float64(analytics.Finished))
+	}
+	if analytics.WithReserve > 0 {
+		analytics.ReserveMetRate = roundRatio(float64(analytics.ReserveMet) / float64(analytics.WithReserve))
+	}
+	if ratioCount > 0 {
+		analytics.AvgHammerRatio = roundRatio(ratioSum / float64(ratioCount))
+	}
+	return analytics, nil
+}
+
+func validateAnalyticsRange(window models.AnalyticsRange) error {
+	if window.From != nil && window.To != nil && !window.To.After(*window.From) {
+		return shared_errors.ValidationError("INVALID_DATE_RANGE", "The end of the date range must be after its start")
+	}
+	return nil
+}
+
+// summaryOutcome reports how an auction finished, or "" while it is running
+func summaryOutcome(status string, unitsSold int) string {
+	switch status {
+	case constants.AuctionStatusCancelled:
+		return models.OutcomeCancelled
+	case constants.AuctionStatusEnded:
+		if unitsSold > 0 {
+			return models.OutcomeSold
+		}
+		return models.OutcomeUnsold
+	}
+	return ""
+}
+
+// priceCurve traces the auction price bid by bid: the running high bid, or
+// for a drop auction the price each claim paid
+func priceCurve(auction *models.Auction, history []*models.Bid) []models.PricePoint {
+	curve := make([]models.PricePoint, 0, len(history))
+	var high models.Money
+	for _, bid := range history {
+		price := bid.Amount
+		if auction.Type != models.AuctionTypeDrop {
+			high = max(high, bid.Amount)
+			price = high
+		}
+		curve = append(curve, models.PricePoint{Time: bid.BidTime, Price: price})
+	}
+	return curve
+}
+
+func roundRatio(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
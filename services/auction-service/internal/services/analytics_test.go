@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+)
+
+func TestAuctionAnalytics(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{ReservePrice: 2000, EndTime: time.Now().Add(time.Hour)})
+	placeTestBid(t, service, auction.AuctionID, "alice", 1000)
+	placeTestBid(t, service, auction.AuctionID, "bob", 1500)
+	placeTestBid(t, service, auction.AuctionID, "alice", 2500)
+
+	if _, err := service.GetAuctionAnalytics(ctx, auction.AuctionID, "someone-else", models.AnalyticsRange{}); errorCode(err) != "FORBIDDEN" {
+		t.Errorf("non-seller: %v, want FORBIDDEN", err)
+	}
+	from, to := time.Now(), time.Now().Add(-time.Hour)
+	if _, err := service.GetAuctionAnalytics(ctx, auction.AuctionID, auction.SellerID, models.AnalyticsRange{From: &from, To: &to}); errorCode(err) != "INVALID_DATE_RANGE" {
+		t.Errorf("inverted range: %v, want INVALID_DATE_RANGE", err)
+	}
+
+	if _, err := service.CloseDueAuctions(ctx, auction.EndTime.Add(time.Second)); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	analytics, err := service.GetAuctionAnalytics(ctx, auction.AuctionID, auction.SellerID, models.AnalyticsRange{})
+	if err != nil {
+		t.Fatalf("analytics: %v", err)
+	}
+	if analytics.TotalBids != 3 || analytics.UniqueBidders != 2 {
+		t.Errorf("%d bids from %d bidders, want 3 from 2", analytics.TotalBids, analytics.UniqueBidders)
+	}
+	if analytics.Outcome != models.OutcomeSold || analytics.HammerPrice != 2500 || analytics.HammerRatio != 2.5 || !analytics.ReserveMet {
+		t.Errorf("outcome %s at %s (ratio %v, reserve met %v)", analytics.Outcome, analytics.HammerPrice, analytics.HammerRatio, analytics.ReserveMet)
+	}
+	var curve []models.Money
+	for _, point := range analytics.PriceCurve {
+		curve = append(curve, point.Price)
+	}
+	if want := []models.Money{1000, 1500, 2500}; !slices.Equal(curve, want) {
+		t.Errorf("price curve %v, want %v", curve, want)
+	}
+}
+
+func TestSellerAnalytics(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+	endTime := time.Now().Add(time.Hour)
+	sold := createTestAuction(t, service, models.Auction{EndTime: endTime})
+	placeTestBid(t, service, sold.AuctionID, "alice", 1500)
+	unsold := createTestAuction(t, service, models.Auction{ReservePrice: 5000, EndTime: endTime})
+	placeTestBid(t, service, unsold.AuctionID, "bob", 2000)
+	running := createTestAuction(t, service, models.Auction{EndTime: endTime.Add(time.Hour)})
+	placeTestBid(t, service, running.AuctionID, "carol", 1000)
+	createTestAuction(t, service, models.Auction{SellerID: "seller-2"})
+
+	if _, err := service.CloseDueAuctions(ctx, endTime.Add(time.Second)); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	analytics, err := service.GetSellerAnalytics(ctx, "seller-1", models.AnalyticsRange{})
+	if err != nil {
+		t.Fatalf("analytics: %v", err)
+	}
+	if analytics.TotalAuctions != 3 || analytics.Finished != 2 || analytics.Sold != 1 || analytics.TotalBids != 3 || analytics.UniqueBidders != 3 {
+		t.Errorf("%d auctions, %d finished, %d sold, %d bids from %d bidders",
+			analytics.TotalAuctions, analytics.Finished, analytics.Sold, analytics.TotalBids, analytics.UniqueBidders)
+	}
+	if analytics.SellThroughRate != 0.5 || analytics.WithReserve != 1 || analytics.ReserveMet != 0 || analytics.AvgHammerRatio != 1.5 {
+		t.Errorf("sell-through %v, reserve met %d of %d, average hammer ratio %v",
+			analytics.SellThroughRate, analytics.ReserveMet, analytics.WithReserve, analytics.AvgHammerRatio)
+	}
+	if analytics.Currency != sold.Currency || analytics.GrossSales != 1500 {
+		t.Errorf("gross sales %s %s, want %s 15.00", analytics.Currency, analytics.GrossSales, sold.Currency)
+	}
+}
+
+func TestSummaryOutcome(t *testing.T) {
+	tests := []struct {
+		status    string
+		unitsSold int
+		want      string
+	}{
+		{constants.AuctionStatusActive, 0, ""},
+		{constants.AuctionStatusEnded, 1, models.OutcomeSold},
+		{constants.AuctionStatusEnded, 0, models.OutcomeUnsold},
+		{constants.AuctionStatusCancelled, 0, models.OutcomeCancelled},
+	}
+	for _, tt := range tests {
+		if got := summaryOutcome(tt.status, tt.unitsSold); got != tt.want {
+			t.Errorf("summaryOutcome(%q, %d) = %q, want %q", tt.status, tt.unitsSold, got, tt.want)
+		}
+	}
+}
@@ -2,36 +2,70 @@ package services
 
 import (
 	"context"
-	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 
 	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/config"
 	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
 	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/pkg/accounts"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/pkg/orders"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/pkg/payments"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/pkg/products"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
 	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
 )
 
 type AuctionService struct {
-	db     *sql.DB
-	logger *zap.Logger
-	config *config.Config
+	repo          repository.AuctionRepo
+	logger        *zap.Logger
+	config        *config.Config
+	orders        *orders.Client
+	products      *products.Client
+	accounts      *accounts.Client
+	payments      *payments.Client
+	eventsPending chan struct{}
 }
 
-func NewAuctionService(db *sql.DB, logger *zap.Logger, config *config.Config) *AuctionService {
-	return &AuctionService{db: db, logger: logger, config: config}
+func NewAuctionService(repo repository.AuctionRepo, logger *zap.Logger, config *config.Config) *AuctionService {
+	return &AuctionService{
+		repo:          repo,
+		logger:        logger,
+		config:        config,
+		orders:        orders.NewClient(config.OrderServiceURL, config.ServiceToken),
+		products:      products.NewClient(config.ProductServiceURL),
+		accounts:      accounts.NewClient(config.AuthServiceURL, config.ServiceToken),
+		payments:      payments.NewClient(config.PaymentServiceURL, config.ServiceToken),
+		eventsPending: make(chan struct{}, 1),
+	}
+}
+
+// Ping checks that the auction database is reachable
+func (s *AuctionService) Ping(ctx context.Context) error {
+	return s.repo.Ping(ctx)
 }
 
 func (s *AuctionService) CreateAuction(ctx context.Context, auction *models.Auction) error {
-	if auction.StartTime.IsZero() {
-		auction.StartTime = time.Now()
+	return s.createAuction(ctx, s.repo, auction)
+}
+
+// createAuction validates and saves a new auction through repo, which may
+// be a transaction
+func (s *AuctionService) createAuction(ctx context.Context, repo repository.AuctionRepo, auction *models.Auction) error {
+	if auction.Category == "" || len(auction.Images) == 0 {
+		s.applyProductDefaults(ctx, auction)
 	}
-	if auction.EndTime.IsZero() {
-		auction.EndTime = auction.StartTime.Add(s.config.DefaultAuctionDuration)
+	if err := s.prepareAuction(auction, time.Now()); err != nil {
+		return err
 	}
 
-	repo := repository.NewPostgresRepo(s.db, s.logger)
 	if err := repo.Create(ctx, auction); err != nil {
 		s.logger.Error("Failed to create auction", zap.Error(err))
 		return shared_errors.ErrInternalServer
@@ -39,22 +73,361 @@ func (s *AuctionService) CreateAuction(ctx context.Context, auction *models.Auct
 	return nil
 }
 
+// applyProductDefaults fills in an auction's category and images from its
+// product when the seller left them out. The category only feeds listing
+// filters and the images are cosmetic, so a product-service outage leaves
+// them empty rather than blocking the seller.
+func (s *AuctionService) applyProductDefaults(ctx context.Context, auction *models.Auction) {
+	product, err := s.products.GetProduct(ctx, auction.ProductID)
+	if err != nil {
+		s.logger.Warn("Failed to look up auction product", zap.String("product_id", auction.ProductID), zap.Error(err))
+		return
+	}
+	if auction.Category == "" {
+		auction.Category = product.Category
+	}
+	if len(auction.Images) == 0 {
+		for _, url := range product.ImageURLs() {
+			auction.Images = append(auction.Images, models.AuctionImage{ImageURL: url, AltText: product.Name})
+		}
+	}
+}
+
+// productCategory looks up a product's category, or returns "" if
+// product-service cannot be reached
+func (s *AuctionService) productCategory(ctx context.Context, productID string) string {
+	product, err := s.products.GetProduct(ctx, productID)
+	if err != nil {
+		s.logger.Warn("Failed to look up auction product category", zap.String("product_id", productID), zap.Error(err))
+		return ""
+	}
+	return product.Category
+}
+
+// auctionCurrency normalises a requested currency code, falling back to the
+// service default when none is given
+func (s *AuctionService) auctionCurrency(currency string) (string, error) {
+	if currency == "" {
+		currency = s.config.DefaultCurrency
+	}
+	currency = strings.ToUpper(currency)
+	if !models.SupportedCurrencies[currency] {
+		return "", shared_errors.ValidationError("UNSUPPORTED_CURRENCY", fmt.Sprintf("Auctions cannot be priced in %q", currency))
+	}
+	return currency, nil
+}
+
+// validateAuction checks a new auction would be accepted without changing
+// it. An unset start time counts as now and an unset end time as the
+// default duration after the start.
+func (s *AuctionService) validateAuction(auction *models.Auction, now time.Time) error {
+	start := auction.StartTime
+	if start.IsZero() {
+		start = now
+	}
+	if !auction.EndTime.IsZero() && !auction.EndTime.After(start) {
+		return shared_errors.ValidationError("INVALID_AUCTION_WINDOW", "End time must be after start time")
+	}
+	if _, err := s.auctionCurrency(auction.Currency); err != nil {
+		return err
+	}
+	switch auction.Type {
+	case models.AuctionTypeDrop:
+		if err := validateDropAuction(auction); err != nil {
+			return err
+		}
+	case models.AuctionTypeSealed:
+		if err := validateSealedAuction(auction); err != nil {
+			return err
+		}
+	default:
+		if auction.ReservePrice > 0 && auction.ReservePrice < auction.StartingPrice {
+			return shared_errors.ValidationError("INVALID_RESERVE_PRICE", "Reserve price cannot be below starting price")
+		}
+	}
+	if err := validateBuyNowPrice(auction); err != nil {
+		return err
+	}
+	if auction.DepositAmount < 0 || (auction.DepositAmount > 0 && auction.Type == models.AuctionTypeDrop) {
+		return shared_errors.ValidationError("INVALID_DEPOSIT", "Deposits can only be required on bidding auctions and cannot be negative")
+	}
+	return nil
+}
+
+// prepareAuction validates a new auction and fills in its defaults and
+// initial state
+func (s *AuctionService) prepareAuction(auction *models.Auction, now time.Time) error {
+	if err := s.validateAuction(auction, now); err != nil {
+		return err
+	}
+
+	if auction.StartTime.IsZero() {
+		auction.StartTime = now
+	}
+	if auction.EndTime.IsZero() {
+		auction.EndTime = auction.StartTime.Add(s.config.DefaultAuctionDuration)
+	}
+	auction.Currency, _ = s.auctionCurrency(auction.Currency)
+	if auction.Type != models.AuctionTypeSealed {
+		auction.PricingRule = ""
+	}
+	switch auction.Type {
+	case models.AuctionTypeDrop:
+		prepareDropAuction(auction)
+	case models.AuctionTypeSealed:
+		prepareSealedAuction(auction)
+	default:
+		auction.Quantity = 1
+		auction.QuantityRemaining = 1
+		s.applySoftCloseDefaults(auction)
+	}
+	auction.BuyNowPrice = max(auction.BuyNowPrice, 0)
+
+	if auction.AuctionID == "" {
+		auction.AuctionID = uuid.New().String()
+	}
+	auction.Images = numberImages(auction.Images, auction.AuctionID)
+	auction.CurrentPrice = auction.StartingPrice
+	auction.Status = constants.AuctionStatusScheduled
+	if !auction.StartTime.After(now) {
+		auction.Status = constants.AuctionStatusActive
+	}
+	auction.IsActive = true
+	auction.CreatedAt = now
+	auction.UpdatedAt = now
+	return nil
+}
+
+// numberImages puts a gallery in display order, giving new images an ID
+// and tying each to auctionID, which is empty for template images
+func numberImages(images []models.AuctionImage, auctionID string) []models.AuctionImage {
+	for i := range images {
+		images[i].AuctionID = auctionID
+		images[i].Order = i
+		if images[i].ImageID == "" {
+			images[i].ImageID = uuid.New().String()
+		}
+	}
+	if images == nil {
+		images = []models.AuctionImage{}
+	}
+	return images
+}
+
+// updatedImages builds the gallery an update asks for from the auction's
+// current images. Images are kept, reordered and removed by ID; entries with
+// a URL instead are added.
+func updatedImages(current []models.AuctionImage, updates []models.ImageUpdate) ([]models.AuctionImage, error) {
+	existing := make(map[string]models.AuctionImage, len(current))
+	for _, image := range current {
+		existing[image.ImageID] = image
+	}
+
+	images := make([]models.AuctionImage, 0, len(updates))
+	seen := make(map[string]bool, len(updates))
+	for _, update := range updates {
+		switch {
+		case update.ImageID != "" && update.ImageURL != "":
+			return nil, shared_errors.ValidationError("INVALID_IMAGE", "An image takes either an image_id or an image_url, not both")
+		case update.ImageID != "":
+			image, ok := existing[update.ImageID]
+			if !ok {
+				return nil, shared_errors.ValidationError("INVALID_IMAGE", fmt.Sprintf("Image %s is not on this auction", update.ImageID))
+			}
+			if seen[update.ImageID] {
+				return nil, shared_errors.ValidationError("INVALID_IMAGE", fmt.Sprintf("Image %s is listed twice", update.ImageID))
+			}
+			seen[update.ImageID] = true
+			if update.AltText != "" {
+				image.AltText = update.AltText
+			}
+			images = append(images, image)
+		case update.ImageURL != "":
+			images = append(images, models.AuctionImage{ImageURL: update.ImageURL, AltText: update.AltText})
+		default:
+			return nil, shared_errors.ValidationError("INVALID_IMAGE", "An image needs an image_id or an image_url")
+		}
+	}
+	return images, nil
+}
+
 func (s *AuctionService) GetAuction(ctx context.Context, id string) (*models.Auction, error) {
-	repo := repository.NewPostgresRepo(s.db, s.logger)
-	auction, err := repo.GetByID(ctx, id)
+	auction, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		s.logger.Error("Failed to get auction", zap.String("auction_id", id), zap.Error(err))
 		return nil, shared_errors.ErrNotFound
 	}
+	hideSealedBidCount(auction, time.Now())
+	return auction, nil
+}
+
+// UpdateAuction applies a seller's changes to an auction. Pricing and timing
+// can only change while no bids have been placed.
+func (s *AuctionService) UpdateAuction(ctx context.Context, id, sellerID string, req *models.UpdateAuctionRequest) (*models.Auction, error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	// Lock the row so a bid cannot land between the bid-count check and the write
+	auction, err := txRepo.GetByIDForUpdate(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to get auction for update", zap.String("auction_id", id), zap.Error(err))
+		return nil, shared_errors.ErrNotFound
+	}
+	if auction.SellerID != sellerID {
+		return nil, shared_errors.ErrForbidden
+	}
+	if auction.Status == constants.AuctionStatusEnded || auction.Status == constants.AuctionStatusCancelled {
+		return nil, shared_errors.ConflictError("AUCTION_CLOSED", "Auction can no longer be modified")
+	}
+
+	if req.Title != "" {
+		auction.Title = req.Title
+	}
+	if req.Description != "" {
+		auction.Description = req.Description
+	}
+	// Images are cosmetic, so unlike pricing they can change after bidding starts
+	if req.Images != nil {
+		images, err := updatedImages(auction.Images, req.Images)
+		if err != nil {
+			return nil, err
+		}
+		auction.Images = numberImages(images, auction.AuctionID)
+	}
+
+	changesTerms := req.ReservePrice > 0 || req.MinBidIncrement > 0 || !req.StartTime.IsZero() || !req.EndTime.IsZero()
+	if changesTerms {
+		bidCount, err := txRepo.CountBids(ctx, id)
+		if err != nil {
+			return nil, shared_errors.ErrInternalServer
+		}
+		if bidCount > 0 {
+			return nil, shared_errors.ConflictError("AUCTION_HAS_BIDS", "Pricing and timing cannot change once bidding has started")
+		}
+		if req.ReservePrice > 0 {
+			if req.ReservePrice < auction.StartingPrice {
+				return nil, shared_errors.ValidationError("INVALID_RESERVE_PRICE", "Reserve price cannot be below starting price")
+			}
+			auction.ReservePrice = req.ReservePrice
+		}
+		if req.MinBidIncrement > 0 {
+			auction.MinBidIncrement = req.MinBidIncrement
+		}
+		if !req.StartTime.IsZero() {
+			if auction.Status != constants.AuctionStatusScheduled {
+				return nil, shared_errors.ConflictError("AUCTION_STARTED", "Start time cannot change after the auction has started")
+			}
+			auction.StartTime = req.StartTime
+			if auction.Type == models.AuctionTypeDrop {
+				auction.NextDropAt = nextDropAt(auction, auction.StartTime)
+				if err := txRepo.UpdateDropState(ctx, auction); err != nil {
+					return nil, shared_errors.ErrInternalServer
+				}
+			}
+		}
+		if !req.EndTime.IsZero() {
+			auction.EndTime = req.EndTime
+		}
+		if !auction.EndTime.After(auction.StartTime) {
+			return nil, shared_errors.ValidationError("INVALID_AUCTION_WINDOW", "End time must be after start time")
+		}
+	}
+
+	auction.UpdatedAt = time.Now()
+	if err := txRepo.Update(ctx, auction); err != nil {
+		s.logger.Error("Failed to update auction", zap.String("auction_id", id), zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	hideSealedBidCount(auction, time.Now())
 	return auction, nil
 }
 
+// CancelAuction withdraws an auction that has not finished. Sellers may cancel
+// their own auctions; admins may cancel any and must give a reason.
+func (s *AuctionService) CancelAuction(ctx context.Context, id, actorID, reason string, admin bool) error {
+	if admin && reason == "" {
+		return shared_errors.ValidationError("REASON_REQUIRED", "A reason is required to cancel another seller's auction")
+	}
+
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return shared_errors.ErrInternalServer
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	auction, err := txRepo.GetByIDForUpdate(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to get auction for cancellation", zap.String("auction_id", id), zap.Error(err))
+		return shared_errors.ErrNotFound
+	}
+	actorRole := models.ActorSeller
+	if admin {
+		actorRole = models.ActorAdmin
+	} else if auction.SellerID != actorID {
+		return shared_errors.ErrForbidden
+	}
+	if auction.Status == constants.AuctionStatusEnded || auction.Status == constants.AuctionStatusCancelled {
+		return shared_errors.ConflictError("AUCTION_CLOSED", "Auction has already finished")
+	}
+
+	now := time.Now()
+	if err := txRepo.CancelAuction(ctx, id, reason, now); err != nil {
+		return shared_errors.ErrInternalServer
+	}
+	if err := txRepo.ReleaseBidDeposits(ctx, id, "", now); err != nil {
+		return shared_errors.ErrInternalServer
+	}
+	if err := recordEvent(ctx, txRepo, id, models.EventAuctionEnded, map[string]interface{}{
+		"end_time":    now,
+		"final_price": auction.CurrentPrice,
+		"outcome":     models.OutcomeCancelled,
+		"reason":      reason,
+	}); err != nil {
+		return shared_errors.ErrInternalServer
+	}
+	if err := recordAudit(ctx, txRepo, &models.AuditEntry{
+		AuctionID:  id,
+		ActorID:    actorID,
+		ActorRole:  actorRole,
+		Action:     models.AuditCancelled,
+		FromStatus: auction.Status,
+		ToStatus:   constants.AuctionStatusCancelled,
+		Reason:     reason,
+		CreatedAt:  now,
+	}, nil); err != nil {
+		return shared_errors.ErrInternalServer
+	}
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return shared_errors.ErrInternalServer
+	}
+	s.signalEvents()
+
+	s.logger.Info("Auction cancelled",
+		zap.String("auction_id", id),
+		zap.String("actor_role", actorRole),
+		zap.String("reason", reason))
+	return nil
+}
+
 func (s *AuctionService) PlaceBid(ctx context.Context, bid *models.Bid) error {
-	// Create a new repository instance for the transaction
-	repo := repository.NewPostgresRepo(s.db, s.logger)
+	if err := s.checkVerified(ctx, bid.BidderID, bid.Amount); err != nil {
+		return err
+	}
 
 	// Begin a transaction
-	tx, err := repo.BeginTx(ctx)
+	tx, err := s.repo.BeginTx(ctx)
 	if err != nil {
 		s.logger.Error("Failed to begin transaction", zap.Error(err))
 		return shared_errors.ErrInternalServer
@@ -62,29 +435,57 @@ func (s *AuctionService) PlaceBid(ctx context.Context, bid *models.Bid) error {
 	defer tx.Rollback() // Rollback is a no-op if the transaction is already committed
 
 	// Create a new repository with the transaction
-	txRepo := repo.WithTx(tx)
+	txRepo := s.repo.WithTx(tx)
 
-	auction, err := txRepo.GetByID(ctx, bid.AuctionID)
+	auction, err := txRepo.GetByIDForUpdate(ctx, bid.AuctionID)
 	if err != nil {
 		s.logger.Error("Failed to get auction for bid", zap.String("auction_id", bid.AuctionID), zap.Error(err))
 		return shared_errors.ErrNotFound
 	}
 
-	if time.Now().After(auction.EndTime) {
-		return shared_errors.ErrAuctionEnded
+	if auction.Type == models.AuctionTypeDrop {
+		return dropBidError()
+	}
+	if err := checkBiddable(auction, bid.BidderID); err != nil {
+		return err
+	}
+	if err := s.checkEligibility(ctx, txRepo, auction, bid.BidderID, bid.Amount); err != nil {
+		return err
 	}
 
-	if bid.Amount <= auction.CurrentPrice {
-		return shared_errors.ErrBidTooLow
+	if auction.Type == models.AuctionTypeSealed {
+		if err := s.placeSealedBid(ctx, txRepo, auction, bid); err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			s.logger.Error("Failed to commit transaction", zap.Error(err))
+			return shared_errors.ErrInternalServer
+		}
+		return nil
 	}
 
-	if err := txRepo.CreateBid(ctx, bid); err != nil {
-		s.logger.Error("Failed to place bid", zap.Any("bid", bid), zap.Error(err))
+	bidCount, err := txRepo.CountBids(ctx, bid.AuctionID)
+	if err != nil {
 		return shared_errors.ErrInternalServer
 	}
+	minimum := s.nextValidBid(auction, bidCount > 0)
+	if bid.Amount < minimum {
+		return bidTooLowError(auction, minimum)
+	}
 
-	if err := txRepo.UpdateAuctionPrice(ctx, bid.AuctionID, bid.Amount); err != nil {
-		s.logger.Error("Failed to update auction price", zap.String("auction_id", bid.AuctionID), zap.Float64("new_price", bid.Amount), zap.Error(err))
+	now := time.Now()
+	if err := s.acceptBid(ctx, txRepo, auction, bid, now); err != nil {
+		return shared_errors.ErrInternalServer
+	}
+
+	// Competing maximums may immediately outbid the new bid
+	_, leadingBidID, err := s.resolveProxyBids(ctx, txRepo, auction, bid.BidderID, bid.BidID, now)
+	if err != nil {
+		return shared_errors.ErrInternalServer
+	}
+	bid.IsWinning = leadingBidID == bid.BidID
+
+	if err := s.extendForLateBid(ctx, txRepo, auction, now); err != nil {
 		return shared_errors.ErrInternalServer
 	}
 
@@ -93,26 +494,265 @@ func (s *AuctionService) PlaceBid(ctx context.Context, bid *models.Bid) error {
 		s.logger.Error("Failed to commit transaction", zap.Error(err))
 		return shared_errors.ErrInternalServer
 	}
+	s.signalEvents()
+
+	return nil
+}
 
+// checkBiddable rejects bids on auctions that are not open and bids from the
+// auction's own seller
+func checkBiddable(auction *models.Auction, bidderID string) error {
+	if auction.Status == constants.AuctionStatusScheduled {
+		return shared_errors.ConflictError("AUCTION_NOT_STARTED", "Auction has not started yet")
+	}
+	if auction.Status != constants.AuctionStatusActive || time.Now().After(auction.EndTime) {
+		return shared_errors.ErrAuctionEnded
+	}
+	if bidderID == auction.SellerID {
+		return shared_errors.AuthorizationError("SELF_BID", "Sellers cannot bid on their own auctions")
+	}
 	return nil
 }
 
-func (s *AuctionService) ListAuctions(ctx context.Context) ([]*models.Auction, error) {
-	repo := repository.NewPostgresRepo(s.db, s.logger)
-	auctions, err := repo.List(ctx)
+func dropBidError() error {
+	return shared_errors.ConflictError("DROP_AUCTION", "Drop auctions are won by claiming at the current price, not by bidding")
+}
+
+// acceptBid records a validated bid as the new leading bid and moves the
+// auction's current price to it
+func (s *AuctionService) acceptBid(ctx context.Context, txRepo repository.AuctionRepo, auction *models.Auction, bid *models.Bid, at time.Time) error {
+	if bid.BidID == "" {
+		bid.BidID = uuid.New().String()
+	}
+	bid.AuctionID = auction.AuctionID
+	bid.BidTime = at
+	bid.CreatedAt = at
+	bid.IsWinning = true
+
+	previous, err := txRepo.GetHighestBid(ctx, auction.AuctionID)
+	if err != nil {
+		return err
+	}
+
+	if err := txRepo.CreateBid(ctx, bid); err != nil {
+		s.logger.Error("Failed to place bid", zap.Any("bid", bid), zap.Error(err))
+		return err
+	}
+
+	if err := txRepo.UpdateAuctionPrice(ctx, auction.AuctionID, bid.Amount); err != nil {
+		s.logger.Error("Failed to update auction price", zap.String("auction_id", auction.AuctionID), zap.Stringer("new_price", bid.Amount), zap.Error(err))
+		return err
+	}
+
+	if err := txRepo.SetWinningBid(ctx, auction.AuctionID, bid.BidID); err != nil {
+		return err
+	}
+
+	auction.CurrentPrice = bid.Amount
+
+	if err := recordEvent(ctx, txRepo, auction.AuctionID, models.EventBidPlaced, map[string]interface{}{
+		"bid_id":    bid.BidID,
+		"bidder_id": bid.BidderID,
+		"amount":    bid.Amount,
+		"is_proxy":  bid.IsProxy,
+		"bid_time":  bid.BidTime,
+	}); err != nil {
+		return err
+	}
+	if err := recordEvent(ctx, txRepo, auction.AuctionID, models.EventPriceChanged, map[string]interface{}{
+		"current_price": auction.CurrentPrice,
+		"minimum_bid":   s.nextValidBid(auction, true),
+	}); err != nil {
+		return err
+	}
+	if previous != nil && previous.BidderID != bid.BidderID {
+		if err := recordEvent(ctx, txRepo, auction.AuctionID, models.EventOutbid, map[string]interface{}{
+			"bidder_id":     previous.BidderID,
+			"previous_bid":  previous.Amount,
+			"current_price": auction.CurrentPrice,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Bidders follow the auctions they bid on
+	return s.autoWatch(ctx, txRepo, auction, bid.BidderID)
+}
+
+// Listing page sizes
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// ListAuctions returns one page of auctions matching the filter. cursor is
+// the NextCursor of the previous page, or empty for the first page.
+func (s *AuctionService) ListAuctions(ctx context.Context, filter *models.AuctionFilter, cursor string) (*models.AuctionsResponse, error) {
+	if filter.Sort == "" {
+		filter.Sort = models.SortNewest
+	}
+	switch filter.Sort {
+	case models.SortNewest, models.SortEndingSoon, models.SortPriceAsc, models.SortPriceDesc, models.SortMostBids:
+	default:
+		return nil, shared_errors.ValidationError("INVALID_SORT", "Sort must be one of newest, ending_soon, price_asc, price_desc, most_bids")
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+	if filter.MaxPrice > 0 && filter.MinPrice > filter.MaxPrice {
+		return nil, shared_errors.ValidationError("INVALID_PRICE_RANGE", "Minimum price cannot be above maximum price")
+	}
+
+	page := 1
+	if cursor != "" {
+		after, err := decodeAuctionCursor(cursor)
+		if err != nil || after.Sort != filter.Sort {
+			return nil, shared_errors.ValidationError("INVALID_CURSOR", "Cursor is malformed or belongs to a different sort")
+		}
+		filter.After = after
+		page = after.Page + 1
+	}
+
+	auctions, total, err := s.repo.List(ctx, filter)
 	if err != nil {
 		s.logger.Error("Failed to list auctions", zap.Error(err))
 		return nil, shared_errors.ErrInternalServer
 	}
-	return auctions, nil
+
+	resp := &models.AuctionsResponse{
+		Auctions: make([]models.Auction, 0, len(auctions)),
+		Total:    total,
+		Page:     page,
+		Limit:    filter.Limit,
+	}
+	now := time.Now()
+	for _, auction := range auctions {
+		// Hidden counts sort as zero, so the cursor stays in step
+		hideSealedBidCount(auction, now)
+		resp.Auctions = append(resp.Auctions, *auction)
+	}
+	if len(auctions) == filter.Limit && int64(page*filter.Limit) < total {
+		resp.NextCursor = encodeAuctionCursor(filter.Sort, auctions[len(auctions)-1], page)
+	}
+	return resp, nil
+}
+
+// encodeAuctionCursor records where a listing page ended
+func encodeAuctionCursor(sort string, last *models.Auction, page int) string {
+	cursor := models.AuctionCursor{Sort: sort, AuctionID: last.AuctionID, Page: page}
+	switch sort {
+	case models.SortNewest:
+		cursor.Time = &last.CreatedAt
+	case models.SortEndingSoon:
+		cursor.Time = &last.EndTime
+	case models.SortPriceAsc, models.SortPriceDesc:
+		cursor.Value = int64(last.CurrentPrice)
+	case models.SortMostBids:
+		cursor.Value = int64(last.BidCount)
+	}
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeAuctionCursor(raw string) (*models.AuctionCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, err
+	}
+	var cursor models.AuctionCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	if cursor.AuctionID == "" || cursor.Page < 1 {
+		return nil, errors.New("incomplete cursor")
+	}
+	timeSort := cursor.Sort == models.SortNewest || cursor.Sort == models.SortEndingSoon
+	if timeSort != (cursor.Time != nil) {
+		return nil, errors.New("cursor value does not match its sort")
+	}
+	return &cursor, nil
 }
 
 func (s *AuctionService) GetActiveAuctions(ctx context.Context) ([]*models.Auction, error) {
-	repo := repository.NewPostgresRepo(s.db, s.logger)
-	auctions, err := repo.GetActive(ctx)
+	auctions, err := s.repo.GetActive(ctx)
 	if err != nil {
 		s.logger.Error("Failed to get active auctions", zap.Error(err))
 		return nil, shared_errors.ErrInternalServer
 	}
+	now := time.Now()
+	for _, auction := range auctions {
+		hideSealedBidCount(auction, now)
+	}
 	return auctions, nil
 }
+
+// GetBids returns the bids placed on an auction, newest first
+func (s *AuctionService) GetBids(ctx context.Context, auctionID string) ([]models.Bid, error) {
+	auction, err := s.repo.GetByID(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrNotFound
+	}
+	if sealedBidsHidden(auction, time.Now()) {
+		return []models.Bid{}, nil
+	}
+
+	resp, err := s.repo.GetBids(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if resp.Bids == nil {
+		resp.Bids = []models.Bid{}
+	}
+	return resp.Bids, nil
+}
+
+// GetAuctionStatus summarises the live state of an auction
+func (s *AuctionService) GetAuctionStatus(ctx context.Context, auctionID string) (*models.AuctionStatus, error) {
+	auction, err := s.repo.GetByID(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrNotFound
+	}
+
+	totalBids, err := s.repo.CountBids(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if sealedBidsHidden(auction, time.Now()) {
+		totalBids = 0
+	}
+
+	status := &models.AuctionStatus{
+		AuctionID:     auction.AuctionID,
+		Status:        auction.Status,
+		CurrentPrice:  auction.CurrentPrice,
+		TotalBids:     totalBids,
+		MinimumBid:    s.nextValidBid(auction, totalBids > 0),
+		TimeRemaining: "0s",
+		UpdatedAt:     auction.UpdatedAt,
+	}
+	if sealedBidsHidden(auction, time.Now()) {
+		status.MinimumBid = auction.StartingPrice
+	}
+	if auction.Type == models.AuctionTypeDrop {
+		// Drops sell at the asking price, which may have stepped since the last tick
+		if auction.Status == constants.AuctionStatusActive {
+			status.CurrentPrice = dropPriceAt(auction, time.Now())
+			status.NextDropAt = nextDropAt(auction, time.Now())
+		}
+		status.MinimumBid = status.CurrentPrice
+		status.QuantityRemaining = auction.QuantityRemaining
+	} else if winningBid, err := s.repo.GetWinningBid(ctx, auctionID); err == nil {
+		status.WinningBidID = winningBid.BidID
+	}
+	if auction.BuyNowPrice > 0 {
+		status.BuyNowPrice = auction.BuyNowPrice
+		status.BuyNowAvailable = s.buyNowAvailable(auction, totalBids > 0)
+	}
+	if remaining := time.Until(auction.EndTime); auction.Status == constants.AuctionStatusActive && remaining > 0 {
+		status.TimeRemaining = remaining.Round(time.Second).String()
+	}
+	return status, nil
+}
@@ -0,0 +1,545 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/config"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// newTestService returns a service backed by an empty in-memory repository
+func newTestService(t *testing.T) (*AuctionService, repository.AuctionRepo) {
+	t.Helper()
+	cfg, _ := config.Load()
+	repo := repository.NewMemoryRepo()
+	return NewAuctionService(repo, zap.NewNop(), cfg), repo
+}
+
+// createTestAuction creates auction through the service, filling in a
+// seller, product, category, image and starting price when they are unset.
+// The category and image are set so product-service is never called.
+func createTestAuction(t *testing.T, service *AuctionService, auction models.Auction) *models.Auction {
+	t.Helper()
+	if auction.SellerID == "" {
+		auction.SellerID = "seller-1"
+	}
+	if auction.ProductID == "" {
+		auction.ProductID = "product-1"
+	}
+	if auction.Title == "" {
+		auction.Title = "Test auction"
+	}
+	if auction.Category == "" {
+		auction.Category = "collectibles"
+	}
+	if auction.Images == nil {
+		auction.Images = []models.AuctionImage{{ImageURL: "https://example.com/item.jpg"}}
+	}
+	if auction.StartingPrice == 0 {
+		auction.StartingPrice = 1000
+	}
+	if err := service.CreateAuction(context.Background(), &auction); err != nil {
+		t.Fatalf("create auction: %v", err)
+	}
+	return &auction
+}
+
+// placeTestBid places a bid that the test expects to be accepted
+func placeTestBid(t *testing.T, service *AuctionService, auctionID, bidderID string, amount models.Money) *models.Bid {
+	t.Helper()
+	bid := &models.Bid{AuctionID: auctionID, BidderID: bidderID, Amount: amount}
+	if err := service.PlaceBid(context.Background(), bid); err != nil {
+		t.Fatalf("bid %s by %s: %v", amount, bidderID, err)
+	}
+	return bid
+}
+
+// errorCode returns the application error code of err, or "" for nil
+func errorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	var appErr *shared_errors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return err.Error()
+}
+
+// createListingAuctions creates five auctions with distinct prices, bid
+// counts and end times, oldest first
+func createListingAuctions(t *testing.T, service *AuctionService) {
+	t.Helper()
+	fixtures := []struct {
+		title    string
+		category string
+		price    models.Money
+		endsIn   time.Duration
+		bids     int
+	}{
+		{"lamp", "home", 1000, 3 * time.Hour, 0},
+		{"chair", "home", 4000, 1 * time.Hour, 2},
+		{"print", "art", 2500, 2 * time.Hour, 1},
+		{"vase", "art", 6000, 5 * time.Hour, 3},
+		{"sketch", "art", 1500, 4 * time.Hour, 0},
+	}
+	for i, fixture := range fixtures {
+		auction := createTestAuction(t, service, models.Auction{
+			AuctionID:     fmt.Sprintf("auction-%d", i+1),
+			Title:         fixture.title,
+			Category:      fixture.category,
+			StartingPrice: fixture.price,
+			EndTime:       time.Now().Add(fixture.endsIn),
+		})
+		for j := 0; j < fixture.bids; j++ {
+			status, err := service.GetAuctionStatus(context.Background(), auction.AuctionID)
+			if err != nil {
+				t.Fatalf("get status: %v", err)
+			}
+			placeTestBid(t, service, auction.AuctionID, fmt.Sprintf("bidder-%d", j+1), status.MinimumBid)
+		}
+	}
+}
+
+func titles(auctions []models.Auction) []string {
+	var names []string
+	for _, auction := range auctions {
+		names = append(names, auction.Title)
+	}
+	return names
+}
+
+func TestListAuctions(t *testing.T) {
+	service, _ := newTestService(t)
+	createListingAuctions(t, service)
+
+	tests := []struct {
+		name   string
+		filter models.AuctionFilter
+		want   []string
+	}{
+		{"newest by default", models.AuctionFilter{}, []string{"sketch", "vase", "print", "chair", "lamp"}},
+		{"ending soon", models.AuctionFilter{Sort: models.SortEndingSoon}, []string{"chair", "print", "lamp", "sketch", "vase"}},
+		{"price ascending", models.AuctionFilter{Sort: models.SortPriceAsc}, []string{"lamp", "sketch", "print", "chair", "vase"}},
+		{"price descending", models.AuctionFilter{Sort: models.SortPriceDesc}, []string{"vase", "chair", "print", "sketch", "lamp"}},
+		{"most bids, ties by auction ID", models.AuctionFilter{Sort: models.SortMostBids}, []string{"vase", "chair", "print", "sketch", "lamp"}},
+		{"category", models.AuctionFilter{Category: "art"}, []string{"sketch", "vase", "print"}},
+		{"price range", models.AuctionFilter{Sort: models.SortPriceAsc, MinPrice: 2000, MaxPrice: 5000}, []string{"print", "chair"}},
+		{"no matches", models.AuctionFilter{Status: "ended"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			resp, err := service.ListAuctions(context.Background(), &filter, "")
+			if err != nil {
+				t.Fatalf("list: %v", err)
+			}
+			if got := titles(resp.Auctions); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if resp.Total != int64(len(tt.want)) {
+				t.Errorf("total %d, want %d", resp.Total, len(tt.want))
+			}
+			if resp.NextCursor != "" {
+				t.Errorf("unexpected next cursor on a complete page")
+			}
+		})
+	}
+}
+
+func TestListAuctionsPagination(t *testing.T) {
+	service, _ := newTestService(t)
+	createListingAuctions(t, service)
+
+	sorts := []string{models.SortNewest, models.SortEndingSoon, models.SortPriceAsc, models.SortPriceDesc, models.SortMostBids}
+	for _, sort := range sorts {
+		t.Run(sort, func(t *testing.T) {
+			whole, err := service.ListAuctions(context.Background(), &models.AuctionFilter{Sort: sort}, "")
+			if err != nil {
+				t.Fatalf("list: %v", err)
+			}
+
+			var paged []string
+			cursor := ""
+			for page := 1; ; page++ {
+				resp, err := service.ListAuctions(context.Background(), &models.AuctionFilter{Sort: sort, Limit: 2}, cursor)
+				if err != nil {
+					t.Fatalf("page %d: %v", page, err)
+				}
+				if resp.Page != page || resp.Total != 5 {
+					t.Fatalf("page %d reported page %d of %d auctions", page, resp.Page, resp.Total)
+				}
+				paged = append(paged, titles(resp.Auctions)...)
+				if resp.NextCursor == "" {
+					break
+				}
+				cursor = resp.NextCursor
+			}
+			if want := titles(whole.Auctions); !slices.Equal(paged, want) {
+				t.Errorf("pages gave %v, want %v", paged, want)
+			}
+		})
+	}
+}
+
+func TestListAuctionsPagesStayStable(t *testing.T) {
+	service, _ := newTestService(t)
+	createListingAuctions(t, service)
+	ctx := context.Background()
+
+	first, err := service.ListAuctions(ctx, &models.AuctionFilter{Limit: 2}, "")
+	if err != nil {
+		t.Fatalf("first page: %v", err)
+	}
+	// A newer auction sorts before the cursor, so it must not shift the
+	// pages that follow
+	createTestAuction(t, service, models.Auction{Title: "newcomer"})
+
+	second, err := service.ListAuctions(ctx, &models.AuctionFilter{Limit: 2}, first.NextCursor)
+	if err != nil {
+		t.Fatalf("second page: %v", err)
+	}
+	if got, want := titles(second.Auctions), []string{"print", "chair"}; !slices.Equal(got, want) {
+		t.Errorf("second page %v, want %v", got, want)
+	}
+}
+
+func TestListAuctionsFilters(t *testing.T) {
+	service, _ := newTestService(t)
+	createListingAuctions(t, service)
+	now := time.Now()
+	createTestAuction(t, service, models.Auction{
+		AuctionID: "auction-6", Title: "poster", SellerID: "seller-2", Currency: "EUR", StartingPrice: 1000,
+		StartTime: now.Add(time.Hour), EndTime: now.Add(6 * time.Hour),
+	})
+	createTestAuction(t, service, models.Auction{
+		AuctionID: "auction-7", Title: "mug", SellerID: "seller-2", Type: models.AuctionTypeLive, StartingPrice: 1000,
+		EndTime: now.Add(30 * time.Minute),
+	})
+	soon, later := now.Add(90*time.Minute), now.Add(30*time.Minute)
+
+	tests := []struct {
+		name   string
+		filter models.AuctionFilter
+		want   []string
+	}{
+		{"seller", models.AuctionFilter{SellerID: "seller-2"}, []string{"mug", "poster"}},
+		{"status", models.AuctionFilter{Status: "scheduled"}, []string{"poster"}},
+		{"type", models.AuctionFilter{Type: models.AuctionTypeLive}, []string{"mug"}},
+		{"currency", models.AuctionFilter{Currency: "EUR"}, []string{"poster"}},
+		{"ending before", models.AuctionFilter{Sort: models.SortEndingSoon, EndsBefore: &soon}, []string{"mug", "chair"}},
+		{"starting after", models.AuctionFilter{StartsAfter: &later}, []string{"poster"}},
+		{"combined", models.AuctionFilter{SellerID: "seller-1", Category: "art", MaxPrice: 2000}, []string{"sketch"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			resp, err := service.ListAuctions(context.Background(), &filter, "")
+			if err != nil {
+				t.Fatalf("list: %v", err)
+			}
+			if got := titles(resp.Auctions); !slices.Equal(got, tt.want) || resp.Total != int64(len(tt.want)) {
+				t.Errorf("got %v of %d, want %v", got, resp.Total, tt.want)
+			}
+		})
+	}
+
+	t.Run("equal prices page by auction ID", func(t *testing.T) {
+		var paged []string
+		cursor := ""
+		for page := 1; page <= 4; page++ {
+			resp, err := service.ListAuctions(context.Background(), &models.AuctionFilter{Sort: models.SortPriceAsc, MaxPrice: 1000, Limit: 1}, cursor)
+			if err != nil {
+				t.Fatalf("page %d: %v", page, err)
+			}
+			paged = append(paged, titles(resp.Auctions)...)
+			if cursor = resp.NextCursor; cursor == "" {
+				break
+			}
+		}
+		if want := []string{"lamp", "poster", "mug"}; !slices.Equal(paged, want) {
+			t.Errorf("pages gave %v, want %v", paged, want)
+		}
+	})
+
+	t.Run("limit is capped", func(t *testing.T) {
+		resp, err := service.ListAuctions(context.Background(), &models.AuctionFilter{Limit: 500}, "")
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		if resp.Limit != maxListLimit {
+			t.Errorf("limit %d, want %d", resp.Limit, maxListLimit)
+		}
+	})
+}
+
+func TestListAuctionsRejectsBadInput(t *testing.T) {
+	service, _ := newTestService(t)
+	createListingAuctions(t, service)
+	ctx := context.Background()
+
+	priceCursor, err := service.ListAuctions(ctx, &models.AuctionFilter{Sort: models.SortPriceAsc, Limit: 2}, "")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter models.AuctionFilter
+		cursor string
+		want   string
+	}{
+		{"unknown sort", models.AuctionFilter{Sort: "cheapest"}, "", "INVALID_SORT"},
+		{"inverted price range", models.AuctionFilter{MinPrice: 5000, MaxPrice: 1000}, "", "INVALID_PRICE_RANGE"},
+		{"malformed cursor", models.AuctionFilter{}, "not-a-cursor", "INVALID_CURSOR"},
+		{"cursor from another sort", models.AuctionFilter{Sort: models.SortNewest}, priceCursor.NextCursor, "INVALID_CURSOR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			_, err := service.ListAuctions(ctx, &filter, tt.cursor)
+			if got := errorCode(err); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// imageURLs and altTexts list a gallery's URLs and alt texts in order
+func imageURLs(images []models.AuctionImage) []string {
+	var urls []string
+	for _, image := range images {
+		urls = append(urls, image.ImageURL)
+	}
+	return urls
+}
+
+func altTexts(images []models.AuctionImage) []string {
+	var alts []string
+	for _, image := range images {
+		alts = append(alts, image.AltText)
+	}
+	return alts
+}
+
+// checkGallery checks images are numbered in order and tied to auctionID
+func checkGallery(t *testing.T, images []models.AuctionImage, auctionID string) {
+	t.Helper()
+	if images == nil {
+		t.Errorf("gallery is nil, want an empty list")
+	}
+	for i, image := range images {
+		if image.Order != i || image.ImageID == "" || image.AuctionID != auctionID {
+			t.Errorf("image %d is %+v", i, image)
+		}
+	}
+}
+
+func TestCreateAuctionImages(t *testing.T) {
+	products := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/products/lamp" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"success":true,"data":{"product":{"product_id":"lamp","name":"Brass lamp","category":"home",`+
+			`"images":"[\"https://example.com/lamp-1.jpg\",\"https://example.com/lamp-2.jpg\"]"},"available":1}}`)
+	}))
+	defer products.Close()
+
+	tests := []struct {
+		name         string
+		productID    string
+		images       []models.AuctionImage
+		wantURLs     []string
+		wantAlts     []string
+		wantCategory string
+	}{
+		{
+			name:         "seller's images in order",
+			productID:    "lamp",
+			images:       []models.AuctionImage{{ImageURL: "https://example.com/front.jpg", AltText: "Front"}, {ImageURL: "https://example.com/back.jpg"}},
+			wantURLs:     []string{"https://example.com/front.jpg", "https://example.com/back.jpg"},
+			wantAlts:     []string{"Front", ""},
+			wantCategory: "home",
+		},
+		{
+			name:         "product's images when none are given",
+			productID:    "lamp",
+			wantURLs:     []string{"https://example.com/lamp-1.jpg", "https://example.com/lamp-2.jpg"},
+			wantAlts:     []string{"Brass lamp", "Brass lamp"},
+			wantCategory: "home",
+		},
+		{
+			name:      "no images when the product cannot be found",
+			productID: "missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, _ := config.Load()
+			cfg.ProductServiceURL = products.URL
+			service := NewAuctionService(repository.NewMemoryRepo(), zap.NewNop(), cfg)
+
+			auction := &models.Auction{
+				ProductID:     tt.productID,
+				SellerID:      "seller-1",
+				Title:         "Lamp",
+				StartingPrice: 1000,
+				Type:          models.AuctionTypeLive,
+				Images:        tt.images,
+			}
+			if err := service.CreateAuction(context.Background(), auction); err != nil {
+				t.Fatalf("create auction: %v", err)
+			}
+			stored, err := service.GetAuction(context.Background(), auction.AuctionID)
+			if err != nil {
+				t.Fatalf("get auction: %v", err)
+			}
+			if got := imageURLs(stored.Images); !slices.Equal(got, tt.wantURLs) {
+				t.Errorf("images %v, want %v", got, tt.wantURLs)
+			}
+			if got := altTexts(stored.Images); !slices.Equal(got, tt.wantAlts) {
+				t.Errorf("alt texts %q, want %q", got, tt.wantAlts)
+			}
+			checkGallery(t, stored.Images, auction.AuctionID)
+			if stored.Category != tt.wantCategory {
+				t.Errorf("category %q, want %q", stored.Category, tt.wantCategory)
+			}
+		})
+	}
+}
+
+func TestUpdateAuctionImages(t *testing.T) {
+	const (
+		front = "https://example.com/front.jpg"
+		back  = "https://example.com/back.jpg"
+		side  = "https://example.com/side.jpg"
+		box   = "https://example.com/box.jpg"
+	)
+	tests := []struct {
+		name     string
+		images   func(ids map[string]string) []models.ImageUpdate
+		wantURLs []string
+		wantAlts []string
+		wantErr  string
+	}{
+		{
+			name:     "left out keeps the gallery",
+			images:   func(map[string]string) []models.ImageUpdate { return nil },
+			wantURLs: []string{front, back, side},
+			wantAlts: []string{"Front", "", ""},
+		},
+		{
+			name: "reorder",
+			images: func(ids map[string]string) []models.ImageUpdate {
+				return []models.ImageUpdate{{ImageID: ids[side]}, {ImageID: ids[front]}, {ImageID: ids[back]}}
+			},
+			wantURLs: []string{side, front, back},
+			wantAlts: []string{"", "Front", ""},
+		},
+		{
+			name: "remove",
+			images: func(ids map[string]string) []models.ImageUpdate {
+				return []models.ImageUpdate{{ImageID: ids[front]}, {ImageID: ids[side]}}
+			},
+			wantURLs: []string{front, side},
+			wantAlts: []string{"Front", ""},
+		},
+		{
+			name: "add and relabel",
+			images: func(ids map[string]string) []models.ImageUpdate {
+				return []models.ImageUpdate{{ImageID: ids[back], AltText: "Back"}, {ImageURL: box, AltText: "Box"}}
+			},
+			wantURLs: []string{back, box},
+			wantAlts: []string{"Back", "Box"},
+		},
+		{
+			name:   "empty list removes every image",
+			images: func(map[string]string) []models.ImageUpdate { return []models.ImageUpdate{} },
+		},
+		{
+			name: "unknown image",
+			images: func(map[string]string) []models.ImageUpdate {
+				return []models.ImageUpdate{{ImageID: "not-an-image"}}
+			},
+			wantErr: "INVALID_IMAGE",
+		},
+		{
+			name: "image listed twice",
+			images: func(ids map[string]string) []models.ImageUpdate {
+				return []models.ImageUpdate{{ImageID: ids[front]}, {ImageID: ids[front]}}
+			},
+			wantErr: "INVALID_IMAGE",
+		},
+		{
+			name: "both an ID and a URL",
+			images: func(ids map[string]string) []models.ImageUpdate {
+				return []models.ImageUpdate{{ImageID: ids[front], ImageURL: box}}
+			},
+			wantErr: "INVALID_IMAGE",
+		},
+		{
+			name:    "neither an ID nor a URL",
+			images:  func(map[string]string) []models.ImageUpdate { return []models.ImageUpdate{{AltText: "Mystery"}} },
+			wantErr: "INVALID_IMAGE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newTestService(t)
+			ctx := context.Background()
+			auction := createTestAuction(t, service, models.Auction{Images: []models.AuctionImage{
+				{ImageURL: front, AltText: "Front"}, {ImageURL: back}, {ImageURL: side},
+			}})
+			// Images can change after bidding starts, unlike pricing
+			placeTestBid(t, service, auction.AuctionID, "alice", 1000)
+			ids := map[string]string{}
+			for _, image := range auction.Images {
+				ids[image.ImageURL] = image.ImageID
+			}
+
+			req := &models.UpdateAuctionRequest{Title: "Renamed", Images: tt.images(ids)}
+			updated, err := service.UpdateAuction(ctx, auction.AuctionID, auction.SellerID, req)
+			if got := errorCode(err); got != tt.wantErr {
+				t.Fatalf("got error %q, want %q", got, tt.wantErr)
+			}
+			stored, err := service.GetAuction(ctx, auction.AuctionID)
+			if err != nil {
+				t.Fatalf("get auction: %v", err)
+			}
+			if tt.wantErr != "" {
+				if got := imageURLs(stored.Images); !slices.Equal(got, []string{front, back, side}) {
+					t.Errorf("rejected update changed the gallery to %v", got)
+				}
+				return
+			}
+
+			if !slices.Equal(imageURLs(updated.Images), imageURLs(stored.Images)) {
+				t.Errorf("update returned %v, stored %v", imageURLs(updated.Images), imageURLs(stored.Images))
+			}
+			if got := imageURLs(stored.Images); !slices.Equal(got, tt.wantURLs) {
+				t.Errorf("images %v, want %v", got, tt.wantURLs)
+			}
+			if got := altTexts(stored.Images); !slices.Equal(got, tt.wantAlts) {
+				t.Errorf("alt texts %q, want %q", got, tt.wantAlts)
+			}
+			checkGallery(t, stored.Images, auction.AuctionID)
+			for _, image := range stored.Images {
+				if id, kept := ids[image.ImageURL]; kept && image.ImageID != id {
+					t.Errorf("kept image %s changed ID from %s to %s", image.ImageURL, id, image.ImageID)
+				}
+			}
+		})
+	}
+}
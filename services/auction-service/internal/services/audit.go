@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// recordAudit appends to the auction's audit log using the caller's
+// repository, which should be bound to the transaction making the change
+func recordAudit(ctx context.Context, repo repository.AuctionRepo, entry *models.AuditEntry, details interface{}) error {
+	if details != nil {
+		data, err := json.Marshal(details)
+		if err != nil {
+			return err
+		}
+		entry.Details = data
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	return repo.CreateAuditEntry(ctx, entry)
+}
+
+// GetAuditLog returns an auction's history. Sellers see their own auctions;
+// admins see any.
+func (s *AuctionService) GetAuditLog(ctx context.Context, auctionID, userID string, admin bool) ([]*models.AuditEntry, error) {
+	auction, err := s.repo.GetByID(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrNotFound
+	}
+	if !admin && auction.SellerID != userID {
+		return nil, shared_errors.ErrForbidden
+	}
+
+	entries, err := s.repo.GetAuditLog(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	return entries, nil
+}
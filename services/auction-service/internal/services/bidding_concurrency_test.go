@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/config"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// TestPlaceBidConcurrentBidders runs the full PlaceBid path against a
+// migrated database named by AUCTION_TEST_DATABASE_URL
+func TestPlaceBidConcurrentBidders(t *testing.T) {
+	dsn := os.Getenv("AUCTION_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("AUCTION_TEST_DATABASE_URL not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(32)
+
+	cfg, _ := config.Load()
+	service := NewAuctionService(repository.NewPostgresRepo(db, zap.NewNop()), zap.NewNop(), cfg)
+	ctx := context.Background()
+
+	auction := &models.Auction{
+		ProductID:     "product-" + uuid.New().String(),
+		SellerID:      "seller-" + uuid.New().String(),
+		Title:         "Concurrent bidding",
+		StartingPrice: 1000,
+		StartTime:     time.Now().Add(-time.Minute),
+		EndTime:       time.Now().Add(time.Hour),
+	}
+	if err := service.CreateAuction(ctx, auction); err != nil {
+		t.Fatalf("create auction: %v", err)
+	}
+	defer db.ExecContext(ctx, `DELETE FROM auctions WHERE auction_id = $1`, auction.AuctionID)
+
+	const bidders = 16
+	const bidsPerBidder = 8
+	var wg sync.WaitGroup
+	for i := 0; i < bidders; i++ {
+		wg.Add(1)
+		go func(bidderID string) {
+			defer wg.Done()
+			for j := 0; j < bidsPerBidder; j++ {
+				status, err := service.GetAuctionStatus(ctx, auction.AuctionID)
+				if err != nil {
+					t.Errorf("get status: %v", err)
+					return
+				}
+				bid := &models.Bid{AuctionID: auction.AuctionID, BidderID: bidderID, Amount: status.MinimumBid}
+				err = service.PlaceBid(ctx, bid)
+				var appErr *shared_errors.AppError
+				if err != nil && !(errors.As(err, &appErr) && appErr.Code == "BID_TOO_LOW") {
+					t.Errorf("place bid: %v", err)
+				}
+			}
+		}(uuid.New().String())
+	}
+	wg.Wait()
+
+	repo := repository.NewPostgresRepo(db, zap.NewNop())
+	stored, err := repo.GetByID(ctx, auction.AuctionID)
+	if err != nil {
+		t.Fatalf("reload auction: %v", err)
+	}
+	highest, err := repo.GetHighestBid(ctx, auction.AuctionID)
+	if err != nil || highest == nil {
+		t.Fatalf("highest bid: %v", err)
+	}
+	if stored.CurrentPrice != highest.Amount {
+		t.Fatalf("current_price %s does not match highest bid %s", stored.CurrentPrice, highest.Amount)
+	}
+
+	// Racing bidders at the same minimum must not both be accepted
+	resp, err := repo.GetBids(ctx, auction.AuctionID)
+	if err != nil {
+		t.Fatalf("get bids: %v", err)
+	}
+	seen := make(map[models.Money]bool, len(resp.Bids))
+	for _, bid := range resp.Bids {
+		if seen[bid.Amount] {
+			t.Fatalf("two bids accepted at %s", bid.Amount)
+		}
+		seen[bid.Amount] = true
+	}
+}
@@ -0,0 +1,468 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+func TestPlaceBid(t *testing.T) {
+	type bid struct {
+		bidderID string
+		amount   models.Money
+	}
+	tests := []struct {
+		name      string
+		auction   models.Auction
+		earlier   []bid
+		bid       bid
+		want      string
+		wantPrice models.Money
+	}{
+		{
+			name:      "first bid at the starting price",
+			bid:       bid{"bidder-1", 1000},
+			wantPrice: 1000,
+		},
+		{
+			name:      "first bid below the starting price",
+			bid:       bid{"bidder-1", 900},
+			want:      "BID_TOO_LOW",
+			wantPrice: 1000,
+		},
+		{
+			name:      "raise short of the ladder increment",
+			earlier:   []bid{{"bidder-1", 1000}},
+			bid:       bid{"bidder-2", 1050},
+			want:      "BID_TOO_LOW",
+			wantPrice: 1000,
+		},
+		{
+			name:      "raise by the ladder increment",
+			earlier:   []bid{{"bidder-1", 1000}},
+			bid:       bid{"bidder-2", 1100},
+			wantPrice: 1100,
+		},
+		{
+			name:      "ladder rung follows the current price",
+			auction:   models.Auction{StartingPrice: 5000},
+			earlier:   []bid{{"bidder-1", 5000}},
+			bid:       bid{"bidder-2", 5100},
+			want:      "BID_TOO_LOW",
+			wantPrice: 5000,
+		},
+		{
+			name:      "auction increment above the ladder",
+			auction:   models.Auction{MinBidIncrement: 500},
+			earlier:   []bid{{"bidder-1", 1000}},
+			bid:       bid{"bidder-2", 1400},
+			want:      "BID_TOO_LOW",
+			wantPrice: 1000,
+		},
+		{
+			name:      "auction increment met",
+			auction:   models.Auction{MinBidIncrement: 500},
+			earlier:   []bid{{"bidder-1", 1000}},
+			bid:       bid{"bidder-2", 1500},
+			wantPrice: 1500,
+		},
+		{
+			name:      "leader may raise their own bid",
+			earlier:   []bid{{"bidder-1", 1000}},
+			bid:       bid{"bidder-1", 1100},
+			wantPrice: 1100,
+		},
+		{
+			name:      "seller bidding on their own auction",
+			bid:       bid{"seller-1", 1000},
+			want:      "SELF_BID",
+			wantPrice: 1000,
+		},
+		{
+			name:      "auction not started",
+			auction:   models.Auction{StartTime: time.Now().Add(time.Hour)},
+			bid:       bid{"bidder-1", 1000},
+			want:      "AUCTION_NOT_STARTED",
+			wantPrice: 1000,
+		},
+		{
+			name:      "auction past its end time",
+			auction:   models.Auction{StartTime: time.Now().Add(-2 * time.Hour), EndTime: time.Now().Add(-time.Hour)},
+			bid:       bid{"bidder-1", 1000},
+			want:      "AUCTION_ENDED",
+			wantPrice: 1000,
+		},
+		{
+			name:      "deposit required",
+			auction:   models.Auction{DepositAmount: 500},
+			bid:       bid{"bidder-1", 1000},
+			want:      "DEPOSIT_REQUIRED",
+			wantPrice: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newTestService(t)
+			ctx := context.Background()
+			auction := createTestAuction(t, service, tt.auction)
+			for _, earlier := range tt.earlier {
+				placeTestBid(t, service, auction.AuctionID, earlier.bidderID, earlier.amount)
+			}
+
+			placed := &models.Bid{AuctionID: auction.AuctionID, BidderID: tt.bid.bidderID, Amount: tt.bid.amount}
+			err := service.PlaceBid(ctx, placed)
+			if got := errorCode(err); got != tt.want {
+				t.Fatalf("got error %q, want %q", got, tt.want)
+			}
+
+			status, err := service.GetAuctionStatus(ctx, auction.AuctionID)
+			if err != nil {
+				t.Fatalf("get status: %v", err)
+			}
+			if status.CurrentPrice != tt.wantPrice {
+				t.Errorf("price %s, want %s", status.CurrentPrice, tt.wantPrice)
+			}
+			wantBids := len(tt.earlier)
+			if tt.want == "" {
+				wantBids++
+			}
+			if status.TotalBids != wantBids {
+				t.Errorf("%d bids recorded, want %d", status.TotalBids, wantBids)
+			}
+
+			if tt.want == "" {
+				winning, err := repo.GetWinningBid(ctx, auction.AuctionID)
+				if err != nil {
+					t.Fatalf("get winning bid: %v", err)
+				}
+				if winning.BidID != placed.BidID || !placed.IsWinning {
+					t.Errorf("accepted bid is not the winning bid")
+				}
+				if watching, _ := repo.IsWatching(ctx, auction.AuctionID, tt.bid.bidderID); !watching {
+					t.Errorf("bidder does not watch the auction they bid on")
+				}
+			}
+		})
+	}
+}
+
+func TestPlaceBidUnknownAuction(t *testing.T) {
+	service, _ := newTestService(t)
+	err := service.PlaceBid(context.Background(), &models.Bid{AuctionID: "missing", BidderID: "bidder-1", Amount: 1000})
+	if got := errorCode(err); got != "NOT_FOUND" {
+		t.Errorf("got %q, want NOT_FOUND", got)
+	}
+}
+
+func TestProxyBidding(t *testing.T) {
+	type step struct {
+		bidderID string
+		amount   models.Money
+		proxy    bool // set a maximum instead of bidding
+	}
+	tests := []struct {
+		name       string
+		steps      []step
+		wantPrice  models.Money
+		wantLeader string
+	}{
+		{
+			name:       "maximum opens at the starting price",
+			steps:      []step{{"alice", 3000, true}},
+			wantPrice:  1000,
+			wantLeader: "alice",
+		},
+		{
+			name:       "maximum answers a lower bid by one increment",
+			steps:      []step{{"alice", 3000, true}, {"bob", 1500, false}},
+			wantPrice:  1600,
+			wantLeader: "alice",
+		},
+		{
+			name:       "bid above the maximum takes the lead",
+			steps:      []step{{"alice", 3000, true}, {"bob", 3500, false}},
+			wantPrice:  3500,
+			wantLeader: "bob",
+		},
+		{
+			name:       "higher maximum leads one increment over the lower",
+			steps:      []step{{"alice", 3000, true}, {"bob", 5000, true}},
+			wantPrice:  3100,
+			wantLeader: "bob",
+		},
+		{
+			name:       "lower maximum is spent against a higher one",
+			steps:      []step{{"alice", 5000, true}, {"bob", 3000, true}},
+			wantPrice:  3100,
+			wantLeader: "alice",
+		},
+		{
+			name:       "equal maximums go to the one set first",
+			steps:      []step{{"alice", 3000, true}, {"bob", 3000, true}},
+			wantPrice:  3000,
+			wantLeader: "alice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newTestService(t)
+			ctx := context.Background()
+			auction := createTestAuction(t, service, models.Auction{})
+
+			for _, step := range tt.steps {
+				var err error
+				if step.proxy {
+					_, err = service.SetProxyBid(ctx, auction.AuctionID, step.bidderID, step.amount)
+				} else {
+					err = service.PlaceBid(ctx, &models.Bid{AuctionID: auction.AuctionID, BidderID: step.bidderID, Amount: step.amount})
+				}
+				if err != nil {
+					t.Fatalf("%s bidding %s: %v", step.bidderID, step.amount, err)
+				}
+			}
+
+			stored, err := repo.GetByID(ctx, auction.AuctionID)
+			if err != nil {
+				t.Fatalf("reload auction: %v", err)
+			}
+			if stored.CurrentPrice != tt.wantPrice {
+				t.Errorf("price %s, want %s", stored.CurrentPrice, tt.wantPrice)
+			}
+			winning, err := repo.GetWinningBid(ctx, auction.AuctionID)
+			if err != nil {
+				t.Fatalf("get winning bid: %v", err)
+			}
+			if winning.BidderID != tt.wantLeader || winning.Amount != tt.wantPrice {
+				t.Errorf("%s leads at %s, want %s at %s", winning.BidderID, winning.Amount, tt.wantLeader, tt.wantPrice)
+			}
+		})
+	}
+}
+
+func TestProxyBidCannotBeLowered(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{})
+
+	if _, err := service.SetProxyBid(ctx, auction.AuctionID, "alice", 5000); err != nil {
+		t.Fatalf("set maximum: %v", err)
+	}
+	placeTestBid(t, service, auction.AuctionID, "bob", 1500)
+
+	_, err := service.SetProxyBid(ctx, auction.AuctionID, "alice", 4000)
+	if got := errorCode(err); got != "MAX_BID_DECREASE" {
+		t.Errorf("got %q, want MAX_BID_DECREASE", got)
+	}
+}
+
+func TestSoftClose(t *testing.T) {
+	tests := []struct {
+		name           string
+		softClose      bool
+		endsIn         time.Duration
+		maxExtensions  int
+		bids           int
+		wantExtensions int
+	}{
+		{"late bid extends", true, 10 * time.Second, 0, 1, 1},
+		{"early bid does not extend", true, 10 * time.Minute, 0, 1, 0},
+		{"extensions are capped", true, 10 * time.Second, 1, 3, 1},
+		{"soft close off", false, 10 * time.Second, 0, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newTestService(t)
+			ctx := context.Background()
+			auction := createTestAuction(t, service, models.Auction{
+				StartTime:     time.Now().Add(-time.Hour),
+				EndTime:       time.Now().Add(tt.endsIn),
+				SoftClose:     tt.softClose,
+				MaxExtensions: tt.maxExtensions,
+			})
+
+			amount := auction.StartingPrice
+			for i := 0; i < tt.bids; i++ {
+				placeTestBid(t, service, auction.AuctionID, "bidder-1", amount)
+				amount += 100
+			}
+
+			stored, err := repo.GetByID(ctx, auction.AuctionID)
+			if err != nil {
+				t.Fatalf("reload auction: %v", err)
+			}
+			if stored.ExtensionCount != tt.wantExtensions {
+				t.Errorf("%d extensions, want %d", stored.ExtensionCount, tt.wantExtensions)
+			}
+			extension := time.Duration(auction.SoftCloseExtension) * time.Second
+			if want := auction.EndTime.Add(time.Duration(tt.wantExtensions) * extension); !stored.EndTime.Equal(want) {
+				t.Errorf("ends at %v, want %v", stored.EndTime, want)
+			}
+		})
+	}
+}
+
+func TestSealedBidding(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{Type: models.AuctionTypeSealed})
+
+	tests := []struct {
+		name     string
+		bidderID string
+		amount   models.Money
+		want     string
+	}{
+		{"below the starting price", "alice", 900, "BID_TOO_LOW"},
+		{"first sealed bid", "alice", 2000, ""},
+		{"revised sealed bid", "alice", 1500, ""},
+		{"another bidder", "bob", 1200, ""},
+		{"seller", "seller-1", 2000, "SELF_BID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.PlaceBid(ctx, &models.Bid{AuctionID: auction.AuctionID, BidderID: tt.bidderID, Amount: tt.amount})
+			if got := errorCode(err); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	status, err := service.GetAuctionStatus(ctx, auction.AuctionID)
+	if err != nil {
+		t.Fatalf("get status: %v", err)
+	}
+	if status.CurrentPrice != auction.StartingPrice {
+		t.Errorf("sealed bids moved the price to %s", status.CurrentPrice)
+	}
+	if status.TotalBids != 0 {
+		t.Errorf("status shows %d sealed bids while the auction runs", status.TotalBids)
+	}
+	if count, err := repo.CountBids(ctx, auction.AuctionID); err != nil || count != 2 {
+		t.Errorf("%d bids recorded, want one per bidder: %v", count, err)
+	}
+	if bids, err := service.GetBids(ctx, auction.AuctionID); err != nil || len(bids) != 0 {
+		t.Errorf("sealed bids visible while the auction runs: %v, %v", bids, err)
+	}
+	mine, err := service.GetMyBids(ctx, auction.AuctionID, "alice")
+	if err != nil || len(mine) != 1 || mine[0].Amount != 1500 {
+		t.Errorf("alice sees %v, %v; want her revised bid", mine, err)
+	}
+	if _, err := repo.GetWinningBid(ctx, auction.AuctionID); err == nil {
+		t.Errorf("a sealed bid leads before settlement")
+	}
+}
+
+func TestSealedBidCountsHidden(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+	sealed := createTestAuction(t, service, models.Auction{Type: models.AuctionTypeSealed, EndTime: time.Now().Add(time.Hour)})
+	open := createTestAuction(t, service, models.Auction{EndTime: time.Now().Add(2 * time.Hour)})
+	placeTestBid(t, service, sealed.AuctionID, "alice", 2000)
+	placeTestBid(t, service, sealed.AuctionID, "bob", 1500)
+	placeTestBid(t, service, open.AuctionID, "alice", 1000)
+
+	if got, err := service.GetAuction(ctx, sealed.AuctionID); err != nil || got.BidCount != 0 {
+		t.Errorf("auction shows %d sealed bids while the auction runs: %v", got.BidCount, err)
+	}
+	if status, err := service.GetAuctionStatus(ctx, sealed.AuctionID); err != nil || status.TotalBids != 0 {
+		t.Errorf("status shows %d sealed bids while the auction runs: %v", status.TotalBids, err)
+	}
+	resp, err := service.ListAuctions(ctx, &models.AuctionFilter{Sort: models.SortMostBids}, "")
+	if err != nil || len(resp.Auctions) != 2 {
+		t.Fatalf("listed %d auctions: %v", len(resp.Auctions), err)
+	}
+	if resp.Auctions[0].AuctionID != open.AuctionID || resp.Auctions[1].BidCount != 0 {
+		t.Errorf("most_bids ranks the sealed auction by its hidden count")
+	}
+
+	if _, err := service.CloseDueAuctions(ctx, sealed.EndTime.Add(time.Second)); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if got, err := service.GetAuction(ctx, sealed.AuctionID); err != nil || got.BidCount != 2 {
+		t.Errorf("auction shows %d bids after the reveal, want 2: %v", got.BidCount, err)
+	}
+	if status, err := service.GetAuctionStatus(ctx, sealed.AuctionID); err != nil || status.TotalBids != 2 {
+		t.Errorf("status shows %d bids after the reveal, want 2: %v", status.TotalBids, err)
+	}
+}
+
+func TestRetractBidReresolvesProxies(t *testing.T) {
+	type step struct {
+		bidderID string
+		amount   models.Money
+		proxy    bool // set a maximum instead of bidding
+	}
+	tests := []struct {
+		name       string
+		steps      []step
+		wantPrice  models.Money
+		wantLeader string
+	}{
+		{
+			name:       "answers to the retracted bid are withdrawn",
+			steps:      []step{{"alice", 3000, true}, {"bob", 2000, false}},
+			wantPrice:  1000,
+			wantLeader: "alice",
+		},
+		{
+			name:       "maximums held back by the retracted bid compete again",
+			steps:      []step{{"carol", 2500, true}, {"bob", 2800, false}, {"alice", 3500, true}},
+			wantPrice:  2600,
+			wantLeader: "alice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newTestService(t)
+			ctx := context.Background()
+			auction := createTestAuction(t, service, models.Auction{})
+
+			var retracted *models.Bid
+			for _, step := range tt.steps {
+				if step.proxy {
+					if _, err := service.SetProxyBid(ctx, auction.AuctionID, step.bidderID, step.amount); err != nil {
+						t.Fatalf("%s setting maximum %s: %v", step.bidderID, step.amount, err)
+					}
+					continue
+				}
+				retracted = placeTestBid(t, service, auction.AuctionID, step.bidderID, step.amount)
+			}
+
+			updated, err := service.RetractBid(ctx, auction.AuctionID, retracted.BidID, retracted.BidderID, "Typo")
+			if err != nil {
+				t.Fatalf("retract: %v", err)
+			}
+			if updated.CurrentPrice != tt.wantPrice {
+				t.Errorf("returned price %s, want %s", updated.CurrentPrice, tt.wantPrice)
+			}
+			stored, err := repo.GetByID(ctx, auction.AuctionID)
+			if err != nil {
+				t.Fatalf("reload auction: %v", err)
+			}
+			winning, err := repo.GetWinningBid(ctx, auction.AuctionID)
+			if err != nil {
+				t.Fatalf("get winning bid: %v", err)
+			}
+			if stored.CurrentPrice != tt.wantPrice || winning.BidderID != tt.wantLeader || winning.Amount != tt.wantPrice {
+				t.Errorf("%s leads at %s with price %s, want %s at %s", winning.BidderID, winning.Amount, stored.CurrentPrice, tt.wantLeader, tt.wantPrice)
+			}
+
+			// No bid left standing is above the price
+			bids, err := repo.GetBids(ctx, auction.AuctionID)
+			if err != nil {
+				t.Fatalf("get bids: %v", err)
+			}
+			for _, bid := range bids.Bids {
+				if bid.Amount > tt.wantPrice {
+					t.Errorf("%s's bid of %s is still standing", bid.BidderID, bid.Amount)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// validateBuyNowPrice checks a new auction's optional buy-now price
+func validateBuyNowPrice(auction *models.Auction) error {
+	if auction.BuyNowPrice <= 0 {
+		return nil
+	}
+	if auction.Type == models.AuctionTypeDrop {
+		return shared_errors.ValidationError("INVALID_BUY_NOW_PRICE", "Drop auctions cannot have a buy-now price")
+	}
+	if auction.BuyNowPrice <= auction.StartingPrice {
+		return shared_errors.ValidationError("INVALID_BUY_NOW_PRICE", "Buy-now price must be above the starting price")
+	}
+	if auction.ReservePrice > 0 && auction.BuyNowPrice < auction.ReservePrice {
+		return shared_errors.ValidationError("INVALID_BUY_NOW_PRICE", "Buy-now price cannot be below the reserve price")
+	}
+	return nil
+}
+
+// buyNowAvailable reports whether an open auction still offers buy-now. The
+// option goes once the reserve is met or the current bid reaches the
+// configured share of the buy-now price.
+func (s *AuctionService) buyNowAvailable(auction *models.Auction, hasBids bool) bool {
+	if auction.BuyNowPrice <= 0 {
+		return false
+	}
+	// A scheduled auction cannot be bought before it opens
+	if auction.Status != constants.AuctionStatusActive {
+		return false
+	}
+	if !hasBids {
+		return true
+	}
+	if auction.ReservePrice > 0 && auction.CurrentPrice >= auction.ReservePrice {
+		return false
+	}
+	return float64(auction.CurrentPrice) < float64(auction.BuyNowPrice)*s.config.BuyNowThreshold &&
+		auction.CurrentPrice < auction.BuyNowPrice
+}
+
+// BuyNow buys an auction outright at its buy-now price. The purchase is
+// recorded as a bid and the auction is settled through the normal path, so
+// the buyer wins exactly as if the auction had closed on their bid.
+func (s *AuctionService) BuyNow(ctx context.Context, auctionID, buyerID string) (*models.Bid, error) {
+	// The buy-now price is fixed at creation, so it can be read unlocked
+	offer, err := s.repo.GetByID(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrNotFound
+	}
+	if err := s.checkVerified(ctx, buyerID, offer.BuyNowPrice); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	auction, err := txRepo.GetByIDForUpdate(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrNotFound
+	}
+	if err := checkBiddable(auction, buyerID); err != nil {
+		return nil, err
+	}
+
+	bidCount, err := txRepo.CountBids(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if !s.buyNowAvailable(auction, bidCount > 0) {
+		return nil, shared_errors.ConflictError("BUY_NOW_UNAVAILABLE", "Buy now is not available on this auction")
+	}
+	if err := s.checkEligibility(ctx, txRepo, auction, buyerID, auction.BuyNowPrice); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	bid := &models.Bid{
+		BidderID: buyerID,
+		Amount:   auction.BuyNowPrice,
+		IsBuyNow: true,
+	}
+	if err := s.acceptBid(ctx, txRepo, auction, bid, now); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if err := s.settleAuction(ctx, txRepo, auction, now); err != nil {
+		s.logger.Error("Failed to settle buy-now purchase", zap.String("auction_id", auctionID), zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	s.signalEvents()
+
+	return bid, nil
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+)
+
+func TestBuyNowAvailability(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+
+	scheduled := createTestAuction(t, service, models.Auction{
+		StartTime:   time.Now().Add(time.Hour),
+		EndTime:     time.Now().Add(2 * time.Hour),
+		BuyNowPrice: 5000,
+	})
+	status, err := service.GetAuctionStatus(ctx, scheduled.AuctionID)
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if status.BuyNowAvailable {
+		t.Error("buy-now offered before the auction opened")
+	}
+	if _, err := service.BuyNow(ctx, scheduled.AuctionID, "alice"); errorCode(err) != "AUCTION_NOT_STARTED" {
+		t.Errorf("buy now before start = %v, want AUCTION_NOT_STARTED", err)
+	}
+
+	active := createTestAuction(t, service, models.Auction{EndTime: time.Now().Add(time.Hour), BuyNowPrice: 5000})
+	steps := []struct {
+		name string
+		bid  models.Money
+		want bool
+	}{
+		{"no bids", 0, true},
+		{"bid below the threshold", 2000, true},
+		{"bid at the threshold", 2500, false},
+	}
+	for _, step := range steps {
+		if step.bid > 0 {
+			placeTestBid(t, service, active.AuctionID, "bob", step.bid)
+		}
+		status, err := service.GetAuctionStatus(ctx, active.AuctionID)
+		if err != nil {
+			t.Fatalf("%s: status: %v", step.name, err)
+		}
+		if status.BuyNowAvailable != step.want {
+			t.Errorf("%s: buy-now available %v, want %v", step.name, status.BuyNowAvailable, step.want)
+		}
+	}
+	if _, err := service.BuyNow(ctx, active.AuctionID, "alice"); errorCode(err) != "BUY_NOW_UNAVAILABLE" {
+		t.Errorf("buy now past the threshold = %v, want BUY_NOW_UNAVAILABLE", err)
+	}
+}
+
+func TestBuyNowSettlesAuction(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{EndTime: time.Now().Add(time.Hour), BuyNowPrice: 5000})
+	placeTestBid(t, service, auction.AuctionID, "bob", 1500)
+
+	bid, err := service.BuyNow(ctx, auction.AuctionID, "alice")
+	if err != nil {
+		t.Fatalf("buy now: %v", err)
+	}
+	if !bid.IsBuyNow || bid.Amount != 5000 {
+		t.Errorf("buy-now bid %+v", bid)
+	}
+	stored, err := repo.GetByID(ctx, auction.AuctionID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if stored.Status != constants.AuctionStatusEnded || stored.WinnerID != "alice" || stored.WinningBidID != bid.BidID {
+		t.Errorf("auction %s won by %q with %q, want ended and won by alice", stored.Status, stored.WinnerID, stored.WinningBidID)
+	}
+	if _, err := service.BuyNow(ctx, auction.AuctionID, "carol"); errorCode(err) != "AUCTION_ENDED" {
+		t.Errorf("second buy now = %v, want AUCTION_ENDED", err)
+	}
+}
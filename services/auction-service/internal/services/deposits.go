@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/pkg/payments"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// PlaceDeposit has payment-service hold the auction's deposit on the
+// bidder's payment method, which lets them bid. Placing a deposit the bidder
+// already holds returns it unchanged.
+func (s *AuctionService) PlaceDeposit(ctx context.Context, auctionID, bidderID string, req *models.PlaceDepositRequest) (*models.BidDeposit, error) {
+	auction, err := s.repo.GetByID(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrNotFound
+	}
+	if err := checkDepositable(auction, bidderID); err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.GetBidDeposit(ctx, auctionID, bidderID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if existing != nil && existing.Status == models.DepositHeld {
+		return existing, nil
+	}
+
+	// The hold is placed before the auction is locked so a slow payment
+	// provider does not stall bidding
+	held, err := s.payments.AuthorizeDeposit(ctx, &payments.DepositRequest{
+		UserID:        bidderID,
+		Reference:     "auction:" + auctionID,
+		Amount:        int64(auction.DepositAmount),
+		Currency:      auction.Currency,
+		PaymentMethod: req.PaymentMethod,
+		Provider:      req.Provider,
+		Token:         req.Token,
+	})
+	if err != nil {
+		var refused *payments.Error
+		if errors.As(err, &refused) && refused.StatusCode < http.StatusInternalServerError {
+			return nil, shared_errors.ValidationError("DEPOSIT_DECLINED", "The deposit could not be authorized").
+				WithDetails(map[string]interface{}{"reason": refused.Message})
+		}
+		s.logger.Error("Failed to authorize deposit", zap.String("auction_id", auctionID), zap.Error(err))
+		return nil, shared_errors.ServiceError("DEPOSIT_UNAVAILABLE", "Deposits are temporarily unavailable")
+	}
+
+	deposit, closed, err := s.recordDeposit(ctx, auctionID, bidderID, held.ID)
+	if err != nil {
+		// Without a record the settlement worker would never release the hold
+		s.releaseUnrecordedDeposit(ctx, auctionID, bidderID, held.ID)
+		return nil, err
+	}
+	if closed != nil {
+		return nil, closed
+	}
+
+	s.logger.Info("Bid deposit held",
+		zap.String("auction_id", auctionID),
+		zap.String("bidder_id", bidderID),
+		zap.Stringer("amount", deposit.Amount))
+	return deposit, nil
+}
+
+// recordDeposit saves a hold placed for a bidder under the auction lock. If
+// the auction closed while the hold was being placed it is saved for release
+// and the reason is returned as closed.
+func (s *AuctionService) recordDeposit(ctx context.Context, auctionID, bidderID, paymentDepositID string) (deposit *models.BidDeposit, closed error, err error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, nil, shared_errors.ErrInternalServer
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	auction, err := txRepo.GetByIDForUpdate(ctx, auctionID)
+	if err != nil {
+		return nil, nil, shared_errors.ErrNotFound
+	}
+	now := time.Now()
+	deposit = &models.BidDeposit{
+		AuctionID:        auctionID,
+		BidderID:         bidderID,
+		Amount:           auction.DepositAmount,
+		PaymentDepositID: paymentDepositID,
+		Status:           models.DepositHeld,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	// If the auction closed while the hold was being placed, the money goes
+	// straight back
+	closed = checkDepositable(auction, bidderID)
+	if closed != nil {
+		deposit.Status = models.DepositReleasePending
+	}
+	if err := txRepo.SaveBidDeposit(ctx, deposit); err != nil {
+		return nil, nil, shared_errors.ErrInternalServer
+	}
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, nil, shared_errors.ErrInternalServer
+	}
+	return deposit, closed, nil
+}
+
+// releaseUnrecordedDeposit lets go of a hold PlaceDeposit failed to record.
+// payment-service returns the same hold for a repeated request, so it is kept
+// if a concurrent request recorded it.
+func (s *AuctionService) releaseUnrecordedDeposit(ctx context.Context, auctionID, bidderID, paymentDepositID string) {
+	if recorded, err := s.repo.GetBidDeposit(ctx, auctionID, bidderID); err == nil && recorded != nil && recorded.PaymentDepositID == paymentDepositID {
+		return
+	}
+	if _, err := s.payments.ReleaseDeposit(ctx, paymentDepositID); err != nil {
+		s.logger.Error("Failed to release unrecorded deposit",
+			zap.String("auction_id", auctionID),
+			zap.String("payment_deposit_id", paymentDepositID),
+			zap.Error(err))
+	}
+}
+
+// checkDepositable rejects deposits on auctions that do not take them or can
+// no longer be bid on
+func checkDepositable(auction *models.Auction, bidderID string) error {
+	if auction.DepositAmount <= 0 {
+		return shared_errors.ConflictError("DEPOSIT_NOT_REQUIRED", "This auction does not require a deposit")
+	}
+	if auction.Status == constants.AuctionStatusEnded || auction.Status == constants.AuctionStatusCancelled {
+		return shared_errors.ConflictError("AUCTION_CLOSED", "Auction has already finished")
+	}
+	if bidderID == auction.SellerID {
+		return shared_errors.AuthorizationError("SELF_BID", "Sellers cannot bid on their own auctions")
+	}
+	return nil
+}
+
+// GetBidDeposit returns the caller's deposit on an auction
+func (s *AuctionService) GetBidDeposit(ctx context.Context, auctionID, bidderID string) (*models.BidDeposit, error) {
+	deposit, err := s.repo.GetBidDeposit(ctx, auctionID, bidderID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if deposit == nil {
+		return nil, shared_errors.ErrNotFound
+	}
+	return deposit, nil
+}
+
+// depositSettlementLease is how long a claimed deposit is left to its
+// replica before another may settle it. payment-service treats a repeated
+// release or capture of the same deposit as a no-op.
+const depositSettlementLease = 2 * time.Minute
+
+// SettleDeposits releases or captures deposits whose auction has been
+// decided. Like order hand-offs it stops at the first failure and retries
+// with backoff on a later tick.
+func (s *AuctionService) SettleDeposits(ctx context.Context, now time.Time) (int, error) {
+	settled := 0
+	for settled < lifecycleBatchSize {
+		ok, err := s.settleNextDeposit(ctx, now)
+		if err != nil {
+			return settled, err
+		}
+		if !ok {
+			break
+		}
+		settled++
+	}
+	return settled, nil
+}
+
+// settleNextDeposit leases a deposit, which commits on its own, then calls
+// payment-service outside any transaction and records the result
+func (s *AuctionService) settleNextDeposit(ctx context.Context, now time.Time) (bool, error) {
+	deposit, err := s.repo.ClaimNextDepositSettlement(ctx, now, now.Add(depositSettlementLease))
+	if err != nil || deposit == nil {
+		return false, err
+	}
+
+	status := models.DepositReleased
+	var settleErr error
+	if deposit.Status == models.DepositCapturePending {
+		status = models.DepositCaptured
+		_, settleErr = s.payments.CaptureDeposit(ctx, deposit.PaymentDepositID)
+	} else {
+		_, settleErr = s.payments.ReleaseDeposit(ctx, deposit.PaymentDepositID)
+	}
+	if settleErr != nil {
+		if err := s.repo.RetryDepositSettlement(ctx, deposit.AuctionID, deposit.BidderID, now.Add(orderRetryDelay(deposit.Attempts)), settleErr.Error()); err != nil {
+			return false, err
+		}
+		return false, settleErr
+	}
+
+	if err := s.repo.CompleteDepositSettlement(ctx, deposit.AuctionID, deposit.BidderID, status, now); err != nil {
+		return false, err
+	}
+
+	s.logger.Info("Bid deposit settled",
+		zap.String("auction_id", deposit.AuctionID),
+		zap.String("bidder_id", deposit.BidderID),
+		zap.String("status", status))
+	return true, nil
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/config"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/pkg/payments"
+)
+
+// failingDepositRepo fails to save deposits inside transactions
+type failingDepositRepo struct {
+	repository.AuctionRepo
+}
+
+func (r failingDepositRepo) WithTx(tx repository.Tx) repository.AuctionRepo {
+	return failingDepositRepo{r.AuctionRepo.WithTx(tx)}
+}
+
+func (r failingDepositRepo) SaveBidDeposit(ctx context.Context, deposit *models.BidDeposit) error {
+	return errors.New("database unavailable")
+}
+
+func TestPlaceDepositReleasesUnrecordedHold(t *testing.T) {
+	var calls []string
+	payments := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.URL.Path)
+		fmt.Fprint(w, `{"success":true,"data":{"id":"hold-1","status":"held"}}`)
+	}))
+	defer payments.Close()
+
+	cfg, _ := config.Load()
+	cfg.PaymentServiceURL = payments.URL
+	service := NewAuctionService(repository.NewMemoryRepo(), zap.NewNop(), cfg)
+	auction := createTestAuction(t, service, models.Auction{DepositAmount: 5000})
+	service.repo = failingDepositRepo{service.repo}
+
+	req := &models.PlaceDepositRequest{PaymentMethod: "card", Provider: "stripe", Token: "tok"}
+	if _, err := service.PlaceDeposit(context.Background(), auction.AuctionID, "alice", req); errorCode(err) != "INTERNAL_ERROR" {
+		t.Fatalf("got %v, want an internal error", err)
+	}
+	want := []string{"/internal/v1/deposits", "/internal/v1/deposits/hold-1/release"}
+	if fmt.Sprint(calls) != fmt.Sprint(want) {
+		t.Errorf("payment calls %v, want %v", calls, want)
+	}
+}
+
+func TestSettleDepositsLeasesClaims(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryRepo()
+	now := time.Now()
+	var claimedDuringCall []bool
+	payments := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The lease is committed before payment-service is called
+		other, err := repo.ClaimNextDepositSettlement(ctx, now, now.Add(depositSettlementLease))
+		claimedDuringCall = append(claimedDuringCall, err == nil && other != nil)
+		fmt.Fprint(w, `{"success":true,"data":{"id":"hold-1","status":"released"}}`)
+	}))
+	defer payments.Close()
+
+	cfg, _ := config.Load()
+	cfg.PaymentServiceURL = payments.URL
+	service := NewAuctionService(repo, zap.NewNop(), cfg)
+	if err := repo.SaveBidDeposit(ctx, &models.BidDeposit{
+		AuctionID: "auction-1", BidderID: "alice", Amount: 5000, PaymentDepositID: "hold-1",
+		Status: models.DepositReleasePending, CreatedAt: now, UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("save deposit: %v", err)
+	}
+
+	if settled, err := service.SettleDeposits(ctx, now); err != nil || settled != 1 {
+		t.Fatalf("settled %d: %v", settled, err)
+	}
+	if len(claimedDuringCall) != 1 || claimedDuringCall[0] {
+		t.Errorf("deposit claimable while being settled: %v", claimedDuringCall)
+	}
+	deposit, err := repo.GetBidDeposit(ctx, "auction-1", "alice")
+	if err != nil || deposit.Status != models.DepositReleased {
+		t.Errorf("deposit is %+v, want released: %v", deposit, err)
+	}
+
+	// A late result from a replica whose lease ran out no longer applies
+	if err := repo.RetryDepositSettlement(ctx, "auction-1", "alice", now, "timeout"); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if deposit, _ := repo.GetBidDeposit(ctx, "auction-1", "alice"); deposit.LastError != "" {
+		t.Errorf("late retry recorded %q on a settled deposit", deposit.LastError)
+	}
+}
+
+func TestDepositRequiredToBid(t *testing.T) {
+	var holds int
+	paymentService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req payments.DepositRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode deposit request: %v", err)
+		}
+		if req.Token == "declined" {
+			w.WriteHeader(http.StatusPaymentRequired)
+			fmt.Fprint(w, `{"success":false,"error":{"code":"CARD_DECLINED","message":"Card declined"}}`)
+			return
+		}
+		holds++
+		fmt.Fprintf(w, `{"success":true,"data":{"id":"hold-%s","status":"held"}}`, req.UserID)
+	}))
+	defer paymentService.Close()
+
+	cfg, _ := config.Load()
+	cfg.PaymentServiceURL = paymentService.URL
+	repo := repository.NewMemoryRepo()
+	service := NewAuctionService(repo, zap.NewNop(), cfg)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{DepositAmount: 5000, EndTime: time.Now().Add(time.Hour)})
+	req := &models.PlaceDepositRequest{PaymentMethod: "card", Provider: "stripe", Token: "tok"}
+
+	bid := &models.Bid{AuctionID: auction.AuctionID, BidderID: "alice", Amount: 1000}
+	if err := service.PlaceBid(ctx, bid); errorCode(err) != "DEPOSIT_REQUIRED" {
+		t.Fatalf("bid without a deposit: %v, want DEPOSIT_REQUIRED", err)
+	}
+	if _, err := service.PlaceDeposit(ctx, auction.AuctionID, auction.SellerID, req); errorCode(err) != "SELF_BID" {
+		t.Errorf("seller deposit: %v, want SELF_BID", err)
+	}
+	declined := &models.PlaceDepositRequest{PaymentMethod: "card", Provider: "stripe", Token: "declined"}
+	if _, err := service.PlaceDeposit(ctx, auction.AuctionID, "alice", declined); errorCode(err) != "DEPOSIT_DECLINED" {
+		t.Errorf("declined card: %v, want DEPOSIT_DECLINED", err)
+	}
+
+	for _, bidderID := range []string{"alice", "bob"} {
+		deposit, err := service.PlaceDeposit(ctx, auction.AuctionID, bidderID, req)
+		if err != nil || deposit.Status != models.DepositHeld || deposit.Amount != 5000 {
+			t.Fatalf("%s's deposit %+v: %v", bidderID, deposit, err)
+		}
+	}
+	// A second request returns the hold already placed
+	if _, err := service.PlaceDeposit(ctx, auction.AuctionID, "alice", req); err != nil || holds != 2 {
+		t.Errorf("repeated deposit placed %d holds: %v", holds, err)
+	}
+	placeTestBid(t, service, auction.AuctionID, "alice", 1000)
+	placeTestBid(t, service, auction.AuctionID, "bob", 1500)
+
+	// Losers get their money back; the winner's is kept until they pay
+	if _, err := service.CloseDueAuctions(ctx, auction.EndTime.Add(time.Second)); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	for bidderID, want := range map[string]string{"alice": models.DepositReleasePending, "bob": models.DepositHeld} {
+		if deposit, err := repo.GetBidDeposit(ctx, auction.AuctionID, bidderID); err != nil || deposit.Status != want {
+			t.Errorf("%s's deposit %+v, want %s: %v", bidderID, deposit, want, err)
+		}
+	}
+	if _, err := service.PlaceDeposit(ctx, auction.AuctionID, "carol", req); errorCode(err) != "AUCTION_CLOSED" {
+		t.Errorf("deposit after close: %v, want AUCTION_CLOSED", err)
+	}
+}
+
+func TestSettleDepositsCapturesAndBacksOff(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryRepo()
+	now := time.Now()
+	available := false
+	var calls []string
+	paymentService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"success":false,"error":{"code":"UNAVAILABLE","message":"down"}}`)
+			return
+		}
+		calls = append(calls, r.URL.Path)
+		fmt.Fprint(w, `{"success":true,"data":{"id":"hold-1","status":"captured"}}`)
+	}))
+	defer paymentService.Close()
+
+	cfg, _ := config.Load()
+	cfg.PaymentServiceURL = paymentService.URL
+	service := NewAuctionService(repo, zap.NewNop(), cfg)
+	if err := repo.SaveBidDeposit(ctx, &models.BidDeposit{
+		AuctionID: "auction-1", BidderID: "alice", Amount: 5000, PaymentDepositID: "hold-1",
+		Status: models.DepositCapturePending, CreatedAt: now, UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("save deposit: %v", err)
+	}
+
+	if settled, err := service.SettleDeposits(ctx, now); err == nil || settled != 0 {
+		t.Fatalf("settled %d with payment-service down: %v", settled, err)
+	}
+	deposit, err := repo.GetBidDeposit(ctx, "auction-1", "alice")
+	if err != nil || deposit.Status != models.DepositCapturePending || deposit.Attempts != 1 || deposit.LastError == "" {
+		t.Fatalf("deposit after a failure %+v: %v", deposit, err)
+	}
+
+	available = true
+	if settled, err := service.SettleDeposits(ctx, now.Add(orderRetryBase-time.Second)); err != nil || settled != 0 {
+		t.Fatalf("settled %d during backoff: %v", settled, err)
+	}
+	if settled, err := service.SettleDeposits(ctx, now.Add(orderRetryBase)); err != nil || settled != 1 {
+		t.Fatalf("settled %d after backoff: %v", settled, err)
+	}
+	if want := []string{"/internal/v1/deposits/hold-1/capture"}; fmt.Sprint(calls) != fmt.Sprint(want) {
+		t.Errorf("payment calls %v, want %v", calls, want)
+	}
+	if deposit, err := repo.GetBidDeposit(ctx, "auction-1", "alice"); err != nil || deposit.Status != models.DepositCaptured {
+		t.Errorf("deposit %+v, want captured: %v", deposit, err)
+	}
+}
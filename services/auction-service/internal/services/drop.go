@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// validateDropAuction checks a new drop auction's price schedule
+func validateDropAuction(auction *models.Auction) error {
+	if auction.DropStep <= 0 || auction.DropInterval <= 0 {
+		return shared_errors.ValidationError("INVALID_DROP_SCHEDULE", "Drop auctions need a positive drop step and drop interval")
+	}
+	if auction.FloorPrice <= 0 || auction.FloorPrice >= auction.StartingPrice {
+		return shared_errors.ValidationError("INVALID_FLOOR_PRICE", "Floor price must be above zero and below the starting price")
+	}
+	return nil
+}
+
+// prepareDropAuction sets a validated drop auction's opening price schedule.
+// Drops have no reserve and no soft close; the floor plays the reserve's
+// role.
+func prepareDropAuction(auction *models.Auction) {
+	if auction.Quantity <= 0 {
+		auction.Quantity = 1
+	}
+
+	auction.QuantityRemaining = auction.Quantity
+	auction.ReservePrice = 0
+	auction.SoftClose = false
+	auction.SoftCloseWindow = 0
+	auction.SoftCloseExtension = 0
+	auction.MaxExtensions = 0
+	auction.NextDropAt = nextDropAt(auction, auction.StartTime)
+}
+
+// dropPriceAt is the price a drop auction asks at the given time
+func dropPriceAt(auction *models.Auction, at time.Time) models.Money {
+	if !at.After(auction.StartTime) {
+		return auction.StartingPrice
+	}
+	interval := time.Duration(auction.DropInterval) * time.Second
+	steps := models.Money(at.Sub(auction.StartTime) / interval)
+	return max(auction.FloorPrice, auction.StartingPrice-steps*auction.DropStep)
+}
+
+// nextDropAt is when the price next falls after the given time, or nil once
+// it has reached the floor
+func nextDropAt(auction *models.Auction, at time.Time) *time.Time {
+	if dropPriceAt(auction, at) <= auction.FloorPrice {
+		return nil
+	}
+	interval := time.Duration(auction.DropInterval) * time.Second
+	steps := time.Duration(0)
+	if at.After(auction.StartTime) {
+		steps = at.Sub(auction.StartTime) / interval
+	}
+	next := auction.StartTime.Add((steps + 1) * interval)
+	return &next
+}
+
+// ClaimDrop buys quantity units of a drop auction at the price asked right
+// now. The first claim to take the lock wins; once the quantity runs out the
+// auction ends.
+func (s *AuctionService) ClaimDrop(ctx context.Context, auctionID, bidderID string, quantity int) (*models.Bid, error) {
+	if quantity <= 0 {
+		quantity = 1
+	}
+
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	auction, err := txRepo.GetByIDForUpdate(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrNotFound
+	}
+	if auction.Type != models.AuctionTypeDrop {
+		return nil, shared_errors.ConflictError("NOT_A_DROP_AUCTION", "Only drop auctions can be claimed")
+	}
+	if err := checkBiddable(auction, bidderID); err != nil {
+		return nil, err
+	}
+	if quantity > auction.QuantityRemaining {
+		return nil, shared_errors.ConflictError("INSUFFICIENT_QUANTITY", "Not enough units left in this drop").
+			WithDetails(map[string]interface{}{"quantity_remaining": auction.QuantityRemaining})
+	}
+
+	now := time.Now()
+	bid := &models.Bid{
+		BidID:     uuid.New().String(),
+		AuctionID: auctionID,
+		BidderID:  bidderID,
+		Amount:    dropPriceAt(auction, now),
+		Quantity:  quantity,
+		IsWinning: true,
+		BidTime:   now,
+		CreatedAt: now,
+	}
+	if err := txRepo.CreateBid(ctx, bid); err != nil {
+		s.logger.Error("Failed to record drop claim", zap.Any("bid", bid), zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	auction.CurrentPrice = bid.Amount
+	auction.QuantityRemaining -= quantity
+	auction.NextDropAt = nextDropAt(auction, now)
+	if auction.QuantityRemaining == 0 {
+		auction.NextDropAt = nil
+	}
+	if err := txRepo.UpdateDropState(ctx, auction); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if err := s.queueOrderHandoff(ctx, txRepo, auction, bid.BidID, bid.BidderID, bid.Quantity, bid.Amount, now); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	if err := recordEvent(ctx, txRepo, auctionID, models.EventBidPlaced, map[string]interface{}{
+		"bid_id":    bid.BidID,
+		"bidder_id": bid.BidderID,
+		"amount":    bid.Amount,
+		"quantity":  bid.Quantity,
+		"bid_time":  bid.BidTime,
+	}); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if err := recordEvent(ctx, txRepo, auctionID, models.EventDropClaimed, models.DropClaimedPayload{
+		SellerID:          auction.SellerID,
+		BidID:             bid.BidID,
+		BidderID:          bid.BidderID,
+		Price:             bid.Amount,
+		Quantity:          bid.Quantity,
+		QuantityRemaining: auction.QuantityRemaining,
+	}); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	if auction.QuantityRemaining == 0 {
+		if err := s.settleDropAuction(ctx, txRepo, auction, now); err != nil {
+			s.logger.Error("Failed to close sold-out drop", zap.String("auction_id", auctionID), zap.Error(err))
+			return nil, shared_errors.ErrInternalServer
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	s.signalEvents()
+
+	return bid, nil
+}
+
+// AdvanceDropPrices steps down the price of drop auctions whose next drop is
+// due, publishing each new price
+func (s *AuctionService) AdvanceDropPrices(ctx context.Context, now time.Time) (int, error) {
+	advanced := 0
+	for advanced < lifecycleBatchSize {
+		ok, err := s.advanceNextDrop(ctx, now)
+		if err != nil {
+			return advanced, err
+		}
+		if !ok {
+			break
+		}
+		advanced++
+	}
+	return advanced, nil
+}
+
+func (s *AuctionService) advanceNextDrop(ctx context.Context, now time.Time) (bool, error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	auction, err := txRepo.ClaimNextDropDue(ctx, now)
+	if err != nil || auction == nil {
+		return false, err
+	}
+
+	auction.CurrentPrice = dropPriceAt(auction, now)
+	auction.NextDropAt = nextDropAt(auction, now)
+	if err := txRepo.UpdateDropState(ctx, auction); err != nil {
+		return false, err
+	}
+	if err := recordEvent(ctx, txRepo, auction.AuctionID, models.EventPriceChanged, map[string]interface{}{
+		"current_price":      auction.CurrentPrice,
+		"next_drop_at":       auction.NextDropAt,
+		"quantity_remaining": auction.QuantityRemaining,
+	}); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// settleDropAuction ends a locked drop auction. Each claim was already a sale,
+// so there is no single winner to pick; the outcome only records whether
+// anything sold.
+func (s *AuctionService) settleDropAuction(ctx context.Context, txRepo repository.AuctionRepo, auction *models.Auction, now time.Time) error {
+	claims, err := txRepo.CountBids(ctx, auction.AuctionID)
+	if err != nil {
+		return err
+	}
+
+	fromStatus := auction.Status
+	auction.Status = constants.AuctionStatusEnded
+	auction.SettledAt = &now
+	auction.NextDropAt = nil
+	outcome := models.OutcomeUnsold
+	if claims > 0 {
+		outcome = models.OutcomeSold
+	}
+
+	if err := txRepo.UpdateDropState(ctx, auction); err != nil {
+		return err
+	}
+	if err := txRepo.SettleAuction(ctx, auction); err != nil {
+		return err
+	}
+	if err := recordSettlementAudit(ctx, txRepo, auction, fromStatus, outcome, now); err != nil {
+		return err
+	}
+
+	if err := recordEvent(ctx, txRepo, auction.AuctionID, models.EventAuctionEnded, map[string]interface{}{
+		"end_time":           now,
+		"final_price":        auction.CurrentPrice,
+		"outcome":            outcome,
+		"quantity_remaining": auction.QuantityRemaining,
+	}); err != nil {
+		return err
+	}
+	if err := recordEvent(ctx, txRepo, auction.AuctionID, models.EventAuctionSettled, models.AuctionSettledPayload{
+		SellerID:    auction.SellerID,
+		Outcome:     outcome,
+		HammerPrice: auction.CurrentPrice,
+	}); err != nil {
+		return err
+	}
+
+	s.logger.Info("Drop auction closed",
+		zap.String("auction_id", auction.AuctionID),
+		zap.String("outcome", outcome),
+		zap.Int("quantity_remaining", auction.QuantityRemaining))
+	return nil
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+)
+
+func TestDropPriceSchedule(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	auction := &models.Auction{StartTime: start, StartingPrice: 1000, FloorPrice: 650, DropStep: 100, DropInterval: 60}
+
+	tests := []struct {
+		name      string
+		at        time.Time
+		wantPrice models.Money
+		wantNext  time.Time // zero once the floor is reached
+	}{
+		{"before the start", start.Add(-time.Minute), 1000, start.Add(time.Minute)},
+		{"at the start", start, 1000, start.Add(time.Minute)},
+		{"just before the first drop", start.Add(59 * time.Second), 1000, start.Add(time.Minute)},
+		{"first drop", start.Add(time.Minute), 900, start.Add(2 * time.Minute)},
+		{"third drop", start.Add(3*time.Minute + 30*time.Second), 700, start.Add(4 * time.Minute)},
+		{"stops at the floor", start.Add(4 * time.Minute), 650, time.Time{}},
+		{"long after", start.Add(time.Hour), 650, time.Time{}},
+	}
+	for _, tt := range tests {
+		if got := dropPriceAt(auction, tt.at); got != tt.wantPrice {
+			t.Errorf("%s: price %s, want %s", tt.name, got, tt.wantPrice)
+		}
+		next := nextDropAt(auction, tt.at)
+		switch {
+		case tt.wantNext.IsZero() && next != nil:
+			t.Errorf("%s: next drop %v, want none", tt.name, next)
+		case !tt.wantNext.IsZero() && (next == nil || !next.Equal(tt.wantNext)):
+			t.Errorf("%s: next drop %v, want %v", tt.name, next, tt.wantNext)
+		}
+	}
+}
+
+func TestCreateDropAuctionValidation(t *testing.T) {
+	service, _ := newTestService(t)
+	tests := []struct {
+		name    string
+		auction models.Auction
+		want    string
+	}{
+		{"no drop step", models.Auction{FloorPrice: 500, DropInterval: 60}, "INVALID_DROP_SCHEDULE"},
+		{"no drop interval", models.Auction{FloorPrice: 500, DropStep: 100}, "INVALID_DROP_SCHEDULE"},
+		{"no floor", models.Auction{DropStep: 100, DropInterval: 60}, "INVALID_FLOOR_PRICE"},
+		{"floor at the starting price", models.Auction{FloorPrice: 1000, DropStep: 100, DropInterval: 60}, "INVALID_FLOOR_PRICE"},
+		{"buy-now price", models.Auction{FloorPrice: 500, DropStep: 100, DropInterval: 60, BuyNowPrice: 2000}, "INVALID_BUY_NOW_PRICE"},
+		{"deposit", models.Auction{FloorPrice: 500, DropStep: 100, DropInterval: 60, DepositAmount: 100}, "INVALID_DEPOSIT"},
+	}
+	for _, tt := range tests {
+		auction := tt.auction
+		auction.SellerID, auction.ProductID, auction.Title, auction.Category = "seller-1", "product-1", tt.name, "home"
+		auction.Images = []models.AuctionImage{{ImageURL: "https://example.com/item.jpg"}}
+		auction.StartingPrice = 1000
+		auction.Type = models.AuctionTypeDrop
+		if err := service.CreateAuction(context.Background(), &auction); errorCode(err) != tt.want {
+			t.Errorf("%s: got %v, want %s", tt.name, err, tt.want)
+		}
+	}
+
+	created := createTestAuction(t, service, models.Auction{Type: models.AuctionTypeDrop, FloorPrice: 500, DropStep: 100, DropInterval: 60, Quantity: 3, ReservePrice: 800})
+	if created.Quantity != 3 || created.QuantityRemaining != 3 || created.ReservePrice != 0 || created.NextDropAt == nil {
+		t.Errorf("drop created with quantity %d/%d, reserve %s, next drop %v", created.QuantityRemaining, created.Quantity, created.ReservePrice, created.NextDropAt)
+	}
+}
+
+func TestClaimDrop(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	drop := createTestAuction(t, service, models.Auction{
+		Type:         models.AuctionTypeDrop,
+		EndTime:      time.Now().Add(time.Hour),
+		FloorPrice:   500,
+		DropStep:     100,
+		DropInterval: 600,
+		Quantity:     3,
+	})
+	auction := createTestAuction(t, service, models.Auction{})
+
+	if err := service.PlaceBid(ctx, &models.Bid{AuctionID: drop.AuctionID, BidderID: "alice", Amount: 1000}); errorCode(err) != "DROP_AUCTION" {
+		t.Errorf("bid on a drop = %v, want DROP_AUCTION", err)
+	}
+	if _, err := service.ClaimDrop(ctx, auction.AuctionID, "alice", 1); errorCode(err) != "NOT_A_DROP_AUCTION" {
+		t.Errorf("claim on a bidding auction = %v, want NOT_A_DROP_AUCTION", err)
+	}
+	if _, err := service.ClaimDrop(ctx, drop.AuctionID, "seller-1", 1); errorCode(err) != "SELF_BID" {
+		t.Errorf("claim by the seller = %v, want SELF_BID", err)
+	}
+
+	claim, err := service.ClaimDrop(ctx, drop.AuctionID, "alice", 2)
+	if err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	if claim.Amount != 1000 || claim.Quantity != 2 || !claim.IsWinning {
+		t.Errorf("claim %+v, want 2 at 10.00", claim)
+	}
+	if _, err := service.ClaimDrop(ctx, drop.AuctionID, "bob", 2); errorCode(err) != "INSUFFICIENT_QUANTITY" {
+		t.Errorf("claim over the remaining quantity = %v, want INSUFFICIENT_QUANTITY", err)
+	}
+	if _, err := service.ClaimDrop(ctx, drop.AuctionID, "bob", 0); err != nil {
+		t.Fatalf("claim the last unit: %v", err)
+	}
+
+	// Selling out ends the drop, and every claim is its own sale
+	stored, err := repo.GetByID(ctx, drop.AuctionID)
+	if err != nil {
+		t.Fatalf("reload drop: %v", err)
+	}
+	if stored.Status != constants.AuctionStatusEnded || stored.QuantityRemaining != 0 || stored.NextDropAt != nil {
+		t.Errorf("sold-out drop %s with %d left, next drop %v", stored.Status, stored.QuantityRemaining, stored.NextDropAt)
+	}
+	handoffs, err := repo.GetOrderHandoffs(ctx, drop.AuctionID)
+	if err != nil || len(handoffs) != 2 {
+		t.Fatalf("%d orders queued, want one per claim: %v", len(handoffs), err)
+	}
+	quantities := map[string]int{}
+	for _, handoff := range handoffs {
+		quantities[handoff.BuyerID] += handoff.Quantity
+	}
+	if quantities["alice"] != 2 || quantities["bob"] != 1 {
+		t.Errorf("order quantities %v, want alice 2 and bob 1", quantities)
+	}
+	if _, err := service.ClaimDrop(ctx, drop.AuctionID, "carol", 1); errorCode(err) != "AUCTION_ENDED" {
+		t.Errorf("claim after selling out = %v, want AUCTION_ENDED", err)
+	}
+}
+
+func TestAdvanceDropPrices(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	drop := createTestAuction(t, service, models.Auction{
+		Type:         models.AuctionTypeDrop,
+		EndTime:      time.Now().Add(time.Hour),
+		FloorPrice:   800,
+		DropStep:     100,
+		DropInterval: 60,
+	})
+	start := drop.StartTime
+
+	steps := []struct {
+		name         string
+		now          time.Time
+		wantAdvanced int
+		wantPrice    models.Money
+	}{
+		{"before the first drop", start.Add(30 * time.Second), 0, 1000},
+		{"first drop", start.Add(time.Minute), 1, 900},
+		{"same drop again", start.Add(90 * time.Second), 0, 900},
+		{"missed drops catch up", start.Add(5 * time.Minute), 1, 800},
+		{"floor reached", start.Add(10 * time.Minute), 0, 800},
+	}
+	for _, step := range steps {
+		advanced, err := service.AdvanceDropPrices(ctx, step.now)
+		if err != nil || advanced != step.wantAdvanced {
+			t.Fatalf("%s: advanced %d, want %d: %v", step.name, advanced, step.wantAdvanced, err)
+		}
+		stored, err := repo.GetByID(ctx, drop.AuctionID)
+		if err != nil || stored.CurrentPrice != step.wantPrice {
+			t.Fatalf("%s: price %s, want %s: %v", step.name, stored.CurrentPrice, step.wantPrice, err)
+		}
+	}
+
+	changes := 0
+	for _, eventType := range eventTypes(t, repo, drop.AuctionID) {
+		if eventType == models.EventPriceChanged {
+			changes++
+		}
+	}
+	if changes != 2 {
+		t.Errorf("%d price change events, want 2", changes)
+	}
+}
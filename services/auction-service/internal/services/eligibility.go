@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// checkVerified refuses amounts over the verified-bid threshold from bidders
+// without a verified account. It asks auth-service, so it runs before the
+// auction is locked: a slow answer then holds up only this bidder.
+func (s *AuctionService) checkVerified(ctx context.Context, bidderID string, amount models.Money) error {
+	threshold := s.config.VerifiedBidThreshold
+	if threshold <= 0 || amount <= threshold {
+		return nil
+	}
+	verified, err := s.accounts.IsVerified(ctx, bidderID)
+	if err != nil {
+		s.logger.Error("Failed to check bidder verification", zap.String("bidder_id", bidderID), zap.Error(err))
+		return shared_errors.ServiceError("VERIFICATION_UNAVAILABLE", "Account verification is temporarily unavailable")
+	}
+	if !verified {
+		return shared_errors.AuthorizationError("VERIFICATION_REQUIRED", "Bids of this size require a verified account").
+			WithDetails(map[string]interface{}{"threshold": threshold})
+	}
+	return nil
+}
+
+// checkEligibility decides whether a bidder may commit amount to a locked
+// auction: flagged auctions need a held deposit, and the bidder's open
+// commitments must stay within the limit. Verification is checked by
+// checkVerified before the lock is taken.
+func (s *AuctionService) checkEligibility(ctx context.Context, txRepo repository.AuctionRepo, auction *models.Auction, bidderID string, amount models.Money) error {
+	if auction.DepositAmount > 0 {
+		deposit, err := txRepo.GetBidDeposit(ctx, auction.AuctionID, bidderID)
+		if err != nil {
+			return shared_errors.ErrInternalServer
+		}
+		if deposit == nil || deposit.Status != models.DepositHeld {
+			return shared_errors.AuthorizationError("DEPOSIT_REQUIRED", "This auction requires a refundable deposit before bidding").
+				WithDetails(map[string]interface{}{"deposit_amount": auction.DepositAmount})
+		}
+	}
+
+	if limit := s.config.OpenCommitmentLimit; limit > 0 {
+		committed, err := txRepo.GetOpenCommitment(ctx, bidderID, auction.AuctionID)
+		if err != nil {
+			return shared_errors.ErrInternalServer
+		}
+		if committed+amount > limit {
+			return shared_errors.ConflictError("COMMITMENT_LIMIT_EXCEEDED", "This bid would take your open commitments past your limit").
+				WithDetails(map[string]interface{}{
+					"limit":     limit,
+					"committed": committed,
+				})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/config"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+)
+
+func TestCheckVerified(t *testing.T) {
+	lookups := make(map[string]int)
+	authService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID := path.Base(r.URL.Path)
+		lookups[userID]++
+		if userID == "unreachable" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"status":"error","message":"down"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"success","data":{"id":%q,"is_active":true,"is_verified":%t}}`, userID, userID == "verified")
+	}))
+	defer authService.Close()
+
+	cfg, _ := config.Load()
+	cfg.AuthServiceURL = authService.URL
+	cfg.VerifiedBidThreshold = 5000
+	service := NewAuctionService(repository.NewMemoryRepo(), zap.NewNop(), cfg)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		bidderID string
+		amount   models.Money
+		want     string
+	}{
+		{"at the threshold", "unverified", 5000, ""},
+		{"unverified above the threshold", "unverified", 5001, "VERIFICATION_REQUIRED"},
+		{"verified above the threshold", "verified", 9000, ""},
+		{"auth-service unavailable", "unreachable", 9000, "VERIFICATION_UNAVAILABLE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorCode(service.checkVerified(ctx, tt.bidderID, tt.amount)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Verified users are remembered; unverified ones are asked about again
+	if err := service.checkVerified(ctx, "verified", 9000); err != nil {
+		t.Fatalf("verified again: %v", err)
+	}
+	service.checkVerified(ctx, "unverified", 9000)
+	if lookups["verified"] != 1 || lookups["unverified"] != 2 {
+		t.Errorf("lookups %v, want verified once and unverified twice", lookups)
+	}
+}
+
+func TestOpenCommitmentLimit(t *testing.T) {
+	cfg, _ := config.Load()
+	cfg.OpenCommitmentLimit = 3000
+	service := NewAuctionService(repository.NewMemoryRepo(), zap.NewNop(), cfg)
+	ctx := context.Background()
+	first := createTestAuction(t, service, models.Auction{})
+	second := createTestAuction(t, service, models.Auction{})
+	placeTestBid(t, service, first.AuctionID, "alice", 2000)
+
+	bid := &models.Bid{AuctionID: second.AuctionID, BidderID: "alice", Amount: 1500}
+	if err := service.PlaceBid(ctx, bid); errorCode(err) != "COMMITMENT_LIMIT_EXCEEDED" {
+		t.Fatalf("bid past the limit: %v, want COMMITMENT_LIMIT_EXCEEDED", err)
+	}
+	placeTestBid(t, service, second.AuctionID, "alice", 1000)
+
+	// Raising a bid counts only the new amount against the limit
+	bid = &models.Bid{AuctionID: second.AuctionID, BidderID: "alice", Amount: 1100}
+	if err := service.PlaceBid(ctx, bid); errorCode(err) != "COMMITMENT_LIMIT_EXCEEDED" {
+		t.Errorf("raise past the limit: %v, want COMMITMENT_LIMIT_EXCEEDED", err)
+	}
+
+	// Losing the lead frees the commitment
+	placeTestBid(t, service, first.AuctionID, "bob", 2100)
+	placeTestBid(t, service, second.AuctionID, "alice", 2900)
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+const (
+	// eventRelayBatchSize is how many auctions' events one relay pass claims
+	eventRelayBatchSize = 100
+	eventReplayLimit    = 500
+)
+
+// EventHandler receives auction events after they have been committed. An
+// event whose handler fails is relayed again later, to every handler, so
+// handlers must tolerate seeing an event more than once.
+type EventHandler func(ctx context.Context, event *models.AuctionEvent) error
+
+// EventRelay delivers events from the auction_events outbox to subscribers.
+// Each event is claimed by exactly one replica, so handlers see it once per
+// cluster rather than once per process. A replica claims all of an auction's
+// pending events at once, so each auction's events reach handlers in
+// sequence order. When a handler fails the event stays pending and is retried
+// with backoff, and the auction's later events wait behind it.
+type EventRelay struct {
+	repo     repository.AuctionRepo
+	logger   *zap.Logger
+	mu       sync.RWMutex
+	handlers []EventHandler
+}
+
+func NewEventRelay(repo repository.AuctionRepo, logger *zap.Logger) *EventRelay {
+	return &EventRelay{repo: repo, logger: logger}
+}
+
+// Subscribe registers a handler for every relayed event
+func (r *EventRelay) Subscribe(handler EventHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers = append(r.handlers, handler)
+}
+
+// Dispatch relays one batch of pending events and returns how many were sent
+func (r *EventRelay) Dispatch(ctx context.Context) (int, error) {
+	tx, err := r.repo.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	txRepo := r.repo.WithTx(tx)
+
+	now := time.Now()
+	events, err := txRepo.ClaimPendingEvents(ctx, now, eventRelayBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	handlers := r.handlers
+	r.mu.RUnlock()
+
+	dispatched := 0
+	held := map[string]bool{} // auctions with an earlier event still pending
+	for _, event := range events {
+		if held[event.AuctionID] {
+			continue
+		}
+		if handlerErr := r.handle(ctx, handlers, event); handlerErr != nil {
+			held[event.AuctionID] = true
+			r.logger.Error("Auction event handler failed",
+				zap.Int64("event_id", event.EventID),
+				zap.String("type", event.Type),
+				zap.String("auction_id", event.AuctionID),
+				zap.Int("attempts", event.Attempts+1),
+				zap.Error(handlerErr))
+			if err := txRepo.RetryEvent(ctx, event.EventID, now.Add(orderRetryDelay(event.Attempts)), handlerErr.Error()); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		if err := txRepo.MarkEventDispatched(ctx, event.EventID); err != nil {
+			return 0, err
+		}
+		dispatched++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return dispatched, nil
+}
+
+// handle runs every handler on the event and returns the first failure
+func (r *EventRelay) handle(ctx context.Context, handlers []EventHandler, event *models.AuctionEvent) error {
+	var first error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// recordEvent writes an event to the outbox using the caller's repository,
+// which should be bound to the transaction making the state change
+func recordEvent(ctx context.Context, repo repository.AuctionRepo, auctionID, eventType string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return repo.CreateEvent(ctx, &models.AuctionEvent{
+		AuctionID: auctionID,
+		Type:      eventType,
+		Payload:   data,
+		CreatedAt: time.Now(),
+	})
+}
+
+// signalEvents tells the scheduler that new events were committed. Signals
+// coalesce, so a burst of bids triggers a single relay pass.
+func (s *AuctionService) signalEvents() {
+	select {
+	case s.eventsPending <- struct{}{}:
+	default:
+	}
+}
+
+// EventsPending fires after this replica commits new outbox events
+func (s *AuctionService) EventsPending() <-chan struct{} {
+	return s.eventsPending
+}
+
+// GetEventsAfter returns a page of an auction's events with sequences above
+// afterSequence so stream clients can resume where they left off
+func (s *AuctionService) GetEventsAfter(ctx context.Context, auctionID string, afterSequence int64) ([]*models.AuctionEvent, error) {
+	events, err := s.repo.GetEventsAfter(ctx, auctionID, afterSequence, eventReplayLimit)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	return events, nil
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+func TestEventRelayRetriesFailedEvents(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{})
+	placeTestBid(t, service, auction.AuctionID, "alice", 1000)
+	placeTestBid(t, service, auction.AuctionID, "bob", 1500)
+
+	stored, err := repo.GetEventsAfter(ctx, auction.AuctionID, 0, 100)
+	if err != nil || len(stored) < 2 {
+		t.Fatalf("recorded %d events: %v", len(stored), err)
+	}
+	head := stored[0]
+
+	var seen []int64
+	failing := true
+	relay := NewEventRelay(repo, zap.NewNop())
+	relay.Subscribe(func(ctx context.Context, event *models.AuctionEvent) error {
+		seen = append(seen, event.Sequence)
+		if failing {
+			return errors.New("redis unavailable")
+		}
+		return nil
+	})
+
+	// The failed event stays pending and holds back the auction's later ones
+	if dispatched, err := relay.Dispatch(ctx); err != nil || dispatched != 0 {
+		t.Fatalf("dispatched %d with a failing handler: %v", dispatched, err)
+	}
+	if len(seen) != 1 || seen[0] != head.Sequence {
+		t.Fatalf("handled sequences %v, want only %d", seen, head.Sequence)
+	}
+
+	// Nothing is tried again until the backoff runs out
+	failing = false
+	if dispatched, err := relay.Dispatch(ctx); err != nil || dispatched != 0 || len(seen) != 1 {
+		t.Fatalf("dispatched %d during backoff: %v", dispatched, err)
+	}
+	if err := repo.RetryEvent(ctx, head.EventID, time.Now().Add(-time.Second), ""); err != nil {
+		t.Fatalf("expire backoff: %v", err)
+	}
+
+	dispatched, err := relay.Dispatch(ctx)
+	if err != nil || dispatched != len(stored) {
+		t.Fatalf("dispatched %d after backoff, want %d: %v", dispatched, len(stored), err)
+	}
+	for i, event := range stored {
+		if seen[i+1] != event.Sequence {
+			t.Fatalf("handled sequences %v, want the retried event then the rest in order", seen)
+		}
+	}
+	if dispatched, err := relay.Dispatch(ctx); err != nil || dispatched != 0 {
+		t.Errorf("dispatched %d again: %v", dispatched, err)
+	}
+}
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// Shill-bidding signal weights. A bidder's signals add up, capped at
+// maxFraudScore; the auction scores as its most suspicious bidder.
+const (
+	scoreSharedWithSeller = 60
+	scoreSharedWithBidder = 30
+	scoreReservePushing   = 30
+	scoreRepeatNonWinner  = 20
+	// Each further losing auction with the same seller adds this much
+	scoreRepeatStep     = 5
+	scoreRepeatMax      = 40
+	maxFraudScore       = 100
+	repeatNonWinnerMin  = 3
+	reservePushingShare = 0.8
+	fraudQueueLimit     = 100
+)
+
+// ScanForShillBidding scores newly ended auctions for shill bidding and
+// queues suspicious ones for moderation
+func (s *AuctionService) ScanForShillBidding(ctx context.Context, now time.Time) (int, error) {
+	flagged := 0
+	for scanned := 0; scanned < lifecycleBatchSize; scanned++ {
+		ok, wasFlagged, err := s.scanNextForShilling(ctx, now)
+		if err != nil {
+			return flagged, err
+		}
+		if !ok {
+			break
+		}
+		if wasFlagged {
+			flagged++
+		}
+	}
+	return flagged, nil
+}
+
+func (s *AuctionService) scanNextForShilling(ctx context.Context, now time.Time) (bool, bool, error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return false, false, err
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	auction, err := txRepo.ClaimNextFraudScan(ctx)
+	if err != nil || auction == nil {
+		return false, false, err
+	}
+
+	history, err := txRepo.GetBidHistory(ctx, auction.AuctionID, models.AnalyticsRange{})
+	if err != nil {
+		return false, false, err
+	}
+	signals, err := s.shillSignals(ctx, txRepo, auction, history, now)
+	if err != nil {
+		return false, false, err
+	}
+
+	score := fraudScore(signals)
+	flagged := score >= s.config.FraudFlagThreshold
+	if flagged {
+		if err := txRepo.CreateFraudFlag(ctx, &models.FraudFlag{
+			FlagID:    uuid.New().String(),
+			AuctionID: auction.AuctionID,
+			SellerID:  auction.SellerID,
+			Score:     score,
+			Signals:   signals,
+			Status:    models.FlagOpen,
+			CreatedAt: now,
+		}); err != nil {
+			return false, false, err
+		}
+		s.logger.Warn("Auction flagged for shill bidding",
+			zap.String("auction_id", auction.AuctionID),
+			zap.String("seller_id", auction.SellerID),
+			zap.Int("score", score))
+	}
+	if err := txRepo.MarkFraudScanned(ctx, auction.AuctionID, now); err != nil {
+		return false, false, err
+	}
+	return true, flagged, tx.Commit()
+}
+
+// shillSignals looks for the patterns shill bidders leave: sharing an IP or
+// device with the seller or another bidder, bidding on the same seller again
+// and again without winning, and bidding up towards the reserve but never
+// past it
+func (s *AuctionService) shillSignals(ctx context.Context, txRepo repository.AuctionRepo, auction *models.Auction, history []*models.Bid, now time.Time) ([]models.FraudSignal, error) {
+	var signals []models.FraudSignal
+	byBidder := make(map[string][]*models.Bid)
+	var bidderIDs []string
+	for _, bid := range history {
+		if _, seen := byBidder[bid.BidderID]; !seen {
+			bidderIDs = append(bidderIDs, bid.BidderID)
+		}
+		byBidder[bid.BidderID] = append(byBidder[bid.BidderID], bid)
+	}
+	if len(bidderIDs) == 0 {
+		return nil, nil
+	}
+
+	// Shared origins: with the seller, and between bidders
+	originUsers := make(map[string]map[string]bool)
+	for _, bid := range history {
+		for _, origin := range []string{"ip:" + bid.IPAddress, "device:" + bid.DeviceID} {
+			if origin == "ip:" || origin == "device:" {
+				continue
+			}
+			if originUsers[origin] == nil {
+				originUsers[origin] = make(map[string]bool)
+			}
+			originUsers[origin][bid.BidderID] = true
+		}
+	}
+	for _, bidderID := range bidderIDs {
+		sharedWithSeller, sharedWith := "", ""
+		for _, bid := range byBidder[bidderID] {
+			if (auction.SellerIP != "" && bid.IPAddress == auction.SellerIP) ||
+				(auction.SellerDeviceID != "" && bid.DeviceID == auction.SellerDeviceID) {
+				sharedWithSeller = "Bid from the IP address or device the seller listed the auction from"
+			}
+			for _, origin := range []string{"ip:" + bid.IPAddress, "device:" + bid.DeviceID} {
+				for other := range originUsers[origin] {
+					if other != bidderID {
+						sharedWith = other
+					}
+				}
+			}
+		}
+		if sharedWithSeller != "" {
+			signals = append(signals, models.FraudSignal{
+				BidderID: bidderID, Signal: models.SignalSharedWithSeller, Score: scoreSharedWithSeller, Detail: sharedWithSeller,
+			})
+		}
+		if sharedWith != "" {
+			signals = append(signals, models.FraudSignal{
+				BidderID: bidderID, Signal: models.SignalSharedWithBidder, Score: scoreSharedWithBidder,
+				Detail: fmt.Sprintf("Shares an IP address or device with bidder %s", sharedWith),
+			})
+		}
+	}
+
+	// Reserve pushing: several bids, all below the reserve, ending close to it
+	if auction.ReservePrice > 0 {
+		for _, bidderID := range bidderIDs {
+			bids := byBidder[bidderID]
+			if len(bids) < 2 {
+				continue
+			}
+			var highest models.Money
+			for _, bid := range bids {
+				if bid.Amount > highest {
+					highest = bid.Amount
+				}
+			}
+			if highest < auction.ReservePrice && float64(highest) >= float64(auction.ReservePrice)*reservePushingShare {
+				signals = append(signals, models.FraudSignal{
+					BidderID: bidderID, Signal: models.SignalReservePushing, Score: scoreReservePushing,
+					Detail: fmt.Sprintf("%d bids climbing to %s against a reserve of %s, never past it", len(bids), highest, auction.ReservePrice),
+				})
+			}
+		}
+	}
+
+	// Repeat non-winners: keeps bidding on this seller's auctions, never wins
+	stats, err := txRepo.GetSellerBidderStats(ctx, auction.SellerID, bidderIDs, now.Add(-s.config.FraudLookback))
+	if err != nil {
+		return nil, err
+	}
+	for _, stat := range stats {
+		if stat.Wins > 0 || stat.Auctions < repeatNonWinnerMin {
+			continue
+		}
+		score := scoreRepeatNonWinner + scoreRepeatStep*(stat.Auctions-repeatNonWinnerMin)
+		if score > scoreRepeatMax {
+			score = scoreRepeatMax
+		}
+		signals = append(signals, models.FraudSignal{
+			BidderID: stat.BidderID, Signal: models.SignalRepeatNonWinner, Score: score,
+			Detail: fmt.Sprintf("Bid on %d of this seller's auctions without winning any", stat.Auctions),
+		})
+	}
+
+	sort.SliceStable(signals, func(i, j int) bool { return signals[i].Score > signals[j].Score })
+	return signals, nil
+}
+
+// fraudScore is the highest total of any one bidder's signals
+func fraudScore(signals []models.FraudSignal) int {
+	totals := make(map[string]int)
+	best := 0
+	for _, signal := range signals {
+		totals[signal.BidderID] += signal.Score
+		if totals[signal.BidderID] > best {
+			best = totals[signal.BidderID]
+		}
+	}
+	if best > maxFraudScore {
+		best = maxFraudScore
+	}
+	return best
+}
+
+// GetFraudFlags returns the moderation queue for one status, most suspicious
+// first
+func (s *AuctionService) GetFraudFlags(ctx context.Context, status string) ([]*models.FraudFlag, error) {
+	if status == "" {
+		status = models.FlagOpen
+	}
+	switch status {
+	case models.FlagOpen, models.FlagDismissed, models.FlagConfirmed:
+	default:
+		return nil, shared_errors.ValidationError("INVALID_STATUS", "Status must be one of open, dismissed, confirmed")
+	}
+
+	flags, err := s.repo.GetFraudFlags(ctx, status, fraudQueueLimit)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	return flags, nil
+}
+
+// ReviewFraudFlag records a moderator dismissing or confirming a flag
+func (s *AuctionService) ReviewFraudFlag(ctx context.Context, flagID, reviewerID, status, note string) (*models.FraudFlag, error) {
+	reviewed, err := s.repo.ReviewFraudFlag(ctx, flagID, status, reviewerID, note, time.Now())
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	flag, err := s.repo.GetFraudFlag(ctx, flagID)
+	if err == sql.ErrNoRows {
+		return nil, shared_errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if !reviewed {
+		return nil, shared_errors.ConflictError("FLAG_ALREADY_REVIEWED", "This flag has already been reviewed")
+	}
+
+	s.logger.Info("Fraud flag reviewed",
+		zap.String("flag_id", flagID),
+		zap.String("auction_id", flag.AuctionID),
+		zap.String("status", status),
+		zap.String("reviewer_id", reviewerID))
+	return flag, nil
+}
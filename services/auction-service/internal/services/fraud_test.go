@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// placeOriginBid places a bid the test expects to be accepted from the given
+// IP address and device
+func placeOriginBid(t *testing.T, service *AuctionService, auctionID, bidderID string, amount models.Money, ip, device string) {
+	t.Helper()
+	bid := &models.Bid{AuctionID: auctionID, BidderID: bidderID, Amount: amount, IPAddress: ip, DeviceID: device}
+	if err := service.PlaceBid(context.Background(), bid); err != nil {
+		t.Fatalf("bid %s by %s: %v", amount, bidderID, err)
+	}
+}
+
+func TestFraudScore(t *testing.T) {
+	tests := []struct {
+		name    string
+		signals []models.FraudSignal
+		want    int
+	}{
+		{"no signals", nil, 0},
+		{"one bidder's signals add up", []models.FraudSignal{{BidderID: "a", Score: 30}, {BidderID: "a", Score: 20}, {BidderID: "b", Score: 40}}, 50},
+		{"capped", []models.FraudSignal{{BidderID: "a", Score: scoreSharedWithSeller}, {BidderID: "a", Score: scoreSharedWithBidder}, {BidderID: "a", Score: scoreReservePushing}}, maxFraudScore},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fraudScore(tt.signals); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanForShillBidding(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+	endTime := time.Now().Add(time.Hour)
+
+	// The seller bids up their own auction towards the reserve
+	shilled := createTestAuction(t, service, models.Auction{ReservePrice: 5000, SellerIP: "10.0.0.1", EndTime: endTime})
+	placeOriginBid(t, service, shilled.AuctionID, "alice", 1000, "203.0.113.5", "phone-a")
+	placeOriginBid(t, service, shilled.AuctionID, "shill", 2000, "10.0.0.1", "laptop")
+	placeOriginBid(t, service, shilled.AuctionID, "alice", 3000, "203.0.113.5", "phone-a")
+	placeOriginBid(t, service, shilled.AuctionID, "shill", 4500, "10.0.0.1", "laptop")
+
+	clean := createTestAuction(t, service, models.Auction{SellerIP: "10.0.0.1", EndTime: endTime})
+	placeOriginBid(t, service, clean.AuctionID, "alice", 1000, "203.0.113.5", "phone-a")
+	placeOriginBid(t, service, clean.AuctionID, "bob", 1500, "198.51.100.7", "tablet")
+
+	now := endTime.Add(time.Second)
+	if _, err := service.CloseDueAuctions(ctx, now); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if flagged, err := service.ScanForShillBidding(ctx, now); err != nil || flagged != 1 {
+		t.Fatalf("flagged %d: %v", flagged, err)
+	}
+	// Each auction is only scanned once
+	if flagged, err := service.ScanForShillBidding(ctx, now); err != nil || flagged != 0 {
+		t.Fatalf("flagged %d on a second scan: %v", flagged, err)
+	}
+
+	flags, err := service.GetFraudFlags(ctx, "")
+	if err != nil || len(flags) != 1 {
+		t.Fatalf("flags %+v: %v", flags, err)
+	}
+	flag := flags[0]
+	if flag.AuctionID != shilled.AuctionID || flag.Score != scoreSharedWithSeller+scoreReservePushing || flag.Status != models.FlagOpen {
+		t.Errorf("flag %+v", flag)
+	}
+	signals := make(map[string]string)
+	for _, signal := range flag.Signals {
+		signals[signal.Signal] = signal.BidderID
+	}
+	if signals[models.SignalSharedWithSeller] != "shill" || signals[models.SignalReservePushing] != "shill" {
+		t.Errorf("signals %+v", flag.Signals)
+	}
+}
+
+func TestShillSignalsRepeatNonWinner(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	endTime := time.Now().Add(time.Hour)
+
+	var last *models.Auction
+	for i := 0; i < repeatNonWinnerMin+1; i++ {
+		last = createTestAuction(t, service, models.Auction{EndTime: endTime})
+		placeTestBid(t, service, last.AuctionID, "lurker", 1000)
+		placeTestBid(t, service, last.AuctionID, "alice", 1500)
+	}
+	now := endTime.Add(time.Second)
+	if _, err := service.CloseDueAuctions(ctx, now); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	history, err := repo.GetBidHistory(ctx, last.AuctionID, models.AnalyticsRange{})
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	signals, err := service.shillSignals(ctx, repo, last, history, now)
+	if err != nil {
+		t.Fatalf("signals: %v", err)
+	}
+	if len(signals) != 1 {
+		t.Fatalf("signals %+v, want one", signals)
+	}
+	if signal := signals[0]; signal.BidderID != "lurker" || signal.Signal != models.SignalRepeatNonWinner || signal.Score != scoreRepeatNonWinner+scoreRepeatStep {
+		t.Errorf("signal %+v", signal)
+	}
+}
+
+func TestReviewFraudFlag(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	if err := repo.CreateFraudFlag(ctx, &models.FraudFlag{
+		FlagID: "flag-1", AuctionID: "auction-1", SellerID: "seller-1", Score: 60, Status: models.FlagOpen, CreatedAt: time.Now(),
+	}); err != nil {
+		t.Fatalf("create flag: %v", err)
+	}
+
+	if _, err := service.GetFraudFlags(ctx, "pending"); errorCode(err) != "INVALID_STATUS" {
+		t.Errorf("unknown status: %v, want INVALID_STATUS", err)
+	}
+	if _, err := service.ReviewFraudFlag(ctx, "flag-2", "mod-1", models.FlagConfirmed, ""); errorCode(err) != "NOT_FOUND" {
+		t.Errorf("unknown flag: %v, want NOT_FOUND", err)
+	}
+	flag, err := service.ReviewFraudFlag(ctx, "flag-1", "mod-1", models.FlagConfirmed, "Same household")
+	if err != nil {
+		t.Fatalf("review: %v", err)
+	}
+	if flag.Status != models.FlagConfirmed || flag.ReviewerID != "mod-1" || flag.ReviewNote != "Same household" {
+		t.Errorf("reviewed flag %+v", flag)
+	}
+	if _, err := service.ReviewFraudFlag(ctx, "flag-1", "mod-2", models.FlagDismissed, ""); errorCode(err) != "FLAG_ALREADY_REVIEWED" {
+		t.Errorf("second review: %v, want FLAG_ALREADY_REVIEWED", err)
+	}
+
+	if open, err := service.GetFraudFlags(ctx, ""); err != nil || len(open) != 0 {
+		t.Errorf("open queue %+v: %v", open, err)
+	}
+	if confirmed, err := service.GetFraudFlags(ctx, models.FlagConfirmed); err != nil || len(confirmed) != 1 {
+		t.Errorf("confirmed queue %+v: %v", confirmed, err)
+	}
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// minimumIncrement returns the smallest raise allowed over price: the ladder
+// rung for that price, or the auction's own MinBidIncrement if that is larger
+func (s *AuctionService) minimumIncrement(auction *models.Auction, price models.Money) models.Money {
+	var increment models.Money
+	for _, step := range s.config.BidIncrementLadder {
+		if step.Below == 0 || price < step.Below {
+			increment = step.Increment
+			break
+		}
+	}
+	if auction.MinBidIncrement > increment {
+		increment = auction.MinBidIncrement
+	}
+	return increment
+}
+
+// nextValidBid returns the lowest amount the next bid may be. The first bid
+// may be placed at the starting price; later bids must clear the increment.
+func (s *AuctionService) nextValidBid(auction *models.Auction, hasBids bool) models.Money {
+	if !hasBids {
+		return auction.StartingPrice
+	}
+	return auction.CurrentPrice + s.minimumIncrement(auction, auction.CurrentPrice)
+}
+
+// bidTooLowError tells the client the amount its next bid must reach
+func bidTooLowError(auction *models.Auction, minimum models.Money) error {
+	return shared_errors.ValidationError("BID_TOO_LOW", fmt.Sprintf("Bid must be at least %s", minimum)).
+		WithDetails(map[string]interface{}{
+			"minimum_bid":   minimum,
+			"current_price": auction.CurrentPrice,
+		})
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+func TestMinimumIncrement(t *testing.T) {
+	service, _ := newTestService(t)
+	tests := []struct {
+		name         string
+		minIncrement models.Money
+		price        models.Money
+		want         models.Money
+	}{
+		{"bottom rung", 0, 1000, 100},
+		{"just below a rung boundary", 0, 4999, 100},
+		{"at a rung boundary", 0, 5000, 500},
+		{"middle rung", 0, 75000, 1000},
+		{"open-ended top rung", 0, 1000000, 5000},
+		{"auction increment above the rung", 700, 1000, 700},
+		{"auction increment below the rung", 200, 60000, 1000},
+	}
+	for _, tt := range tests {
+		auction := &models.Auction{MinBidIncrement: tt.minIncrement}
+		if got := service.minimumIncrement(auction, tt.price); got != tt.want {
+			t.Errorf("%s: increment over %s = %s, want %s", tt.name, tt.price, got, tt.want)
+		}
+	}
+}
+
+func TestBidTooLowReportsMinimum(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{StartingPrice: 4950})
+
+	status, err := service.GetAuctionStatus(ctx, auction.AuctionID)
+	if err != nil || status.MinimumBid != 4950 {
+		t.Fatalf("minimum before any bid %s, want the starting price: %v", status.MinimumBid, err)
+	}
+	placeTestBid(t, service, auction.AuctionID, "alice", 4950)
+
+	// The rung is picked by the current price, so the next bid crosses into
+	// the next rung without having to clear its larger increment
+	status, err = service.GetAuctionStatus(ctx, auction.AuctionID)
+	if err != nil || status.MinimumBid != 5050 {
+		t.Fatalf("minimum after a bid %s, want 50.50: %v", status.MinimumBid, err)
+	}
+
+	err = service.PlaceBid(ctx, &models.Bid{AuctionID: auction.AuctionID, BidderID: "bob", Amount: 5000})
+	var appErr *shared_errors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != "BID_TOO_LOW" {
+		t.Fatalf("got %v, want BID_TOO_LOW", err)
+	}
+	if appErr.Details["minimum_bid"] != models.Money(5050) || appErr.Details["current_price"] != models.Money(4950) {
+		t.Errorf("details %v, want minimum 50.50 over 49.50", appErr.Details)
+	}
+	placeTestBid(t, service, auction.AuctionID, "bob", 5050)
+}
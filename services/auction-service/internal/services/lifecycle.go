@@ -0,0 +1,308 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/config"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+)
+
+// lifecycleBatchSize caps how many auctions one replica moves per tick so a
+// backlog after downtime is worked through without starving the relay
+const lifecycleBatchSize = 50
+
+// LifecycleScheduler periodically opens and closes auctions and relays the
+// resulting events. All state lives in Postgres and every transition claims its
+// row with SKIP LOCKED, so restarts and extra replicas are safe.
+type LifecycleScheduler struct {
+	auctionService *AuctionService
+	relay          *EventRelay
+	notifications  *NotificationQueue
+	logger         *zap.Logger
+	interval       time.Duration
+}
+
+func NewLifecycleScheduler(auctionService *AuctionService, relay *EventRelay, notifications *NotificationQueue, logger *zap.Logger, cfg *config.Config) *LifecycleScheduler {
+	return &LifecycleScheduler{
+		auctionService: auctionService,
+		relay:          relay,
+		notifications:  notifications,
+		logger:         logger,
+		interval:       cfg.LifecycleInterval,
+	}
+}
+
+// Run ticks until ctx is cancelled
+func (l *LifecycleScheduler) Run(ctx context.Context) {
+	l.logger.Info("Starting auction lifecycle scheduler", zap.Duration("interval", l.interval))
+
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	l.tick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			l.logger.Info("Auction lifecycle scheduler stopped")
+			return
+		case <-ticker.C:
+			l.tick(ctx)
+		case <-l.auctionService.EventsPending():
+			// Relay bid events straight away rather than on the next tick
+			l.relayEvents(ctx)
+		}
+	}
+}
+
+func (l *LifecycleScheduler) tick(ctx context.Context) {
+	now := time.Now()
+
+	if created, err := l.auctionService.MaterializeSchedules(ctx, now); err != nil {
+		l.logger.Error("Failed to materialize auction schedules", zap.Error(err))
+	} else if created > 0 {
+		l.logger.Info("Ran auction schedules", zap.Int("count", created))
+	}
+
+	if started, err := l.auctionService.ActivateDueAuctions(ctx, now); err != nil {
+		l.logger.Error("Failed to activate due auctions", zap.Error(err))
+	} else if started > 0 {
+		l.logger.Info("Activated auctions", zap.Int("count", started))
+	}
+
+	if _, err := l.auctionService.AdvanceDropPrices(ctx, now); err != nil {
+		l.logger.Error("Failed to advance drop prices", zap.Error(err))
+	}
+
+	if _, err := l.auctionService.ScheduleEndingSoonAlerts(ctx, now); err != nil {
+		l.logger.Error("Failed to schedule ending-soon alerts", zap.Error(err))
+	}
+
+	if closed, err := l.auctionService.CloseDueAuctions(ctx, now); err != nil {
+		l.logger.Error("Failed to close due auctions", zap.Error(err))
+	} else if closed > 0 {
+		l.logger.Info("Closed auctions", zap.Int("count", closed))
+	}
+
+	if _, err := l.auctionService.HandOffOrders(ctx, now); err != nil {
+		l.logger.Error("Failed to hand off auction orders", zap.Error(err))
+	}
+
+	if _, err := l.auctionService.ProcessLapsedPayments(ctx, now); err != nil {
+		l.logger.Error("Failed to process lapsed payments", zap.Error(err))
+	}
+
+	if _, err := l.auctionService.ExpireSecondChanceOffers(ctx, now); err != nil {
+		l.logger.Error("Failed to expire second-chance offers", zap.Error(err))
+	}
+
+	if _, err := l.auctionService.SettleDeposits(ctx, now); err != nil {
+		l.logger.Error("Failed to settle bid deposits", zap.Error(err))
+	}
+
+	if _, err := l.auctionService.ScanForShillBidding(ctx, now); err != nil {
+		l.logger.Error("Failed to scan auctions for shill bidding", zap.Error(err))
+	}
+
+	l.relayEvents(ctx)
+}
+
+func (l *LifecycleScheduler) relayEvents(ctx context.Context) {
+	if _, err := l.relay.Dispatch(ctx); err != nil {
+		l.logger.Error("Failed to relay auction events", zap.Error(err))
+	}
+	// Deliver what the relay just queued, and retry earlier failures that are due
+	if _, err := l.notifications.Deliver(ctx, time.Now()); err != nil {
+		l.logger.Error("Failed to deliver notifications", zap.Error(err))
+	}
+}
+
+// ActivateDueAuctions opens scheduled auctions whose start time has passed
+func (s *AuctionService) ActivateDueAuctions(ctx context.Context, now time.Time) (int, error) {
+	activated := 0
+	for activated < lifecycleBatchSize {
+		ok, err := s.activateNext(ctx, now)
+		if err != nil {
+			return activated, err
+		}
+		if !ok {
+			break
+		}
+		activated++
+	}
+	return activated, nil
+}
+
+func (s *AuctionService) activateNext(ctx context.Context, now time.Time) (bool, error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	auction, err := txRepo.ClaimNextToStart(ctx, now)
+	if err != nil || auction == nil {
+		return false, err
+	}
+	if err := activateAuction(ctx, txRepo, auction, now); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// activateAuction opens a locked scheduled auction for bidding
+func activateAuction(ctx context.Context, txRepo repository.AuctionRepo, auction *models.Auction, now time.Time) error {
+	if err := txRepo.UpdateAuctionStatus(ctx, auction.AuctionID, constants.AuctionStatusActive); err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, txRepo, &models.AuditEntry{
+		AuctionID:  auction.AuctionID,
+		ActorRole:  models.ActorSystem,
+		Action:     models.AuditActivated,
+		FromStatus: auction.Status,
+		ToStatus:   constants.AuctionStatusActive,
+		CreatedAt:  now,
+	}, nil); err != nil {
+		return err
+	}
+	auction.Status = constants.AuctionStatusActive
+	return recordEvent(ctx, txRepo, auction.AuctionID, models.EventAuctionStarted, map[string]interface{}{
+		"seller_id":     auction.SellerID,
+		"current_price": auction.CurrentPrice,
+		"end_time":      auction.EndTime,
+	})
+}
+
+// CloseDueAuctions ends and settles active auctions whose end time has passed
+func (s *AuctionService) CloseDueAuctions(ctx context.Context, now time.Time) (int, error) {
+	closed := 0
+	for closed < lifecycleBatchSize {
+		ok, err := s.closeNext(ctx, now)
+		if err != nil {
+			return closed, err
+		}
+		if !ok {
+			break
+		}
+		closed++
+	}
+	return closed, nil
+}
+
+func (s *AuctionService) closeNext(ctx context.Context, now time.Time) (bool, error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	auction, err := txRepo.ClaimNextToEnd(ctx, now)
+	if err != nil || auction == nil {
+		return false, err
+	}
+
+	if err := s.settleAuction(ctx, txRepo, auction, now); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// settleAuction ends a locked auction, picks the winner and records the
+// outcome. The highest bid wins only if it meets the reserve price.
+func (s *AuctionService) settleAuction(ctx context.Context, txRepo repository.AuctionRepo, auction *models.Auction, now time.Time) error {
+	if auction.Type == models.AuctionTypeDrop {
+		return s.settleDropAuction(ctx, txRepo, auction, now)
+	}
+
+	highest, err := txRepo.GetHighestBid(ctx, auction.AuctionID)
+	if err != nil {
+		return err
+	}
+
+	fromStatus := auction.Status
+	auction.Status = constants.AuctionStatusEnded
+	auction.SettledAt = &now
+	auction.WinnerID = ""
+	auction.WinningBidID = ""
+	outcome := models.OutcomeUnsold
+	if highest != nil && (auction.ReservePrice <= 0 || highest.Amount >= auction.ReservePrice) {
+		auction.WinnerID = highest.BidderID
+		auction.WinningBidID = highest.BidID
+		auction.CurrentPrice = highest.Amount
+		outcome = models.OutcomeSold
+	}
+	if outcome == models.OutcomeSold && auction.Type == models.AuctionTypeSealed {
+		if auction.CurrentPrice, err = sealedClearingPrice(ctx, txRepo, auction, highest); err != nil {
+			return err
+		}
+	}
+
+	if err := txRepo.SetWinningBid(ctx, auction.AuctionID, auction.WinningBidID); err != nil {
+		return err
+	}
+	if err := txRepo.SettleAuction(ctx, auction); err != nil {
+		return err
+	}
+	// Losing bidders get their deposits back now; the winner's is held until
+	// they pay
+	if err := txRepo.ReleaseBidDeposits(ctx, auction.AuctionID, auction.WinnerID, now); err != nil {
+		return err
+	}
+	if outcome == models.OutcomeSold {
+		if err := s.queueOrderHandoff(ctx, txRepo, auction, highest.BidID, highest.BidderID, 1, auction.CurrentPrice, now); err != nil {
+			return err
+		}
+	}
+	if err := recordSettlementAudit(ctx, txRepo, auction, fromStatus, outcome, now); err != nil {
+		return err
+	}
+
+	if err := recordEvent(ctx, txRepo, auction.AuctionID, models.EventAuctionEnded, map[string]interface{}{
+		"end_time":    now,
+		"final_price": auction.CurrentPrice,
+		"outcome":     outcome,
+	}); err != nil {
+		return err
+	}
+	if err := recordEvent(ctx, txRepo, auction.AuctionID, models.EventAuctionSettled, models.AuctionSettledPayload{
+		SellerID:     auction.SellerID,
+		Outcome:      outcome,
+		WinnerID:     auction.WinnerID,
+		WinningBidID: auction.WinningBidID,
+		HammerPrice:  auction.CurrentPrice,
+		BuyNow:       outcome == models.OutcomeSold && highest.IsBuyNow,
+	}); err != nil {
+		return err
+	}
+
+	s.logger.Info("Auction settled",
+		zap.String("auction_id", auction.AuctionID),
+		zap.String("outcome", outcome),
+		zap.String("winner_id", auction.WinnerID),
+		zap.Stringer("hammer_price", auction.CurrentPrice))
+	return nil
+}
+
+// recordSettlementAudit logs the scheduler, a buy-now or a sold-out drop
+// ending an auction
+func recordSettlementAudit(ctx context.Context, txRepo repository.AuctionRepo, auction *models.Auction, fromStatus, outcome string, now time.Time) error {
+	return recordAudit(ctx, txRepo, &models.AuditEntry{
+		AuctionID:  auction.AuctionID,
+		ActorRole:  models.ActorSystem,
+		Action:     models.AuditSettled,
+		FromStatus: fromStatus,
+		ToStatus:   auction.Status,
+		CreatedAt:  now,
+	}, map[string]interface{}{
+		"outcome":     outcome,
+		"winner_id":   auction.WinnerID,
+		"final_price": auction.CurrentPrice,
+	})
+}
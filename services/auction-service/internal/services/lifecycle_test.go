@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+)
+
+func TestActivateDueAuctions(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	start := time.Now().Add(time.Hour)
+	auction := createTestAuction(t, service, models.Auction{StartTime: start})
+	if auction.Status != constants.AuctionStatusScheduled {
+		t.Fatalf("future auction created %s, want scheduled", auction.Status)
+	}
+
+	steps := []struct {
+		name          string
+		now           time.Time
+		wantActivated int
+		wantStatus    string
+	}{
+		{"before the start time", start.Add(-time.Second), 0, constants.AuctionStatusScheduled},
+		{"at the start time", start, 1, constants.AuctionStatusActive},
+		{"already active", start.Add(time.Minute), 0, constants.AuctionStatusActive},
+	}
+	for _, step := range steps {
+		activated, err := service.ActivateDueAuctions(ctx, step.now)
+		if err != nil {
+			t.Fatalf("%s: activate: %v", step.name, err)
+		}
+		if activated != step.wantActivated {
+			t.Errorf("%s: activated %d, want %d", step.name, activated, step.wantActivated)
+		}
+		stored, err := repo.GetByID(ctx, auction.AuctionID)
+		if err != nil {
+			t.Fatalf("%s: reload auction: %v", step.name, err)
+		}
+		if stored.Status != step.wantStatus {
+			t.Errorf("%s: status %s, want %s", step.name, stored.Status, step.wantStatus)
+		}
+	}
+
+	entries, err := repo.GetAuditLog(ctx, auction.AuctionID)
+	if err != nil || len(entries) != 1 || entries[0].Action != models.AuditActivated {
+		t.Errorf("audit log %v, %v; want one activation", entries, err)
+	}
+}
+
+func TestCloseDueAuctions(t *testing.T) {
+	type bid struct {
+		bidderID string
+		amount   models.Money
+	}
+	tests := []struct {
+		name       string
+		auction    models.Auction
+		bids       []bid
+		wantWinner string
+		wantPrice  models.Money
+	}{
+		{
+			name:      "no bids",
+			wantPrice: 1000,
+		},
+		{
+			name:       "highest bid wins",
+			bids:       []bid{{"alice", 1000}, {"bob", 1500}},
+			wantWinner: "bob",
+			wantPrice:  1500,
+		},
+		{
+			name:       "reserve met",
+			auction:    models.Auction{ReservePrice: 2000},
+			bids:       []bid{{"alice", 1000}, {"bob", 2000}},
+			wantWinner: "bob",
+			wantPrice:  2000,
+		},
+		{
+			name:      "reserve not met",
+			auction:   models.Auction{ReservePrice: 5000},
+			bids:      []bid{{"alice", 1000}, {"bob", 2000}},
+			wantPrice: 2000,
+		},
+		{
+			name:       "sealed first price",
+			auction:    models.Auction{Type: models.AuctionTypeSealed},
+			bids:       []bid{{"alice", 3000}, {"bob", 2000}},
+			wantWinner: "alice",
+			wantPrice:  3000,
+		},
+		{
+			name:       "sealed second price",
+			auction:    models.Auction{Type: models.AuctionTypeSealed, PricingRule: models.PricingSecondPrice},
+			bids:       []bid{{"alice", 3000}, {"bob", 2000}},
+			wantWinner: "alice",
+			wantPrice:  2000,
+		},
+		{
+			name:       "sealed second price with a lone bid pays the reserve",
+			auction:    models.Auction{Type: models.AuctionTypeSealed, PricingRule: models.PricingSecondPrice, ReservePrice: 2500},
+			bids:       []bid{{"alice", 3000}},
+			wantWinner: "alice",
+			wantPrice:  2500,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newTestService(t)
+			ctx := context.Background()
+			tt.auction.EndTime = time.Now().Add(time.Hour)
+			auction := createTestAuction(t, service, tt.auction)
+			for _, bid := range tt.bids {
+				placeTestBid(t, service, auction.AuctionID, bid.bidderID, bid.amount)
+			}
+
+			if closed, err := service.CloseDueAuctions(ctx, auction.EndTime.Add(-time.Second)); err != nil || closed != 0 {
+				t.Fatalf("closed %d before the end time: %v", closed, err)
+			}
+			now := auction.EndTime.Add(time.Second)
+			if closed, err := service.CloseDueAuctions(ctx, now); err != nil || closed != 1 {
+				t.Fatalf("closed %d at the end time: %v", closed, err)
+			}
+
+			stored, err := repo.GetByID(ctx, auction.AuctionID)
+			if err != nil {
+				t.Fatalf("reload auction: %v", err)
+			}
+			if stored.Status != constants.AuctionStatusEnded {
+				t.Errorf("status %s, want ended", stored.Status)
+			}
+			if stored.WinnerID != tt.wantWinner {
+				t.Errorf("winner %q, want %q", stored.WinnerID, tt.wantWinner)
+			}
+			if stored.CurrentPrice != tt.wantPrice {
+				t.Errorf("price %s, want %s", stored.CurrentPrice, tt.wantPrice)
+			}
+
+			handoffs, err := repo.GetOrderHandoffs(ctx, auction.AuctionID)
+			if err != nil {
+				t.Fatalf("get order handoffs: %v", err)
+			}
+			if tt.wantWinner == "" {
+				if len(handoffs) != 0 || stored.PaymentDueAt != nil {
+					t.Errorf("unsold auction queued %d orders", len(handoffs))
+				}
+				if _, err := repo.GetWinningBid(ctx, auction.AuctionID); err == nil {
+					t.Errorf("unsold auction kept a winning bid")
+				}
+				return
+			}
+
+			if len(handoffs) != 1 {
+				t.Fatalf("%d orders queued, want 1", len(handoffs))
+			}
+			handoff := handoffs[0]
+			if handoff.BidID != stored.WinningBidID || handoff.BuyerID != tt.wantWinner || handoff.Price != tt.wantPrice {
+				t.Errorf("order %+v does not match the winning bid %s", handoff, stored.WinningBidID)
+			}
+			if stored.PaymentDueAt == nil || !stored.PaymentDueAt.Equal(now.Add(service.config.PaymentWindow)) {
+				t.Errorf("payment due %v, want %v", stored.PaymentDueAt, now.Add(service.config.PaymentWindow))
+			}
+			winning, err := repo.GetWinningBid(ctx, auction.AuctionID)
+			if err != nil || winning.BidID != stored.WinningBidID {
+				t.Errorf("winning bid %v, %v; want %s", winning, err, stored.WinningBidID)
+			}
+		})
+	}
+}
+
+func TestCloseDueAuctionsOnlyOnce(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{EndTime: time.Now().Add(time.Hour)})
+	placeTestBid(t, service, auction.AuctionID, "alice", 1000)
+
+	now := auction.EndTime.Add(time.Second)
+	for i, want := range []int{1, 0} {
+		closed, err := service.CloseDueAuctions(ctx, now)
+		if err != nil || closed != want {
+			t.Fatalf("pass %d closed %d, want %d: %v", i+1, closed, want, err)
+		}
+	}
+
+	handoffs, err := repo.GetOrderHandoffs(ctx, auction.AuctionID)
+	if err != nil || len(handoffs) != 1 {
+		t.Errorf("%d orders queued, want 1: %v", len(handoffs), err)
+	}
+	events, err := repo.GetEventsAfter(ctx, auction.AuctionID, 0, 100)
+	if err != nil {
+		t.Fatalf("get events: %v", err)
+	}
+	settled := 0
+	for _, event := range events {
+		if event.Type == models.EventAuctionSettled {
+			settled++
+		}
+	}
+	if settled != 1 {
+		t.Errorf("%d settlement events, want 1", settled)
+	}
+}
+
+// eventTypes lists the types of an auction's recorded events in order
+func eventTypes(t *testing.T, repo repository.AuctionRepo, auctionID string) []string {
+	t.Helper()
+	events, err := repo.GetEventsAfter(context.Background(), auctionID, 0, 100)
+	if err != nil {
+		t.Fatalf("get events: %v", err)
+	}
+	types := make([]string, 0, len(events))
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+// auditActions lists the actions in an auction's audit log in order
+func auditActions(t *testing.T, repo repository.AuctionRepo, auctionID string) []string {
+	t.Helper()
+	entries, err := repo.GetAuditLog(context.Background(), auctionID)
+	if err != nil {
+		t.Fatalf("get audit log: %v", err)
+	}
+	actions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		actions = append(actions, entry.Action)
+	}
+	return actions
+}
+
+func TestLifecycleRecordsTransitions(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	start := time.Now().Add(time.Hour)
+	auction := createTestAuction(t, service, models.Auction{StartTime: start, EndTime: start.Add(time.Hour)})
+
+	if _, err := service.ActivateDueAuctions(ctx, start); err != nil {
+		t.Fatalf("activate: %v", err)
+	}
+	if got := eventTypes(t, repo, auction.AuctionID); !slices.Equal(got, []string{models.EventAuctionStarted}) {
+		t.Errorf("events after activation %v", got)
+	}
+
+	if _, err := service.CloseDueAuctions(ctx, auction.EndTime.Add(time.Second)); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	want := []string{models.EventAuctionStarted, models.EventAuctionEnded, models.EventAuctionSettled}
+	if got := eventTypes(t, repo, auction.AuctionID); !slices.Equal(got, want) {
+		t.Errorf("events %v, want %v", got, want)
+	}
+	if got := auditActions(t, repo, auction.AuctionID); !slices.Equal(got, []string{models.AuditActivated, models.AuditSettled}) {
+		t.Errorf("audit log %v", got)
+	}
+	entries, err := repo.GetAuditLog(ctx, auction.AuctionID)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("audit log %v: %v", entries, err)
+	}
+	if settled := entries[1]; settled.FromStatus != constants.AuctionStatusActive || settled.ToStatus != constants.AuctionStatusEnded || settled.ActorRole != models.ActorSystem {
+		t.Errorf("settlement entry %+v", settled)
+	}
+}
+
+func TestActivateDueAuctionsBatches(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	start := time.Now().Add(time.Hour)
+	for i := 0; i < lifecycleBatchSize+1; i++ {
+		createTestAuction(t, service, models.Auction{StartTime: start, EndTime: start.Add(time.Hour)})
+	}
+
+	for pass, want := range []int{lifecycleBatchSize, 1, 0} {
+		activated, err := service.ActivateDueAuctions(ctx, start)
+		if err != nil || activated != want {
+			t.Fatalf("pass %d activated %d, want %d: %v", pass+1, activated, want, err)
+		}
+	}
+	active, total, err := repo.List(ctx, &models.AuctionFilter{Status: constants.AuctionStatusActive, Sort: models.SortEndingSoon, Limit: 100})
+	if err != nil || total != lifecycleBatchSize+1 || len(active) != lifecycleBatchSize+1 {
+		t.Errorf("%d active auctions, want %d: %v", total, lifecycleBatchSize+1, err)
+	}
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+)
+
+// NewSettlementNotifier returns an EventHandler that tells the seller, and the
+// winner if there is one, how an auction closed, and tells buyers when their
+// order is ready to pay
+func NewSettlementNotifier(notifier Notifier, logger *zap.Logger) EventHandler {
+	return func(ctx context.Context, event *models.AuctionEvent) error {
+		switch event.Type {
+		case models.EventDropClaimed:
+			return notifyDropClaim(ctx, notifier, event)
+		case models.EventOrderCreated:
+			return notifyOrderCreated(ctx, notifier, event)
+		case models.EventPaymentDefaulted, models.EventSecondChanceOffered, models.EventSecondChanceExhausted:
+			return notifySecondChance(ctx, notifier, event)
+		}
+		if event.Type != models.EventAuctionSettled {
+			return nil
+		}
+
+		var payload models.AuctionSettledPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to decode settlement payload: %w", err)
+		}
+
+		data := map[string]string{
+			"auction_id": event.AuctionID,
+			"outcome":    payload.Outcome,
+		}
+
+		if payload.Outcome == models.OutcomeSold {
+			// Drop auctions have no single winner; claimants were told as they claimed
+			if payload.WinnerID != "" {
+				if err := notifier.Notify(ctx, pushNotification(event, payload.WinnerID, "You won!",
+					fmt.Sprintf("You won the auction at %s", payload.HammerPrice), data)); err != nil {
+					return err
+				}
+			}
+			return notifier.Notify(ctx, pushNotification(event, payload.SellerID, "Auction sold",
+				fmt.Sprintf("Your auction sold for %s", payload.HammerPrice), data))
+		}
+
+		return notifier.Notify(ctx, pushNotification(event, payload.SellerID, "Auction ended",
+			"Your auction ended without meeting the reserve", data))
+	}
+}
+
+func notifyDropClaim(ctx context.Context, notifier Notifier, event *models.AuctionEvent) error {
+	var payload models.DropClaimedPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("failed to decode drop claim payload: %w", err)
+	}
+
+	data := map[string]string{
+		"auction_id": event.AuctionID,
+		"bid_id":     payload.BidID,
+	}
+	if err := notifier.Notify(ctx, pushNotification(event, payload.BidderID, "You got it!",
+		fmt.Sprintf("You claimed %d at %s each", payload.Quantity, payload.Price), data)); err != nil {
+		return err
+	}
+	return notifier.Notify(ctx, pushNotification(event, payload.SellerID, "Drop claimed",
+		fmt.Sprintf("%d claimed at %s, %d left", payload.Quantity, payload.Price, payload.QuantityRemaining), data))
+}
+
+func notifyOrderCreated(ctx context.Context, notifier Notifier, event *models.AuctionEvent) error {
+	var payload models.OrderCreatedPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("failed to decode order payload: %w", err)
+	}
+
+	data := map[string]string{
+		"auction_id": event.AuctionID,
+		"order_id":   payload.OrderID,
+	}
+	return notifier.Notify(ctx, pushNotification(event, payload.BuyerID, "Complete your purchase",
+		fmt.Sprintf("Pay %s by %s to secure your item", payload.Price.Times(payload.Quantity),
+			payload.PaymentDueAt.Format("Jan 2 15:04 MST")), data))
+}
+
+// notifySecondChance covers the non-payment flow: the defaulting buyer and the
+// seller hear about the default, runners-up about their offer, and the seller
+// when nobody is left to offer the item to
+func notifySecondChance(ctx context.Context, notifier Notifier, event *models.AuctionEvent) error {
+	data := map[string]string{"auction_id": event.AuctionID}
+
+	switch event.Type {
+	case models.EventPaymentDefaulted:
+		var payload models.PaymentDefaultedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to decode default payload: %w", err)
+		}
+		if err := notifier.Notify(ctx, pushNotification(event, payload.BidderID, "Payment deadline missed",
+			"Your order was cancelled and a non-payment strike was recorded", data)); err != nil {
+			return err
+		}
+		return notifier.Notify(ctx, pushNotification(event, payload.SellerID, "Buyer didn't pay",
+			"The winner missed the payment deadline; we'll offer your item to the next bidder if there is one", data))
+
+	case models.EventSecondChanceOffered:
+		var payload models.SecondChanceOfferedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to decode offer payload: %w", err)
+		}
+		data["offer_id"] = payload.OfferID
+		return notifier.Notify(ctx, pushNotification(event, payload.BidderID, "Second chance!",
+			fmt.Sprintf("The winner didn't pay. Buy it for your bid of %s before %s",
+				payload.Amount, payload.ExpiresAt.Format("Jan 2 15:04 MST")), data))
+
+	default:
+		var payload struct {
+			SellerID string `json:"seller_id"`
+		}
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to decode payload: %w", err)
+		}
+		return notifier.Notify(ctx, pushNotification(event, payload.SellerID, "No buyer found",
+			"None of the other bidders took up the second-chance offer", data))
+	}
+}
+
+// NewEndTimeBroadcaster returns an EventHandler that pushes soft-close
+// extensions to the Firebase auction document viewers' countdowns follow
+func NewEndTimeBroadcaster(notifier Notifier) EventHandler {
+	return func(ctx context.Context, event *models.AuctionEvent) error {
+		if event.Type != models.EventTimeExtended {
+			return nil
+		}
+
+		return notifier.Notify(ctx, &models.Notification{
+			DedupeKey: fmt.Sprintf("event:%d:%s", event.EventID, models.NotificationAuctionUpdate),
+			Kind:      models.NotificationAuctionUpdate,
+			AuctionID: event.AuctionID,
+			Payload:   event.Payload,
+		})
+	}
+}
+
+// NewBidNotifier returns an EventHandler that announces each new bid through
+// the Firebase bid notification
+func NewBidNotifier(notifier Notifier) EventHandler {
+	return func(ctx context.Context, event *models.AuctionEvent) error {
+		if event.Type != models.EventBidPlaced {
+			return nil
+		}
+
+		var bid map[string]interface{}
+		if err := json.Unmarshal(event.Payload, &bid); err != nil {
+			return fmt.Errorf("failed to decode bid payload: %w", err)
+		}
+		bid["auction_id"] = event.AuctionID
+		payload, err := json.Marshal(bid)
+		if err != nil {
+			return err
+		}
+		return notifier.Notify(ctx, &models.Notification{
+			DedupeKey: fmt.Sprintf("event:%d:%s", event.EventID, models.NotificationBid),
+			Kind:      models.NotificationBid,
+			AuctionID: event.AuctionID,
+			Payload:   payload,
+		})
+	}
+}
+
+// NewWatcherAlerter returns an EventHandler that alerts watchers when an
+// auction starts or is about to end, and alerts a watching bidder who has
+// been outbid
+func NewWatcherAlerter(repo repository.AuctionRepo, notifier Notifier, logger *zap.Logger) EventHandler {
+	return func(ctx context.Context, event *models.AuctionEvent) error {
+		var title, body string
+		switch event.Type {
+		case models.EventAuctionStarted:
+			title, body = "Auction started", "An auction you're watching is now live"
+		case models.EventEndingSoon:
+			title, body = "Ending soon", "An auction you're watching is about to end"
+		case models.EventOutbid:
+			return notifyOutbid(ctx, repo, notifier, logger, event)
+		default:
+			return nil
+		}
+
+		watchers, err := repo.GetWatcherIDs(ctx, event.AuctionID)
+		if err != nil {
+			return err
+		}
+
+		// Alerts are deduplicated per event and watcher, so a failed event is
+		// safe to redeliver in full
+		data := map[string]string{"auction_id": event.AuctionID, "type": event.Type}
+		for _, userID := range watchers {
+			if err := notifier.Notify(ctx, pushNotification(event, userID, title, body, data)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func notifyOutbid(ctx context.Context, repo repository.AuctionRepo, notifier Notifier, logger *zap.Logger, event *models.AuctionEvent) error {
+	var payload struct {
+		BidderID     string       `json:"bidder_id"`
+		CurrentPrice models.Money `json:"current_price"`
+	}
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("failed to decode outbid payload: %w", err)
+	}
+
+	watching, err := repo.IsWatching(ctx, event.AuctionID, payload.BidderID)
+	if err != nil || !watching {
+		return err
+	}
+
+	return notifier.Notify(ctx, pushNotification(event, payload.BidderID, "You've been outbid",
+		fmt.Sprintf("The current bid is now %s", payload.CurrentPrice),
+		map[string]string{"auction_id": event.AuctionID, "type": event.Type}))
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+// recordingNotifier keeps the notifications it is given and fails for the
+// users in fail
+type recordingNotifier struct {
+	sent []*models.Notification
+	fail map[string]bool
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, notification *models.Notification) error {
+	if n.fail[notification.UserID] {
+		return errors.New("queue unavailable")
+	}
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func TestWatcherAlerterReturnsNotifyErrors(t *testing.T) {
+	_, repo := newTestService(t)
+	ctx := context.Background()
+	for _, userID := range []string{"alice", "bob"} {
+		if _, err := repo.AddWatch(ctx, "auction-1", userID); err != nil {
+			t.Fatalf("watch: %v", err)
+		}
+	}
+	event := &models.AuctionEvent{EventID: 1, AuctionID: "auction-1", Type: models.EventEndingSoon}
+
+	notifier := &recordingNotifier{}
+	if err := NewWatcherAlerter(repo, notifier, zap.NewNop())(ctx, event); err != nil {
+		t.Fatalf("alert: %v", err)
+	}
+	if len(notifier.sent) != 2 {
+		t.Errorf("%d watchers alerted, want 2", len(notifier.sent))
+	}
+
+	// The relay retries the event, so a failed alert must not be swallowed
+	notifier = &recordingNotifier{fail: map[string]bool{"alice": true, "bob": true}}
+	if err := NewWatcherAlerter(repo, notifier, zap.NewNop())(ctx, event); err == nil {
+		t.Error("alert succeeded although no watcher could be notified")
+	}
+}
+
+func TestSettlementNotifierQuotesHammerPrice(t *testing.T) {
+	ctx := context.Background()
+	// In a sealed second-price auction the winner pays less than they bid
+	payload, err := json.Marshal(models.AuctionSettledPayload{
+		SellerID:     "seller-1",
+		Outcome:      models.OutcomeSold,
+		WinnerID:     "alice",
+		WinningBidID: "bid-1",
+		HammerPrice:  1500,
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	event := &models.AuctionEvent{EventID: 1, AuctionID: "auction-1", Type: models.EventAuctionSettled, Payload: payload}
+
+	notifier := &recordingNotifier{}
+	if err := NewSettlementNotifier(notifier, zap.NewNop())(ctx, event); err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+	if len(notifier.sent) != 2 {
+		t.Fatalf("%d notifications sent, want 2", len(notifier.sent))
+	}
+	if winner := notifier.sent[0]; winner.UserID != "alice" || winner.Body != "You won the auction at 15.00" {
+		t.Errorf("winner told %q", winner.Body)
+	}
+	if seller := notifier.sent[1]; seller.UserID != "seller-1" || seller.Body != "Your auction sold for 15.00" {
+		t.Errorf("seller told %q", seller.Body)
+	}
+}
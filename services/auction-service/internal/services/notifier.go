@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/config"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/notify"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+const (
+	// notificationListLimit caps the notifications returned to moderators
+	notificationListLimit = 100
+	// notificationLease is how long a claimed notification is left to its
+	// replica before another may deliver it
+	notificationLease = 2 * time.Minute
+)
+
+// Notifier queues notifications for delivery
+type Notifier interface {
+	Notify(ctx context.Context, notification *models.Notification) error
+}
+
+// NotificationQueue is the Notifier backed by the notifications table.
+// Queued notifications are delivered through a notify.Driver by Deliver,
+// which the scheduler runs, and retried with backoff when the driver fails.
+type NotificationQueue struct {
+	repo        repository.AuctionRepo
+	driver      notify.Driver
+	logger      *zap.Logger
+	maxAttempts int
+}
+
+func NewNotificationQueue(repo repository.AuctionRepo, driver notify.Driver, logger *zap.Logger, cfg *config.Config) *NotificationQueue {
+	return &NotificationQueue{
+		repo:        repo,
+		driver:      driver,
+		logger:      logger,
+		maxAttempts: cfg.NotificationAttempts,
+	}
+}
+
+// Notify queues a notification to be delivered on the next pass. A
+// notification with the same dedupe key as one already queued is dropped.
+func (q *NotificationQueue) Notify(ctx context.Context, notification *models.Notification) error {
+	now := time.Now()
+	notification.Status = models.NotificationPending
+	notification.NextAttemptAt = now
+	notification.CreatedAt = now
+
+	_, err := q.repo.EnqueueNotification(ctx, notification)
+	return err
+}
+
+// Deliver sends the notifications that are due. Like order hand-offs it
+// stops at the first failure, since the driver is most likely down, and
+// the failed notification is retried with backoff on a later pass.
+func (q *NotificationQueue) Deliver(ctx context.Context, now time.Time) (int, error) {
+	delivered := 0
+	for delivered < eventRelayBatchSize {
+		ok, err := q.deliverNext(ctx, now)
+		if err != nil {
+			return delivered, err
+		}
+		if !ok {
+			break
+		}
+		delivered++
+	}
+	return delivered, nil
+}
+
+// deliverNext claims a notification, which commits on its own, then calls the
+// driver outside any transaction and records the result
+func (q *NotificationQueue) deliverNext(ctx context.Context, now time.Time) (bool, error) {
+	notification, err := q.repo.ClaimNextNotification(ctx, now, now.Add(notificationLease))
+	if err != nil || notification == nil {
+		return false, err
+	}
+
+	if deliverErr := q.driver.Deliver(ctx, notification); deliverErr != nil {
+		if notification.Attempts+1 >= q.maxAttempts {
+			if err := q.repo.FailNotification(ctx, notification.NotificationID, deliverErr.Error(), now); err != nil {
+				return false, err
+			}
+			q.logger.Error("Giving up on notification",
+				zap.Int64("notification_id", notification.NotificationID),
+				zap.String("kind", notification.Kind),
+				zap.String("user_id", notification.UserID),
+				zap.Int("attempts", notification.Attempts+1),
+				zap.Error(deliverErr))
+		} else if err := q.repo.RetryNotification(ctx, notification.NotificationID, now.Add(orderRetryDelay(notification.Attempts)), deliverErr.Error(), now); err != nil {
+			return false, err
+		}
+		return false, deliverErr
+	}
+
+	if err := q.repo.CompleteNotification(ctx, notification.NotificationID, q.driver.Name(), now); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// pushNotification builds the push notification an event sends to userID.
+// Its dedupe key ties it to the event, so relaying the event again does
+// not notify the user twice.
+func pushNotification(event *models.AuctionEvent, userID, title, body string, data map[string]string) *models.Notification {
+	return &models.Notification{
+		DedupeKey: fmt.Sprintf("event:%d:%s:%s", event.EventID, models.NotificationPush, userID),
+		Kind:      models.NotificationPush,
+		UserID:    userID,
+		AuctionID: event.AuctionID,
+		Title:     title,
+		Body:      body,
+		Data:      data,
+	}
+}
+
+// GetNotifications lists notifications by delivery status for moderators,
+// newest first. Status defaults to failed.
+func (s *AuctionService) GetNotifications(ctx context.Context, status, userID string) ([]*models.Notification, error) {
+	if status == "" {
+		status = models.NotificationFailed
+	}
+	switch status {
+	case models.NotificationPending, models.NotificationSending, models.NotificationSent, models.NotificationFailed:
+	default:
+		return nil, shared_errors.ValidationError("INVALID_STATUS", "Status must be one of pending, sending, sent, failed")
+	}
+
+	notifications, err := s.repo.GetNotifications(ctx, status, userID, notificationListLimit)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	return notifications, nil
+}
+
+// RetryNotification puts a notification that ran out of attempts back in
+// the queue, for example once the driver's outage is over
+func (s *AuctionService) RetryNotification(ctx context.Context, notificationID int64) error {
+	requeued, err := s.repo.RequeueNotification(ctx, notificationID, time.Now())
+	if err != nil {
+		return shared_errors.ErrInternalServer
+	}
+	if !requeued {
+		return shared_errors.ConflictError("NOTIFICATION_NOT_FAILED", "Only failed notifications can be retried")
+	}
+	return nil
+}
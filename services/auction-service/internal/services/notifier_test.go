@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+)
+
+// leaseCheckingDriver records deliveries and the claimed notifications it
+// could see while delivering
+type leaseCheckingDriver struct {
+	repo      repository.AuctionRepo
+	delivered []int64
+	sending   []int
+}
+
+func (d *leaseCheckingDriver) Name() string { return "test" }
+
+func (d *leaseCheckingDriver) Deliver(ctx context.Context, notification *models.Notification) error {
+	sending, err := d.repo.GetNotifications(ctx, models.NotificationSending, "", 10)
+	if err != nil {
+		return err
+	}
+	d.sending = append(d.sending, len(sending))
+	d.delivered = append(d.delivered, notification.NotificationID)
+	return nil
+}
+
+func TestNotificationQueueLeasesClaims(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	driver := &leaseCheckingDriver{repo: repo}
+	queue := NewNotificationQueue(repo, driver, zap.NewNop(), service.config)
+
+	notify := func(key string) {
+		t.Helper()
+		if err := queue.Notify(ctx, &models.Notification{DedupeKey: key, Kind: models.NotificationPush, UserID: "alice", Title: key}); err != nil {
+			t.Fatalf("notify: %v", err)
+		}
+	}
+
+	// The claim is recorded before the driver is called
+	notify("first")
+	now := time.Now().Add(time.Second)
+	if delivered, err := queue.Deliver(ctx, now); err != nil || delivered != 1 {
+		t.Fatalf("delivered %d: %v", delivered, err)
+	}
+	if len(driver.sending) != 1 || driver.sending[0] != 1 {
+		t.Errorf("driver saw %v claimed notifications, want 1", driver.sending)
+	}
+
+	// A notification claimed by a replica that died waits out its lease
+	notify("second")
+	claimed, err := repo.ClaimNextNotification(ctx, now, now.Add(notificationLease))
+	if err != nil || claimed == nil {
+		t.Fatalf("claim: %v, %v", claimed, err)
+	}
+	if delivered, err := queue.Deliver(ctx, now); err != nil || delivered != 0 {
+		t.Fatalf("delivered %d while leased: %v", delivered, err)
+	}
+	if delivered, err := queue.Deliver(ctx, now.Add(notificationLease)); err != nil || delivered != 1 {
+		t.Fatalf("delivered %d after the lease ran out: %v", delivered, err)
+	}
+
+	sent, err := repo.GetNotifications(ctx, models.NotificationSent, "", 10)
+	if err != nil || len(sent) != 2 {
+		t.Fatalf("%d notifications sent, want 2: %v", len(sent), err)
+	}
+
+	// The dead replica's late result no longer applies
+	if err := repo.RetryNotification(ctx, claimed.NotificationID, now, "timeout", now); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if pending, _ := repo.GetNotifications(ctx, models.NotificationPending, "", 10); len(pending) != 0 {
+		t.Errorf("late retry put %d notifications back in the queue", len(pending))
+	}
+}
+
+// flakyDriver fails every delivery until it is brought back up
+type flakyDriver struct {
+	up bool
+}
+
+func (d *flakyDriver) Name() string { return "flaky" }
+
+func (d *flakyDriver) Deliver(ctx context.Context, notification *models.Notification) error {
+	if !d.up {
+		return errors.New("push gateway unavailable")
+	}
+	return nil
+}
+
+func TestNotificationQueueDropsDuplicates(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	queue := NewNotificationQueue(repo, &flakyDriver{}, zap.NewNop(), service.config)
+
+	for i := 0; i < 2; i++ {
+		if err := queue.Notify(ctx, &models.Notification{DedupeKey: "event:1:push:alice", Kind: models.NotificationPush, UserID: "alice"}); err != nil {
+			t.Fatalf("notify: %v", err)
+		}
+	}
+	if pending, err := repo.GetNotifications(ctx, models.NotificationPending, "alice", 10); err != nil || len(pending) != 1 {
+		t.Errorf("%d notifications queued, want 1: %v", len(pending), err)
+	}
+}
+
+func TestNotificationQueueRetriesThenFails(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	service.config.NotificationAttempts = 3
+	driver := &flakyDriver{}
+	queue := NewNotificationQueue(repo, driver, zap.NewNop(), service.config)
+	if err := queue.Notify(ctx, &models.Notification{DedupeKey: "outbid", Kind: models.NotificationPush, UserID: "alice"}); err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+
+	// Failures back off like order hand-offs until the attempts run out
+	now := time.Now().Add(time.Second)
+	for attempt, at := range []time.Time{now, now.Add(orderRetryDelay(0)), now.Add(orderRetryDelay(0) + orderRetryDelay(1))} {
+		if attempt > 0 {
+			if delivered, err := queue.Deliver(ctx, at.Add(-time.Second)); err != nil || delivered != 0 {
+				t.Fatalf("attempt %d ran early: %d, %v", attempt+1, delivered, err)
+			}
+		}
+		if delivered, err := queue.Deliver(ctx, at); err == nil || delivered != 0 {
+			t.Fatalf("attempt %d delivered %d: %v", attempt+1, delivered, err)
+		}
+	}
+
+	if _, err := service.GetNotifications(ctx, "lost", ""); errorCode(err) != "INVALID_STATUS" {
+		t.Errorf("unknown status: %v, want INVALID_STATUS", err)
+	}
+	failed, err := service.GetNotifications(ctx, "", "alice")
+	if err != nil || len(failed) != 1 {
+		t.Fatalf("failed notifications %+v: %v", failed, err)
+	}
+	if failed[0].Attempts != 3 || failed[0].LastError != "push gateway unavailable" {
+		t.Errorf("failed notification %+v", failed[0])
+	}
+
+	// A moderator requeues it once the driver is back
+	if err := service.RetryNotification(ctx, failed[0].NotificationID); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if err := service.RetryNotification(ctx, failed[0].NotificationID); errorCode(err) != "NOTIFICATION_NOT_FAILED" {
+		t.Errorf("retrying a queued notification: %v, want NOTIFICATION_NOT_FAILED", err)
+	}
+	driver.up = true
+	if delivered, err := queue.Deliver(ctx, time.Now().Add(time.Second)); err != nil || delivered != 1 {
+		t.Fatalf("delivered %d after requeue: %v", delivered, err)
+	}
+	sent, err := service.GetNotifications(ctx, models.NotificationSent, "alice")
+	if err != nil || len(sent) != 1 || sent[0].Driver != "flaky" || sent[0].SentAt == nil {
+		t.Errorf("sent notifications %+v: %v", sent, err)
+	}
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/pkg/orders"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+const (
+	// Failed hand-offs back off from orderRetryBase, doubling up to orderRetryMax
+	orderRetryBase = 30 * time.Second
+	orderRetryMax  = time.Hour
+	// orderHandoffLease is how long a claimed hand-off is left to its replica
+	// before another may send it. order-service creates one order per bid
+	// however often it is asked.
+	orderHandoffLease = 2 * time.Minute
+)
+
+// queueOrderHandoff records a sale so the scheduler turns it into a pending
+// order. The payment deadline starts at the moment of sale.
+func (s *AuctionService) queueOrderHandoff(ctx context.Context, txRepo repository.AuctionRepo, auction *models.Auction, bidID, buyerID string, quantity int, price models.Money, now time.Time) error {
+	dueAt := now.Add(s.config.PaymentWindow)
+	if err := txRepo.CreateOrderHandoff(ctx, &models.OrderHandoff{
+		BidID:        bidID,
+		AuctionID:    auction.AuctionID,
+		BuyerID:      buyerID,
+		Quantity:     quantity,
+		Price:        price,
+		PaymentDueAt: dueAt,
+		CreatedAt:    now,
+	}); err != nil {
+		return err
+	}
+	if bidID == auction.WinningBidID {
+		auction.PaymentDueAt = &dueAt
+	}
+	return nil
+}
+
+// HandOffOrders sends queued sales to order-service. It stops at the first
+// failure, which is usually order-service being unavailable; the failed sale
+// is retried with backoff on a later tick.
+func (s *AuctionService) HandOffOrders(ctx context.Context, now time.Time) (int, error) {
+	handed := 0
+	for handed < lifecycleBatchSize {
+		ok, err := s.handOffNext(ctx, now)
+		if err != nil {
+			return handed, err
+		}
+		if !ok {
+			break
+		}
+		handed++
+	}
+	return handed, nil
+}
+
+// handOffNext leases a hand-off, which commits on its own, then calls
+// order-service outside any transaction and records the order in a short one
+func (s *AuctionService) handOffNext(ctx context.Context, now time.Time) (bool, error) {
+	handoff, err := s.repo.ClaimNextOrderHandoff(ctx, now, now.Add(orderHandoffLease))
+	if err != nil || handoff == nil {
+		return false, err
+	}
+	auction, err := s.repo.GetByID(ctx, handoff.AuctionID)
+	if err != nil {
+		return false, err
+	}
+
+	order, orderErr := s.orders.CreateAuctionOrder(ctx, &orders.AuctionOrderRequest{
+		AuctionID:    handoff.AuctionID,
+		BidID:        handoff.BidID,
+		UserID:       handoff.BuyerID,
+		ProductID:    auction.ProductID,
+		ProductName:  auction.Title,
+		Quantity:     handoff.Quantity,
+		Price:        int64(handoff.Price),
+		Currency:     auction.Currency,
+		PaymentDueAt: handoff.PaymentDueAt,
+	})
+	if orderErr != nil {
+		if err := s.repo.RetryOrderHandoff(ctx, handoff.BidID, now.Add(orderRetryDelay(handoff.Attempts)), orderErr.Error()); err != nil {
+			return false, err
+		}
+		return false, orderErr
+	}
+
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	// A replica whose lease ran out may have recorded the same order already
+	handoff.OrderID = order.ID
+	completed, err := txRepo.CompleteOrderHandoff(ctx, handoff)
+	if err != nil {
+		return false, err
+	}
+	if !completed {
+		return true, nil
+	}
+	if err := recordEvent(ctx, txRepo, handoff.AuctionID, models.EventOrderCreated, models.OrderCreatedPayload{
+		OrderID:      order.ID,
+		BidID:        handoff.BidID,
+		BuyerID:      handoff.BuyerID,
+		Quantity:     handoff.Quantity,
+		Price:        handoff.Price,
+		PaymentDueAt: handoff.PaymentDueAt,
+	}); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	s.logger.Info("Auction order created",
+		zap.String("auction_id", handoff.AuctionID),
+		zap.String("bid_id", handoff.BidID),
+		zap.String("order_id", order.ID))
+	return true, nil
+}
+
+// orderRetryDelay is the backoff after the given number of failed attempts
+func orderRetryDelay(attempts int) time.Duration {
+	delay := orderRetryBase
+	for i := 0; i < attempts && delay < orderRetryMax; i++ {
+		delay *= 2
+	}
+	if delay > orderRetryMax {
+		delay = orderRetryMax
+	}
+	return delay
+}
+
+// GetAuctionOrders shows a seller each sale from their auction and how far
+// its order has got. Sales order-service has not accepted yet are listed as
+// awaiting_order.
+func (s *AuctionService) GetAuctionOrders(ctx context.Context, auctionID, sellerID string) ([]models.AuctionOrder, error) {
+	auction, err := s.repo.GetByID(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrNotFound
+	}
+	if auction.SellerID != sellerID {
+		return nil, shared_errors.ErrForbidden
+	}
+
+	handoffs, err := s.repo.GetOrderHandoffs(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	byBid := make(map[string]orders.Order)
+	if len(handoffs) > 0 {
+		found, err := s.orders.GetAuctionOrders(ctx, auctionID)
+		if err != nil {
+			s.logger.Error("Failed to fetch auction orders", zap.String("auction_id", auctionID), zap.Error(err))
+			return nil, shared_errors.ErrInternalServer
+		}
+		for _, order := range found {
+			if order.BidID != nil {
+				byBid[*order.BidID] = order
+			}
+		}
+	}
+
+	sales := make([]models.AuctionOrder, 0, len(handoffs))
+	for _, handoff := range handoffs {
+		sale := models.AuctionOrder{
+			OrderID:      handoff.OrderID,
+			BidID:        handoff.BidID,
+			BuyerID:      handoff.BuyerID,
+			Quantity:     handoff.Quantity,
+			Price:        handoff.Price,
+			PaymentDueAt: handoff.PaymentDueAt,
+			Status:       models.AwaitingOrder,
+		}
+		if order, ok := byBid[handoff.BidID]; ok {
+			sale.OrderID = order.ID
+			sale.Status = order.Status
+			sale.PaymentStatus = order.PaymentStatus
+		}
+		sales = append(sales, sale)
+	}
+	return sales, nil
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/config"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/pkg/orders"
+)
+
+func TestHandOffOrdersLeasesClaims(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryRepo()
+	var now time.Time
+	var claimedDuringCall []bool
+	orderService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The lease is committed before order-service is called
+		other, err := repo.ClaimNextOrderHandoff(ctx, now, now.Add(orderHandoffLease))
+		claimedDuringCall = append(claimedDuringCall, err == nil && other != nil)
+		fmt.Fprint(w, `{"success":true,"data":{"id":"order-1","status":"pending"}}`)
+	}))
+	defer orderService.Close()
+
+	cfg, _ := config.Load()
+	cfg.OrderServiceURL = orderService.URL
+	service := NewAuctionService(repo, zap.NewNop(), cfg)
+	auction := createTestAuction(t, service, models.Auction{EndTime: time.Now().Add(time.Hour)})
+	placeTestBid(t, service, auction.AuctionID, "alice", 1000)
+	now = auction.EndTime.Add(time.Second)
+	if _, err := service.CloseDueAuctions(ctx, now); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	if handed, err := service.HandOffOrders(ctx, now); err != nil || handed != 1 {
+		t.Fatalf("handed off %d: %v", handed, err)
+	}
+	if len(claimedDuringCall) != 1 || claimedDuringCall[0] {
+		t.Errorf("hand-off claimable while order-service was called: %v", claimedDuringCall)
+	}
+	handoffs, err := repo.GetOrderHandoffs(ctx, auction.AuctionID)
+	if err != nil || len(handoffs) != 1 || handoffs[0].OrderID != "order-1" {
+		t.Fatalf("hand-offs %+v: %v", handoffs, err)
+	}
+
+	// A replica whose lease ran out records nothing a second time
+	if completed, err := repo.CompleteOrderHandoff(ctx, handoffs[0]); err != nil || completed {
+		t.Errorf("late completion applied: %v, %v", completed, err)
+	}
+}
+
+func TestHandOffOrdersBacksOffFailures(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryRepo()
+	available := false
+	var sent []orders.AuctionOrderRequest
+	orderService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"success":false,"error":{"code":"UNAVAILABLE","message":"down"}}`)
+			return
+		}
+		var req orders.AuctionOrderRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode order request: %v", err)
+		}
+		sent = append(sent, req)
+		fmt.Fprint(w, `{"success":true,"data":{"id":"order-1","status":"pending"}}`)
+	}))
+	defer orderService.Close()
+
+	cfg, _ := config.Load()
+	cfg.OrderServiceURL = orderService.URL
+	service := NewAuctionService(repo, zap.NewNop(), cfg)
+	auction := createTestAuction(t, service, models.Auction{EndTime: time.Now().Add(time.Hour)})
+	placeTestBid(t, service, auction.AuctionID, "alice", 1000)
+	now := auction.EndTime.Add(time.Second)
+	if _, err := service.CloseDueAuctions(ctx, now); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	if handed, err := service.HandOffOrders(ctx, now); err == nil || handed != 0 {
+		t.Fatalf("handed off %d with order-service down: %v", handed, err)
+	}
+	handoffs, err := repo.GetOrderHandoffs(ctx, auction.AuctionID)
+	if err != nil || len(handoffs) != 1 {
+		t.Fatalf("hand-offs %+v: %v", handoffs, err)
+	}
+	failed := handoffs[0]
+	if failed.Attempts != 1 || failed.OrderID != "" || failed.LastError == "" {
+		t.Errorf("failed hand-off %+v", failed)
+	}
+	if want := now.Add(orderRetryBase); !failed.NextAttemptAt.Equal(want) {
+		t.Errorf("next attempt at %v, want %v", failed.NextAttemptAt, want)
+	}
+
+	// Nothing is sent again until the backoff has passed
+	available = true
+	if handed, err := service.HandOffOrders(ctx, now.Add(orderRetryBase-time.Second)); err != nil || handed != 0 {
+		t.Fatalf("handed off %d during backoff: %v", handed, err)
+	}
+	if handed, err := service.HandOffOrders(ctx, now.Add(orderRetryBase)); err != nil || handed != 1 {
+		t.Fatalf("handed off %d after backoff: %v", handed, err)
+	}
+	if len(sent) != 1 {
+		t.Fatalf("sent %d order requests", len(sent))
+	}
+	req := sent[0]
+	if req.AuctionID != auction.AuctionID || req.BidID != failed.BidID || req.UserID != "alice" ||
+		req.Quantity != 1 || req.Price != 1000 || req.Currency != auction.Currency ||
+		!req.PaymentDueAt.Equal(failed.PaymentDueAt) {
+		t.Errorf("order request %+v", req)
+	}
+
+	handoffs, err = repo.GetOrderHandoffs(ctx, auction.AuctionID)
+	if err != nil || len(handoffs) != 1 || handoffs[0].OrderID != "order-1" {
+		t.Fatalf("hand-offs %+v: %v", handoffs, err)
+	}
+	types := eventTypes(t, repo, auction.AuctionID)
+	if types[len(types)-1] != models.EventOrderCreated {
+		t.Errorf("events %v, want %s last", types, models.EventOrderCreated)
+	}
+}
+
+func TestOrderRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{6, 32 * time.Minute},
+		{7, time.Hour},
+		{100, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := orderRetryDelay(tt.attempts); got != tt.want {
+			t.Errorf("orderRetryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestGetAuctionOrders(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryRepo()
+	orderService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			// Only sales already handed off have an order
+			handoffs, _ := repo.GetOrderHandoffs(ctx, strings.Split(r.URL.Path, "/")[4])
+			if len(handoffs) == 0 || handoffs[0].OrderID == "" {
+				fmt.Fprint(w, `{"success":true,"data":[]}`)
+				return
+			}
+			fmt.Fprintf(w, `{"success":true,"data":[{"id":"order-1","bid_id":%q,"status":"confirmed","payment_status":"paid"}]}`, handoffs[0].BidID)
+			return
+		}
+		fmt.Fprint(w, `{"success":true,"data":{"id":"order-1","status":"pending"}}`)
+	}))
+	defer orderService.Close()
+
+	cfg, _ := config.Load()
+	cfg.OrderServiceURL = orderService.URL
+	service := NewAuctionService(repo, zap.NewNop(), cfg)
+	auction := createTestAuction(t, service, models.Auction{EndTime: time.Now().Add(time.Hour)})
+	placeTestBid(t, service, auction.AuctionID, "alice", 1000)
+	now := auction.EndTime.Add(time.Second)
+	if _, err := service.CloseDueAuctions(ctx, now); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	if _, err := service.GetAuctionOrders(ctx, auction.AuctionID, "someone-else"); errorCode(err) != "FORBIDDEN" {
+		t.Errorf("non-seller got %v, want FORBIDDEN", err)
+	}
+
+	// order-service knows nothing of the sale until it is handed off
+	sales, err := service.GetAuctionOrders(ctx, auction.AuctionID, auction.SellerID)
+	if err != nil || len(sales) != 1 {
+		t.Fatalf("sales %+v: %v", sales, err)
+	}
+	if sales[0].Status != models.AwaitingOrder || sales[0].BuyerID != "alice" || sales[0].Price != 1000 {
+		t.Errorf("sale before hand-off %+v", sales[0])
+	}
+
+	if _, err := service.HandOffOrders(ctx, now); err != nil {
+		t.Fatalf("hand off: %v", err)
+	}
+	sales, err = service.GetAuctionOrders(ctx, auction.AuctionID, auction.SellerID)
+	if err != nil || len(sales) != 1 {
+		t.Fatalf("sales %+v: %v", sales, err)
+	}
+	if sales[0].OrderID != "order-1" || sales[0].Status != "confirmed" || sales[0].PaymentStatus != "paid" {
+		t.Errorf("sale after hand-off %+v", sales[0])
+	}
+}
+
+func TestProcessLapsedPaymentsLeasesClaims(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryRepo()
+	var now time.Time
+	var claimedDuringCall []bool
+	orderService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/expire") {
+			// The lease is committed before order-service is called
+			other, err := repo.ClaimNextLapsedPayment(ctx, now, now.Add(orderHandoffLease))
+			claimedDuringCall = append(claimedDuringCall, err == nil && other != nil)
+			fmt.Fprint(w, `{"success":true,"data":{"id":"order-1","status":"expired","payment_status":"pending"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"success":true,"data":{"id":"order-1","status":"pending"}}`)
+	}))
+	defer orderService.Close()
+
+	cfg, _ := config.Load()
+	cfg.OrderServiceURL = orderService.URL
+	service := NewAuctionService(repo, zap.NewNop(), cfg)
+	auction := createTestAuction(t, service, models.Auction{EndTime: time.Now().Add(time.Hour)})
+	placeTestBid(t, service, auction.AuctionID, "alice", 1000)
+	now = auction.EndTime.Add(time.Second)
+	if _, err := service.CloseDueAuctions(ctx, now); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if _, err := service.HandOffOrders(ctx, now); err != nil {
+		t.Fatalf("hand off: %v", err)
+	}
+
+	now = now.Add(cfg.PaymentWindow + time.Second)
+	if processed, err := service.ProcessLapsedPayments(ctx, now); err != nil || processed != 1 {
+		t.Fatalf("processed %d: %v", processed, err)
+	}
+	if len(claimedDuringCall) != 1 || claimedDuringCall[0] {
+		t.Errorf("lapsed payment claimable while order-service was called: %v", claimedDuringCall)
+	}
+	if strikes, err := repo.CountStrikes(ctx, "alice"); err != nil || strikes != 1 {
+		t.Errorf("strikes %d: %v", strikes, err)
+	}
+
+	// A replica whose lease ran out cannot resolve the hand-off a second time
+	handoffs, err := repo.GetOrderHandoffs(ctx, auction.AuctionID)
+	if err != nil || len(handoffs) != 1 {
+		t.Fatalf("hand-offs %+v: %v", handoffs, err)
+	}
+	if resolved, err := repo.ResolveOrderHandoff(ctx, handoffs[0].BidID, models.HandoffPaid, now); err != nil || resolved {
+		t.Errorf("late resolution applied: %v, %v", resolved, err)
+	}
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// SetProxyBid records a bidder's hidden maximum and lets the proxy engine bid
+// for them straight away. Maximums can be raised but not lowered.
+func (s *AuctionService) SetProxyBid(ctx context.Context, auctionID, bidderID string, maxAmount models.Money) (*models.ProxyBidResponse, error) {
+	// The whole maximum is at stake, so it is what eligibility is judged on
+	if err := s.checkVerified(ctx, bidderID, maxAmount); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	auction, err := txRepo.GetByIDForUpdate(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrNotFound
+	}
+	if auction.Type == models.AuctionTypeDrop {
+		return nil, dropBidError()
+	}
+	if auction.Type == models.AuctionTypeSealed {
+		return nil, shared_errors.ConflictError("SEALED_AUCTION", "Sealed-bid auctions take a single bid per bidder, not a maximum")
+	}
+	if err := checkBiddable(auction, bidderID); err != nil {
+		return nil, err
+	}
+	if err := s.checkEligibility(ctx, txRepo, auction, bidderID, maxAmount); err != nil {
+		return nil, err
+	}
+
+	highest, err := txRepo.GetHighestBid(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	existing, err := txRepo.GetProxyBid(ctx, auctionID, bidderID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	isLeader := highest != nil && highest.BidderID == bidderID
+	minimum := s.nextValidBid(auction, highest != nil)
+	if isLeader && maxAmount <= auction.CurrentPrice {
+		return nil, shared_errors.ValidationError("MAX_BID_TOO_LOW", "Maximum must be above your current leading bid").
+			WithDetails(map[string]interface{}{"current_price": auction.CurrentPrice})
+	}
+	if !isLeader && maxAmount < minimum {
+		return nil, bidTooLowError(auction, minimum)
+	}
+	if existing != nil && maxAmount < existing.MaxAmount {
+		return nil, shared_errors.ConflictError("MAX_BID_DECREASE", "A maximum bid cannot be lowered")
+	}
+
+	now := time.Now()
+	proxy := &models.ProxyBid{
+		AuctionID: auctionID,
+		BidderID:  bidderID,
+		MaxAmount: maxAmount,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if existing != nil {
+		proxy.CreatedAt = existing.CreatedAt
+	}
+	if err := txRepo.UpsertProxyBid(ctx, proxy); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	leaderID, leadingBidID := "", ""
+	if highest != nil {
+		leaderID, leadingBidID = highest.BidderID, highest.BidID
+	}
+	placedBid := false
+	if !isLeader {
+		// Open at the minimum; the engine raises it only as far as needed
+		opening := &models.Bid{BidderID: bidderID, Amount: minimum, IsProxy: true}
+		if err := s.acceptBid(ctx, txRepo, auction, opening, now); err != nil {
+			return nil, shared_errors.ErrInternalServer
+		}
+		leaderID, leadingBidID = bidderID, opening.BidID
+		placedBid = true
+	}
+
+	leaderID, _, err = s.resolveProxyBids(ctx, txRepo, auction, leaderID, leadingBidID, now)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	if placedBid {
+		if err := s.extendForLateBid(ctx, txRepo, auction, now); err != nil {
+			return nil, shared_errors.ErrInternalServer
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	s.signalEvents()
+
+	return &models.ProxyBidResponse{
+		ProxyBid:     *proxy,
+		CurrentPrice: auction.CurrentPrice,
+		IsLeading:    leaderID == bidderID,
+	}, nil
+}
+
+// GetProxyBid returns the caller's maximum on an auction
+func (s *AuctionService) GetProxyBid(ctx context.Context, auctionID, bidderID string) (*models.ProxyBid, error) {
+	proxy, err := s.repo.GetProxyBid(ctx, auctionID, bidderID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if proxy == nil {
+		return nil, shared_errors.ErrNotFound
+	}
+	return proxy, nil
+}
+
+// resolveProxyBids lets stored maximums compete against the current leader
+// eBay-style: whoever has the higher maximum leads, paying one increment over
+// the runner-up's maximum (capped at their own). Equal maximums go to the one
+// set first. Generated bids are recorded through acceptBid in the caller's
+// transaction. It returns the final leader and their leading bid ID.
+func (s *AuctionService) resolveProxyBids(ctx context.Context, txRepo repository.AuctionRepo, auction *models.Auction, leaderID, leadingBidID string, at time.Time) (string, string, error) {
+	proxies, err := txRepo.GetProxyBids(ctx, auction.AuctionID)
+	if err != nil || len(proxies) == 0 {
+		return leaderID, leadingBidID, err
+	}
+
+	byBidder := make(map[string]*models.ProxyBid, len(proxies))
+	for _, proxy := range proxies {
+		byBidder[proxy.BidderID] = proxy
+	}
+
+	// Space generated bids a microsecond apart so bid_time keeps their order
+	seq := 0
+	place := func(bidderID string, amount models.Money) error {
+		seq++
+		bid := &models.Bid{BidderID: bidderID, Amount: amount, IsProxy: true}
+		if err := s.acceptBid(ctx, txRepo, auction, bid, at.Add(time.Duration(seq)*time.Microsecond)); err != nil {
+			return err
+		}
+		leaderID, leadingBidID = bidderID, bid.BidID
+		return nil
+	}
+
+	// Every round either exhausts a challenger or hands them the lead at a
+	// higher price, so the loop is bounded by the number of proxies
+	for round := 0; round <= 2*len(proxies); round++ {
+		next := auction.CurrentPrice + s.minimumIncrement(auction, auction.CurrentPrice)
+
+		var challenger *models.ProxyBid
+		for _, proxy := range proxies {
+			if proxy.BidderID != leaderID && proxy.MaxAmount >= next {
+				challenger = proxy
+				break
+			}
+		}
+		if challenger == nil {
+			break
+		}
+
+		var leaderMax models.Money
+		leaderProxy := byBidder[leaderID]
+		if leaderProxy != nil {
+			leaderMax = leaderProxy.MaxAmount
+		}
+
+		switch {
+		case leaderMax > challenger.MaxAmount:
+			// Leader holds: the challenger goes all in and the leader answers
+			if err := place(challenger.BidderID, challenger.MaxAmount); err != nil {
+				return "", "", err
+			}
+			answer := min(leaderMax, challenger.MaxAmount+s.minimumIncrement(auction, challenger.MaxAmount))
+			if err := place(leaderProxy.BidderID, answer); err != nil {
+				return "", "", err
+			}
+		case leaderMax == challenger.MaxAmount && leaderProxy.UpdatedAt.Before(challenger.UpdatedAt):
+			// Tie on maximums: the earlier one holds at that amount
+			if err := place(leaderProxy.BidderID, leaderMax); err != nil {
+				return "", "", err
+			}
+		case leaderMax == challenger.MaxAmount:
+			if err := place(challenger.BidderID, challenger.MaxAmount); err != nil {
+				return "", "", err
+			}
+		default:
+			// Challenger takes over: the leader's maximum is spent first
+			base := auction.CurrentPrice
+			if leaderMax > base {
+				if err := place(leaderProxy.BidderID, leaderMax); err != nil {
+					return "", "", err
+				}
+				base = leaderMax
+			}
+			amount := min(challenger.MaxAmount, base+s.minimumIncrement(auction, base))
+			if err := place(challenger.BidderID, amount); err != nil {
+				return "", "", err
+			}
+		}
+	}
+
+	return leaderID, leadingBidID, nil
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+func TestProxyBiddingThreeWay(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{})
+
+	for _, proxy := range []struct {
+		bidderID string
+		max      models.Money
+	}{{"alice", 3000}, {"bob", 5000}, {"carol", 4000}} {
+		if _, err := service.SetProxyBid(ctx, auction.AuctionID, proxy.bidderID, proxy.max); err != nil {
+			t.Fatalf("%s setting maximum %s: %v", proxy.bidderID, proxy.max, err)
+		}
+	}
+
+	// Bob holds against both, one increment over the next highest maximum
+	winning, err := repo.GetWinningBid(ctx, auction.AuctionID)
+	if err != nil || winning.BidderID != "bob" || winning.Amount != 4100 || !winning.IsProxy {
+		t.Fatalf("winning bid %+v: %v", winning, err)
+	}
+
+	// Every generated bid is a proxy bid and none exceeds its bidder's maximum
+	bids, err := service.GetBids(ctx, auction.AuctionID)
+	if err != nil {
+		t.Fatalf("get bids: %v", err)
+	}
+	maximums := map[string]models.Money{"alice": 3000, "bob": 5000, "carol": 4000}
+	for _, bid := range bids {
+		if !bid.IsProxy || bid.Amount > maximums[bid.BidderID] {
+			t.Errorf("%s bid %s (proxy %v) over a maximum of %s", bid.BidderID, bid.Amount, bid.IsProxy, maximums[bid.BidderID])
+		}
+	}
+}
+
+func TestProxyAnswerFollowsLadder(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{})
+	if _, err := service.SetProxyBid(ctx, auction.AuctionID, "alice", 10000); err != nil {
+		t.Fatalf("set maximum: %v", err)
+	}
+
+	// The answer to 49.50 uses the rung for 49.50, not the one it lands in
+	placeTestBid(t, service, auction.AuctionID, "bob", 4950)
+	winning, err := repo.GetWinningBid(ctx, auction.AuctionID)
+	if err != nil || winning.BidderID != "alice" || winning.Amount != 5050 {
+		t.Fatalf("winning bid %+v: %v", winning, err)
+	}
+	// Above 50.00 the next rung applies
+	if err := service.PlaceBid(ctx, &models.Bid{AuctionID: auction.AuctionID, BidderID: "bob", Amount: 5100}); errorCode(err) != "BID_TOO_LOW" {
+		t.Errorf("bid under the 5.00 rung = %v, want BID_TOO_LOW", err)
+	}
+	placeTestBid(t, service, auction.AuctionID, "bob", 5550)
+	if winning, err = repo.GetWinningBid(ctx, auction.AuctionID); err != nil || winning.BidderID != "alice" || winning.Amount != 6050 {
+		t.Errorf("winning bid %+v: %v", winning, err)
+	}
+}
+
+func TestSetProxyBidRules(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{})
+	sealed := createTestAuction(t, service, models.Auction{Type: models.AuctionTypeSealed})
+	drop := createTestAuction(t, service, models.Auction{Type: models.AuctionTypeDrop, FloorPrice: 500, DropStep: 100, DropInterval: 60})
+	placeTestBid(t, service, auction.AuctionID, "alice", 2000)
+
+	tests := []struct {
+		name      string
+		auctionID string
+		bidderID  string
+		max       models.Money
+		want      string
+	}{
+		{"non-leader below the next valid bid", auction.AuctionID, "bob", 2050, "BID_TOO_LOW"},
+		{"leader at the current price", auction.AuctionID, "alice", 2000, "MAX_BID_TOO_LOW"},
+		{"seller", auction.AuctionID, "seller-1", 5000, "SELF_BID"},
+		{"sealed auction", sealed.AuctionID, "bob", 5000, "SEALED_AUCTION"},
+		{"drop auction", drop.AuctionID, "bob", 5000, "DROP_AUCTION"},
+		{"unknown auction", "missing", "bob", 5000, "NOT_FOUND"},
+	}
+	for _, tt := range tests {
+		if _, err := service.SetProxyBid(ctx, tt.auctionID, tt.bidderID, tt.max); errorCode(err) != tt.want {
+			t.Errorf("%s: got %v, want %s", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestLeaderRaisesMaximumWithoutBidding(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{EndTime: time.Now().Add(time.Hour)})
+	placeTestBid(t, service, auction.AuctionID, "alice", 2000)
+
+	resp, err := service.SetProxyBid(ctx, auction.AuctionID, "alice", 8000)
+	if err != nil {
+		t.Fatalf("set maximum: %v", err)
+	}
+	if !resp.IsLeading || resp.CurrentPrice != 2000 || resp.ProxyBid.MaxAmount != 8000 {
+		t.Errorf("response %+v, want alice leading at 20.00 with a maximum of 80.00", resp)
+	}
+	if count, err := repo.CountBids(ctx, auction.AuctionID); err != nil || count != 1 {
+		t.Errorf("%d bids after raising the maximum, want 1: %v", count, err)
+	}
+
+	stored, err := service.GetProxyBid(ctx, auction.AuctionID, "alice")
+	if err != nil || stored.MaxAmount != 8000 {
+		t.Errorf("stored maximum %+v: %v", stored, err)
+	}
+	if _, err := service.GetProxyBid(ctx, auction.AuctionID, "bob"); errorCode(err) != "NOT_FOUND" {
+		t.Errorf("maximum for a bidder without one = %v, want NOT_FOUND", err)
+	}
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// RelistAuction puts an unsold auction up again with the same settings and
+// duration, starting at startTime or now. A drop auction that ended with
+// units left is relisted for the units it did not sell. Each auction can be
+// relisted once; relist the new auction if that one does not sell either.
+func (s *AuctionService) RelistAuction(ctx context.Context, auctionID, sellerID string, startTime time.Time) (*models.Auction, error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	// Locking the original serialises relist requests, so only one of them
+	// sees it without a relisting
+	original, err := txRepo.GetByIDForUpdate(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrNotFound
+	}
+	if original.SellerID != sellerID {
+		return nil, shared_errors.ErrForbidden
+	}
+	if err := s.checkRelistable(ctx, txRepo, original); err != nil {
+		return nil, err
+	}
+
+	if startTime.IsZero() {
+		startTime = time.Now()
+	}
+	auction := relistedAuction(original, startTime)
+	if err := s.createAuction(ctx, txRepo, auction); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	s.logger.Info("Auction relisted",
+		zap.String("auction_id", original.AuctionID),
+		zap.String("relisted_as", auction.AuctionID))
+	return auction, nil
+}
+
+// checkRelistable accepts an auction that ended without selling and has not
+// been relisted already
+func (s *AuctionService) checkRelistable(ctx context.Context, txRepo repository.AuctionRepo, auction *models.Auction) error {
+	if auction.Status != constants.AuctionStatusEnded {
+		return shared_errors.ConflictError("NOT_RELISTABLE", "Only auctions that have ended can be relisted")
+	}
+	if auction.Type == models.AuctionTypeDrop {
+		if auction.QuantityRemaining <= 0 {
+			return shared_errors.ConflictError("NOT_RELISTABLE", "Every unit of this drop has sold")
+		}
+	} else {
+		if auction.WinnerID != "" {
+			return shared_errors.ConflictError("NOT_RELISTABLE", "This auction sold")
+		}
+		// With the winner cleared, a runner-up may still be deciding on a
+		// second-chance offer
+		offer, err := txRepo.GetPendingOffer(ctx, auction.AuctionID)
+		if err != nil {
+			return shared_errors.ErrInternalServer
+		}
+		if offer != nil {
+			return shared_errors.ConflictError("NOT_RELISTABLE", "A second-chance offer on this auction is still open")
+		}
+	}
+
+	relisting, err := txRepo.GetRelisting(ctx, auction.AuctionID)
+	if err != nil {
+		return shared_errors.ErrInternalServer
+	}
+	if relisting != nil {
+		return shared_errors.ConflictError("ALREADY_RELISTED", "This auction has already been relisted").
+			WithDetails(map[string]interface{}{"auction_id": relisting.AuctionID})
+	}
+	return nil
+}
+
+// relistedAuction copies an auction's settings onto a new one starting at
+// start with the same duration
+func relistedAuction(original *models.Auction, start time.Time) *models.Auction {
+	quantity := original.Quantity
+	if original.Type == models.AuctionTypeDrop {
+		quantity = original.QuantityRemaining
+	}
+	images := make([]models.AuctionImage, len(original.Images))
+	for i, image := range original.Images {
+		images[i] = models.AuctionImage{ImageURL: image.ImageURL, AltText: image.AltText, Order: image.Order}
+	}
+	return &models.Auction{
+		ProductID:          original.ProductID,
+		Category:           original.Category,
+		TemplateID:         original.TemplateID,
+		RelistedFrom:       original.AuctionID,
+		SellerID:           original.SellerID,
+		Title:              original.Title,
+		Description:        original.Description,
+		Currency:           original.Currency,
+		StartingPrice:      original.StartingPrice,
+		ReservePrice:       original.ReservePrice,
+		MinBidIncrement:    original.MinBidIncrement,
+		BuyNowPrice:        original.BuyNowPrice,
+		PricingRule:        original.PricingRule,
+		StartTime:          start,
+		EndTime:            start.Add(original.EndTime.Sub(original.StartTime) - extendedBy(original)),
+		Type:               original.Type,
+		SoftClose:          original.SoftClose,
+		SoftCloseWindow:    original.SoftCloseWindow,
+		SoftCloseExtension: original.SoftCloseExtension,
+		MaxExtensions:      original.MaxExtensions,
+		FloorPrice:         original.FloorPrice,
+		DropStep:           original.DropStep,
+		DropInterval:       original.DropInterval,
+		Quantity:           quantity,
+		DepositAmount:      original.DepositAmount,
+		Images:             images,
+	}
+}
+
+// extendedBy is how much soft close pushed an auction's end time back
+func extendedBy(auction *models.Auction) time.Duration {
+	return time.Duration(auction.ExtensionCount*auction.SoftCloseExtension) * time.Second
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+)
+
+func TestRelistAuction(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+	endTime := time.Now().Add(time.Hour)
+	unsold := createTestAuction(t, service, models.Auction{ReservePrice: 5000, EndTime: endTime})
+	placeTestBid(t, service, unsold.AuctionID, "alice", 2000)
+	sold := createTestAuction(t, service, models.Auction{EndTime: endTime})
+	placeTestBid(t, service, sold.AuctionID, "alice", 1000)
+	running := createTestAuction(t, service, models.Auction{EndTime: endTime.Add(time.Hour)})
+
+	if _, err := service.CloseDueAuctions(ctx, endTime.Add(time.Second)); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		auctionID string
+		sellerID  string
+		want      string
+	}{
+		{"another seller", unsold.AuctionID, "seller-2", "FORBIDDEN"},
+		{"sold", sold.AuctionID, "seller-1", "NOT_RELISTABLE"},
+		{"still running", running.AuctionID, "seller-1", "NOT_RELISTABLE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.RelistAuction(ctx, tt.auctionID, tt.sellerID, time.Time{}); errorCode(err) != tt.want {
+				t.Errorf("got %v, want %s", err, tt.want)
+			}
+		})
+	}
+
+	start := time.Now().Add(time.Hour)
+	relisted, err := service.RelistAuction(ctx, unsold.AuctionID, unsold.SellerID, start)
+	if err != nil {
+		t.Fatalf("relist: %v", err)
+	}
+	if relisted.AuctionID == unsold.AuctionID || relisted.RelistedFrom != unsold.AuctionID ||
+		relisted.ReservePrice != 5000 || relisted.CurrentPrice != unsold.StartingPrice || relisted.Status != constants.AuctionStatusScheduled {
+		t.Errorf("relisted auction %+v", relisted)
+	}
+	if got, want := relisted.EndTime.Sub(relisted.StartTime), unsold.EndTime.Sub(unsold.StartTime); got != want {
+		t.Errorf("relisted for %v, want %v", got, want)
+	}
+	if _, err := service.RelistAuction(ctx, unsold.AuctionID, unsold.SellerID, time.Time{}); errorCode(err) != "ALREADY_RELISTED" {
+		t.Errorf("second relist: %v, want ALREADY_RELISTED", err)
+	}
+}
+
+func TestRelistedAuctionDropsSoftCloseExtensions(t *testing.T) {
+	start := time.Now()
+	original := &models.Auction{
+		AuctionID:          "auction-1",
+		Type:               models.AuctionTypeScheduled,
+		StartTime:          start,
+		EndTime:            start.Add(time.Hour + 90*time.Second),
+		SoftCloseExtension: 30,
+		ExtensionCount:     3,
+		Quantity:           1,
+	}
+	relisted := relistedAuction(original, start.Add(24*time.Hour))
+	if got := relisted.EndTime.Sub(relisted.StartTime); got != time.Hour {
+		t.Errorf("relisted for %v, want the original hour", got)
+	}
+
+	drop := &models.Auction{Type: models.AuctionTypeDrop, Quantity: 10, QuantityRemaining: 4, StartTime: start, EndTime: start.Add(time.Hour)}
+	if relisted := relistedAuction(drop, start); relisted.Quantity != 4 {
+		t.Errorf("drop relisted with %d units, want the 4 unsold", relisted.Quantity)
+	}
+}
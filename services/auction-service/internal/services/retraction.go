@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// RetractBid withdraws a mistaken bid. Bidders may retract within
+// BidRetractionWindow of bidding, and never once the auction is within
+// BidRetractionCutoff of its end. The bidder's proxy maximum is dropped so it
+// cannot bid again, and the automatic bids other maximums placed after the
+// retracted bid are withdrawn with it, since it is what pushed them up. The
+// price falls back to the highest bid left and the remaining maximums compete
+// again from there.
+func (s *AuctionService) RetractBid(ctx context.Context, auctionID, bidID, bidderID, reason string) (*models.Auction, error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	auction, err := txRepo.GetByIDForUpdate(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrNotFound
+	}
+	bid, err := txRepo.GetBid(ctx, bidID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if bid == nil || bid.AuctionID != auctionID {
+		return nil, shared_errors.ErrNotFound
+	}
+	if bid.BidderID != bidderID {
+		return nil, shared_errors.ErrForbidden
+	}
+
+	now := time.Now()
+	if err := s.checkRetractable(auction, bid, now); err != nil {
+		return nil, err
+	}
+
+	previousPrice := auction.CurrentPrice
+	if err := txRepo.RetractBid(ctx, bidID, reason, now); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if err := txRepo.DeleteProxyBid(ctx, auctionID, bidderID); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	withdrawn, err := s.withdrawProxyAnswers(ctx, txRepo, bid, now)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	highest, err := txRepo.GetHighestBid(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	auction.CurrentPrice = auction.StartingPrice
+	leaderID, winningBidID := "", ""
+	if highest != nil {
+		auction.CurrentPrice = highest.Amount
+		leaderID, winningBidID = highest.BidderID, highest.BidID
+	}
+	if err := txRepo.ResetAuctionPrice(ctx, auctionID, auction.CurrentPrice); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if err := txRepo.SetWinningBid(ctx, auctionID, winningBidID); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	for _, retracted := range append([]*models.Bid{bid}, withdrawn...) {
+		if err := recordEvent(ctx, txRepo, auctionID, models.EventBidRetracted, map[string]interface{}{
+			"bid_id":        retracted.BidID,
+			"bidder_id":     retracted.BidderID,
+			"amount":        retracted.Amount,
+			"current_price": auction.CurrentPrice,
+		}); err != nil {
+			return nil, shared_errors.ErrInternalServer
+		}
+	}
+	if auction.CurrentPrice != previousPrice {
+		if err := recordEvent(ctx, txRepo, auctionID, models.EventPriceChanged, map[string]interface{}{
+			"current_price": auction.CurrentPrice,
+			"minimum_bid":   s.nextValidBid(auction, highest != nil),
+		}); err != nil {
+			return nil, shared_errors.ErrInternalServer
+		}
+	}
+
+	// Maximums held back by the retracted bid may now outbid the leader
+	if _, _, err := s.resolveProxyBids(ctx, txRepo, auction, leaderID, winningBidID, now); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	if err := recordAudit(ctx, txRepo, &models.AuditEntry{
+		AuctionID: auctionID,
+		ActorID:   bidderID,
+		ActorRole: models.ActorBidder,
+		Action:    models.AuditBidRetracted,
+		Reason:    reason,
+		CreatedAt: now,
+	}, map[string]interface{}{
+		"bid_id":         bid.BidID,
+		"amount":         bid.Amount,
+		"withdrawn_bids": len(withdrawn),
+		"previous_price": previousPrice,
+		"current_price":  auction.CurrentPrice,
+	}); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	s.signalEvents()
+
+	s.logger.Info("Bid retracted",
+		zap.String("auction_id", auctionID),
+		zap.String("bid_id", bidID),
+		zap.Stringer("current_price", auction.CurrentPrice))
+	return auction, nil
+}
+
+// withdrawProxyAnswers retracts the automatic bids other bidders' maximums
+// placed after a retracted bid. resolveProxyBids places them again as far as
+// the bids left still call for.
+func (s *AuctionService) withdrawProxyAnswers(ctx context.Context, txRepo repository.AuctionRepo, retracted *models.Bid, at time.Time) ([]*models.Bid, error) {
+	bids, err := txRepo.GetBids(ctx, retracted.AuctionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var withdrawn []*models.Bid
+	for i := range bids.Bids {
+		bid := &bids.Bids[i]
+		if !bid.IsProxy || bid.BidderID == retracted.BidderID || !bid.BidTime.After(retracted.BidTime) {
+			continue
+		}
+		if err := txRepo.RetractBid(ctx, bid.BidID, proxyAnswerRetraction, at); err != nil {
+			return nil, err
+		}
+		withdrawn = append(withdrawn, bid)
+	}
+	return withdrawn, nil
+}
+
+// proxyAnswerRetraction is the reason recorded on automatic bids withdrawn
+// along with the bid they answered
+const proxyAnswerRetraction = "Automatic bid placed against a retracted bid"
+
+// checkRetractable applies the retraction rules to a locked auction
+func (s *AuctionService) checkRetractable(auction *models.Auction, bid *models.Bid, now time.Time) error {
+	if bid.Retracted {
+		return shared_errors.ConflictError("BID_ALREADY_RETRACTED", "This bid has already been retracted")
+	}
+	if auction.Status != constants.AuctionStatusActive {
+		return shared_errors.ConflictError("AUCTION_CLOSED", "Bids can only be retracted while the auction is running")
+	}
+	switch auction.Type {
+	case models.AuctionTypeDrop:
+		return shared_errors.ConflictError("RETRACTION_NOT_ALLOWED", "Drop claims are purchases and cannot be retracted")
+	case models.AuctionTypeSealed:
+		return shared_errors.ConflictError("RETRACTION_NOT_ALLOWED", "Revise your sealed bid instead of retracting it")
+	}
+	if now.Sub(bid.BidTime) > s.config.BidRetractionWindow {
+		return shared_errors.ConflictError("RETRACTION_WINDOW_PASSED", "Bids can only be retracted shortly after they are placed").
+			WithDetails(map[string]interface{}{"retraction_window_seconds": int(s.config.BidRetractionWindow.Seconds())})
+	}
+	if auction.EndTime.Sub(now) < s.config.BidRetractionCutoff {
+		return shared_errors.ConflictError("RETRACTION_TOO_LATE", "Bids cannot be retracted this close to the end of the auction").
+			WithDetails(map[string]interface{}{"retraction_cutoff_seconds": int(s.config.BidRetractionCutoff.Seconds())})
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+)
+
+func TestCheckRetractable(t *testing.T) {
+	service, _ := newTestService(t)
+	now := time.Now()
+	window, cutoff := service.config.BidRetractionWindow, service.config.BidRetractionCutoff
+
+	tests := []struct {
+		name      string
+		status    string
+		kind      string
+		bidAge    time.Duration
+		untilEnd  time.Duration
+		retracted bool
+		want      string
+	}{
+		{"fresh bid", constants.AuctionStatusActive, models.AuctionTypeScheduled, time.Minute, 2 * cutoff, false, ""},
+		{"at the end of the window", constants.AuctionStatusActive, models.AuctionTypeScheduled, window, 2 * cutoff, false, ""},
+		{"already retracted", constants.AuctionStatusActive, models.AuctionTypeScheduled, time.Minute, 2 * cutoff, true, "BID_ALREADY_RETRACTED"},
+		{"auction ended", constants.AuctionStatusEnded, models.AuctionTypeScheduled, time.Minute, 2 * cutoff, false, "AUCTION_CLOSED"},
+		{"drop claim", constants.AuctionStatusActive, models.AuctionTypeDrop, time.Minute, 2 * cutoff, false, "RETRACTION_NOT_ALLOWED"},
+		{"sealed bid", constants.AuctionStatusActive, models.AuctionTypeSealed, time.Minute, 2 * cutoff, false, "RETRACTION_NOT_ALLOWED"},
+		{"window passed", constants.AuctionStatusActive, models.AuctionTypeScheduled, window + time.Second, 2 * cutoff, false, "RETRACTION_WINDOW_PASSED"},
+		{"inside the cutoff", constants.AuctionStatusActive, models.AuctionTypeScheduled, time.Minute, cutoff - time.Second, false, "RETRACTION_TOO_LATE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auction := &models.Auction{Status: tt.status, Type: tt.kind, EndTime: now.Add(tt.untilEnd)}
+			bid := &models.Bid{BidTime: now.Add(-tt.bidAge), Retracted: tt.retracted}
+			if got := errorCode(service.checkRetractable(auction, bid, now)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetractBidAudit(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{})
+	placeTestBid(t, service, auction.AuctionID, "alice", 1000)
+	bid := placeTestBid(t, service, auction.AuctionID, "bob", 5000)
+
+	if _, err := service.RetractBid(ctx, auction.AuctionID, bid.BidID, "alice", "Typo"); errorCode(err) != "FORBIDDEN" {
+		t.Errorf("retracting someone else's bid: %v, want FORBIDDEN", err)
+	}
+	updated, err := service.RetractBid(ctx, auction.AuctionID, bid.BidID, "bob", "Meant 50.00")
+	if err != nil {
+		t.Fatalf("retract: %v", err)
+	}
+	if updated.CurrentPrice != 1000 {
+		t.Errorf("price %s after retraction, want 10.00", updated.CurrentPrice)
+	}
+	if _, err := service.RetractBid(ctx, auction.AuctionID, bid.BidID, "bob", "Again"); errorCode(err) != "BID_ALREADY_RETRACTED" {
+		t.Errorf("retracting twice: %v, want BID_ALREADY_RETRACTED", err)
+	}
+
+	entries, err := repo.GetAuditLog(ctx, auction.AuctionID)
+	if err != nil {
+		t.Fatalf("get audit log: %v", err)
+	}
+	entry := entries[len(entries)-1]
+	if entry.Action != models.AuditBidRetracted || entry.ActorID != "bob" || entry.ActorRole != models.ActorBidder || entry.Reason != "Meant 50.00" {
+		t.Fatalf("audit entry %+v", entry)
+	}
+	var details struct {
+		BidID         string       `json:"bid_id"`
+		PreviousPrice models.Money `json:"previous_price"`
+		CurrentPrice  models.Money `json:"current_price"`
+	}
+	if err := json.Unmarshal(entry.Details, &details); err != nil {
+		t.Fatalf("decode details: %v", err)
+	}
+	if details.BidID != bid.BidID || details.PreviousPrice != 5000 || details.CurrentPrice != 1000 {
+		t.Errorf("audit details %+v", details)
+	}
+}
+
+func TestCancelAuction(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{})
+
+	if err := service.CancelAuction(ctx, auction.AuctionID, "someone-else", "", false); errorCode(err) != "FORBIDDEN" {
+		t.Errorf("non-seller cancelling: %v, want FORBIDDEN", err)
+	}
+	if err := service.CancelAuction(ctx, auction.AuctionID, "admin-1", "", true); errorCode(err) != "REASON_REQUIRED" {
+		t.Errorf("admin cancelling without a reason: %v, want REASON_REQUIRED", err)
+	}
+	if err := service.CancelAuction(ctx, auction.AuctionID, auction.SellerID, "Item damaged", false); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	if err := service.CancelAuction(ctx, auction.AuctionID, auction.SellerID, "", false); errorCode(err) != "AUCTION_CLOSED" {
+		t.Errorf("cancelling twice: %v, want AUCTION_CLOSED", err)
+	}
+
+	stored, err := repo.GetByID(ctx, auction.AuctionID)
+	if err != nil || stored.Status != constants.AuctionStatusCancelled {
+		t.Fatalf("auction %+v: %v", stored, err)
+	}
+
+	// The seller and admins can read the trail, nobody else can
+	if _, err := service.GetAuditLog(ctx, auction.AuctionID, "someone-else", false); errorCode(err) != "FORBIDDEN" {
+		t.Errorf("non-seller reading the audit log: %v, want FORBIDDEN", err)
+	}
+	if _, err := service.GetAuditLog(ctx, auction.AuctionID, "admin-1", true); err != nil {
+		t.Errorf("admin reading the audit log: %v", err)
+	}
+	entries, err := service.GetAuditLog(ctx, auction.AuctionID, auction.SellerID, false)
+	if err != nil {
+		t.Fatalf("get audit log: %v", err)
+	}
+	entry := entries[len(entries)-1]
+	if entry.Action != models.AuditCancelled || entry.ActorRole != models.ActorSeller || entry.Reason != "Item damaged" ||
+		entry.FromStatus != constants.AuctionStatusActive || entry.ToStatus != constants.AuctionStatusCancelled {
+		t.Errorf("audit entry %+v", entry)
+	}
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// validateSealedAuction checks a new sealed-bid auction. Buy-now would reveal
+// bidding activity, so it is not allowed.
+func validateSealedAuction(auction *models.Auction) error {
+	switch auction.PricingRule {
+	case "", models.PricingFirstPrice, models.PricingSecondPrice:
+	default:
+		return shared_errors.ValidationError("INVALID_PRICING_RULE", "Pricing rule must be first_price or second_price")
+	}
+	if auction.BuyNowPrice > 0 {
+		return shared_errors.ValidationError("INVALID_BUY_NOW_PRICE", "Sealed-bid auctions cannot have a buy-now price")
+	}
+	if auction.ReservePrice > 0 && auction.ReservePrice < auction.StartingPrice {
+		return shared_errors.ValidationError("INVALID_RESERVE_PRICE", "Reserve price cannot be below starting price")
+	}
+	return nil
+}
+
+// prepareSealedAuction fills in a validated sealed-bid auction's pricing
+// rule. Soft close would reveal bidding activity, so it is turned off.
+func prepareSealedAuction(auction *models.Auction) {
+	if auction.PricingRule == "" {
+		auction.PricingRule = models.PricingFirstPrice
+	}
+	auction.Quantity = 1
+	auction.QuantityRemaining = 1
+	auction.SoftClose = false
+	auction.SoftCloseWindow = 0
+	auction.SoftCloseExtension = 0
+	auction.MaxExtensions = 0
+}
+
+// sealedBidsHidden reports whether an auction's bids must stay secret. Sealed
+// bids are revealed at EndTime and never for cancelled auctions.
+func sealedBidsHidden(auction *models.Auction, now time.Time) bool {
+	if auction.Type != models.AuctionTypeSealed {
+		return false
+	}
+	if auction.Status == constants.AuctionStatusEnded {
+		return false
+	}
+	return auction.Status == constants.AuctionStatusCancelled || now.Before(auction.EndTime)
+}
+
+// hideSealedBidCount zeroes the bid count of a sealed auction whose bids are
+// still hidden, since how many have bid gives away as much as the amounts
+func hideSealedBidCount(auction *models.Auction, now time.Time) {
+	if sealedBidsHidden(auction, now) {
+		auction.BidCount = 0
+	}
+}
+
+// placeSealedBid records or revises the bidder's single sealed bid. Nothing
+// public changes: the current price stays at the starting price and no stream
+// events are written until settlement.
+func (s *AuctionService) placeSealedBid(ctx context.Context, txRepo repository.AuctionRepo, auction *models.Auction, bid *models.Bid) error {
+	if bid.Amount < auction.StartingPrice {
+		return bidTooLowError(auction, auction.StartingPrice)
+	}
+
+	existing, err := txRepo.GetBidsByBidder(ctx, auction.AuctionID, bid.BidderID)
+	if err != nil {
+		return shared_errors.ErrInternalServer
+	}
+
+	now := time.Now()
+	if len(existing) > 0 {
+		revised := existing[0]
+		revised.Amount = bid.Amount
+		revised.BidTime = now
+		if err := txRepo.ReviseBid(ctx, revised); err != nil {
+			return shared_errors.ErrInternalServer
+		}
+		*bid = *revised
+		return nil
+	}
+
+	bid.BidID = uuid.New().String()
+	bid.BidTime = now
+	bid.CreatedAt = now
+	bid.IsWinning = false
+	if err := txRepo.CreateBid(ctx, bid); err != nil {
+		s.logger.Error("Failed to place sealed bid", zap.String("auction_id", auction.AuctionID), zap.Error(err))
+		return shared_errors.ErrInternalServer
+	}
+	if err := s.autoWatch(ctx, txRepo, auction, bid.BidderID); err != nil {
+		return shared_errors.ErrInternalServer
+	}
+	return nil
+}
+
+// sealedClearingPrice is what the winner of a sealed auction pays. Under the
+// second-price rule that is the runner-up's bid, but never less than the
+// starting price or the reserve.
+func sealedClearingPrice(ctx context.Context, txRepo repository.AuctionRepo, auction *models.Auction, winning *models.Bid) (models.Money, error) {
+	if auction.PricingRule != models.PricingSecondPrice {
+		return winning.Amount, nil
+	}
+
+	top, err := txRepo.GetTopBids(ctx, auction.AuctionID, 2)
+	if err != nil {
+		return 0, err
+	}
+	if len(top) == 0 || top[0].BidID != winning.BidID {
+		return 0, fmt.Errorf("winning bid %s is not the top sealed bid", winning.BidID)
+	}
+
+	price := max(auction.StartingPrice, auction.ReservePrice)
+	if len(top) > 1 {
+		price = max(price, top[1].Amount)
+	}
+	return min(price, winning.Amount), nil
+}
+
+// GetMyBids returns the caller's own bids on an auction. Sealed bidders use it
+// to check the bid they submitted while everyone else's stays hidden.
+func (s *AuctionService) GetMyBids(ctx context.Context, auctionID, bidderID string) ([]models.Bid, error) {
+	if _, err := s.repo.GetByID(ctx, auctionID); err != nil {
+		return nil, shared_errors.ErrNotFound
+	}
+
+	found, err := s.repo.GetBidsByBidder(ctx, auctionID, bidderID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	bids := make([]models.Bid, 0, len(found))
+	for _, bid := range found {
+		bids = append(bids, *bid)
+	}
+	return bids, nil
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+func TestSealedClearingPrice(t *testing.T) {
+	type bid struct {
+		bidderID string
+		amount   models.Money
+	}
+	tests := []struct {
+		name       string
+		rule       string
+		reserve    models.Money
+		bids       []bid
+		wantWinner string
+		wantPrice  models.Money
+	}{
+		{
+			name:       "first price pays the winning bid",
+			rule:       models.PricingFirstPrice,
+			bids:       []bid{{"alice", 3000}, {"bob", 2000}},
+			wantWinner: "alice",
+			wantPrice:  3000,
+		},
+		{
+			name:       "second price pays the runner-up's bid",
+			rule:       models.PricingSecondPrice,
+			bids:       []bid{{"alice", 3000}, {"bob", 2000}, {"carol", 1500}},
+			wantWinner: "alice",
+			wantPrice:  2000,
+		},
+		{
+			name:       "second price never below the reserve",
+			rule:       models.PricingSecondPrice,
+			reserve:    2500,
+			bids:       []bid{{"alice", 3000}, {"bob", 2000}},
+			wantWinner: "alice",
+			wantPrice:  2500,
+		},
+		{
+			name:       "second price lone bid pays the starting price",
+			rule:       models.PricingSecondPrice,
+			bids:       []bid{{"alice", 3000}},
+			wantWinner: "alice",
+			wantPrice:  1000,
+		},
+		{
+			name:       "second price tie pays the tied amount",
+			rule:       models.PricingSecondPrice,
+			bids:       []bid{{"alice", 3000}, {"bob", 3000}},
+			wantWinner: "alice",
+			wantPrice:  3000,
+		},
+		{
+			name:       "revised bid replaces the original",
+			rule:       models.PricingSecondPrice,
+			bids:       []bid{{"alice", 3000}, {"bob", 2000}, {"alice", 1800}},
+			wantWinner: "bob",
+			wantPrice:  1800,
+		},
+		{
+			name:      "reserve not met",
+			rule:      models.PricingSecondPrice,
+			reserve:   5000,
+			bids:      []bid{{"alice", 3000}, {"bob", 2000}},
+			wantPrice: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newTestService(t)
+			ctx := context.Background()
+			auction := createTestAuction(t, service, models.Auction{
+				Type:         models.AuctionTypeSealed,
+				PricingRule:  tt.rule,
+				ReservePrice: tt.reserve,
+				EndTime:      time.Now().Add(time.Hour),
+			})
+			for _, bid := range tt.bids {
+				placeTestBid(t, service, auction.AuctionID, bid.bidderID, bid.amount)
+			}
+			if got := eventTypes(t, repo, auction.AuctionID); len(got) != 0 {
+				t.Errorf("sealed bids recorded events %v", got)
+			}
+
+			if _, err := service.CloseDueAuctions(ctx, auction.EndTime.Add(time.Second)); err != nil {
+				t.Fatalf("close: %v", err)
+			}
+			stored, err := repo.GetByID(ctx, auction.AuctionID)
+			if err != nil {
+				t.Fatalf("reload auction: %v", err)
+			}
+			if stored.WinnerID != tt.wantWinner || stored.CurrentPrice != tt.wantPrice {
+				t.Errorf("won by %q at %s, want %q at %s", stored.WinnerID, stored.CurrentPrice, tt.wantWinner, tt.wantPrice)
+			}
+
+			events, err := repo.GetEventsAfter(ctx, auction.AuctionID, 0, 100)
+			if err != nil || len(events) == 0 {
+				t.Fatalf("events %v: %v", events, err)
+			}
+			var settled models.AuctionSettledPayload
+			if err := json.Unmarshal(events[len(events)-1].Payload, &settled); err != nil {
+				t.Fatalf("decode settlement: %v", err)
+			}
+			if settled.WinnerID != tt.wantWinner || settled.HammerPrice != tt.wantPrice {
+				t.Errorf("settlement event %+v", settled)
+			}
+			if tt.wantWinner == "" {
+				return
+			}
+			handoffs, err := repo.GetOrderHandoffs(ctx, auction.AuctionID)
+			if err != nil || len(handoffs) != 1 || handoffs[0].Price != tt.wantPrice {
+				t.Errorf("orders %+v, want one at %s: %v", handoffs, tt.wantPrice, err)
+			}
+		})
+	}
+}
+
+func TestCreateSealedAuctionValidation(t *testing.T) {
+	service, _ := newTestService(t)
+	tests := []struct {
+		name    string
+		auction models.Auction
+		want    string
+	}{
+		{"unknown pricing rule", models.Auction{PricingRule: "vickrey"}, "INVALID_PRICING_RULE"},
+		{"buy-now price", models.Auction{BuyNowPrice: 5000}, "INVALID_BUY_NOW_PRICE"},
+		{"reserve below start", models.Auction{ReservePrice: 500}, "INVALID_RESERVE_PRICE"},
+	}
+	for _, tt := range tests {
+		auction := tt.auction
+		auction.SellerID, auction.ProductID, auction.Title, auction.Category = "seller-1", "product-1", tt.name, "home"
+		auction.Images = []models.AuctionImage{{ImageURL: "https://example.com/item.jpg"}}
+		auction.StartingPrice = 1000
+		auction.Type = models.AuctionTypeSealed
+		if err := service.CreateAuction(context.Background(), &auction); errorCode(err) != tt.want {
+			t.Errorf("%s: got %v, want %s", tt.name, err, tt.want)
+		}
+	}
+
+	if created := createTestAuction(t, service, models.Auction{Type: models.AuctionTypeSealed}); created.PricingRule != models.PricingFirstPrice {
+		t.Errorf("pricing rule defaulted to %q, want %s", created.PricingRule, models.PricingFirstPrice)
+	}
+	if created := createTestAuction(t, service, models.Auction{PricingRule: models.PricingSecondPrice}); created.PricingRule != "" {
+		t.Errorf("open auction kept pricing rule %q", created.PricingRule)
+	}
+}
@@ -0,0 +1,372 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// ProcessLapsedPayments checks orders whose payment deadline has passed.
+// Unpaid ones are expired in order-service, the buyer gets a non-payment
+// strike and, for single-winner auctions, the runner-up is offered the item.
+func (s *AuctionService) ProcessLapsedPayments(ctx context.Context, now time.Time) (int, error) {
+	processed := 0
+	for processed < lifecycleBatchSize {
+		ok, err := s.processNextLapsedPayment(ctx, now)
+		if err != nil {
+			return processed, err
+		}
+		if !ok {
+			break
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+func (s *AuctionService) processNextLapsedPayment(ctx context.Context, now time.Time) (bool, error) {
+	// The lease commits before order-service is called so no transaction or
+	// row lock is held across the request. A result that arrives after the
+	// lease expired and the hand-off was resolved elsewhere is discarded.
+	handoff, err := s.repo.ClaimNextLapsedPayment(ctx, now, now.Add(orderHandoffLease))
+	if err != nil || handoff == nil {
+		return false, err
+	}
+
+	// Expiring rather than just reading the order closes the race with a
+	// payment landing right now: whichever wins, the answer is final
+	order, orderErr := s.orders.ExpireOrder(ctx, handoff.OrderID)
+	if orderErr != nil {
+		if err := s.repo.RetryOrderHandoff(ctx, handoff.BidID, now.Add(orderRetryDelay(handoff.Attempts)), orderErr.Error()); err != nil {
+			return false, err
+		}
+		return false, orderErr
+	}
+
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	if order.Paid() {
+		resolved, err := txRepo.ResolveOrderHandoff(ctx, handoff.BidID, models.HandoffPaid, now)
+		if err != nil || !resolved {
+			return err == nil, err
+		}
+		if err := txRepo.QueueBidDeposit(ctx, handoff.AuctionID, handoff.BuyerID, models.DepositReleasePending, now); err != nil {
+			return false, err
+		}
+		return true, tx.Commit()
+	}
+
+	auction, err := txRepo.GetByIDForUpdate(ctx, handoff.AuctionID)
+	if err != nil {
+		return false, err
+	}
+	resolved, err := txRepo.ResolveOrderHandoff(ctx, handoff.BidID, models.HandoffDefaulted, now)
+	if err != nil || !resolved {
+		return err == nil, err
+	}
+	if err := s.recordDefault(ctx, txRepo, auction, handoff, now); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	s.signalEvents()
+	return true, nil
+}
+
+// recordDefault marks a lapsed sale whose hand-off was just resolved as
+// defaulted and, if it was the auction's winning bid, moves on to the runner-up
+func (s *AuctionService) recordDefault(ctx context.Context, txRepo repository.AuctionRepo, auction *models.Auction, handoff *models.OrderHandoff, now time.Time) error {
+	if err := txRepo.MarkBidDefaulted(ctx, handoff.BidID, now); err != nil {
+		return err
+	}
+	// A defaulting winner forfeits their deposit
+	if err := txRepo.QueueBidDeposit(ctx, handoff.AuctionID, handoff.BuyerID, models.DepositCapturePending, now); err != nil {
+		return err
+	}
+	if err := txRepo.CreateStrike(ctx, &models.NonPaymentStrike{
+		BidID:     handoff.BidID,
+		UserID:    handoff.BuyerID,
+		AuctionID: handoff.AuctionID,
+		OrderID:   handoff.OrderID,
+		CreatedAt: now,
+	}); err != nil {
+		return err
+	}
+	if err := recordEvent(ctx, txRepo, auction.AuctionID, models.EventPaymentDefaulted, models.PaymentDefaultedPayload{
+		SellerID: auction.SellerID,
+		BidID:    handoff.BidID,
+		BidderID: handoff.BuyerID,
+		OrderID:  handoff.OrderID,
+	}); err != nil {
+		return err
+	}
+
+	if err := recordAudit(ctx, txRepo, &models.AuditEntry{
+		AuctionID: auction.AuctionID,
+		ActorRole: models.ActorSystem,
+		Action:    models.AuditPaymentDefaulted,
+		CreatedAt: now,
+	}, map[string]interface{}{
+		"bid_id":    handoff.BidID,
+		"bidder_id": handoff.BuyerID,
+		"order_id":  handoff.OrderID,
+	}); err != nil {
+		return err
+	}
+
+	s.logger.Info("Winner defaulted on payment",
+		zap.String("auction_id", auction.AuctionID),
+		zap.String("bid_id", handoff.BidID),
+		zap.String("bidder_id", handoff.BuyerID))
+
+	// Drop claims are independent sales with no runner-up to fall back on
+	if auction.WinningBidID != handoff.BidID {
+		return nil
+	}
+	if err := txRepo.ClearAuctionWinner(ctx, auction.AuctionID); err != nil {
+		return err
+	}
+	auction.WinnerID = ""
+	auction.WinningBidID = ""
+	return s.offerNextRunnerUp(ctx, txRepo, auction, now)
+}
+
+// offerNextRunnerUp offers a locked auction to the highest remaining eligible
+// bidder at their own bid. Bids below the reserve never qualify.
+func (s *AuctionService) offerNextRunnerUp(ctx context.Context, txRepo repository.AuctionRepo, auction *models.Auction, now time.Time) error {
+	minAmount := max(auction.StartingPrice, auction.ReservePrice)
+	bid, err := txRepo.GetNextSecondChanceBid(ctx, auction.AuctionID, minAmount, s.config.NonPaymentStrikeLimit)
+	if err != nil {
+		return err
+	}
+	if bid == nil {
+		s.logger.Info("No runner-up left for second-chance offer", zap.String("auction_id", auction.AuctionID))
+		return recordEvent(ctx, txRepo, auction.AuctionID, models.EventSecondChanceExhausted, map[string]interface{}{
+			"seller_id": auction.SellerID,
+		})
+	}
+
+	offer := &models.SecondChanceOffer{
+		OfferID:   uuid.New().String(),
+		AuctionID: auction.AuctionID,
+		BidID:     bid.BidID,
+		BidderID:  bid.BidderID,
+		Amount:    bid.Amount,
+		Status:    models.OfferPending,
+		ExpiresAt: now.Add(s.config.SecondChanceWindow),
+		CreatedAt: now,
+	}
+	if err := txRepo.CreateSecondChanceOffer(ctx, offer); err != nil {
+		return err
+	}
+	return recordEvent(ctx, txRepo, auction.AuctionID, models.EventSecondChanceOffered, models.SecondChanceOfferedPayload{
+		SellerID:  auction.SellerID,
+		OfferID:   offer.OfferID,
+		BidderID:  offer.BidderID,
+		Amount:    offer.Amount,
+		ExpiresAt: offer.ExpiresAt,
+	})
+}
+
+// ExpireSecondChanceOffers closes offers nobody answered in time and offers
+// the auction to the next bidder in line
+func (s *AuctionService) ExpireSecondChanceOffers(ctx context.Context, now time.Time) (int, error) {
+	expired := 0
+	for expired < lifecycleBatchSize {
+		ok, err := s.expireNextOffer(ctx, now)
+		if err != nil {
+			return expired, err
+		}
+		if !ok {
+			break
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+func (s *AuctionService) expireNextOffer(ctx context.Context, now time.Time) (bool, error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	auction, err := txRepo.ClaimNextExpiredOffer(ctx, now)
+	if err != nil || auction == nil {
+		return false, err
+	}
+	offer, err := txRepo.GetPendingOffer(ctx, auction.AuctionID)
+	if err != nil {
+		return false, err
+	}
+	// Answered while we waited for the lock
+	if offer == nil || offer.ExpiresAt.After(now) {
+		return true, tx.Commit()
+	}
+
+	if err := txRepo.UpdateOfferStatus(ctx, offer.OfferID, models.OfferExpired, now); err != nil {
+		return false, err
+	}
+	if err := s.offerNextRunnerUp(ctx, txRepo, auction, now); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	s.signalEvents()
+	return true, nil
+}
+
+// GetSecondChanceOffer returns the offer the caller received for an auction
+func (s *AuctionService) GetSecondChanceOffer(ctx context.Context, auctionID, bidderID string) (*models.SecondChanceOffer, error) {
+	offer, err := s.repo.GetOfferForBidder(ctx, auctionID, bidderID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if offer == nil {
+		return nil, shared_errors.ErrNotFound
+	}
+	return offer, nil
+}
+
+// AcceptSecondChanceOffer makes the caller the auction's winner at their
+// offered bid and hands the sale to order-service like any other win
+func (s *AuctionService) AcceptSecondChanceOffer(ctx context.Context, auctionID, bidderID string) (*models.Auction, error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	auction, err := txRepo.GetByIDForUpdate(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrNotFound
+	}
+	now := time.Now()
+	offer, err := openOfferFor(ctx, txRepo, auction, bidderID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	auction.Status = constants.AuctionStatusEnded
+	auction.WinnerID = offer.BidderID
+	auction.WinningBidID = offer.BidID
+	auction.CurrentPrice = offer.Amount
+	if err := txRepo.UpdateOfferStatus(ctx, offer.OfferID, models.OfferAccepted, now); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if err := txRepo.SetWinningBid(ctx, auction.AuctionID, offer.BidID); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if err := txRepo.SettleAuction(ctx, auction); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if err := s.queueOrderHandoff(ctx, txRepo, auction, offer.BidID, offer.BidderID, 1, offer.Amount, now); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if err := recordEvent(ctx, txRepo, auction.AuctionID, models.EventAuctionSettled, models.AuctionSettledPayload{
+		SellerID:     auction.SellerID,
+		Outcome:      models.OutcomeSold,
+		WinnerID:     auction.WinnerID,
+		WinningBidID: auction.WinningBidID,
+		HammerPrice:  auction.CurrentPrice,
+		SecondChance: true,
+	}); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	if err := recordAudit(ctx, txRepo, &models.AuditEntry{
+		AuctionID: auction.AuctionID,
+		ActorID:   bidderID,
+		ActorRole: models.ActorBidder,
+		Action:    models.AuditSecondChanceAccepted,
+		CreatedAt: now,
+	}, map[string]interface{}{
+		"offer_id": offer.OfferID,
+		"bid_id":   offer.BidID,
+		"amount":   offer.Amount,
+	}); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	s.signalEvents()
+
+	s.logger.Info("Second-chance offer accepted",
+		zap.String("auction_id", auctionID),
+		zap.String("bidder_id", bidderID),
+		zap.Stringer("amount", offer.Amount))
+	return auction, nil
+}
+
+// DeclineSecondChanceOffer turns an offer down and passes the auction to the
+// next bidder in line
+func (s *AuctionService) DeclineSecondChanceOffer(ctx context.Context, auctionID, bidderID string) error {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return shared_errors.ErrInternalServer
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	auction, err := txRepo.GetByIDForUpdate(ctx, auctionID)
+	if err != nil {
+		return shared_errors.ErrNotFound
+	}
+	now := time.Now()
+	offer, err := openOfferFor(ctx, txRepo, auction, bidderID, now)
+	if err != nil {
+		return err
+	}
+
+	if err := txRepo.UpdateOfferStatus(ctx, offer.OfferID, models.OfferDeclined, now); err != nil {
+		return shared_errors.ErrInternalServer
+	}
+	if err := s.offerNextRunnerUp(ctx, txRepo, auction, now); err != nil {
+		return shared_errors.ErrInternalServer
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return shared_errors.ErrInternalServer
+	}
+	s.signalEvents()
+	return nil
+}
+
+// openOfferFor returns the locked auction's open offer if it belongs to the
+// bidder and can still be answered
+func openOfferFor(ctx context.Context, txRepo repository.AuctionRepo, auction *models.Auction, bidderID string, now time.Time) (*models.SecondChanceOffer, error) {
+	offer, err := txRepo.GetPendingOffer(ctx, auction.AuctionID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if offer == nil || offer.BidderID != bidderID {
+		return nil, shared_errors.ErrNotFound
+	}
+	if !offer.ExpiresAt.After(now) {
+		return nil, shared_errors.ConflictError("OFFER_EXPIRED", "This second-chance offer has expired")
+	}
+	return offer, nil
+}
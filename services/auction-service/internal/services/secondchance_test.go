@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/config"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/pkg/orders"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+)
+
+// newLapsedTestService returns a service whose order-service opens one order
+// per bid and reports it paid on expiry if paid
+func newLapsedTestService(t *testing.T, paid bool) (*AuctionService, repository.AuctionRepo, *config.Config) {
+	t.Helper()
+	orderService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/expire") {
+			status := "pending"
+			if paid {
+				status = orders.PaymentStatusPaid
+			}
+			fmt.Fprintf(w, `{"success":true,"data":{"id":"order","status":"expired","payment_status":%q}}`, status)
+			return
+		}
+		var req orders.AuctionOrderRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode order request: %v", err)
+		}
+		fmt.Fprintf(w, `{"success":true,"data":{"id":"order-%s","status":"pending"}}`, req.BidID)
+	}))
+	t.Cleanup(orderService.Close)
+
+	cfg, _ := config.Load()
+	cfg.OrderServiceURL = orderService.URL
+	repo := repository.NewMemoryRepo()
+	return NewAuctionService(repo, zap.NewNop(), cfg), repo, cfg
+}
+
+// sellAndLapse closes auction, hands its sale to order-service and runs the
+// lapsed payment check once the payment window has passed. It returns the
+// time of that check.
+func sellAndLapse(t *testing.T, service *AuctionService, cfg *config.Config, auction *models.Auction) time.Time {
+	t.Helper()
+	ctx := context.Background()
+	now := auction.EndTime.Add(time.Second)
+	if _, err := service.CloseDueAuctions(ctx, now); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if _, err := service.HandOffOrders(ctx, now); err != nil {
+		t.Fatalf("hand off: %v", err)
+	}
+	now = now.Add(cfg.PaymentWindow + time.Second)
+	if processed, err := service.ProcessLapsedPayments(ctx, now); err != nil || processed != 1 {
+		t.Fatalf("processed %d: %v", processed, err)
+	}
+	return now
+}
+
+func TestLapsedPaymentOffersRunnersUp(t *testing.T) {
+	ctx := context.Background()
+	service, repo, cfg := newLapsedTestService(t, false)
+	auction := createTestAuction(t, service, models.Auction{EndTime: time.Now().Add(time.Hour)})
+	placeTestBid(t, service, auction.AuctionID, "alice", 1000)
+	placeTestBid(t, service, auction.AuctionID, "carol", 1500)
+	placeTestBid(t, service, auction.AuctionID, "bob", 2000)
+	now := sellAndLapse(t, service, cfg, auction)
+
+	if strikes, err := repo.CountStrikes(ctx, "bob"); err != nil || strikes != 1 {
+		t.Errorf("bob has %d strikes: %v", strikes, err)
+	}
+	if got := auditActions(t, repo, auction.AuctionID); !slices.Contains(got, models.AuditPaymentDefaulted) {
+		t.Errorf("audit %v, want %s", got, models.AuditPaymentDefaulted)
+	}
+	stored, err := repo.GetByID(ctx, auction.AuctionID)
+	if err != nil || stored.WinnerID != "" {
+		t.Fatalf("auction %+v after default: %v", stored, err)
+	}
+
+	// The highest remaining bidder is offered the item at their own bid
+	offer, err := service.GetSecondChanceOffer(ctx, auction.AuctionID, "carol")
+	if err != nil || offer.Amount != 1500 || offer.Status != models.OfferPending {
+		t.Fatalf("carol's offer %+v: %v", offer, err)
+	}
+	if want := now.Add(cfg.SecondChanceWindow); !offer.ExpiresAt.Equal(want) {
+		t.Errorf("offer expires at %v, want %v", offer.ExpiresAt, want)
+	}
+	if _, err := service.AcceptSecondChanceOffer(ctx, auction.AuctionID, "alice"); errorCode(err) != "NOT_FOUND" {
+		t.Errorf("accepting someone else's offer: %v, want NOT_FOUND", err)
+	}
+
+	// Declining passes the offer down the line
+	if err := service.DeclineSecondChanceOffer(ctx, auction.AuctionID, "carol"); err != nil {
+		t.Fatalf("decline: %v", err)
+	}
+	offer, err = service.GetSecondChanceOffer(ctx, auction.AuctionID, "alice")
+	if err != nil || offer.Amount != 1000 || offer.Status != models.OfferPending {
+		t.Fatalf("alice's offer %+v: %v", offer, err)
+	}
+
+	// An unanswered offer expires and nobody is left to ask
+	if expired, err := service.ExpireSecondChanceOffers(ctx, offer.ExpiresAt.Add(-time.Second)); err != nil || expired != 0 {
+		t.Fatalf("expired %d before the deadline: %v", expired, err)
+	}
+	if expired, err := service.ExpireSecondChanceOffers(ctx, offer.ExpiresAt); err != nil || expired != 1 {
+		t.Fatalf("expired %d: %v", expired, err)
+	}
+	if offer, err := service.GetSecondChanceOffer(ctx, auction.AuctionID, "alice"); err != nil || offer.Status != models.OfferExpired {
+		t.Errorf("alice's offer %+v: %v", offer, err)
+	}
+
+	types := eventTypes(t, repo, auction.AuctionID)
+	offered := 0
+	for _, eventType := range types {
+		if eventType == models.EventSecondChanceOffered {
+			offered++
+		}
+	}
+	if offered != 2 || !slices.Contains(types, models.EventPaymentDefaulted) || types[len(types)-1] != models.EventSecondChanceExhausted {
+		t.Errorf("events %v", types)
+	}
+}
+
+func TestAcceptSecondChanceOffer(t *testing.T) {
+	ctx := context.Background()
+	service, repo, cfg := newLapsedTestService(t, false)
+	auction := createTestAuction(t, service, models.Auction{EndTime: time.Now().Add(time.Hour)})
+	runnerUp := placeTestBid(t, service, auction.AuctionID, "carol", 1500)
+	placeTestBid(t, service, auction.AuctionID, "bob", 2000)
+	sellAndLapse(t, service, cfg, auction)
+
+	accepted, err := service.AcceptSecondChanceOffer(ctx, auction.AuctionID, "carol")
+	if err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	if accepted.WinnerID != "carol" || accepted.WinningBidID != runnerUp.BidID || accepted.CurrentPrice != 1500 ||
+		accepted.Status != constants.AuctionStatusEnded {
+		t.Errorf("auction after accepting %+v", accepted)
+	}
+	if got := auditActions(t, repo, auction.AuctionID); !slices.Contains(got, models.AuditSecondChanceAccepted) {
+		t.Errorf("audit %v, want %s", got, models.AuditSecondChanceAccepted)
+	}
+
+	// The runner-up's sale goes to order-service like any other win
+	handoffs, err := repo.GetOrderHandoffs(ctx, auction.AuctionID)
+	if err != nil || len(handoffs) != 2 {
+		t.Fatalf("hand-offs %+v: %v", handoffs, err)
+	}
+	i := slices.IndexFunc(handoffs, func(h *models.OrderHandoff) bool { return h.BidID == runnerUp.BidID })
+	if i < 0 || handoffs[i].BuyerID != "carol" || handoffs[i].Price != 1500 || handoffs[i].Resolution != "" {
+		t.Errorf("no open sale for the runner-up in %+v", handoffs)
+	}
+	if err := service.DeclineSecondChanceOffer(ctx, auction.AuctionID, "carol"); errorCode(err) != "NOT_FOUND" {
+		t.Errorf("declining an accepted offer: %v, want NOT_FOUND", err)
+	}
+}
+
+func TestLapsedPaymentSettlesDeposit(t *testing.T) {
+	tests := []struct {
+		name        string
+		paid        bool
+		resolution  string
+		deposit     string
+		strikes     int
+		secondOffer bool
+	}{
+		{"paid", true, models.HandoffPaid, models.DepositReleasePending, 0, false},
+		{"defaulted", false, models.HandoffDefaulted, models.DepositCapturePending, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			service, repo, cfg := newLapsedTestService(t, tt.paid)
+			auction := createTestAuction(t, service, models.Auction{EndTime: time.Now().Add(time.Hour)})
+			placeTestBid(t, service, auction.AuctionID, "carol", 1500)
+			placeTestBid(t, service, auction.AuctionID, "bob", 2000)
+			if err := repo.SaveBidDeposit(ctx, &models.BidDeposit{
+				AuctionID: auction.AuctionID, BidderID: "bob", Amount: 5000, PaymentDepositID: "hold-1",
+				Status: models.DepositHeld, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+			}); err != nil {
+				t.Fatalf("save deposit: %v", err)
+			}
+			sellAndLapse(t, service, cfg, auction)
+
+			handoffs, err := repo.GetOrderHandoffs(ctx, auction.AuctionID)
+			if err != nil || len(handoffs) != 1 || handoffs[0].Resolution != tt.resolution {
+				t.Errorf("hand-offs %+v: %v", handoffs, err)
+			}
+			if deposit, err := repo.GetBidDeposit(ctx, auction.AuctionID, "bob"); err != nil || deposit.Status != tt.deposit {
+				t.Errorf("deposit %+v, want %s: %v", deposit, tt.deposit, err)
+			}
+			if strikes, err := repo.CountStrikes(ctx, "bob"); err != nil || strikes != tt.strikes {
+				t.Errorf("bob has %d strikes, want %d: %v", strikes, tt.strikes, err)
+			}
+			_, err = service.GetSecondChanceOffer(ctx, auction.AuctionID, "carol")
+			if offered := err == nil; offered != tt.secondOffer {
+				t.Errorf("runner-up offered %v, want %v", offered, tt.secondOffer)
+			}
+		})
+	}
+}
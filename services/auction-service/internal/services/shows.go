@@ -0,0 +1,283 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// CreateShow opens a new show with an empty lot queue
+func (s *AuctionService) CreateShow(ctx context.Context, show *models.Show) error {
+	now := time.Now()
+	show.ShowID = uuid.New().String()
+	show.Status = models.ShowScheduled
+	if show.LotDuration <= 0 {
+		show.LotDuration = int(s.config.ShowLotDuration / time.Second)
+	}
+	currency, err := s.auctionCurrency(show.Currency)
+	if err != nil {
+		return err
+	}
+	show.Currency = currency
+	show.CreatedAt = now
+	show.UpdatedAt = now
+	show.Lots = []models.ShowLot{}
+
+	if err := s.repo.CreateShow(ctx, show); err != nil {
+		return shared_errors.ErrInternalServer
+	}
+	return nil
+}
+
+// GetShow returns a show with its lot queue
+func (s *AuctionService) GetShow(ctx context.Context, showID string) (*models.Show, error) {
+	show, err := s.repo.GetShow(ctx, showID)
+	if err == sql.ErrNoRows {
+		return nil, shared_errors.ErrNotFound
+	}
+	if err != nil {
+		s.logger.Error("Failed to get show", zap.String("show_id", showID), zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	if show.Lots, err = s.repo.GetShowLots(ctx, showID); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	return show, nil
+}
+
+// AddLot appends a lot to the end of a show's queue
+func (s *AuctionService) AddLot(ctx context.Context, showID, hostID string, lot *models.ShowLot) (*models.Show, error) {
+	now := time.Now()
+	lot.LotID = uuid.New().String()
+	lot.ShowID = showID
+	lot.Status = models.LotQueued
+	lot.CreatedAt = now
+	lot.Category = s.productCategory(ctx, lot.ProductID)
+
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	show, err := s.lockHostShow(ctx, txRepo, showID, hostID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the lot now rather than when the host brings it up mid-show
+	if err := s.validateAuction(lotAuction(show, lot, now), now); err != nil {
+		return nil, err
+	}
+
+	if err := txRepo.CreateShowLot(ctx, lot); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	return s.GetShow(ctx, showID)
+}
+
+// RemoveLot takes a lot out of the queue before it has been brought up
+func (s *AuctionService) RemoveLot(ctx context.Context, showID, lotID, hostID string) (*models.Show, error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	if _, err := s.lockHostShow(ctx, txRepo, showID, hostID); err != nil {
+		return nil, err
+	}
+	deleted, err := txRepo.DeleteQueuedLot(ctx, showID, lotID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if !deleted {
+		return nil, shared_errors.ConflictError("LOT_NOT_QUEUED", "Only lots still waiting in the queue can be removed")
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	return s.GetShow(ctx, showID)
+}
+
+// AdvanceShow brings up the next lot in the queue as a live auction. The
+// first advance puts the show live; advancing past the last lot ends it. The
+// current lot's auction must have ended first.
+func (s *AuctionService) AdvanceShow(ctx context.Context, showID, hostID string) (*models.Show, error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	show, err := s.lockHostShow(ctx, txRepo, showID, hostID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	var previous *models.Auction
+	if show.CurrentAuctionID != "" {
+		if previous, err = txRepo.GetByIDForUpdate(ctx, show.CurrentAuctionID); err != nil {
+			s.logger.Error("Failed to get current lot auction", zap.String("auction_id", show.CurrentAuctionID), zap.Error(err))
+			return nil, shared_errors.ErrInternalServer
+		}
+		// The timer may have run out before the scheduler got to it
+		if previous.Status == constants.AuctionStatusActive && !previous.EndTime.After(now) {
+			if err := s.settleAuction(ctx, txRepo, previous, now); err != nil {
+				s.logger.Error("Failed to settle current lot auction", zap.String("auction_id", previous.AuctionID), zap.Error(err))
+				return nil, shared_errors.ErrInternalServer
+			}
+		}
+		if previous.Status == constants.AuctionStatusActive || previous.Status == constants.AuctionStatusScheduled {
+			return nil, shared_errors.ConflictError("LOT_IN_PROGRESS", "The current lot is still open for bidding")
+		}
+		if err := txRepo.CloseShowLot(ctx, show.CurrentLotID); err != nil {
+			return nil, shared_errors.ErrInternalServer
+		}
+	}
+
+	next, err := txRepo.GetNextQueuedLot(ctx, showID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if next == nil {
+		if previous == nil {
+			return nil, shared_errors.ConflictError("NO_LOTS_QUEUED", "Add lots to the show before starting it")
+		}
+		if err := txRepo.EndShow(ctx, showID, now); err != nil {
+			return nil, shared_errors.ErrInternalServer
+		}
+		if err := recordEvent(ctx, txRepo, previous.AuctionID, models.EventLotAdvanced, models.LotAdvancedPayload{
+			ShowID:    showID,
+			ShowEnded: true,
+		}); err != nil {
+			return nil, shared_errors.ErrInternalServer
+		}
+	} else if err := s.startLot(ctx, txRepo, show, next, previous, now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	s.signalEvents()
+	return s.GetShow(ctx, showID)
+}
+
+// startLot creates the live auction for a lot and points the show at it.
+// Viewers on the previous lot's stream are told where to go next before the
+// new lot's first event, so following the chain never skips an event.
+func (s *AuctionService) startLot(ctx context.Context, txRepo repository.AuctionRepo, show *models.Show, lot *models.ShowLot, previous *models.Auction, now time.Time) error {
+	auction := lotAuction(show, lot, now)
+	if err := s.prepareAuction(auction, now); err != nil {
+		return err
+	}
+	// The auction is created scheduled and opened the way the scheduler opens
+	// one, so it gets the same audit entry and start event
+	auction.Status = constants.AuctionStatusScheduled
+	if err := txRepo.Create(ctx, auction); err != nil {
+		s.logger.Error("Failed to create lot auction", zap.String("lot_id", lot.LotID), zap.Error(err))
+		return shared_errors.ErrInternalServer
+	}
+	if err := txRepo.StartShowLot(ctx, lot.LotID, auction.AuctionID, now); err != nil {
+		return shared_errors.ErrInternalServer
+	}
+	if err := txRepo.SetShowCurrentLot(ctx, show.ShowID, lot.LotID, auction.AuctionID, now); err != nil {
+		return shared_errors.ErrInternalServer
+	}
+
+	if previous != nil {
+		if err := recordEvent(ctx, txRepo, previous.AuctionID, models.EventLotAdvanced, models.LotAdvancedPayload{
+			ShowID:        show.ShowID,
+			NextLotID:     lot.LotID,
+			NextAuctionID: auction.AuctionID,
+		}); err != nil {
+			return shared_errors.ErrInternalServer
+		}
+	}
+	if err := activateAuction(ctx, txRepo, auction, now); err != nil {
+		s.logger.Error("Failed to activate lot auction", zap.String("lot_id", lot.LotID), zap.Error(err))
+		return shared_errors.ErrInternalServer
+	}
+	if err := recordEvent(ctx, txRepo, auction.AuctionID, models.EventLotStarted, models.LotStartedPayload{
+		ShowID:   show.ShowID,
+		LotID:    lot.LotID,
+		Position: lot.Position,
+		EndTime:  auction.EndTime,
+	}); err != nil {
+		return shared_errors.ErrInternalServer
+	}
+
+	s.logger.Info("Show lot started",
+		zap.String("show_id", show.ShowID),
+		zap.String("lot_id", lot.LotID),
+		zap.String("auction_id", auction.AuctionID))
+	return nil
+}
+
+// lockHostShow locks a show that the caller hosts and that has not ended
+func (s *AuctionService) lockHostShow(ctx context.Context, txRepo repository.AuctionRepo, showID, hostID string) (*models.Show, error) {
+	show, err := txRepo.GetShowForUpdate(ctx, showID)
+	if err == sql.ErrNoRows {
+		return nil, shared_errors.ErrNotFound
+	}
+	if err != nil {
+		s.logger.Error("Failed to get show", zap.String("show_id", showID), zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	if show.HostID != hostID {
+		return nil, shared_errors.ErrForbidden
+	}
+	if show.Status == models.ShowEnded {
+		return nil, shared_errors.ConflictError("SHOW_ENDED", "This show has ended")
+	}
+	return show, nil
+}
+
+// lotAuction builds the short live auction a lot runs as, starting now
+func lotAuction(show *models.Show, lot *models.ShowLot, now time.Time) *models.Auction {
+	duration := lot.Duration
+	if duration <= 0 {
+		duration = show.LotDuration
+	}
+	return &models.Auction{
+		ProductID:       lot.ProductID,
+		Category:        lot.Category,
+		ShowID:          show.ShowID,
+		SellerID:        show.HostID,
+		Title:           lot.Title,
+		Description:     lot.Description,
+		Currency:        show.Currency,
+		StartingPrice:   lot.StartingPrice,
+		ReservePrice:    lot.ReservePrice,
+		MinBidIncrement: lot.MinBidIncrement,
+		BuyNowPrice:     lot.BuyNowPrice,
+		StartTime:       now,
+		EndTime:         now.Add(time.Duration(duration) * time.Second),
+		Type:            models.AuctionTypeLive,
+		SoftClose:       true,
+	}
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/config"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+)
+
+// newShowTestService returns a service whose product lookups fail, which
+// leaves lots without a category
+func newShowTestService(t *testing.T) (*AuctionService, repository.AuctionRepo) {
+	t.Helper()
+	productService := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(productService.Close)
+	cfg, _ := config.Load()
+	cfg.ProductServiceURL = productService.URL
+	repo := repository.NewMemoryRepo()
+	return NewAuctionService(repo, zap.NewNop(), cfg), repo
+}
+
+func TestAddLotValidatesLots(t *testing.T) {
+	service, _ := newShowTestService(t)
+	ctx := context.Background()
+	show := &models.Show{HostID: "host-1", Title: "Friday drops"}
+	if err := service.CreateShow(ctx, show); err != nil {
+		t.Fatalf("create show: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		lot      models.ShowLot
+		wantCode string
+	}{
+		{"reserve below start", models.ShowLot{StartingPrice: 1000, ReservePrice: 500}, "INVALID_RESERVE_PRICE"},
+		{"buy-now below start", models.ShowLot{StartingPrice: 1000, BuyNowPrice: 800}, "INVALID_BUY_NOW_PRICE"},
+		{"valid", models.ShowLot{StartingPrice: 1000, ReservePrice: 2000}, ""},
+	}
+	for _, tt := range tests {
+		lot := tt.lot
+		lot.ProductID, lot.Title = "product-1", tt.name
+		if _, err := service.AddLot(ctx, show.ShowID, "host-1", &lot); errorCode(err) != tt.wantCode {
+			t.Errorf("%s: add lot = %v, want %q", tt.name, err, tt.wantCode)
+		}
+	}
+
+	stored, err := service.GetShow(ctx, show.ShowID)
+	if err != nil || len(stored.Lots) != 1 {
+		t.Fatalf("show has %d lots, want 1: %v", len(stored.Lots), err)
+	}
+	// The lot waits in the queue with no auction until the host brings it up
+	if lot := stored.Lots[0]; lot.Status != models.LotQueued || lot.AuctionID != "" {
+		t.Errorf("added lot %s with auction %q, want queued without one", lot.Status, lot.AuctionID)
+	}
+}
+
+func TestAdvanceShowActivatesLotAuction(t *testing.T) {
+	service, repo := newShowTestService(t)
+	ctx := context.Background()
+	show := &models.Show{HostID: "host-1", Title: "Friday drops"}
+	if err := service.CreateShow(ctx, show); err != nil {
+		t.Fatalf("create show: %v", err)
+	}
+	if _, err := service.AddLot(ctx, show.ShowID, "host-1", &models.ShowLot{ProductID: "product-1", Title: "Lamp", StartingPrice: 1000}); err != nil {
+		t.Fatalf("add lot: %v", err)
+	}
+
+	live, err := service.AdvanceShow(ctx, show.ShowID, "host-1")
+	if err != nil {
+		t.Fatalf("advance: %v", err)
+	}
+	auction, err := repo.GetByID(ctx, live.CurrentAuctionID)
+	if err != nil || auction.Status != constants.AuctionStatusActive {
+		t.Fatalf("lot auction %+v: %v", auction, err)
+	}
+
+	// The lot opens with the same audit entry and event as a scheduled auction
+	audit, err := repo.GetAuditLog(ctx, auction.AuctionID)
+	if err != nil || len(audit) != 1 || audit[0].Action != models.AuditActivated || audit[0].ToStatus != constants.AuctionStatusActive {
+		t.Errorf("audit log %+v: %v", audit, err)
+	}
+	events, err := repo.GetEventsAfter(ctx, auction.AuctionID, 0, 10)
+	if err != nil || len(events) != 2 {
+		t.Fatalf("events %+v: %v", events, err)
+	}
+	if events[0].Type != models.EventAuctionStarted || events[1].Type != models.EventLotStarted {
+		t.Errorf("events %s, %s, want %s then %s", events[0].Type, events[1].Type, models.EventAuctionStarted, models.EventLotStarted)
+	}
+}
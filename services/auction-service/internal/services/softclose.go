@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+)
+
+// applySoftCloseDefaults fills in unset soft-close settings from config
+func (s *AuctionService) applySoftCloseDefaults(auction *models.Auction) {
+	if !auction.SoftClose {
+		return
+	}
+	if auction.SoftCloseWindow <= 0 {
+		auction.SoftCloseWindow = int(s.config.SoftCloseWindow / time.Second)
+	}
+	if auction.SoftCloseExtension <= 0 {
+		auction.SoftCloseExtension = int(s.config.SoftCloseExtension / time.Second)
+	}
+	if auction.MaxExtensions <= 0 {
+		auction.MaxExtensions = s.config.SoftCloseMaxExtensions
+	}
+}
+
+// extendForLateBid pushes back the end of a soft-close auction when a bid
+// lands inside the closing window. It must run in the bid's transaction so
+// the extension commits or rolls back with the bid.
+func (s *AuctionService) extendForLateBid(ctx context.Context, txRepo repository.AuctionRepo, auction *models.Auction, bidTime time.Time) error {
+	if !auction.SoftClose || auction.ExtensionCount >= auction.MaxExtensions {
+		return nil
+	}
+	window := time.Duration(auction.SoftCloseWindow) * time.Second
+	if auction.EndTime.Sub(bidTime) > window {
+		return nil
+	}
+
+	extension := time.Duration(auction.SoftCloseExtension) * time.Second
+	auction.EndTime = auction.EndTime.Add(extension)
+	auction.ExtensionCount++
+
+	if err := txRepo.ExtendEndTime(ctx, auction.AuctionID, auction.EndTime, auction.ExtensionCount); err != nil {
+		s.logger.Error("Failed to extend auction end time", zap.String("auction_id", auction.AuctionID), zap.Error(err))
+		return err
+	}
+
+	return recordEvent(ctx, txRepo, auction.AuctionID, models.EventTimeExtended, map[string]interface{}{
+		"end_time":            auction.EndTime,
+		"extended_by_seconds": auction.SoftCloseExtension,
+		"extension_count":     auction.ExtensionCount,
+		"extensions_allowed":  auction.MaxExtensions,
+	})
+}
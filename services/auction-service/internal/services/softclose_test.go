@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+)
+
+func TestSoftCloseDefaults(t *testing.T) {
+	service, _ := newTestService(t)
+	tests := []struct {
+		name                      string
+		auction                   models.Auction
+		wantSoftClose             bool
+		wantWindow, wantExtension int
+		wantMaxExtensions         int
+	}{
+		{
+			name:              "service defaults",
+			auction:           models.Auction{SoftClose: true},
+			wantSoftClose:     true,
+			wantWindow:        int(service.config.SoftCloseWindow / time.Second),
+			wantExtension:     int(service.config.SoftCloseExtension / time.Second),
+			wantMaxExtensions: service.config.SoftCloseMaxExtensions,
+		},
+		{
+			name:              "seller settings kept",
+			auction:           models.Auction{SoftClose: true, SoftCloseWindow: 60, SoftCloseExtension: 120, MaxExtensions: 3},
+			wantSoftClose:     true,
+			wantWindow:        60,
+			wantExtension:     120,
+			wantMaxExtensions: 3,
+		},
+		{
+			name:    "sealed auctions never extend",
+			auction: models.Auction{Type: models.AuctionTypeSealed, SoftClose: true, SoftCloseWindow: 60},
+		},
+		{
+			name:    "drop auctions never extend",
+			auction: models.Auction{Type: models.AuctionTypeDrop, SoftClose: true, SoftCloseWindow: 60, FloorPrice: 500, DropStep: 100, DropInterval: 60},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auction := createTestAuction(t, service, tt.auction)
+			if auction.SoftClose != tt.wantSoftClose || auction.SoftCloseWindow != tt.wantWindow ||
+				auction.SoftCloseExtension != tt.wantExtension || auction.MaxExtensions != tt.wantMaxExtensions {
+				t.Errorf("soft close %v window %d extension %d max %d, want %v %d %d %d",
+					auction.SoftClose, auction.SoftCloseWindow, auction.SoftCloseExtension, auction.MaxExtensions,
+					tt.wantSoftClose, tt.wantWindow, tt.wantExtension, tt.wantMaxExtensions)
+			}
+		})
+	}
+}
+
+func TestSoftCloseExtensionDelaysClose(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{
+		EndTime:            time.Now().Add(10 * time.Second),
+		SoftClose:          true,
+		SoftCloseWindow:    30,
+		SoftCloseExtension: 60,
+	})
+	placeTestBid(t, service, auction.AuctionID, "alice", 1000)
+
+	events, err := repo.GetEventsAfter(ctx, auction.AuctionID, 0, 100)
+	if err != nil {
+		t.Fatalf("get events: %v", err)
+	}
+	var extended *models.AuctionEvent
+	for _, event := range events {
+		if event.Type == models.EventTimeExtended {
+			extended = event
+		}
+	}
+	if extended == nil {
+		t.Fatalf("no %s event among %v", models.EventTimeExtended, eventTypes(t, repo, auction.AuctionID))
+	}
+	var payload struct {
+		EndTime           time.Time `json:"end_time"`
+		ExtendedBySeconds int       `json:"extended_by_seconds"`
+		ExtensionCount    int       `json:"extension_count"`
+	}
+	if err := json.Unmarshal(extended.Payload, &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	newEnd := auction.EndTime.Add(time.Minute)
+	if !payload.EndTime.Equal(newEnd) || payload.ExtendedBySeconds != 60 || payload.ExtensionCount != 1 {
+		t.Errorf("extension payload %+v, want end %v after 60s, count 1", payload, newEnd)
+	}
+
+	// The scheduler goes by the extended end time
+	if closed, err := service.CloseDueAuctions(ctx, auction.EndTime.Add(time.Second)); err != nil || closed != 0 {
+		t.Fatalf("closed %d at the original end time: %v", closed, err)
+	}
+	if closed, err := service.CloseDueAuctions(ctx, newEnd.Add(time.Second)); err != nil || closed != 1 {
+		t.Fatalf("closed %d at the extended end time: %v", closed, err)
+	}
+	stored, err := repo.GetByID(ctx, auction.AuctionID)
+	if err != nil || stored.Status != constants.AuctionStatusEnded || stored.WinnerID != "alice" {
+		t.Errorf("auction %+v after close: %v", stored, err)
+	}
+}
+
+func TestSoftCloseExtendsForProxyAnswers(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{
+		EndTime:       time.Now().Add(time.Hour),
+		SoftClose:     true,
+		MaxExtensions: 1,
+	})
+	if _, err := service.SetProxyBid(ctx, auction.AuctionID, "alice", 5000); err != nil {
+		t.Fatalf("set maximum: %v", err)
+	}
+	if err := repo.ExtendEndTime(ctx, auction.AuctionID, time.Now().Add(5*time.Second), 0); err != nil {
+		t.Fatalf("move end time: %v", err)
+	}
+
+	// Bob's late bid is answered by alice's maximum; the one extension allowed
+	// is used once however many bids the exchange places
+	placeTestBid(t, service, auction.AuctionID, "bob", 2000)
+	stored, err := repo.GetByID(ctx, auction.AuctionID)
+	if err != nil {
+		t.Fatalf("reload auction: %v", err)
+	}
+	if stored.ExtensionCount != 1 {
+		t.Errorf("%d extensions, want 1", stored.ExtensionCount)
+	}
+	if winning, err := repo.GetWinningBid(ctx, auction.AuctionID); err != nil || winning.BidderID != "alice" {
+		t.Errorf("winning bid %+v: %v", winning, err)
+	}
+}
@@ -0,0 +1,387 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// scheduledAuctionNamespace derives the ID of each auction a schedule
+// creates from the schedule and start time, so a run retried after a failed
+// commit finds the auction it already made instead of listing it twice
+var scheduledAuctionNamespace = uuid.MustParse("6f1c9f0e-2f4b-4b7e-9d55-3c8a61d0a4b2")
+
+// CreateTemplate saves a seller's auction settings for reuse
+func (s *AuctionService) CreateTemplate(ctx context.Context, template *models.AuctionTemplate) error {
+	now := time.Now()
+	template.TemplateID = uuid.New().String()
+	template.CreatedAt = now
+	template.UpdatedAt = now
+	if err := s.prepareTemplate(template, now); err != nil {
+		return err
+	}
+
+	if err := s.repo.CreateTemplate(ctx, template); err != nil {
+		return shared_errors.ErrInternalServer
+	}
+	return nil
+}
+
+// UpdateTemplate replaces a template's settings. Auctions already made from
+// it keep the settings they were created with.
+func (s *AuctionService) UpdateTemplate(ctx context.Context, templateID, sellerID string, template *models.AuctionTemplate) (*models.AuctionTemplate, error) {
+	existing, err := s.getSellerTemplate(ctx, s.repo, templateID, sellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template.TemplateID = existing.TemplateID
+	template.SellerID = existing.SellerID
+	template.CreatedAt = existing.CreatedAt
+	template.UpdatedAt = now
+	if err := s.prepareTemplate(template, now); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateTemplate(ctx, template); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	return template, nil
+}
+
+// GetTemplate returns one of the caller's templates
+func (s *AuctionService) GetTemplate(ctx context.Context, templateID, sellerID string) (*models.AuctionTemplate, error) {
+	return s.getSellerTemplate(ctx, s.repo, templateID, sellerID)
+}
+
+// GetSellerTemplates lists the caller's templates, newest first
+func (s *AuctionService) GetSellerTemplates(ctx context.Context, sellerID string) ([]*models.AuctionTemplate, error) {
+	templates, err := s.repo.GetSellerTemplates(ctx, sellerID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	return templates, nil
+}
+
+// DeleteTemplate removes a template along with its schedules
+func (s *AuctionService) DeleteTemplate(ctx context.Context, templateID, sellerID string) error {
+	if _, err := s.getSellerTemplate(ctx, s.repo, templateID, sellerID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteTemplate(ctx, templateID); err != nil {
+		return shared_errors.ErrInternalServer
+	}
+	return nil
+}
+
+// CreateAuctionFromTemplate lists an auction with a template's settings,
+// starting at startTime or now
+func (s *AuctionService) CreateAuctionFromTemplate(ctx context.Context, templateID, sellerID string, startTime time.Time) (*models.Auction, error) {
+	template, err := s.getSellerTemplate(ctx, s.repo, templateID, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	if startTime.IsZero() {
+		startTime = time.Now()
+	}
+
+	auction := templateAuction(template, startTime)
+	if err := s.CreateAuction(ctx, auction); err != nil {
+		return nil, err
+	}
+	return auction, nil
+}
+
+// CreateSchedule repeats a template at a local time of day on the given
+// weekdays. The first auction is created once its start is within the
+// schedule lead.
+func (s *AuctionService) CreateSchedule(ctx context.Context, templateID, sellerID string, req *models.CreateScheduleRequest) (*models.AuctionSchedule, error) {
+	if _, err := s.getSellerTemplate(ctx, s.repo, templateID, sellerID); err != nil {
+		return nil, err
+	}
+
+	if req.Timezone == "" {
+		req.Timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		return nil, shared_errors.ValidationError("INVALID_TIMEZONE", fmt.Sprintf("Unknown timezone %q", req.Timezone))
+	}
+	hour, minute, err := parseTimeOfDay(req.Time)
+	if err != nil {
+		return nil, shared_errors.ValidationError("INVALID_TIME", "Time must be HH:MM in 24-hour form")
+	}
+
+	now := time.Now()
+	schedule := &models.AuctionSchedule{
+		ScheduleID: uuid.New().String(),
+		TemplateID: templateID,
+		SellerID:   sellerID,
+		Weekdays:   normaliseWeekdays(req.Weekdays),
+		TimeOfDay:  fmt.Sprintf("%02d:%02d", hour, minute),
+		Timezone:   loc.String(),
+		Active:     true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	schedule.NextRunAt = nextOccurrence(schedule, loc, now).Local()
+	if err := s.repo.CreateSchedule(ctx, schedule); err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	return schedule, nil
+}
+
+// GetTemplateSchedules lists a template's schedules
+func (s *AuctionService) GetTemplateSchedules(ctx context.Context, templateID, sellerID string) ([]*models.AuctionSchedule, error) {
+	if _, err := s.getSellerTemplate(ctx, s.repo, templateID, sellerID); err != nil {
+		return nil, err
+	}
+	schedules, err := s.repo.GetTemplateSchedules(ctx, templateID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	return schedules, nil
+}
+
+// DeleteSchedule stops a schedule. Auctions it already created stay listed.
+func (s *AuctionService) DeleteSchedule(ctx context.Context, templateID, scheduleID, sellerID string) error {
+	if _, err := s.getSellerTemplate(ctx, s.repo, templateID, sellerID); err != nil {
+		return err
+	}
+	deleted, err := s.repo.DeleteSchedule(ctx, templateID, scheduleID)
+	if err != nil {
+		return shared_errors.ErrInternalServer
+	}
+	if !deleted {
+		return shared_errors.ErrNotFound
+	}
+	return nil
+}
+
+// MaterializeSchedules creates the auctions recurring schedules are due to
+// list, up to the schedule lead ahead of their start. Like order hand-offs
+// it stops at the first failure and tries again on a later tick.
+func (s *AuctionService) MaterializeSchedules(ctx context.Context, now time.Time) (int, error) {
+	processed := 0
+	for processed < lifecycleBatchSize {
+		ok, err := s.materializeNextSchedule(ctx, now)
+		if err != nil {
+			return processed, err
+		}
+		if !ok {
+			break
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+func (s *AuctionService) materializeNextSchedule(ctx context.Context, now time.Time) (bool, error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	schedule, err := txRepo.ClaimNextDueSchedule(ctx, now.Add(s.config.ScheduleLead))
+	if err != nil || schedule == nil {
+		return false, err
+	}
+
+	loc, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return s.stopSchedule(ctx, tx, txRepo, schedule, fmt.Sprintf("unknown timezone %q", schedule.Timezone), now)
+	}
+
+	// An occurrence whose start has already passed was missed, for example
+	// while the service was down; it is skipped rather than started late
+	auctionID := ""
+	runAt := schedule.NextRunAt
+	if runAt.After(now) {
+		auctionID = uuid.NewSHA1(scheduledAuctionNamespace, []byte(schedule.ScheduleID+"/"+runAt.UTC().Format(time.RFC3339))).String()
+		created, err := s.createScheduledAuction(ctx, txRepo, schedule, auctionID, runAt)
+		if err != nil {
+			if appErr, ok := shared_errors.IsAppError(err); ok && appErr.StatusCode < http.StatusInternalServerError {
+				return s.stopSchedule(ctx, tx, txRepo, schedule, appErr.Message, now)
+			}
+			return false, err
+		}
+		if created {
+			s.logger.Info("Scheduled auction created",
+				zap.String("schedule_id", schedule.ScheduleID),
+				zap.String("auction_id", auctionID),
+				zap.Time("start_time", runAt))
+		}
+	}
+
+	next := nextOccurrence(schedule, loc, maxTime(runAt, now))
+	if err := txRepo.AdvanceSchedule(ctx, schedule.ScheduleID, next.Local(), auctionID, now); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// createScheduledAuction lists a schedule's auction for runAt through
+// CreateAuction. It reports false if an earlier attempt already created it.
+func (s *AuctionService) createScheduledAuction(ctx context.Context, txRepo repository.AuctionRepo, schedule *models.AuctionSchedule, auctionID string, runAt time.Time) (bool, error) {
+	if _, err := s.repo.GetByID(ctx, auctionID); err == nil {
+		return false, nil
+	} else if err != sql.ErrNoRows {
+		return false, err
+	}
+
+	template, err := txRepo.GetTemplate(ctx, schedule.TemplateID)
+	if err != nil {
+		return false, err
+	}
+	auction := templateAuction(template, runAt)
+	auction.AuctionID = auctionID
+	if err := s.CreateAuction(ctx, auction); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// stopSchedule deactivates a schedule that cannot make a valid auction, so
+// it does not fail again on every tick
+func (s *AuctionService) stopSchedule(ctx context.Context, tx repository.Tx, txRepo repository.AuctionRepo, schedule *models.AuctionSchedule, reason string, now time.Time) (bool, error) {
+	if err := txRepo.DeactivateSchedule(ctx, schedule.ScheduleID, reason, now); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	s.logger.Warn("Auction schedule stopped",
+		zap.String("schedule_id", schedule.ScheduleID),
+		zap.String("template_id", schedule.TemplateID),
+		zap.String("reason", reason))
+	return true, nil
+}
+
+// getSellerTemplate reads a template that belongs to sellerID
+func (s *AuctionService) getSellerTemplate(ctx context.Context, repo repository.AuctionRepo, templateID, sellerID string) (*models.AuctionTemplate, error) {
+	template, err := repo.GetTemplate(ctx, templateID)
+	if err == sql.ErrNoRows {
+		return nil, shared_errors.ErrNotFound
+	}
+	if err != nil {
+		s.logger.Error("Failed to get auction template", zap.String("template_id", templateID), zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	if template.SellerID != sellerID {
+		return nil, shared_errors.ErrForbidden
+	}
+	return template, nil
+}
+
+// prepareTemplate checks a template would make a valid auction, so problems
+// surface when it is saved rather than when a schedule next runs
+func (s *AuctionService) prepareTemplate(template *models.AuctionTemplate, now time.Time) error {
+	if template.Duration <= 0 {
+		return shared_errors.ValidationError("INVALID_AUCTION_WINDOW", "Duration must be positive")
+	}
+	template.Images = numberImages(template.Images, "")
+
+	trial := templateAuction(template, now)
+	if err := s.prepareAuction(trial, now); err != nil {
+		return err
+	}
+	template.Currency = trial.Currency
+	return nil
+}
+
+// templateAuction builds the auction a template makes when started at start
+func templateAuction(template *models.AuctionTemplate, start time.Time) *models.Auction {
+	images := make([]models.AuctionImage, len(template.Images))
+	for i, image := range template.Images {
+		images[i] = models.AuctionImage{ImageURL: image.ImageURL, AltText: image.AltText, Order: image.Order}
+	}
+	return &models.Auction{
+		ProductID:          template.ProductID,
+		TemplateID:         template.TemplateID,
+		SellerID:           template.SellerID,
+		Title:              template.Title,
+		Description:        template.Description,
+		Currency:           template.Currency,
+		StartingPrice:      template.StartingPrice,
+		ReservePrice:       template.ReservePrice,
+		MinBidIncrement:    template.MinBidIncrement,
+		BuyNowPrice:        template.BuyNowPrice,
+		PricingRule:        template.PricingRule,
+		StartTime:          start,
+		EndTime:            start.Add(time.Duration(template.Duration) * time.Second),
+		Type:               template.Type,
+		SoftClose:          template.SoftClose,
+		SoftCloseWindow:    template.SoftCloseWindow,
+		SoftCloseExtension: template.SoftCloseExtension,
+		MaxExtensions:      template.MaxExtensions,
+		FloorPrice:         template.FloorPrice,
+		DropStep:           template.DropStep,
+		DropInterval:       template.DropInterval,
+		Quantity:           template.Quantity,
+		DepositAmount:      template.DepositAmount,
+		Images:             images,
+	}
+}
+
+// nextOccurrence returns the first time strictly after after that falls on
+// one of the schedule's weekdays at its local time of day
+func nextOccurrence(schedule *models.AuctionSchedule, loc *time.Location, after time.Time) time.Time {
+	hour, minute, _ := parseTimeOfDay(schedule.TimeOfDay)
+	days := map[time.Weekday]bool{}
+	for _, day := range schedule.Weekdays {
+		days[time.Weekday(day)] = true
+	}
+
+	local := after.In(loc)
+	for offset := 0; offset <= 7; offset++ {
+		// time.Date normalises the day overflow and DST gaps
+		candidate := time.Date(local.Year(), local.Month(), local.Day()+offset, hour, minute, 0, 0, loc)
+		if candidate.After(after) && (len(days) == 0 || days[candidate.Weekday()]) {
+			return candidate
+		}
+	}
+	// Unreachable: every weekday occurs within eight days
+	return after.Add(7 * 24 * time.Hour)
+}
+
+// parseTimeOfDay reads an HH:MM time of day
+func parseTimeOfDay(value string) (int, int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, 0, err
+	}
+	return parsed.Hour(), parsed.Minute(), nil
+}
+
+// normaliseWeekdays sorts and de-duplicates weekdays
+func normaliseWeekdays(weekdays []int) []int {
+	seen := map[int]bool{}
+	normalised := []int{}
+	for _, day := range weekdays {
+		if !seen[day] {
+			seen[day] = true
+			normalised = append(normalised, day)
+		}
+	}
+	sort.Ints(normalised)
+	return normalised
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/constants"
+)
+
+// createTestTemplate saves a valid template for seller-1 lasting an hour
+func createTestTemplate(t *testing.T, service *AuctionService) *models.AuctionTemplate {
+	t.Helper()
+	template := &models.AuctionTemplate{
+		SellerID:      "seller-1",
+		Name:          "Weekly lamp",
+		ProductID:     "product-1",
+		Title:         "Lamp",
+		Type:          models.AuctionTypeScheduled,
+		StartingPrice: 1000,
+		Duration:      3600,
+		Images:        []models.AuctionImage{{ImageURL: "https://example.com/lamp.jpg"}},
+	}
+	if err := service.CreateTemplate(context.Background(), template); err != nil {
+		t.Fatalf("create template: %v", err)
+	}
+	return template
+}
+
+// createDailySchedule schedules template every day at the UTC minute two
+// hours from now, so its first run falls inside the default schedule lead
+func createDailySchedule(t *testing.T, service *AuctionService, template *models.AuctionTemplate) *models.AuctionSchedule {
+	t.Helper()
+	schedule, err := service.CreateSchedule(context.Background(), template.TemplateID, template.SellerID, &models.CreateScheduleRequest{
+		Time: time.Now().UTC().Add(2 * time.Hour).Format("15:04"),
+	})
+	if err != nil {
+		t.Fatalf("create schedule: %v", err)
+	}
+	return schedule
+}
+
+func TestNextOccurrence(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Kuala_Lumpur")
+	if err != nil {
+		t.Skipf("no timezone data: %v", err)
+	}
+	mondaysAndWednesdays := &models.AuctionSchedule{Weekdays: []int{1, 3}, TimeOfDay: "09:30"}
+	daily := &models.AuctionSchedule{TimeOfDay: "09:30"}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.October, day, hour, minute, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name     string
+		schedule *models.AuctionSchedule
+		after    time.Time
+		want     time.Time
+	}{
+		{"later the same day", mondaysAndWednesdays, at(12, 8, 0), at(12, 9, 30)},
+		{"strictly after", mondaysAndWednesdays, at(12, 9, 30), at(14, 9, 30)},
+		{"over the weekend", mondaysAndWednesdays, at(17, 12, 0), at(19, 9, 30)},
+		{"every day", daily, at(17, 12, 0), at(18, 9, 30)},
+		{"from another zone", daily, at(12, 8, 0).UTC(), at(12, 9, 30)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextOccurrence(tt.schedule, loc, tt.after); !got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateTemplateValidation(t *testing.T) {
+	service, _ := newShowTestService(t)
+	ctx := context.Background()
+	template := createTestTemplate(t, service)
+	if template.Currency == "" || template.Images[0].Order != 0 {
+		t.Errorf("template defaults not filled in: %+v", template)
+	}
+
+	tests := []struct {
+		name   string
+		change func(*models.AuctionTemplate)
+		want   string
+	}{
+		{"no duration", func(tmpl *models.AuctionTemplate) { tmpl.Duration = 0 }, "INVALID_AUCTION_WINDOW"},
+		{"reserve below start", func(tmpl *models.AuctionTemplate) { tmpl.ReservePrice = 500 }, "INVALID_RESERVE_PRICE"},
+		{"unknown currency", func(tmpl *models.AuctionTemplate) { tmpl.Currency = "XYZ" }, "UNSUPPORTED_CURRENCY"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invalid := *template
+			tt.change(&invalid)
+			if err := service.CreateTemplate(ctx, &invalid); errorCode(err) != tt.want {
+				t.Errorf("got %v, want %s", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := service.GetTemplate(ctx, template.TemplateID, "seller-2"); errorCode(err) != "FORBIDDEN" {
+		t.Errorf("another seller's template: %v, want FORBIDDEN", err)
+	}
+	start := time.Now().Add(time.Hour)
+	auction, err := service.CreateAuctionFromTemplate(ctx, template.TemplateID, template.SellerID, start)
+	if err != nil {
+		t.Fatalf("create from template: %v", err)
+	}
+	if auction.TemplateID != template.TemplateID || auction.Status != constants.AuctionStatusScheduled ||
+		!auction.EndTime.Equal(start.Add(time.Hour)) || auction.StartingPrice != 1000 {
+		t.Errorf("auction from template %+v", auction)
+	}
+}
+
+func TestCreateScheduleValidation(t *testing.T) {
+	service, _ := newShowTestService(t)
+	ctx := context.Background()
+	template := createTestTemplate(t, service)
+
+	tests := []struct {
+		name     string
+		sellerID string
+		req      models.CreateScheduleRequest
+		want     string
+	}{
+		{"unknown timezone", "seller-1", models.CreateScheduleRequest{Time: "09:30", Timezone: "Mars/Olympus"}, "INVALID_TIMEZONE"},
+		{"bad time", "seller-1", models.CreateScheduleRequest{Time: "9.30pm"}, "INVALID_TIME"},
+		{"another seller", "seller-2", models.CreateScheduleRequest{Time: "09:30"}, "FORBIDDEN"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			if _, err := service.CreateSchedule(ctx, template.TemplateID, tt.sellerID, &req); errorCode(err) != tt.want {
+				t.Errorf("got %v, want %s", err, tt.want)
+			}
+		})
+	}
+
+	schedule, err := service.CreateSchedule(ctx, template.TemplateID, template.SellerID, &models.CreateScheduleRequest{
+		Weekdays: []int{3, 1, 3}, Time: "9:05",
+	})
+	if err != nil {
+		t.Fatalf("create schedule: %v", err)
+	}
+	if !slices.Equal(schedule.Weekdays, []int{1, 3}) || schedule.TimeOfDay != "09:05" || schedule.Timezone != "UTC" || !schedule.NextRunAt.After(time.Now()) {
+		t.Errorf("schedule %+v", schedule)
+	}
+}
+
+func TestMaterializeSchedules(t *testing.T) {
+	service, repo := newShowTestService(t)
+	ctx := context.Background()
+	template := createTestTemplate(t, service)
+	schedule := createDailySchedule(t, service, template)
+
+	now := time.Now()
+	if processed, err := service.MaterializeSchedules(ctx, now); err != nil || processed != 1 {
+		t.Fatalf("processed %d: %v", processed, err)
+	}
+	// The next day's auction is not due until the following run
+	if processed, err := service.MaterializeSchedules(ctx, now); err != nil || processed != 0 {
+		t.Fatalf("processed %d again: %v", processed, err)
+	}
+
+	schedules, err := service.GetTemplateSchedules(ctx, template.TemplateID, template.SellerID)
+	if err != nil || len(schedules) != 1 {
+		t.Fatalf("schedules %+v: %v", schedules, err)
+	}
+	advanced := schedules[0]
+	if !advanced.NextRunAt.Equal(schedule.NextRunAt.Add(24*time.Hour)) || advanced.LastAuctionID == "" {
+		t.Errorf("schedule after a run %+v", advanced)
+	}
+	auction, err := repo.GetByID(ctx, advanced.LastAuctionID)
+	if err != nil {
+		t.Fatalf("get scheduled auction: %v", err)
+	}
+	if auction.TemplateID != template.TemplateID || !auction.StartTime.Equal(schedule.NextRunAt) || auction.Status != constants.AuctionStatusScheduled {
+		t.Errorf("scheduled auction %+v", auction)
+	}
+}
+
+func TestMaterializeSchedulesSkipsMissedRuns(t *testing.T) {
+	service, repo := newShowTestService(t)
+	ctx := context.Background()
+	template := createTestTemplate(t, service)
+	schedule := createDailySchedule(t, service, template)
+	// A short lead keeps the following day's run out of reach
+	service.config.ScheduleLead = time.Hour
+
+	// The service was down when the auction should have been created
+	now := schedule.NextRunAt.Add(time.Minute)
+	if processed, err := service.MaterializeSchedules(ctx, now); err != nil || processed != 1 {
+		t.Fatalf("processed %d: %v", processed, err)
+	}
+	schedules, err := service.GetTemplateSchedules(ctx, template.TemplateID, template.SellerID)
+	if err != nil || len(schedules) != 1 {
+		t.Fatalf("schedules %+v: %v", schedules, err)
+	}
+	if !schedules[0].NextRunAt.Equal(schedule.NextRunAt.Add(24*time.Hour)) || schedules[0].LastAuctionID != "" {
+		t.Errorf("schedule after a missed run %+v", schedules[0])
+	}
+	if _, total, err := repo.List(ctx, &models.AuctionFilter{SellerID: template.SellerID, Sort: models.SortNewest, Limit: 10}); err != nil || total != 0 {
+		t.Errorf("%d auctions created for a missed run: %v", total, err)
+	}
+}
+
+func TestMaterializeSchedulesStopsInvalidTemplates(t *testing.T) {
+	service, repo := newShowTestService(t)
+	ctx := context.Background()
+	template := createTestTemplate(t, service)
+	createDailySchedule(t, service, template)
+
+	// Saved before the currency was withdrawn, say
+	broken := *template
+	broken.Currency = "XYZ"
+	if err := repo.UpdateTemplate(ctx, &broken); err != nil {
+		t.Fatalf("update template: %v", err)
+	}
+	if processed, err := service.MaterializeSchedules(ctx, time.Now()); err != nil || processed != 1 {
+		t.Fatalf("processed %d: %v", processed, err)
+	}
+	schedules, err := service.GetTemplateSchedules(ctx, template.TemplateID, template.SellerID)
+	if err != nil || len(schedules) != 1 {
+		t.Fatalf("schedules %+v: %v", schedules, err)
+	}
+	if schedules[0].Active || schedules[0].LastError == "" {
+		t.Errorf("schedule with an invalid template %+v", schedules[0])
+	}
+	if processed, err := service.MaterializeSchedules(ctx, time.Now()); err != nil || processed != 0 {
+		t.Errorf("stopped schedule processed %d times: %v", processed, err)
+	}
+}
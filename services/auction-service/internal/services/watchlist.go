@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/repository"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// WatchAuction adds an auction to the user's watchlist. Watching twice is
+// not an error.
+func (s *AuctionService) WatchAuction(ctx context.Context, auctionID, userID string) (*models.WatchResponse, error) {
+	return s.setWatching(ctx, auctionID, userID, true)
+}
+
+// UnwatchAuction removes an auction from the user's watchlist
+func (s *AuctionService) UnwatchAuction(ctx context.Context, auctionID, userID string) (*models.WatchResponse, error) {
+	return s.setWatching(ctx, auctionID, userID, false)
+}
+
+func (s *AuctionService) setWatching(ctx context.Context, auctionID, userID string, watching bool) (*models.WatchResponse, error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	auction, err := txRepo.GetByIDForUpdate(ctx, auctionID)
+	if err != nil {
+		return nil, shared_errors.ErrNotFound
+	}
+
+	if watching {
+		err = s.autoWatch(ctx, txRepo, auction, userID)
+	} else {
+		var removed bool
+		if removed, err = txRepo.RemoveWatch(ctx, auctionID, userID); removed {
+			auction.WatcherCount--
+		}
+	}
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, shared_errors.ErrInternalServer
+	}
+
+	return &models.WatchResponse{
+		AuctionID: auctionID,
+		Watching:  watching,
+		Watchers:  auction.WatcherCount,
+	}, nil
+}
+
+// autoWatch adds the user to a locked auction's watchers
+func (s *AuctionService) autoWatch(ctx context.Context, txRepo repository.AuctionRepo, auction *models.Auction, userID string) error {
+	added, err := txRepo.AddWatch(ctx, auction.AuctionID, userID)
+	if err != nil {
+		return err
+	}
+	if added {
+		auction.WatcherCount++
+	}
+	return nil
+}
+
+// GetWatchedAuctions lists the auctions a user watches
+func (s *AuctionService) GetWatchedAuctions(ctx context.Context, userID string) ([]*models.Auction, error) {
+	auctions, err := s.repo.GetWatchedAuctions(ctx, userID)
+	if err != nil {
+		return nil, shared_errors.ErrInternalServer
+	}
+	if auctions == nil {
+		auctions = []*models.Auction{}
+	}
+	now := time.Now()
+	for _, auction := range auctions {
+		hideSealedBidCount(auction, now)
+	}
+	return auctions, nil
+}
+
+// ScheduleEndingSoonAlerts records an ending-soon event for each active
+// auction that has entered its final EndingSoonLead
+func (s *AuctionService) ScheduleEndingSoonAlerts(ctx context.Context, now time.Time) (int, error) {
+	scheduled := 0
+	for scheduled < lifecycleBatchSize {
+		ok, err := s.scheduleNextEndingSoon(ctx, now)
+		if err != nil {
+			return scheduled, err
+		}
+		if !ok {
+			break
+		}
+		scheduled++
+	}
+	return scheduled, nil
+}
+
+func (s *AuctionService) scheduleNextEndingSoon(ctx context.Context, now time.Time) (bool, error) {
+	tx, err := s.repo.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	txRepo := s.repo.WithTx(tx)
+
+	auction, err := txRepo.ClaimNextEndingSoon(ctx, now.Add(s.config.EndingSoonLead))
+	if err != nil || auction == nil {
+		return false, err
+	}
+
+	if err := txRepo.MarkEndingSoon(ctx, auction.AuctionID, now); err != nil {
+		return false, err
+	}
+	if err := recordEvent(ctx, txRepo, auction.AuctionID, models.EventEndingSoon, map[string]interface{}{
+		"end_time":      auction.EndTime,
+		"current_price": auction.CurrentPrice,
+	}); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+func TestWatchAuction(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{})
+	other := createTestAuction(t, service, models.Auction{})
+
+	steps := []struct {
+		name         string
+		userID       string
+		watch        bool
+		wantWatchers int
+	}{
+		{"first watcher", "alice", true, 1},
+		{"watching twice", "alice", true, 1},
+		{"second watcher", "bob", true, 2},
+		{"unwatch", "alice", false, 1},
+		{"unwatch twice", "alice", false, 1},
+	}
+	for _, step := range steps {
+		var resp *models.WatchResponse
+		var err error
+		if step.watch {
+			resp, err = service.WatchAuction(ctx, auction.AuctionID, step.userID)
+		} else {
+			resp, err = service.UnwatchAuction(ctx, auction.AuctionID, step.userID)
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if resp.Watching != step.watch || resp.Watchers != step.wantWatchers {
+			t.Errorf("%s: watching %v with %d watchers, want %v with %d", step.name, resp.Watching, resp.Watchers, step.watch, step.wantWatchers)
+		}
+	}
+
+	if _, err := service.WatchAuction(ctx, other.AuctionID, "bob"); err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	watched, err := service.GetWatchedAuctions(ctx, "bob")
+	if err != nil || len(watched) != 2 {
+		t.Errorf("bob watches %d auctions, want 2: %v", len(watched), err)
+	}
+	if watched, err := service.GetWatchedAuctions(ctx, "alice"); err != nil || watched == nil || len(watched) != 0 {
+		t.Errorf("alice watches %v, want an empty list: %v", watched, err)
+	}
+	if _, err := service.WatchAuction(ctx, "missing", "alice"); errorCode(err) != "NOT_FOUND" {
+		t.Errorf("watching an unknown auction = %v, want NOT_FOUND", err)
+	}
+}
+
+func TestEndingSoonAlertsWatchers(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{EndTime: time.Now().Add(time.Hour)})
+	for _, userID := range []string{"alice", "bob"} {
+		if _, err := service.WatchAuction(ctx, auction.AuctionID, userID); err != nil {
+			t.Fatalf("watch: %v", err)
+		}
+	}
+
+	alertAt := auction.EndTime.Add(-service.config.EndingSoonLead)
+	for _, step := range []struct {
+		name string
+		now  time.Time
+		want int
+	}{
+		{"before the lead", alertAt.Add(-time.Second), 0},
+		{"inside the lead", alertAt, 1},
+		{"only once", alertAt.Add(time.Minute), 0},
+	} {
+		if scheduled, err := service.ScheduleEndingSoonAlerts(ctx, step.now); err != nil || scheduled != step.want {
+			t.Fatalf("%s: scheduled %d, want %d: %v", step.name, scheduled, step.want, err)
+		}
+	}
+
+	notifier := &recordingNotifier{}
+	relay := NewEventRelay(repo, zap.NewNop())
+	relay.Subscribe(NewWatcherAlerter(repo, notifier, zap.NewNop()))
+	if _, err := relay.Dispatch(ctx); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	alerted := map[string]bool{}
+	for _, notification := range notifier.sent {
+		if notification.Title == "Ending soon" {
+			alerted[notification.UserID] = true
+		}
+	}
+	if len(alerted) != 2 || !alerted["alice"] || !alerted["bob"] {
+		t.Errorf("ending-soon alerts went to %v, want alice and bob", alerted)
+	}
+}
+
+func TestOutbidAlertsWatchingBidder(t *testing.T) {
+	service, repo := newTestService(t)
+	ctx := context.Background()
+	auction := createTestAuction(t, service, models.Auction{})
+	placeTestBid(t, service, auction.AuctionID, "alice", 1000)
+	placeTestBid(t, service, auction.AuctionID, "carol", 1100)
+	// Carol stops watching, so being outbid in turn does not alert her
+	if _, err := service.UnwatchAuction(ctx, auction.AuctionID, "carol"); err != nil {
+		t.Fatalf("unwatch: %v", err)
+	}
+	placeTestBid(t, service, auction.AuctionID, "bob", 1200)
+
+	notifier := &recordingNotifier{}
+	relay := NewEventRelay(repo, zap.NewNop())
+	relay.Subscribe(NewWatcherAlerter(repo, notifier, zap.NewNop()))
+	if _, err := relay.Dispatch(ctx); err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+	var outbid []string
+	for _, notification := range notifier.sent {
+		if notification.Title == "You've been outbid" {
+			outbid = append(outbid, notification.UserID)
+		}
+	}
+	if len(outbid) != 1 || outbid[0] != "alice" {
+		t.Errorf("outbid alerts went to %v, want only alice", outbid)
+	}
+}
@@ -0,0 +1,174 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+const (
+	channelPrefix = "auction:"
+
+	// subscriberBuffer is how far a client may fall behind before it is
+	// dropped. Dropped clients reconnect and resume from their last event ID.
+	subscriberBuffer = 64
+)
+
+// streamedEvents are the outbox event types pushed to stream clients
+var streamedEvents = map[string]bool{
+	models.EventBidPlaced:    true,
+	models.EventPriceChanged: true,
+	models.EventTimeExtended: true,
+	models.EventAuctionEnded: true,
+	models.EventBidRetracted: true,
+	models.EventLotStarted:   true,
+	models.EventLotAdvanced:  true,
+}
+
+// Streamed reports whether events of this type are sent to stream clients
+func Streamed(eventType string) bool {
+	return streamedEvents[eventType]
+}
+
+// Channel is the Redis pub/sub channel carrying an auction's events
+func Channel(auctionID string) string {
+	return channelPrefix + auctionID
+}
+
+// Hub fans auction events out to the stream clients connected to this
+// replica. Events reach every replica through Redis pub/sub, so a client sees
+// them regardless of which replica relayed them from the outbox.
+type Hub struct {
+	client      *redis.Client
+	logger      *zap.Logger
+	mu          sync.Mutex
+	subscribers map[string]map[*Subscription]struct{}
+	closed      bool
+}
+
+// Subscription delivers one auction's live events to a single client. Events
+// is closed when the client falls too far behind or the hub shuts down.
+type Subscription struct {
+	Events    <-chan *models.AuctionEvent
+	events    chan *models.AuctionEvent
+	auctionID string
+	hub       *Hub
+}
+
+func NewHub(client *redis.Client, logger *zap.Logger) *Hub {
+	return &Hub{
+		client:      client,
+		logger:      logger,
+		subscribers: make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+// Publish sends a committed event to every replica's stream clients. It is
+// registered as an EventRelay handler.
+func (h *Hub) Publish(ctx context.Context, event *models.AuctionEvent) error {
+	if !Streamed(event.Type) {
+		return nil
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return h.client.Publish(ctx, Channel(event.AuctionID), data).Err()
+}
+
+// Run receives events from Redis until ctx is cancelled, then closes all
+// subscriptions
+func (h *Hub) Run(ctx context.Context) {
+	pubsub := h.client.PSubscribe(ctx, channelPrefix+"*")
+	defer pubsub.Close()
+	defer h.closeAll()
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			var event models.AuctionEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				h.logger.Error("Failed to decode streamed auction event", zap.String("channel", msg.Channel), zap.Error(err))
+				continue
+			}
+			h.broadcast(strings.TrimPrefix(msg.Channel, channelPrefix), &event)
+		}
+	}
+}
+
+// Subscribe registers a client for an auction's live events
+func (h *Hub) Subscribe(auctionID string) *Subscription {
+	events := make(chan *models.AuctionEvent, subscriberBuffer)
+	sub := &Subscription{Events: events, events: events, auctionID: auctionID, hub: h}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(events)
+		return sub
+	}
+	if h.subscribers[auctionID] == nil {
+		h.subscribers[auctionID] = make(map[*Subscription]struct{})
+	}
+	h.subscribers[auctionID][sub] = struct{}{}
+	return sub
+}
+
+// Close unregisters the subscription. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.remove(s)
+}
+
+func (h *Hub) broadcast(auctionID string, event *models.AuctionEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subscribers[auctionID] {
+		select {
+		case sub.events <- event:
+		default:
+			h.logger.Warn("Dropping slow auction stream client", zap.String("auction_id", auctionID))
+			h.remove(sub)
+		}
+	}
+}
+
+// remove must be called with h.mu held
+func (h *Hub) remove(sub *Subscription) {
+	subs, ok := h.subscribers[sub.auctionID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	close(sub.events)
+	if len(subs) == 0 {
+		delete(h.subscribers, sub.auctionID)
+	}
+}
+
+func (h *Hub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for _, subs := range h.subscribers {
+		for sub := range subs {
+			h.remove(sub)
+		}
+	}
+}
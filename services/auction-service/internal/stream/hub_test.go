@@ -0,0 +1,94 @@
+package stream
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/gmsas95/blytz-mvp/services/auction-service/internal/models"
+)
+
+func TestHubBroadcastsToAuctionSubscribers(t *testing.T) {
+	hub := NewHub(nil, zap.NewNop())
+	first := hub.Subscribe("auction-1")
+	second := hub.Subscribe("auction-1")
+	other := hub.Subscribe("auction-2")
+	defer first.Close()
+	defer second.Close()
+	defer other.Close()
+
+	hub.broadcast("auction-1", &models.AuctionEvent{AuctionID: "auction-1", Sequence: 1, Type: models.EventBidPlaced})
+
+	for name, sub := range map[string]*Subscription{"first": first, "second": second} {
+		select {
+		case event := <-sub.Events:
+			if event.Sequence != 1 {
+				t.Errorf("%s subscriber got sequence %d, want 1", name, event.Sequence)
+			}
+		default:
+			t.Errorf("%s subscriber got nothing", name)
+		}
+	}
+	select {
+	case event := <-other.Events:
+		t.Errorf("subscriber to another auction got %+v", event)
+	default:
+	}
+}
+
+func TestHubDropsSlowSubscribers(t *testing.T) {
+	hub := NewHub(nil, zap.NewNop())
+	slow := hub.Subscribe("auction-1")
+
+	for seq := int64(1); seq <= subscriberBuffer+1; seq++ {
+		hub.broadcast("auction-1", &models.AuctionEvent{AuctionID: "auction-1", Sequence: seq})
+	}
+
+	// The buffered events are still delivered, then the channel closes so the
+	// client reconnects and resumes from its last event ID
+	received := 0
+	for range slow.Events {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Errorf("received %d events before being dropped, want %d", received, subscriberBuffer)
+	}
+	slow.Close()
+	if len(hub.subscribers) != 0 {
+		t.Errorf("%d auctions still have subscribers", len(hub.subscribers))
+	}
+}
+
+func TestHubClosesSubscriptionsOnShutdown(t *testing.T) {
+	hub := NewHub(nil, zap.NewNop())
+	sub := hub.Subscribe("auction-1")
+	hub.closeAll()
+
+	if _, ok := <-sub.Events; ok {
+		t.Error("subscription still open after shutdown")
+	}
+	sub.Close()
+
+	late := hub.Subscribe("auction-1")
+	if _, ok := <-late.Events; ok {
+		t.Error("subscription opened after shutdown")
+	}
+}
+
+func TestStreamed(t *testing.T) {
+	tests := map[string]bool{
+		models.EventBidPlaced:      true,
+		models.EventTimeExtended:   true,
+		models.EventLotAdvanced:    true,
+		models.EventAuctionSettled: false,
+		models.EventOutbid:         false,
+	}
+	for eventType, want := range tests {
+		if got := Streamed(eventType); got != want {
+			t.Errorf("Streamed(%s) = %v, want %v", eventType, got, want)
+		}
+	}
+	if got := Channel("auction-1"); got != "auction:auction-1" {
+		t.Errorf("channel %q", got)
+	}
+}
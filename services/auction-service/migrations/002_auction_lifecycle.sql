@@ -0,0 +1,20 @@
+-- Settlement results recorded on the auction
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS winner_id VARCHAR(255);
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS winning_bid_id VARCHAR(255);
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS settled_at TIMESTAMP;
+
+-- Outbox of auction lifecycle events, relayed to subscribers after commit
+CREATE TABLE IF NOT EXISTS auction_events (
+    event_id BIGSERIAL PRIMARY KEY,
+    auction_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    dispatched_at TIMESTAMP,
+    FOREIGN KEY (auction_id) REFERENCES auctions(auction_id) ON DELETE CASCADE
+);
+
+-- Indexes used by the lifecycle scheduler and event relay
+CREATE INDEX IF NOT EXISTS idx_auctions_start_time ON auctions(start_time);
+CREATE INDEX IF NOT EXISTS idx_auction_events_auction_id ON auction_events(auction_id, event_id);
+CREATE INDEX IF NOT EXISTS idx_auction_events_pending ON auction_events(event_id) WHERE dispatched_at IS NULL;
@@ -0,0 +1,6 @@
+-- Anti-sniping soft close: late bids push the end time back
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS soft_close BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS soft_close_window_seconds INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS soft_close_extension_seconds INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS max_extensions INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS extension_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,13 @@
+-- Hidden maximum bids placed on a bidder's behalf
+CREATE TABLE IF NOT EXISTS proxy_bids (
+    auction_id VARCHAR(255) NOT NULL,
+    bidder_id VARCHAR(255) NOT NULL,
+    max_amount DECIMAL(10,2) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (auction_id, bidder_id),
+    FOREIGN KEY (auction_id) REFERENCES auctions(auction_id) ON DELETE CASCADE
+);
+
+-- Bids generated by the proxy engine rather than typed by the bidder
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS is_proxy BOOLEAN NOT NULL DEFAULT false;
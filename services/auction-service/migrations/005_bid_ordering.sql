@@ -0,0 +1,2 @@
+-- Serves highest-bid lookups made while an auction row is locked
+CREATE INDEX IF NOT EXISTS idx_bids_auction_amount ON bids(auction_id, amount DESC, bid_time);
@@ -0,0 +1,12 @@
+-- Descending-price (drop) auctions: the price falls by drop_step every
+-- drop_interval_seconds until floor_price, and each claim buys at the current price
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS floor_price DECIMAL(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS drop_step DECIMAL(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS drop_interval_seconds INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS quantity INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS quantity_remaining INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS next_drop_at TIMESTAMP;
+
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS quantity INTEGER NOT NULL DEFAULT 1;
+
+CREATE INDEX IF NOT EXISTS idx_auctions_next_drop_at ON auctions(next_drop_at) WHERE status = 'active' AND next_drop_at IS NOT NULL;
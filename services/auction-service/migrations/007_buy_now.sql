@@ -0,0 +1,4 @@
+-- Optional buy-it-now price; 0 means the auction has none
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS buy_now_price DECIMAL(10,2) NOT NULL DEFAULT 0;
+
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS is_buy_now BOOLEAN NOT NULL DEFAULT false;
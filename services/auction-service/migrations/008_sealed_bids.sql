@@ -0,0 +1,4 @@
+-- Sealed-bid auctions settle by pricing_rule: first_price or second_price
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS pricing_rule VARCHAR(20) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_bids_auction_bidder ON bids(auction_id, bidder_id);
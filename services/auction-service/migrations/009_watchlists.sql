@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS watchlist (
+    auction_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (auction_id, user_id),
+    FOREIGN KEY (auction_id) REFERENCES auctions(auction_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_watchlist_user_id ON watchlist(user_id, created_at);
+
+-- Kept in step with watchlist rows so listings need no join
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS watcher_count INTEGER NOT NULL DEFAULT 0;
+-- Set once the "ending soon" alert has been scheduled
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS ending_soon_at TIMESTAMP;
@@ -0,0 +1,24 @@
+-- Sales waiting to become orders in order-service. Rows are written in the
+-- settling transaction and pushed by the lifecycle scheduler until accepted.
+CREATE TABLE IF NOT EXISTS order_handoffs (
+    bid_id VARCHAR(255) PRIMARY KEY,
+    auction_id VARCHAR(255) NOT NULL,
+    buyer_id VARCHAR(255) NOT NULL,
+    quantity INTEGER NOT NULL DEFAULT 1,
+    price DECIMAL(10,2) NOT NULL,
+    payment_due_at TIMESTAMP NOT NULL,
+    order_id VARCHAR(255),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (auction_id) REFERENCES auctions(auction_id) ON DELETE CASCADE,
+    FOREIGN KEY (bid_id) REFERENCES bids(bid_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_handoffs_pending ON order_handoffs(next_attempt_at) WHERE order_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_order_handoffs_auction_id ON order_handoffs(auction_id);
+
+-- Single-winner auctions carry their order so sellers can follow fulfilment
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS order_id VARCHAR(255);
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS payment_due_at TIMESTAMP;
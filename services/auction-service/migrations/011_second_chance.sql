@@ -0,0 +1,39 @@
+-- Payment checks on handed-off orders: resolved once the order is paid or the
+-- winner defaults
+ALTER TABLE order_handoffs ADD COLUMN IF NOT EXISTS resolution VARCHAR(20);
+ALTER TABLE order_handoffs ADD COLUMN IF NOT EXISTS resolved_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_order_handoffs_unresolved ON order_handoffs(payment_due_at) WHERE order_id IS NOT NULL AND resolved_at IS NULL;
+
+-- Set when the bidder failed to pay for the sale this bid won
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS defaulted_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS non_payment_strikes (
+    bid_id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    auction_id VARCHAR(255) NOT NULL,
+    order_id VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (auction_id) REFERENCES auctions(auction_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_non_payment_strikes_user_id ON non_payment_strikes(user_id);
+
+CREATE TABLE IF NOT EXISTS second_chance_offers (
+    offer_id VARCHAR(255) PRIMARY KEY,
+    auction_id VARCHAR(255) NOT NULL,
+    bid_id VARCHAR(255) NOT NULL,
+    bidder_id VARCHAR(255) NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMP NOT NULL,
+    responded_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (auction_id) REFERENCES auctions(auction_id) ON DELETE CASCADE,
+    FOREIGN KEY (bid_id) REFERENCES bids(bid_id) ON DELETE CASCADE,
+    UNIQUE (auction_id, bidder_id)
+);
+
+-- At most one open offer per auction
+CREATE UNIQUE INDEX IF NOT EXISTS idx_second_chance_offers_pending ON second_chance_offers(auction_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_second_chance_offers_expiry ON second_chance_offers(expires_at) WHERE status = 'pending';
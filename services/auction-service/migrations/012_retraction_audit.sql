@@ -0,0 +1,22 @@
+-- Retracted bids stay on record but no longer count towards the price
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS retracted_at TIMESTAMP;
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS retraction_reason TEXT;
+
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS cancellation_reason TEXT;
+
+-- Every status change, cancellation and retraction, with who made it and why
+CREATE TABLE IF NOT EXISTS auction_audit_log (
+    entry_id BIGSERIAL PRIMARY KEY,
+    auction_id VARCHAR(255) NOT NULL,
+    actor_id VARCHAR(255),
+    actor_role VARCHAR(20) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    from_status VARCHAR(50),
+    to_status VARCHAR(50),
+    reason TEXT,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (auction_id) REFERENCES auctions(auction_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_auction_audit_log_auction_id ON auction_audit_log(auction_id, entry_id);
@@ -0,0 +1,17 @@
+-- Copied from product-service when the auction is created so listings can
+-- filter by category without a cross-service call
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS category VARCHAR(100) NOT NULL DEFAULT '';
+
+-- Kept in step with unretracted bids so listings can sort by bid count
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS bid_count INTEGER NOT NULL DEFAULT 0;
+
+UPDATE auctions a SET bid_count = (
+    SELECT COUNT(*) FROM bids b WHERE b.auction_id = a.auction_id AND b.retracted_at IS NULL
+);
+
+-- Keyset pagination orders by the sort column with auction_id as tie-breaker
+CREATE INDEX IF NOT EXISTS idx_auctions_created_at_id ON auctions(created_at, auction_id);
+CREATE INDEX IF NOT EXISTS idx_auctions_end_time_id ON auctions(end_time, auction_id);
+CREATE INDEX IF NOT EXISTS idx_auctions_current_price_id ON auctions(current_price, auction_id);
+CREATE INDEX IF NOT EXISTS idx_auctions_bid_count_id ON auctions(bid_count, auction_id);
+CREATE INDEX IF NOT EXISTS idx_auctions_category ON auctions(category);
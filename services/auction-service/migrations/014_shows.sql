@@ -0,0 +1,42 @@
+-- A live show sells a queue of lots back-to-back on one stream. The host
+-- advances the queue; each lot runs as its own short live auction.
+CREATE TABLE IF NOT EXISTS shows (
+    show_id VARCHAR(255) PRIMARY KEY,
+    host_id VARCHAR(255) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    status VARCHAR(50) NOT NULL DEFAULT 'scheduled',
+    lot_duration_seconds INTEGER NOT NULL,
+    current_lot_id VARCHAR(255),
+    current_auction_id VARCHAR(255),
+    started_at TIMESTAMP,
+    ended_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_shows_host_id ON shows(host_id);
+
+CREATE TABLE IF NOT EXISTS show_lots (
+    lot_id VARCHAR(255) PRIMARY KEY,
+    show_id VARCHAR(255) NOT NULL,
+    position INTEGER NOT NULL,
+    product_id VARCHAR(255) NOT NULL,
+    category VARCHAR(100) NOT NULL DEFAULT '',
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    starting_price DECIMAL(10,2) NOT NULL,
+    reserve_price DECIMAL(10,2) NOT NULL DEFAULT 0,
+    min_bid_increment DECIMAL(10,2) NOT NULL,
+    buy_now_price DECIMAL(10,2) NOT NULL DEFAULT 0,
+    duration_seconds INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(50) NOT NULL DEFAULT 'queued',
+    auction_id VARCHAR(255),
+    started_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (show_id, position),
+    FOREIGN KEY (show_id) REFERENCES shows(show_id) ON DELETE CASCADE
+);
+
+-- Lot auctions point back at their show
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS show_id VARCHAR(255);
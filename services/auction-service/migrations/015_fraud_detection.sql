@@ -0,0 +1,29 @@
+-- Where each bid and auction came from, for shill-bidding detection. Only
+-- moderators see these.
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS ip_address VARCHAR(64);
+ALTER TABLE bids ADD COLUMN IF NOT EXISTS device_id VARCHAR(255);
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS seller_ip VARCHAR(64);
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS seller_device_id VARCHAR(255);
+
+-- Set once the detection job has scored an ended auction
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS fraud_scanned_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_auctions_fraud_scan ON auctions(end_time) WHERE status = 'ended' AND fraud_scanned_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_bids_bidder_id ON bids(bidder_id);
+
+-- Moderation queue of auctions whose bidding looked suspicious
+CREATE TABLE IF NOT EXISTS fraud_flags (
+    flag_id VARCHAR(255) PRIMARY KEY,
+    auction_id VARCHAR(255) NOT NULL UNIQUE,
+    seller_id VARCHAR(255) NOT NULL,
+    score INTEGER NOT NULL,
+    signals JSONB NOT NULL DEFAULT '[]',
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    reviewer_id VARCHAR(255),
+    review_note TEXT,
+    reviewed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (auction_id) REFERENCES auctions(auction_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_fraud_flags_status ON fraud_flags(status, score DESC, created_at);
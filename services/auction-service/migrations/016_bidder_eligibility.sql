@@ -0,0 +1,27 @@
+-- Auctions a seller flags as high value make bidders hold a refundable
+-- deposit through payment-service before they can bid
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS deposit_amount DECIMAL(10,2) NOT NULL DEFAULT 0;
+
+-- One deposit per bidder per auction. Once the auction is decided a held
+-- deposit moves to release_pending or capture_pending and the lifecycle
+-- scheduler settles it with payment-service, retrying with backoff.
+CREATE TABLE IF NOT EXISTS bid_deposits (
+    auction_id VARCHAR(255) NOT NULL,
+    bidder_id VARCHAR(255) NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    payment_deposit_id VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'held',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (auction_id, bidder_id),
+    FOREIGN KEY (auction_id) REFERENCES auctions(auction_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_bid_deposits_pending ON bid_deposits(next_attempt_at)
+    WHERE status IN ('release_pending', 'capture_pending');
+
+-- Open commitments sum a bidder's leading bids across active auctions
+CREATE INDEX IF NOT EXISTS idx_bids_bidder_winning ON bids(bidder_id) WHERE is_winning AND retracted_at IS NULL;
@@ -0,0 +1,58 @@
+-- Money is stored as integer minor units (cents) of the auction's currency,
+-- matching product, order and payment services. Each conversion only runs
+-- while the column is still DECIMAL, so re-applying migrations is safe.
+DO $$
+BEGIN
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_name = 'auctions' AND column_name = 'starting_price') = 'numeric' THEN
+        ALTER TABLE auctions
+            ALTER COLUMN starting_price TYPE BIGINT USING ROUND(starting_price * 100),
+            ALTER COLUMN current_price TYPE BIGINT USING ROUND(current_price * 100),
+            ALTER COLUMN reserve_price TYPE BIGINT USING ROUND(reserve_price * 100),
+            ALTER COLUMN min_bid_increment DROP DEFAULT,
+            ALTER COLUMN min_bid_increment TYPE BIGINT USING ROUND(min_bid_increment * 100),
+            ALTER COLUMN min_bid_increment SET DEFAULT 100,
+            ALTER COLUMN floor_price TYPE BIGINT USING ROUND(floor_price * 100),
+            ALTER COLUMN drop_step TYPE BIGINT USING ROUND(drop_step * 100),
+            ALTER COLUMN buy_now_price TYPE BIGINT USING ROUND(buy_now_price * 100),
+            ALTER COLUMN deposit_amount TYPE BIGINT USING ROUND(deposit_amount * 100);
+    END IF;
+
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_name = 'bids' AND column_name = 'amount') = 'numeric' THEN
+        ALTER TABLE bids ALTER COLUMN amount TYPE BIGINT USING ROUND(amount * 100);
+    END IF;
+
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_name = 'proxy_bids' AND column_name = 'max_amount') = 'numeric' THEN
+        ALTER TABLE proxy_bids ALTER COLUMN max_amount TYPE BIGINT USING ROUND(max_amount * 100);
+    END IF;
+
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_name = 'order_handoffs' AND column_name = 'price') = 'numeric' THEN
+        ALTER TABLE order_handoffs ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100);
+    END IF;
+
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_name = 'second_chance_offers' AND column_name = 'amount') = 'numeric' THEN
+        ALTER TABLE second_chance_offers ALTER COLUMN amount TYPE BIGINT USING ROUND(amount * 100);
+    END IF;
+
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_name = 'show_lots' AND column_name = 'starting_price') = 'numeric' THEN
+        ALTER TABLE show_lots
+            ALTER COLUMN starting_price TYPE BIGINT USING ROUND(starting_price * 100),
+            ALTER COLUMN reserve_price TYPE BIGINT USING ROUND(reserve_price * 100),
+            ALTER COLUMN min_bid_increment TYPE BIGINT USING ROUND(min_bid_increment * 100),
+            ALTER COLUMN buy_now_price TYPE BIGINT USING ROUND(buy_now_price * 100);
+    END IF;
+
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_name = 'bid_deposits' AND column_name = 'amount') = 'numeric' THEN
+        ALTER TABLE bid_deposits ALTER COLUMN amount TYPE BIGINT USING ROUND(amount * 100);
+    END IF;
+END $$;
+
+-- Existing auctions and shows were all priced in US dollars
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE shows ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'USD';
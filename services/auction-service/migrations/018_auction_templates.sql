@@ -0,0 +1,60 @@
+-- Templates hold the settings sellers reuse for near-identical auctions.
+-- Durations are stored instead of start and end times; each auction made
+-- from a template gets its own window.
+CREATE TABLE IF NOT EXISTS auction_templates (
+    template_id VARCHAR(255) PRIMARY KEY,
+    seller_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    product_id VARCHAR(255) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    currency VARCHAR(3) NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    pricing_rule VARCHAR(20) NOT NULL DEFAULT '',
+    starting_price BIGINT NOT NULL,
+    reserve_price BIGINT NOT NULL DEFAULT 0,
+    min_bid_increment BIGINT NOT NULL DEFAULT 0,
+    buy_now_price BIGINT NOT NULL DEFAULT 0,
+    deposit_amount BIGINT NOT NULL DEFAULT 0,
+    duration_seconds INTEGER NOT NULL,
+    soft_close BOOLEAN NOT NULL DEFAULT FALSE,
+    soft_close_window_seconds INTEGER NOT NULL DEFAULT 0,
+    soft_close_extension_seconds INTEGER NOT NULL DEFAULT 0,
+    max_extensions INTEGER NOT NULL DEFAULT 0,
+    floor_price BIGINT NOT NULL DEFAULT 0,
+    drop_step BIGINT NOT NULL DEFAULT 0,
+    drop_interval_seconds INTEGER NOT NULL DEFAULT 0,
+    quantity INTEGER NOT NULL DEFAULT 0,
+    images JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_auction_templates_seller ON auction_templates(seller_id, created_at DESC);
+
+-- Recurring schedules create a template's auction at a local time of day on
+-- the given weekdays (0 = Sunday; empty means every day). next_run_at is the
+-- next occurrence's start time; the scheduler creates that auction once it is
+-- within the schedule lead.
+CREATE TABLE IF NOT EXISTS auction_schedules (
+    schedule_id VARCHAR(255) PRIMARY KEY,
+    template_id VARCHAR(255) NOT NULL REFERENCES auction_templates(template_id) ON DELETE CASCADE,
+    seller_id VARCHAR(255) NOT NULL,
+    weekdays INTEGER[] NOT NULL DEFAULT '{}',
+    time_of_day VARCHAR(5) NOT NULL,
+    timezone VARCHAR(64) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at TIMESTAMP NOT NULL,
+    last_auction_id VARCHAR(255),
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_auction_schedules_template ON auction_schedules(template_id);
+CREATE INDEX IF NOT EXISTS idx_auction_schedules_due ON auction_schedules(next_run_at) WHERE active;
+
+-- Where an auction came from. An unsold auction can be relisted only once.
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS template_id VARCHAR(255);
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS relisted_from VARCHAR(255);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_auctions_relisted_from ON auctions(relisted_from) WHERE relisted_from IS NOT NULL;
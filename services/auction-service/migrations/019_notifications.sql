@@ -0,0 +1,26 @@
+-- Outgoing notifications are queued here and delivered by the scheduler
+-- through the configured driver, with retries, instead of being sent inline
+-- where a failure could only be logged. dedupe_key makes queueing the same
+-- notification again, for example when an event is relayed twice, a no-op.
+CREATE TABLE IF NOT EXISTS notifications (
+    notification_id BIGSERIAL PRIMARY KEY,
+    dedupe_key VARCHAR(255) UNIQUE,
+    kind VARCHAR(30) NOT NULL,
+    user_id VARCHAR(255),
+    auction_id VARCHAR(255),
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    body TEXT NOT NULL DEFAULT '',
+    data JSONB NOT NULL DEFAULT '{}',
+    payload JSONB,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    driver VARCHAR(30),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_error TEXT,
+    sent_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_due ON notifications(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_notifications_status ON notifications(status, created_at DESC);
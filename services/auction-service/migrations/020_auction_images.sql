@@ -0,0 +1,3 @@
+-- An auction's image gallery, in display order. Each entry carries its own
+-- image_id so sellers can reorder and remove images without resending them.
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS images JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,21 @@
+-- Number each auction's events. event_id comes from a sequence taken before
+-- commit, so two writers can make their events visible out of ID order.
+-- sequence is assigned while the writer holds the auction row lock, so within
+-- an auction it has no gaps and follows commit order; stream clients resume
+-- on it and the relay publishes in it.
+ALTER TABLE auction_events ADD COLUMN IF NOT EXISTS sequence BIGINT;
+
+UPDATE auction_events e
+SET sequence = numbered.sequence
+FROM (
+    SELECT event_id, ROW_NUMBER() OVER (PARTITION BY auction_id ORDER BY event_id) AS sequence
+    FROM auction_events
+) numbered
+WHERE e.event_id = numbered.event_id AND e.sequence IS NULL;
+
+ALTER TABLE auction_events ALTER COLUMN sequence SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_auction_events_sequence ON auction_events(auction_id, sequence);
+DROP INDEX IF EXISTS idx_auction_events_auction_id;
+DROP INDEX IF EXISTS idx_auction_events_pending;
+CREATE INDEX IF NOT EXISTS idx_auction_events_pending ON auction_events(auction_id, sequence) WHERE dispatched_at IS NULL;
@@ -0,0 +1,6 @@
+-- An event stays pending when a relay handler fails and is retried with
+-- backoff. Until it goes through, the auction's later events wait behind it
+-- so subscribers still see them in sequence order.
+ALTER TABLE auction_events ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE auction_events ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMP;
+ALTER TABLE auction_events ADD COLUMN IF NOT EXISTS last_error TEXT;
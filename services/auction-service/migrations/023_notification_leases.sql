@@ -0,0 +1,6 @@
+-- A claimed notification is marked sending with a lease in next_attempt_at
+-- and committed before the driver is called, so no transaction stays open
+-- across the network call. A replica that dies mid-delivery leaves the row
+-- sending; it is claimed again once the lease runs out.
+DROP INDEX IF EXISTS idx_notifications_due;
+CREATE INDEX IF NOT EXISTS idx_notifications_due ON notifications(next_attempt_at) WHERE status IN ('pending', 'sending');
@@ -0,0 +1,104 @@
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/shared/pkg/auth"
+)
+
+// verifiedTTL is how long a verified user is remembered. Verification is not
+// taken back in the normal course of things, so only positive answers are
+// kept and a user who has just verified is seen straight away.
+const verifiedTTL = 10 * time.Minute
+
+// Client reads user accounts from auth-service's internal endpoints
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+
+	mu       sync.Mutex
+	verified map[string]time.Time // user ID to when the answer expires
+}
+
+// NewClient creates an auth-service client that authenticates with the
+// shared service token
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		baseURL: baseURL,
+		token:   token,
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+		verified: map[string]time.Time{},
+	}
+}
+
+// User is the part of an auth-service user auction-service reads
+type User struct {
+	ID         string `json:"id"`
+	Role       string `json:"role"`
+	IsActive   bool   `json:"is_active"`
+	IsVerified bool   `json:"is_verified"`
+}
+
+// GetUser looks up a user's role and verification state
+func (c *Client) GetUser(ctx context.Context, userID string) (*User, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/internal/v1/users/"+userID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set(auth.ServiceTokenHeader, c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Status  string `json:"status"`
+		Data    User   `json:"data"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("auth service returned status %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("auth service error (status %d): %s", resp.StatusCode, envelope.Message)
+	}
+	return &envelope.Data, nil
+}
+
+// IsVerified reports whether a user's account is verified, asking
+// auth-service only when the user is not already known to be
+func (c *Client) IsVerified(ctx context.Context, userID string) (bool, error) {
+	now := time.Now()
+	c.mu.Lock()
+	expires, ok := c.verified[userID]
+	c.mu.Unlock()
+	if ok && now.Before(expires) {
+		return true, nil
+	}
+
+	user, err := c.GetUser(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if user.IsVerified {
+		c.mu.Lock()
+		for id, expires := range c.verified {
+			if !now.Before(expires) {
+				delete(c.verified, id)
+			}
+		}
+		c.verified[userID] = now.Add(verifiedTTL)
+		c.mu.Unlock()
+	}
+	return user.IsVerified, nil
+}
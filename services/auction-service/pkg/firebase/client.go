@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -18,10 +19,11 @@ type Client struct {
 	logger     *zap.Logger
 }
 
-// NewClient creates a new Firebase client
-func NewClient(logger *zap.Logger) *Client {
+// NewClient creates a Firebase client calling the functions under baseURL,
+// e.g. http://localhost:5001/<project>/us-central1 for the emulator
+func NewClient(baseURL string, logger *zap.Logger) *Client {
 	return &Client{
-		baseURL: "http://localhost:5001/demo-blytz-mvp/us-central1",
+		baseURL: strings.TrimRight(baseURL, "/"),
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -0,0 +1,152 @@
+package orders
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/shared/pkg/auth"
+)
+
+// Client calls order-service's internal endpoints on behalf of auction-service
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient creates an order-service client that authenticates with the
+// shared service token
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		baseURL: baseURL,
+		token:   token,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// AuctionOrderRequest asks order-service to open a pending order for an
+// auction sale. Order-service returns the existing order if the bid already
+// has one, so requests can be retried.
+type AuctionOrderRequest struct {
+	AuctionID    string    `json:"auction_id"`
+	BidID        string    `json:"bid_id"`
+	UserID       string    `json:"user_id"`
+	ProductID    string    `json:"product_id"`
+	ProductName  string    `json:"product_name"`
+	ProductImage string    `json:"product_image,omitempty"`
+	Quantity     int       `json:"quantity"`
+	Price        int64     `json:"price"` // Per unit, in minor units of Currency
+	Currency     string    `json:"currency"`
+	PaymentDueAt time.Time `json:"payment_due_at"`
+}
+
+// Order is the part of an order-service order auction-service reads
+type Order struct {
+	ID            string  `json:"id"`
+	UserID        string  `json:"user_id"`
+	BidID         *string `json:"bid_id,omitempty"`
+	Quantity      int     `json:"quantity"`
+	Price         int64   `json:"price"`
+	TotalAmount   int64   `json:"total_amount"`
+	Currency      string  `json:"currency"`
+	Status        string  `json:"status"`
+	PaymentStatus string  `json:"payment_status"`
+	UpdatedAt     string  `json:"updated_at"`
+}
+
+// PaymentStatusPaid is order-service's payment status for a paid order
+const PaymentStatusPaid = "paid"
+
+// Paid reports whether the buyer has paid for the order
+func (o *Order) Paid() bool {
+	return o.PaymentStatus == PaymentStatusPaid
+}
+
+// CreateAuctionOrder opens a pending order for an auction sale
+func (c *Client) CreateAuctionOrder(ctx context.Context, req *AuctionOrderRequest) (*Order, error) {
+	var order Order
+	if err := c.do(ctx, http.MethodPost, "/internal/v1/orders/auction", req, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// GetOrder looks up an order by ID
+func (c *Client) GetOrder(ctx context.Context, orderID string) (*Order, error) {
+	var order Order
+	if err := c.do(ctx, http.MethodGet, "/internal/v1/orders/"+orderID, nil, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// ExpireOrder cancels an unpaid auction order after its payment deadline. A
+// paid order comes back unchanged.
+func (c *Client) ExpireOrder(ctx context.Context, orderID string) (*Order, error) {
+	var order Order
+	if err := c.do(ctx, http.MethodPost, "/internal/v1/orders/"+orderID+"/expire", nil, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// GetAuctionOrders lists the orders created for an auction's sales
+func (c *Client) GetAuctionOrders(ctx context.Context, auctionID string) ([]Order, error) {
+	var orders []Order
+	if err := c.do(ctx, http.MethodGet, "/internal/v1/auctions/"+auctionID+"/orders", nil, &orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, result interface{}) error {
+	var reqBody *bytes.Buffer
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reqBody = bytes.NewBuffer(jsonData)
+	} else {
+		reqBody = &bytes.Buffer{}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(auth.ServiceTokenHeader, c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Success bool            `json:"success"`
+		Data    json.RawMessage `json:"data"`
+		Error   struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("order service returned status %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK || !envelope.Success {
+		return fmt.Errorf("order service error (status %d): %s", resp.StatusCode, envelope.Error.Message)
+	}
+
+	if err := json.Unmarshal(envelope.Data, result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package payments
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gmsas95/blytz-mvp/shared/pkg/auth"
+)
+
+// Client calls payment-service's internal deposit endpoints on behalf of
+// auction-service
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient creates a payment-service client that authenticates with the
+// shared service token
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		baseURL: baseURL,
+		token:   token,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// DepositRequest asks payment-service to hold a refundable deposit. Reference
+// names what the deposit secures; repeating a request for the same user and
+// reference returns the deposit already held.
+type DepositRequest struct {
+	UserID        string `json:"user_id"`
+	Reference     string `json:"reference"`
+	Amount        int64  `json:"amount"` // In cents
+	Currency      string `json:"currency"`
+	PaymentMethod string `json:"payment_method"`
+	Provider      string `json:"provider"`
+	Token         string `json:"token"`
+}
+
+// Deposit is the part of a payment-service deposit auction-service reads
+type Deposit struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+	Amount int64  `json:"amount"`
+	Status string `json:"status"`
+}
+
+// Error is a request payment-service refused, as opposed to one that never
+// reached it
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("payment service error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// AuthorizeDeposit holds a deposit on the user's payment method
+func (c *Client) AuthorizeDeposit(ctx context.Context, req *DepositRequest) (*Deposit, error) {
+	var deposit Deposit
+	if err := c.do(ctx, "/internal/v1/deposits", req, &deposit); err != nil {
+		return nil, err
+	}
+	return &deposit, nil
+}
+
+// ReleaseDeposit returns a held deposit to the user
+func (c *Client) ReleaseDeposit(ctx context.Context, depositID string) (*Deposit, error) {
+	var deposit Deposit
+	if err := c.do(ctx, "/internal/v1/deposits/"+depositID+"/release", nil, &deposit); err != nil {
+		return nil, err
+	}
+	return &deposit, nil
+}
+
+// CaptureDeposit takes a held deposit
+func (c *Client) CaptureDeposit(ctx context.Context, depositID string) (*Deposit, error) {
+	var deposit Deposit
+	if err := c.do(ctx, "/internal/v1/deposits/"+depositID+"/capture", nil, &deposit); err != nil {
+		return nil, err
+	}
+	return &deposit, nil
+}
+
+func (c *Client) do(ctx context.Context, path string, body, result interface{}) error {
+	reqBody := &bytes.Buffer{}
+	if body != nil {
+		if err := json.NewEncoder(reqBody).Encode(body); err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(auth.ServiceTokenHeader, c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Success bool            `json:"success"`
+		Data    json.RawMessage `json:"data"`
+		Error   struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("payment service returned status %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK || !envelope.Success {
+		return &Error{StatusCode: resp.StatusCode, Code: envelope.Error.Code, Message: envelope.Error.Message}
+	}
+
+	if err := json.Unmarshal(envelope.Data, result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
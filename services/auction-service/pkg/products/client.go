@@ -0,0 +1,82 @@
+package products
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Client reads products from product-service's public API
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a product-service client
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// Product is the part of a product-service product auction-service reads
+type Product struct {
+	ProductID   string `json:"product_id"`
+	Name        string `json:"name"`
+	SellerID    string `json:"seller_id"`
+	Category    string `json:"category"`
+	Subcategory string `json:"subcategory"`
+	ImageURL    string `json:"image_url"`
+	Images      string `json:"images"` // JSON array of image URLs
+}
+
+// ImageURLs returns the product's gallery, or its single image when it has
+// no gallery
+func (p *Product) ImageURLs() []string {
+	var urls []string
+	if p.Images != "" {
+		if err := json.Unmarshal([]byte(p.Images), &urls); err != nil {
+			urls = nil
+		}
+	}
+	if len(urls) == 0 && p.ImageURL != "" {
+		urls = []string{p.ImageURL}
+	}
+	return urls
+}
+
+// GetProduct looks up a product by its product ID
+func (c *Client) GetProduct(ctx context.Context, productID string) (*Product, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v1/products/"+productID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Success bool `json:"success"`
+		Data    struct {
+			Product Product `json:"product"`
+		} `json:"data"`
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("product service returned status %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK || !envelope.Success {
+		return nil, fmt.Errorf("product service error (status %d): %s", resp.StatusCode, envelope.Error.Message)
+	}
+	return &envelope.Data.Product, nil
+}
@@ -36,24 +36,27 @@ echo "✅ Database connection successful"
 
 # Create tables
 echo "🏗️ Creating database tables..."
-PGPASSWORD="$DB_PASSWORD" psql -h "$DB_HOST" -p "$DB_PORT" -U "$DB_USER" -d "$DB_NAME" -f migrations/001_create_auctions_table.sql
+for migration in migrations/*.sql; do
+    echo "   - Applying ${migration}"
+    PGPASSWORD="$DB_PASSWORD" psql -h "$DB_HOST" -p "$DB_PORT" -U "$DB_USER" -d "$DB_NAME" -f "$migration"
+done
 
 echo "✅ Database tables created successfully"
 
 # Insert sample data for demo
 echo "📝 Inserting sample auction data..."
 PGPASSWORD="$DB_PASSWORD" psql -h "$DB_HOST" -p "$DB_PORT" -U "$DB_USER" -d "$DB_NAME" << EOF
--- Insert sample auctions
+-- Insert sample auctions; prices are in cents
 INSERT INTO auctions (auction_id, product_id, seller_id, title, description, starting_price, current_price, reserve_price, min_bid_increment, start_time, end_time, status, type, is_active, created_at, updated_at) VALUES
-('auction_001', 'product_001', 'seller_001', 'Vintage Watch', 'Beautiful vintage Rolex watch from 1960s', 500.00, 500.00, 800.00, 25.00, NOW() - INTERVAL '30 minutes', NOW() + INTERVAL '2 hours', 'active', 'live', true, NOW(), NOW()),
-('auction_002', 'product_002', 'seller_002', 'Antique Vase', 'Ming dynasty porcelain vase', 200.00, 200.00, 400.00, 20.00, NOW() - INTERVAL '1 hour', NOW() + INTERVAL '3 hours', 'active', 'live', true, NOW(), NOW()),
-('auction_003', 'product_003', 'seller_003', 'Collectible Coin', 'Rare 1921 silver dollar', 150.00, 150.00, 300.00, 15.00, NOW() + INTERVAL '1 hour', NOW() + INTERVAL '4 hours', 'scheduled', 'live', true, NOW(), NOW());
+('auction_001', 'product_001', 'seller_001', 'Vintage Watch', 'Beautiful vintage Rolex watch from 1960s', 50000, 50000, 80000, 2500, NOW() - INTERVAL '30 minutes', NOW() + INTERVAL '2 hours', 'active', 'live', true, NOW(), NOW()),
+('auction_002', 'product_002', 'seller_002', 'Antique Vase', 'Ming dynasty porcelain vase', 20000, 20000, 40000, 2000, NOW() - INTERVAL '1 hour', NOW() + INTERVAL '3 hours', 'active', 'live', true, NOW(), NOW()),
+('auction_003', 'product_003', 'seller_003', 'Collectible Coin', 'Rare 1921 silver dollar', 15000, 15000, 30000, 1500, NOW() + INTERVAL '1 hour', NOW() + INTERVAL '4 hours', 'scheduled', 'live', true, NOW(), NOW());
 
 -- Insert sample bids
 INSERT INTO bids (bid_id, auction_id, bidder_id, amount, is_winning, bid_time, created_at) VALUES
-('bid_001', 'auction_001', 'bidder_001', 525.00, false, NOW() - INTERVAL '20 minutes', NOW()),
-('bid_002', 'auction_001', 'bidder_002', 550.00, true, NOW() - INTERVAL '15 minutes', NOW()),
-('bid_003', 'auction_002', 'bidder_003', 220.00, true, NOW() - INTERVAL '30 minutes', NOW());
+('bid_001', 'auction_001', 'bidder_001', 52500, false, NOW() - INTERVAL '20 minutes', NOW()),
+('bid_002', 'auction_001', 'bidder_002', 55000, true, NOW() - INTERVAL '15 minutes', NOW()),
+('bid_003', 'auction_002', 'bidder_003', 22000, true, NOW() - INTERVAL '30 minutes', NOW());
 EOF
 
 echo "✅ Sample data inserted successfully"
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gmsas95/blytz-mvp/services/auth-service/internal/models"
@@ -202,9 +203,41 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 		"avatar_url":   user.AvatarURL,
 		"is_active":    user.IsActive,
 		"role":         user.Role,
+		"is_verified":  user.IsVerified(),
 		"created_at":   user.CreatedAt,
 		"updated_at":   user.UpdatedAt,
 	}
 
 	utils.SendSuccessResponse(c, http.StatusOK, profile)
-}
\ No newline at end of file
+}
+// GetUserStatus serves other services' lookups of a user's role and
+// verification state
+func (h *AuthHandler) GetUserStatus(c *gin.Context) {
+	user, err := h.authService.GetUserByID(c.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+	utils.SendSuccessResponse(c, http.StatusOK, userStatus(user))
+}
+
+// VerifyUser marks a user as identity-verified once an external check has
+// passed
+func (h *AuthHandler) VerifyUser(c *gin.Context) {
+	user, err := h.authService.MarkUserVerified(c.Param("id"), time.Now())
+	if err != nil {
+		utils.SendErrorResponse(c, err)
+		return
+	}
+	utils.SendSuccessResponse(c, http.StatusOK, userStatus(user))
+}
+
+func userStatus(user *models.User) *models.UserStatus {
+	return &models.UserStatus{
+		ID:         user.ID,
+		Role:       user.Role,
+		IsActive:   user.IsActive,
+		IsVerified: user.IsVerified(),
+		VerifiedAt: user.VerifiedAt,
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/gmsas95/blytz-mvp/services/auth-service/internal/config"
 	"github.com/gmsas95/blytz-mvp/services/auth-service/internal/middleware"
 	"github.com/gmsas95/blytz-mvp/services/auth-service/internal/services"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/auth"
 	"go.uber.org/zap"
 )
 
@@ -77,6 +78,14 @@ func NewRouter(router *gin.Engine, authService *services.AuthService, logger *za
 	{
 		authHandler := handlers.NewAuthHandler(authService)
 		SetupAuthRoutes(api, authHandler, authService)
+
+		// Internal endpoints for other services, authenticated by service token
+		internalRoutes := router.Group("/internal/v1")
+		internalRoutes.Use(auth.GinServiceAuthMiddleware(cfg.ServiceToken))
+		{
+			internalRoutes.GET("/users/:id", authHandler.GetUserStatus)
+			internalRoutes.POST("/users/:id/verify", authHandler.VerifyUser)
+		}
 	}
 }
 
@@ -20,6 +20,7 @@ type Config struct {
 	JWTSecret        string `env:"JWT_SECRET"`
 	ServicePort      string `env:"PORT"`
 	Environment      string `env:"ENVIRONMENT"`
+	ServiceToken     string `env:"SERVICE_TOKEN"` // Shared secret for internal service-to-service endpoints
 }
 
 // Load loads configuration from environment variables
@@ -37,6 +38,7 @@ func Load() (*Config, error) {
 		JWTSecret:        getEnvOrDefault("JWT_SECRET", "jwt-secret-key-change-in-production"),
 		ServicePort:      getEnvOrDefault("PORT", "8084"),
 		Environment:      getEnvOrDefault("NODE_ENV", "development"),
+		ServiceToken:     os.Getenv("SERVICE_TOKEN"),
 	}
 
 	// Check if DATABASE_URL is provided (Dokploy style)
@@ -15,16 +15,31 @@ type Claims struct {
 
 // User represents a user in the system
 type User struct {
-	ID          string    `json:"id" gorm:"primaryKey"`
-	Email       string    `json:"email" gorm:"uniqueIndex;not null"`
-	DisplayName string    `json:"display_name"`
-	PhoneNumber string    `json:"phone_number,omitempty"`
-	AvatarURL   string    `json:"avatar_url,omitempty"`
-	IsActive    bool      `json:"is_active" gorm:"default:true"`
-	Role        string    `json:"role" gorm:"default:user"`
-	Password    string    `json:"-" gorm:"not null"` // Hashed password, never returned in JSON
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string     `json:"id" gorm:"primaryKey"`
+	Email       string     `json:"email" gorm:"uniqueIndex;not null"`
+	DisplayName string     `json:"display_name"`
+	PhoneNumber string     `json:"phone_number,omitempty"`
+	AvatarURL   string     `json:"avatar_url,omitempty"`
+	IsActive    bool       `json:"is_active" gorm:"default:true"`
+	Role        string     `json:"role" gorm:"default:user"`
+	Password    string     `json:"-" gorm:"not null"`     // Hashed password, never returned in JSON
+	VerifiedAt  *time.Time `json:"verified_at,omitempty"` // Set once the user's identity has been checked
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// IsVerified reports whether the user has passed identity verification
+func (u *User) IsVerified() bool {
+	return u.VerifiedAt != nil
+}
+
+// UserStatus is what other services read about a user over the internal API
+type UserStatus struct {
+	ID         string     `json:"id"`
+	Role       string     `json:"role"`
+	IsActive   bool       `json:"is_active"`
+	IsVerified bool       `json:"is_verified"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
 }
 
 // RegisterRequest represents user registration request
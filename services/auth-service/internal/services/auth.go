@@ -150,6 +150,25 @@ func (s *AuthService) GetUserByID(userID string) (*models.User, error) {
 	return &user, nil
 }
 
+// MarkUserVerified records that a user has passed identity verification.
+// Verifying an already verified user keeps the original timestamp.
+func (s *AuthService) MarkUserVerified(userID string, at time.Time) (*models.User, error) {
+	user, err := s.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.VerifiedAt != nil {
+		return user, nil
+	}
+
+	user.VerifiedAt = &at
+	user.UpdatedAt = at
+	if err := s.db.Save(user).Error; err != nil {
+		return nil, shared_errors.DatabaseError("USER_UPDATE_FAILED", "Failed to update user")
+	}
+	return user, nil
+}
+
 // UpdateUserProfile updates user profile information
 func (s *AuthService) UpdateUserProfile(ctx context.Context, userID string, req *models.UpdateProfileRequest) error {
 	var user models.User
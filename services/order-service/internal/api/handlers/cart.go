@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -10,16 +11,19 @@ import (
 
 	"github.com/gmsas95/blytz-mvp/services/order-service/internal/models"
 	"github.com/gmsas95/blytz-mvp/services/order-service/internal/services"
+	"github.com/gmsas95/blytz-mvp/services/order-service/pkg/products"
 )
 
 type CartHandler struct {
 	orderService *services.OrderService
+	products     *products.Client
 	logger       *zap.Logger
 }
 
-func NewCartHandler(orderService *services.OrderService, logger *zap.Logger) *CartHandler {
+func NewCartHandler(orderService *services.OrderService, productClient *products.Client, logger *zap.Logger) *CartHandler {
 	return &CartHandler{
 		orderService: orderService,
+		products:     productClient,
 		logger:       logger,
 	}
 }
@@ -70,6 +74,7 @@ func (h *CartHandler) AddToCart(c *gin.Context) {
 
 	var req struct {
 		ProductID string `json:"productId" binding:"required"`
+		VariantID string `json:"variantId,omitempty"` // required for products sold per variant
 		Quantity  int    `json:"quantity" binding:"required,min=1"`
 		AuctionID string `json:"auctionId,omitempty"`
 	}
@@ -104,9 +109,14 @@ func (h *CartHandler) AddToCart(c *gin.Context) {
 		}
 	}
 
-	// Check if item already exists in cart
+	// Check if item already exists in cart; each variant is its own line
 	var existingItem models.CartItem
 	query := db.Where("cart_id = ? AND product_id = ?", cart.ID, req.ProductID)
+	if req.VariantID != "" {
+		query = query.Where("variant_id = ?", req.VariantID)
+	} else {
+		query = query.Where("variant_id IS NULL")
+	}
 	if req.AuctionID != "" {
 		query = query.Where("auction_id = ?", req.AuctionID)
 	} else {
@@ -115,8 +125,14 @@ func (h *CartHandler) AddToCart(c *gin.Context) {
 
 	err = query.First(&existingItem).Error
 	if err == nil {
+		price, ok := h.priceCartItem(c, req.ProductID, req.VariantID, existingItem.Quantity+req.Quantity)
+		if !ok {
+			return
+		}
+
 		// Update existing item quantity
 		existingItem.Quantity += req.Quantity
+		existingItem.Price = price
 		existingItem.Total = int64(existingItem.Quantity) * existingItem.Price
 
 		if updateErr := db.Save(&existingItem).Error; updateErr != nil {
@@ -125,21 +141,26 @@ func (h *CartHandler) AddToCart(c *gin.Context) {
 			return
 		}
 	} else if err == gorm.ErrRecordNotFound {
-		// For demo purposes, we'll use a mock price
-		// In a real implementation, you'd fetch this from the product service
-		price := int64(10000) // $100.00 in cents
+		price, ok := h.priceCartItem(c, req.ProductID, req.VariantID, req.Quantity)
+		if !ok {
+			return
+		}
 
 		// Create new cart item
 		newItem := models.CartItem{
 			ID:        uuid.New().String(),
 			CartID:    cart.ID,
 			ProductID: req.ProductID,
+			VariantID: &req.VariantID,
 			AuctionID: &req.AuctionID,
 			Quantity:  req.Quantity,
 			Price:     price,
 			Total:     int64(req.Quantity) * price,
 		}
 
+		if req.VariantID == "" {
+			newItem.VariantID = nil
+		}
 		if req.AuctionID == "" {
 			newItem.AuctionID = nil
 		}
@@ -271,8 +292,18 @@ func (h *CartHandler) UpdateCartItemQuantity(c *gin.Context) {
 		return
 	}
 
+	variantID := ""
+	if cartItem.VariantID != nil {
+		variantID = *cartItem.VariantID
+	}
+	price, ok := h.priceCartItem(c, cartItem.ProductID, variantID, req.Quantity)
+	if !ok {
+		return
+	}
+
 	// Update quantity and total
 	cartItem.Quantity = req.Quantity
+	cartItem.Price = price
 	cartItem.Total = int64(req.Quantity) * cartItem.Price
 
 	if updateErr := db.Save(&cartItem).Error; updateErr != nil {
@@ -341,6 +372,50 @@ func (h *CartHandler) ClearCart(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": cart})
 }
 
+// priceCartItem looks a cart item up in product-service and returns its unit
+// price, after checking that the variant belongs to the product, that
+// products sold per variant name one, and that quantity is in stock. It
+// writes the error response and returns false when the item can't be carted.
+func (h *CartHandler) priceCartItem(c *gin.Context, productID, variantID string, quantity int) (int64, bool) {
+	product, err := h.products.GetProduct(c.Request.Context(), productID)
+	if err != nil {
+		if errors.Is(err, products.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+			return 0, false
+		}
+		h.logger.Error("Failed to get product", zap.String("product_id", productID), zap.Error(err))
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Failed to look up product"})
+		return 0, false
+	}
+	if product.Status != "active" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Product is not available"})
+		return 0, false
+	}
+
+	price, available := product.Price, product.Available
+	if len(product.Variants) > 0 {
+		if variantID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "variantId is required for this product"})
+			return 0, false
+		}
+		variant := product.Variant(variantID)
+		if variant == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Variant not found for this product"})
+			return 0, false
+		}
+		price, available = product.VariantPrice(variant), variant.Available
+	} else if variantID != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Product has no variants"})
+		return 0, false
+	}
+
+	if quantity > available {
+		c.JSON(http.StatusConflict, gin.H{"error": "Insufficient stock"})
+		return 0, false
+	}
+	return price, true
+}
+
 // Helper method to recalculate cart totals
 func (h *CartHandler) recalculateCartTotals(db *gorm.DB, cartID string) error {
 	var cartItems []models.CartItem
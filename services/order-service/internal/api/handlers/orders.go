@@ -196,12 +196,79 @@ func (h *OrderHandler) CancelOrder(c *gin.Context) {
 	utils.SuccessResponse(c, gin.H{"message": "Order cancelled successfully"})
 }
 
+// CreateAuctionOrder is called by auction-service when an auction is won
+func (h *OrderHandler) CreateAuctionOrder(c *gin.Context) {
+	var req services.CreateAuctionOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, errors.ErrInvalidRequestBody)
+		return
+	}
+
+	order, err := h.orderService.CreateAuctionOrder(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("Failed to create auction order", zap.Error(err))
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	response := h.mapOrderToResponse(order)
+	utils.SuccessResponse(c, response)
+}
+
+// GetAuctionOrders lets auction-service show sellers how their sales are being fulfilled
+func (h *OrderHandler) GetAuctionOrders(c *gin.Context) {
+	auctionID := c.Param("auctionId")
+	if auctionID == "" {
+		utils.ErrorResponse(c, errors.ErrInvalidRequest)
+		return
+	}
+
+	orders, err := h.orderService.GetAuctionOrders(c.Request.Context(), auctionID)
+	if err != nil {
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	orderResponses := make([]services.OrderResponse, len(orders))
+	for i, order := range orders {
+		orderResponses[i] = *h.mapOrderToResponse(order)
+	}
+	utils.SuccessResponse(c, orderResponses)
+}
+
+// GetOrderByID lets auction-service check whether a winner has paid
+func (h *OrderHandler) GetOrderByID(c *gin.Context) {
+	order, err := h.orderService.GetOrderByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	response := h.mapOrderToResponse(order)
+	utils.SuccessResponse(c, response)
+}
+
+// ExpireAuctionOrder is called by auction-service when a winner misses the payment deadline
+func (h *OrderHandler) ExpireAuctionOrder(c *gin.Context) {
+	order, err := h.orderService.ExpireAuctionOrder(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.logger.Error("Failed to expire auction order", zap.Error(err))
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	response := h.mapOrderToResponse(order)
+	utils.SuccessResponse(c, response)
+}
+
 func (h *OrderHandler) mapOrderToResponse(order *models.Order) *services.OrderResponse {
 	return &services.OrderResponse{
 		ID:           order.ID,
 		UserID:       order.UserID,
 		AuctionID:    order.AuctionID,
+		BidID:        order.BidID,
 		ProductID:    order.ProductID,
+		VariantID:    order.VariantID,
 		ProductName:  order.ProductName,
 		ProductImage: order.ProductImage,
 		Quantity:     order.Quantity,
@@ -229,7 +296,8 @@ func (h *OrderHandler) mapOrderToResponse(order *models.Order) *services.OrderRe
 			Country:     order.BillingAddress.Country,
 			PhoneNumber: order.BillingAddress.PhoneNumber,
 		},
-		Notes:     order.Notes,
+		Notes:        order.Notes,
+		PaymentDueAt: order.PaymentDueAt,
 		CreatedAt: order.CreatedAt.Format("2006-01-02T15:04:05Z"),
 		UpdatedAt: order.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 	}
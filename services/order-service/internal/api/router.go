@@ -9,6 +9,7 @@ import (
 	"github.com/gmsas95/blytz-mvp/services/order-service/internal/config"
 	"github.com/gmsas95/blytz-mvp/services/order-service/internal/models"
 	"github.com/gmsas95/blytz-mvp/services/order-service/internal/services"
+	"github.com/gmsas95/blytz-mvp/services/order-service/pkg/products"
 	"github.com/gmsas95/blytz-mvp/shared/pkg/auth"
 	"github.com/gmsas95/blytz-mvp/shared/pkg/utils"
 	"go.uber.org/zap"
@@ -58,7 +59,7 @@ func SetupRouter(logger *zap.Logger) *gin.Engine {
 
 	// Create order and cart handlers
 	orderHandler := handlers.NewOrderHandler(orderService, logger)
-	cartHandler := handlers.NewCartHandler(orderService, logger)
+	cartHandler := handlers.NewCartHandler(orderService, products.NewClient(cfg.ProductServiceURL), logger)
 
 	// Enhanced health check endpoint
 	router.GET("/health", func(c *gin.Context) {
@@ -118,5 +119,15 @@ func SetupRouter(logger *zap.Logger) *gin.Engine {
 		cartRoutes.DELETE("/clear", cartHandler.ClearCart)
 	}
 
+	// Internal endpoints for other services, authenticated by service token
+	internalRoutes := router.Group("/internal/v1")
+	internalRoutes.Use(auth.GinServiceAuthMiddleware(cfg.ServiceToken))
+	{
+		internalRoutes.POST("/orders/auction", orderHandler.CreateAuctionOrder)
+		internalRoutes.GET("/orders/:id", orderHandler.GetOrderByID)
+		internalRoutes.POST("/orders/:id/expire", orderHandler.ExpireAuctionOrder)
+		internalRoutes.GET("/auctions/:auctionId/orders", orderHandler.GetAuctionOrders)
+	}
+
 	return router
 }
@@ -19,8 +19,12 @@ type Config struct {
 	RedisURL         string
 	RedisPassword    string
 	AuthServiceURL   string
+	ServiceToken     string // Shared secret for internal service-to-service endpoints
 	JWTSecret        string
 	LogLevel         string
+
+	// product-service prices and stocks the items added to carts
+	ProductServiceURL string
 }
 
 func LoadConfig() *Config {
@@ -35,8 +39,11 @@ func LoadConfig() *Config {
 		RedisURL:         getEnv("REDIS_URL", "redis:6379"),
 		RedisPassword:    getEnv("REDIS_PASSWORD", ""),
 		AuthServiceURL:   getEnv("AUTH_SERVICE_URL", "http://auth-service:8084"),
+		ServiceToken:     getEnv("SERVICE_TOKEN", ""),
 		JWTSecret:        getEnv("JWT_SECRET", "your-secret-key"),
 		LogLevel:         getEnv("LOG_LEVEL", "info"),
+
+		ProductServiceURL: getEnv("PRODUCT_SERVICE_URL", "http://product-service:8082"),
 	}
 
 	// Check if DATABASE_URL is provided (Dokploy style)
@@ -9,7 +9,9 @@ type Order struct {
 	ID              string         `json:"id" gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
 	UserID          string         `json:"user_id" gorm:"not null;index"`
 	AuctionID       *string        `json:"auction_id,omitempty" gorm:"index"`
+	BidID           *string        `json:"bid_id,omitempty" gorm:"uniqueIndex"` // Winning auction bid; one order per bid
 	ProductID       string         `json:"product_id" gorm:"not null;index"`
+	VariantID       *string        `json:"variant_id,omitempty" gorm:"index"` // Set for products sold per variant
 	ProductName     string         `json:"product_name" gorm:"not null"`
 	ProductImage    string         `json:"product_image,omitempty"`
 	Quantity        int            `json:"quantity" gorm:"not null;default:1"`
@@ -22,6 +24,7 @@ type Order struct {
 	ShippingAddress Address        `json:"shipping_address" gorm:"embedded;embeddedPrefix:shipping_"`
 	BillingAddress  Address        `json:"billing_address" gorm:"embedded;embeddedPrefix:billing_"`
 	Notes           string         `json:"notes,omitempty"`
+	PaymentDueAt    *time.Time     `json:"payment_due_at,omitempty"` // Auction orders must be paid by then
 	CreatedAt       time.Time      `json:"created_at"`
 	UpdatedAt       time.Time      `json:"updated_at"`
 	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
@@ -41,6 +44,7 @@ type OrderItem struct {
 	ID          string    `json:"id" gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
 	OrderID     string    `json:"order_id" gorm:"not null;index"`
 	ProductID   string    `json:"product_id" gorm:"not null"`
+	VariantID   *string   `json:"variant_id,omitempty"`
 	ProductName string    `json:"product_name" gorm:"not null"`
 	Quantity    int       `json:"quantity" gorm:"not null"`
 	Price       int64     `json:"price" gorm:"not null"`       // Price per unit in cents
@@ -86,6 +90,7 @@ type CartItem struct {
 	ID        string    `json:"id" gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
 	CartID    string    `json:"cart_id" gorm:"not null;index"`
 	ProductID string    `json:"product_id" gorm:"not null"`
+	VariantID *string   `json:"variant_id,omitempty"` // Set for products sold per variant
 	AuctionID *string   `json:"auction_id,omitempty" gorm:"index"`
 	Quantity  int       `json:"quantity" gorm:"not null;default:1"`
 	Price     int64     `json:"price" gorm:"not null"` // Price per unit in cents
@@ -7,6 +7,7 @@ import (
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/gmsas95/blytz-mvp/services/order-service/internal/config"
 	"github.com/gmsas95/blytz-mvp/services/order-service/internal/models"
@@ -48,6 +49,7 @@ func (s *OrderService) CreateOrder(ctx context.Context, userID string, req *Crea
 		UserID:        userID,
 		AuctionID:     req.AuctionID,
 		ProductID:     req.ProductID,
+		VariantID:     req.VariantID,
 		ProductName:   req.ProductName,
 		ProductImage:  req.ProductImage,
 		Quantity:      req.Quantity,
@@ -86,6 +88,114 @@ func (s *OrderService) CreateOrder(ctx context.Context, userID string, req *Crea
 	return order, nil
 }
 
+// CreateAuctionOrder opens a pending order for an auction winner. Calls are
+// idempotent per winning bid, so auction-service can safely retry a hand-off
+// whose response it never saw.
+func (s *OrderService) CreateAuctionOrder(ctx context.Context, req *CreateAuctionOrderRequest) (*models.Order, error) {
+	s.logger.Info("Creating auction order", zap.String("auction_id", req.AuctionID), zap.String("bid_id", req.BidID))
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if existing, err := s.getOrderByBid(req.BidID); err != nil || existing != nil {
+		return existing, err
+	}
+
+	order := &models.Order{
+		UserID:        req.UserID,
+		AuctionID:     &req.AuctionID,
+		BidID:         &req.BidID,
+		ProductID:     req.ProductID,
+		ProductName:   req.ProductName,
+		ProductImage:  req.ProductImage,
+		Quantity:      req.Quantity,
+		Price:         req.Price,
+		TotalAmount:   req.Price * int64(req.Quantity),
+		Currency:      req.Currency,
+		Status:        string(models.OrderStatusPending),
+		PaymentStatus: string(models.PaymentStatusPending),
+		PaymentDueAt:  &req.PaymentDueAt,
+	}
+
+	if err := s.db.Create(order).Error; err != nil {
+		// A concurrent retry may have created it first
+		if existing, lookupErr := s.getOrderByBid(req.BidID); lookupErr == nil && existing != nil {
+			return existing, nil
+		}
+		s.logger.Error("Failed to create auction order", zap.Error(err))
+		return nil, errors.ErrInternalServer
+	}
+
+	s.logger.Info("Auction order created successfully", zap.String("order_id", order.ID))
+	return order, nil
+}
+
+// GetAuctionOrders lists the orders created for an auction's winners
+func (s *OrderService) GetAuctionOrders(ctx context.Context, auctionID string) ([]*models.Order, error) {
+	var orders []*models.Order
+	if err := s.db.Where("auction_id = ?", auctionID).Order("created_at").Find(&orders).Error; err != nil {
+		s.logger.Error("Failed to get auction orders", zap.Error(err))
+		return nil, errors.ErrInternalServer
+	}
+	return orders, nil
+}
+
+// GetOrderByID looks up any order, for internal callers acting on behalf of
+// the platform rather than a user
+func (s *OrderService) GetOrderByID(ctx context.Context, orderID string) (*models.Order, error) {
+	var order models.Order
+	if err := s.db.Where("id = ?", orderID).First(&order).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		s.logger.Error("Failed to get order", zap.Error(err))
+		return nil, errors.ErrInternalServer
+	}
+	return &order, nil
+}
+
+// ExpireAuctionOrder cancels an auction order whose payment deadline passed so
+// it can no longer be paid. Orders already paid are returned unchanged, which
+// lets the caller settle a race between payment and expiry.
+func (s *OrderService) ExpireAuctionOrder(ctx context.Context, orderID string) (*models.Order, error) {
+	s.logger.Info("Expiring auction order", zap.String("order_id", orderID))
+
+	var order models.Order
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND auction_id IS NOT NULL", orderID).First(&order).Error; err != nil {
+			return err
+		}
+		if order.PaymentStatus == string(models.PaymentStatusPaid) || !s.canCancelOrder(order.Status) {
+			return nil
+		}
+		order.Status = string(models.OrderStatusCancelled)
+		order.PaymentStatus = string(models.PaymentStatusCancelled)
+		order.UpdatedAt = time.Now()
+		return tx.Save(&order).Error
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		s.logger.Error("Failed to expire auction order", zap.Error(err))
+		return nil, errors.ErrInternalServer
+	}
+	return &order, nil
+}
+
+func (s *OrderService) getOrderByBid(bidID string) (*models.Order, error) {
+	var order models.Order
+	if err := s.db.Where("bid_id = ?", bidID).First(&order).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		s.logger.Error("Failed to look up auction order", zap.Error(err))
+		return nil, errors.ErrInternalServer
+	}
+	return &order, nil
+}
+
 func (s *OrderService) GetOrder(ctx context.Context, orderID string, userID string) (*models.Order, error) {
 	s.logger.Info("Getting order", zap.String("order_id", orderID), zap.String("user_id", userID))
 
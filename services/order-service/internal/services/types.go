@@ -3,11 +3,13 @@ package services
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 type CreateOrderRequest struct {
 	AuctionID       *string        `json:"auction_id,omitempty"`
 	ProductID       string         `json:"product_id" binding:"required"`
+	VariantID       *string        `json:"variant_id,omitempty"`
 	ProductName     string         `json:"product_name" binding:"required"`
 	ProductImage    string         `json:"product_image,omitempty"`
 	Quantity        int            `json:"quantity" binding:"required,min=1"`
@@ -18,6 +20,21 @@ type CreateOrderRequest struct {
 	Notes           string         `json:"notes,omitempty"`
 }
 
+// CreateAuctionOrderRequest is sent by auction-service when an auction is won.
+// The buyer supplies addresses at checkout, so none are required here.
+type CreateAuctionOrderRequest struct {
+	AuctionID    string    `json:"auction_id" binding:"required"`
+	BidID        string    `json:"bid_id" binding:"required"`
+	UserID       string    `json:"user_id" binding:"required"`
+	ProductID    string    `json:"product_id" binding:"required"`
+	ProductName  string    `json:"product_name" binding:"required"`
+	ProductImage string    `json:"product_image,omitempty"`
+	Quantity     int       `json:"quantity" binding:"required,min=1"`
+	Price        int64     `json:"price" binding:"required,min=1"` // Hammer price per unit in cents
+	Currency     string    `json:"currency" binding:"required,len=3"`
+	PaymentDueAt time.Time `json:"payment_due_at" binding:"required"`
+}
+
 type AddressRequest struct {
 	Name        string `json:"name" binding:"required"`
 	Street      string `json:"street" binding:"required"`
@@ -36,7 +53,9 @@ type OrderResponse struct {
 	ID              string         `json:"id"`
 	UserID          string         `json:"user_id"`
 	AuctionID       *string        `json:"auction_id,omitempty"`
+	BidID           *string        `json:"bid_id,omitempty"`
 	ProductID       string         `json:"product_id"`
+	VariantID       *string        `json:"variant_id,omitempty"`
 	ProductName     string         `json:"product_name"`
 	ProductImage    string         `json:"product_image,omitempty"`
 	Quantity        int            `json:"quantity"`
@@ -49,6 +68,7 @@ type OrderResponse struct {
 	ShippingAddress AddressResponse `json:"shipping_address"`
 	BillingAddress  AddressResponse `json:"billing_address"`
 	Notes           string         `json:"notes,omitempty"`
+	PaymentDueAt    *time.Time     `json:"payment_due_at,omitempty"`
 	CreatedAt       string         `json:"created_at"`
 	UpdatedAt       string         `json:"updated_at"`
 }
@@ -88,4 +108,23 @@ func (r *CreateOrderRequest) Validate() error {
 		return fmt.Errorf("product_name is required")
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+func (r *CreateAuctionOrderRequest) Validate() error {
+	if r.Quantity <= 0 {
+		return fmt.Errorf("quantity must be greater than 0")
+	}
+	if r.Price <= 0 {
+		return fmt.Errorf("price must be greater than 0")
+	}
+	if len(r.Currency) != 3 {
+		return fmt.Errorf("currency must be 3 characters")
+	}
+	if strings.TrimSpace(r.AuctionID) == "" || strings.TrimSpace(r.BidID) == "" {
+		return fmt.Errorf("auction_id and bid_id are required")
+	}
+	if strings.TrimSpace(r.UserID) == "" {
+		return fmt.Errorf("user_id is required")
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package products
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrNotFound is returned when product-service has no such product
+var ErrNotFound = errors.New("product not found")
+
+// Client reads products from product-service's public API
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a product-service client
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// Product is the part of a product-service product order-service reads
+type Product struct {
+	ProductID string    `json:"product_id"`
+	Name      string    `json:"name"`
+	Price     int64     `json:"price"` // Price in cents
+	Status    string    `json:"status"`
+	Available int       `json:"available"`
+	Variants  []Variant `json:"variants"`
+}
+
+// Variant is one purchasable variant of a product
+type Variant struct {
+	VariantID string `json:"variant_id"`
+	SKU       string `json:"sku"`
+	Price     *int64 `json:"price"` // Price in cents; nil uses the product's price
+	Available int    `json:"available"`
+}
+
+// Variant returns the product's variant with the given ID, or nil when the
+// product has no such variant
+func (p *Product) Variant(variantID string) *Variant {
+	for i := range p.Variants {
+		if p.Variants[i].VariantID == variantID {
+			return &p.Variants[i]
+		}
+	}
+	return nil
+}
+
+// VariantPrice returns the variant's price, falling back to the product's
+func (p *Product) VariantPrice(variant *Variant) int64 {
+	if variant.Price != nil {
+		return *variant.Price
+	}
+	return p.Price
+}
+
+// GetProduct looks up a product and its variants by product ID
+func (c *Client) GetProduct(ctx context.Context, productID string) (*Product, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v1/products/"+productID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	var envelope struct {
+		Success bool `json:"success"`
+		Data    struct {
+			Product Product `json:"product"`
+		} `json:"data"`
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("product service returned status %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK || !envelope.Success {
+		return nil, fmt.Errorf("product service error (status %d): %s", resp.StatusCode, envelope.Error.Message)
+	}
+	return &envelope.Data.Product, nil
+}
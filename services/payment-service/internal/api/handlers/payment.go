@@ -279,3 +279,39 @@ func (h *PaymentHandler) mapPaymentMethodToResponse(method *models.PaymentMethod
 		Enabled:     method.Enabled,
 	}
 }
+
+// AuthorizeDeposit holds a refundable deposit on behalf of another service
+func (h *PaymentHandler) AuthorizeDeposit(c *gin.Context) {
+	var req models.AuthorizeDepositRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, errors.ErrInvalidRequestBody)
+		return
+	}
+
+	deposit, err := h.paymentService.AuthorizeDeposit(c.Request.Context(), &req)
+	if err != nil {
+		utils.ErrorResponse(c, err)
+		return
+	}
+	utils.SuccessResponse(c, deposit)
+}
+
+// ReleaseDeposit returns a held deposit to the user
+func (h *PaymentHandler) ReleaseDeposit(c *gin.Context) {
+	deposit, err := h.paymentService.ReleaseDeposit(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, err)
+		return
+	}
+	utils.SuccessResponse(c, deposit)
+}
+
+// CaptureDeposit takes a held deposit
+func (h *PaymentHandler) CaptureDeposit(c *gin.Context) {
+	deposit, err := h.paymentService.CaptureDeposit(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, err)
+		return
+	}
+	utils.SuccessResponse(c, deposit)
+}
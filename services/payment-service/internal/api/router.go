@@ -97,6 +97,15 @@ func SetupRouter(logger *zap.Logger) *gin.Engine {
 		paymentRoutes.GET("/seamless/config", paymentHandler.GetSeamlessConfig)
 	}
 
+	// Internal endpoints for other services, authenticated by service token
+	internalRoutes := router.Group("/internal/v1")
+	internalRoutes.Use(auth.GinServiceAuthMiddleware(cfg.ServiceToken))
+	{
+		internalRoutes.POST("/deposits", paymentHandler.AuthorizeDeposit)
+		internalRoutes.POST("/deposits/:id/release", paymentHandler.ReleaseDeposit)
+		internalRoutes.POST("/deposits/:id/capture", paymentHandler.CaptureDeposit)
+	}
+
 	// Public seamless config endpoint (no auth required for frontend)
 	router.GET("/api/v1/public/seamless/config", paymentHandler.GetPublicSeamlessConfig)
 
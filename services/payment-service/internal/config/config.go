@@ -19,6 +19,7 @@ type Config struct {
 	RedisURL             string
 	RedisPassword        string
 	AuthServiceURL       string
+	ServiceToken         string // Shared secret for internal service-to-service endpoints
 	JWTSecret            string
 	LogLevel             string
 	StripeSecretKey      string
@@ -45,6 +46,7 @@ func LoadConfig() *Config {
 		RedisURL:             getEnv("REDIS_URL", "redis:6379"),
 		RedisPassword:        getEnv("REDIS_PASSWORD", ""),
 		AuthServiceURL:       getEnv("AUTH_SERVICE_URL", "http://auth-service:8084"),
+		ServiceToken:         getEnv("SERVICE_TOKEN", ""),
 		JWTSecret:            getEnv("JWT_SECRET", "your-secret-key"),
 		LogLevel:             getEnv("LOG_LEVEL", "info"),
 		StripeSecretKey:      getEnv("STRIPE_SECRET_KEY", "sk_test_..."),
@@ -38,6 +38,34 @@ const (
 	PaymentStatusCancelled  PaymentStatus = "cancelled"
 )
 
+// Deposit is a refundable hold on a user's payment method. It stays
+// authorized until the caller releases it or captures it.
+type Deposit struct {
+	ID            string     `json:"id" gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID        string     `json:"user_id" gorm:"not null;uniqueIndex:deposits_user_reference_unique"`
+	Reference     string     `json:"reference" gorm:"not null;uniqueIndex:deposits_user_reference_unique"`
+	Amount        int64      `json:"amount" gorm:"not null"` // Amount in cents
+	Currency      string     `json:"currency" gorm:"not null;default:'MYR'"`
+	Status        string     `json:"status" gorm:"not null;default:'authorized'"`
+	PaymentMethod string     `json:"payment_method" gorm:"not null"`
+	Provider      string     `json:"provider" gorm:"not null"`
+	ProviderID    string     `json:"provider_id,omitempty"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	ReleasedAt    *time.Time `json:"released_at,omitempty"`
+	CapturedAt    *time.Time `json:"captured_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+type DepositStatus string
+
+const (
+	DepositStatusAuthorized DepositStatus = "authorized"
+	DepositStatusReleased   DepositStatus = "released"
+	DepositStatusCaptured   DepositStatus = "captured"
+	DepositStatusFailed     DepositStatus = "failed"
+)
+
 type PaymentMethod string
 
 const (
@@ -66,6 +94,19 @@ type ProcessPaymentRequest struct {
 	BillDesc      string `json:"bill_desc,omitempty"`
 }
 
+// AuthorizeDepositRequest is sent by other services to hold a deposit on a
+// user's behalf. Reference identifies what the deposit secures, such as an
+// auction, and repeating a request returns the existing deposit.
+type AuthorizeDepositRequest struct {
+	UserID        string `json:"user_id" binding:"required"`
+	Reference     string `json:"reference" binding:"required"`
+	Amount        int64  `json:"amount" binding:"required,min=1"`
+	Currency      string `json:"currency" binding:"required,len=3"`
+	PaymentMethod string `json:"payment_method" binding:"required"`
+	Provider      string `json:"provider" binding:"required"`
+	Token         string `json:"token" binding:"required"`
+}
+
 type RefundRequest struct {
 	Amount int64  `json:"amount" binding:"required,min=1"`
 	Reason string `json:"reason" binding:"required"`
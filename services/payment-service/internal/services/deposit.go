@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/gmsas95/blytz-mvp/services/payment-service/internal/models"
+	"github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// AuthorizeDeposit places a refundable hold for a user. A second request for
+// the same user and reference returns the deposit already held, and a failed
+// authorization can be retried.
+func (s *PaymentService) AuthorizeDeposit(ctx context.Context, req *models.AuthorizeDepositRequest) (*models.Deposit, error) {
+	s.logger.Info("Authorizing deposit",
+		zap.String("user_id", req.UserID),
+		zap.String("reference", req.Reference),
+		zap.Int64("amount", req.Amount))
+
+	var existing models.Deposit
+	err := s.db.Where("user_id = ? AND reference = ?", req.UserID, req.Reference).First(&existing).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		s.logger.Error("Failed to look up deposit", zap.Error(err))
+		return nil, errors.ErrInternalServer
+	}
+	if err == nil {
+		switch models.DepositStatus(existing.Status) {
+		case models.DepositStatusAuthorized:
+			return &existing, nil
+		case models.DepositStatusReleased, models.DepositStatusCaptured:
+			return nil, errors.ConflictError("DEPOSIT_SETTLED", "The deposit for this reference has already been settled")
+		}
+	}
+
+	// Fiuu only supports immediate sale, so holds go through the card
+	// provider integration
+	if req.Provider == "fiuu" {
+		return nil, errors.ValidationError("DEPOSIT_PROVIDER_UNSUPPORTED", "Deposits cannot be held with this provider")
+	}
+
+	deposit := &existing
+	if err == gorm.ErrRecordNotFound {
+		deposit = &models.Deposit{
+			UserID:    req.UserID,
+			Reference: req.Reference,
+		}
+	}
+	deposit.Amount = req.Amount
+	deposit.Currency = req.Currency
+	deposit.PaymentMethod = req.PaymentMethod
+	deposit.Provider = req.Provider
+	deposit.FailureReason = ""
+
+	providerID, holdErr := s.authorizeWithProvider(req)
+	if holdErr != nil {
+		deposit.Status = string(models.DepositStatusFailed)
+		deposit.FailureReason = holdErr.Error()
+		s.db.Save(deposit)
+		s.logger.Error("Deposit authorization failed", zap.Error(holdErr))
+		return nil, errors.ValidationError("DEPOSIT_DECLINED", "The deposit could not be authorized")
+	}
+	deposit.Status = string(models.DepositStatusAuthorized)
+	deposit.ProviderID = providerID
+
+	if err := s.db.Save(deposit).Error; err != nil {
+		s.logger.Error("Failed to save deposit", zap.Error(err))
+		return nil, errors.ErrInternalServer
+	}
+
+	s.logger.Info("Deposit authorized", zap.String("deposit_id", deposit.ID))
+	return deposit, nil
+}
+
+// ReleaseDeposit voids a deposit hold so the funds go back to the user.
+// Releasing an already released deposit is a no-op.
+func (s *PaymentService) ReleaseDeposit(ctx context.Context, depositID string) (*models.Deposit, error) {
+	deposit, err := s.getDeposit(depositID)
+	if err != nil {
+		return nil, err
+	}
+	switch models.DepositStatus(deposit.Status) {
+	case models.DepositStatusReleased:
+		return deposit, nil
+	case models.DepositStatusAuthorized:
+	default:
+		return nil, errors.ConflictError("DEPOSIT_NOT_HELD", fmt.Sprintf("Deposit cannot be released in status %s", deposit.Status))
+	}
+
+	if err := s.voidWithProvider(deposit.ProviderID, deposit.Amount); err != nil {
+		s.logger.Error("Deposit release failed", zap.String("deposit_id", depositID), zap.Error(err))
+		return nil, errors.ErrInternalServer
+	}
+
+	now := time.Now()
+	deposit.Status = string(models.DepositStatusReleased)
+	deposit.ReleasedAt = &now
+	if err := s.db.Save(deposit).Error; err != nil {
+		s.logger.Error("Failed to update deposit after release", zap.Error(err))
+		return nil, errors.ErrInternalServer
+	}
+
+	s.logger.Info("Deposit released", zap.String("deposit_id", depositID))
+	return deposit, nil
+}
+
+// CaptureDeposit takes a held deposit, for example when the user defaults on
+// what it secured. Capturing an already captured deposit is a no-op.
+func (s *PaymentService) CaptureDeposit(ctx context.Context, depositID string) (*models.Deposit, error) {
+	deposit, err := s.getDeposit(depositID)
+	if err != nil {
+		return nil, err
+	}
+	switch models.DepositStatus(deposit.Status) {
+	case models.DepositStatusCaptured:
+		return deposit, nil
+	case models.DepositStatusAuthorized:
+	default:
+		return nil, errors.ConflictError("DEPOSIT_NOT_HELD", fmt.Sprintf("Deposit cannot be captured in status %s", deposit.Status))
+	}
+
+	if err := s.captureWithProvider(deposit.ProviderID, deposit.Amount); err != nil {
+		s.logger.Error("Deposit capture failed", zap.String("deposit_id", depositID), zap.Error(err))
+		return nil, errors.ErrInternalServer
+	}
+
+	now := time.Now()
+	deposit.Status = string(models.DepositStatusCaptured)
+	deposit.CapturedAt = &now
+	if err := s.db.Save(deposit).Error; err != nil {
+		s.logger.Error("Failed to update deposit after capture", zap.Error(err))
+		return nil, errors.ErrInternalServer
+	}
+
+	s.logger.Info("Deposit captured", zap.String("deposit_id", depositID))
+	return deposit, nil
+}
+
+func (s *PaymentService) getDeposit(depositID string) (*models.Deposit, error) {
+	var deposit models.Deposit
+	if err := s.db.Where("id = ?", depositID).First(&deposit).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrNotFound
+		}
+		s.logger.Error("Failed to get deposit", zap.Error(err))
+		return nil, errors.ErrInternalServer
+	}
+	return &deposit, nil
+}
+
+func (s *PaymentService) authorizeWithProvider(req *models.AuthorizeDepositRequest) (string, error) {
+	// Mock authorization - in real implementation, this would place an
+	// authorization-only hold with the card provider
+	s.logger.Info("Authorizing hold with provider", zap.String("provider", req.Provider))
+
+	return fmt.Sprintf("%s_hold_%d", req.Provider, time.Now().UnixNano()), nil
+}
+
+func (s *PaymentService) voidWithProvider(providerID string, amount int64) error {
+	// Mock void - in real implementation, this would cancel the authorization
+	s.logger.Info("Voiding hold with provider", zap.String("provider_id", providerID), zap.Int64("amount", amount))
+	return nil
+}
+
+func (s *PaymentService) captureWithProvider(providerID string, amount int64) error {
+	// Mock capture - in real implementation, this would capture the authorization
+	s.logger.Info("Capturing hold with provider", zap.String("provider_id", providerID), zap.Int64("amount", amount))
+	return nil
+}
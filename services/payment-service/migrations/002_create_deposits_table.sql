@@ -0,0 +1,31 @@
+-- Migration: Refundable deposits held against a user's payment method
+-- Version: 002
+
+-- A deposit is authorised when it is created and later either released back
+-- to the user or captured. The caller's reference makes authorisation
+-- idempotent per user.
+CREATE TABLE IF NOT EXISTS deposits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL,
+    reference VARCHAR(255) NOT NULL,
+
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'MYR',
+
+    status VARCHAR(20) NOT NULL DEFAULT 'authorized',
+    payment_method VARCHAR(50) NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    provider_id VARCHAR(100),
+    failure_reason TEXT,
+
+    released_at TIMESTAMP WITH TIME ZONE,
+    captured_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT deposits_user_reference_unique UNIQUE(user_id, reference),
+    CONSTRAINT deposits_status_check CHECK (status IN ('authorized', 'released', 'captured', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_deposits_user_id ON deposits(user_id);
+CREATE INDEX IF NOT EXISTS idx_deposits_status ON deposits(status);
@@ -35,8 +35,8 @@ func main() {
 		logger.Fatal("Failed to connect to database", zap.Error(err))
 	}
 
-	// Auto-migrate the Product model
-	if err := db.AutoMigrate(&models.Product{}); err != nil {
+	// Auto-migrate the product models
+	if err := db.AutoMigrate(&models.Product{}, &models.ProductVariant{}); err != nil {
 		logger.Fatal("Failed to migrate database", zap.Error(err))
 	}
 
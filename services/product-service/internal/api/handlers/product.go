@@ -269,6 +269,91 @@ func (h *ProductHandler) UpdateInventory(c *gin.Context) {
 	utils.SuccessResponse(c, gin.H{"message": "Inventory updated successfully"})
 }
 
+// AddVariant handles adding a variant to a product
+func (h *ProductHandler) AddVariant(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.ErrorResponse(c, errors.ErrUnauthorized)
+		return
+	}
+
+	productID := c.Param("id")
+	if productID == "" {
+		utils.ErrorResponse(c, errors.ErrInvalidRequest)
+		return
+	}
+
+	var req models.VariantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, errors.ErrInvalidRequestBody)
+		return
+	}
+
+	variant, err := h.productService.AddVariant(c.Request.Context(), userID, productID, &req)
+	if err != nil {
+		h.logger.Error("Failed to add variant", zap.String("product_id", productID), zap.Error(err))
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, variant)
+}
+
+// UpdateVariant handles variant updates
+func (h *ProductHandler) UpdateVariant(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.ErrorResponse(c, errors.ErrUnauthorized)
+		return
+	}
+
+	productID := c.Param("id")
+	variantID := c.Param("variantId")
+	if productID == "" || variantID == "" {
+		utils.ErrorResponse(c, errors.ErrInvalidRequest)
+		return
+	}
+
+	var req models.UpdateVariantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, errors.ErrInvalidRequestBody)
+		return
+	}
+
+	variant, err := h.productService.UpdateVariant(c.Request.Context(), userID, productID, variantID, &req)
+	if err != nil {
+		h.logger.Error("Failed to update variant", zap.String("product_id", productID), zap.String("variant_id", variantID), zap.Error(err))
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, variant)
+}
+
+// DeleteVariant handles variant deletion
+func (h *ProductHandler) DeleteVariant(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		utils.ErrorResponse(c, errors.ErrUnauthorized)
+		return
+	}
+
+	productID := c.Param("id")
+	variantID := c.Param("variantId")
+	if productID == "" || variantID == "" {
+		utils.ErrorResponse(c, errors.ErrInvalidRequest)
+		return
+	}
+
+	if err := h.productService.DeleteVariant(c.Request.Context(), userID, productID, variantID); err != nil {
+		h.logger.Error("Failed to delete variant", zap.String("product_id", productID), zap.String("variant_id", variantID), zap.Error(err))
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, gin.H{"message": "Variant deleted successfully"})
+}
+
 // GetMyProducts handles getting products for the authenticated user
 func (h *ProductHandler) GetMyProducts(c *gin.Context) {
 	userID := c.GetString("userID")
@@ -74,6 +74,9 @@ func SetupRouter(db *gorm.DB, logger *zap.Logger, cfg *config.Config) *gin.Engin
 			protected.PUT("/:id", productHandler.UpdateProduct)
 			protected.DELETE("/:id", productHandler.DeleteProduct)
 			protected.PUT("/:id/inventory", productHandler.UpdateInventory)
+			protected.POST("/:id/variants", productHandler.AddVariant)
+			protected.PUT("/:id/variants/:variantId", productHandler.UpdateVariant)
+			protected.DELETE("/:id/variants/:variantId", productHandler.DeleteVariant)
 			protected.GET("/my", productHandler.GetMyProducts)
 		}
 	}
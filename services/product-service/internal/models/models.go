@@ -47,6 +47,10 @@ type Product struct {
 
 	// Metadata
 	Metadata string `gorm:"type:text" json:"metadata,omitempty"`
+
+	// Variants
+	OptionAxes string           `gorm:"type:text" json:"option_axes"` // JSON array of option names, e.g. ["size","colour"]
+	Variants   []ProductVariant `gorm:"foreignKey:ProductID;references:ProductID" json:"variants,omitempty"`
 }
 
 // BeforeCreate hook to generate ProductID
@@ -57,8 +61,16 @@ func (p *Product) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
-// GetAvailable calculates available stock
+// GetAvailable calculates available stock. A product with variants is
+// stocked per variant, so its availability is theirs combined.
 func (p *Product) GetAvailable() int {
+	if len(p.Variants) > 0 {
+		available := 0
+		for i := range p.Variants {
+			available += p.Variants[i].GetAvailable()
+		}
+		return available
+	}
 	return p.Stock - p.Reserved
 }
 
@@ -102,6 +114,26 @@ func (p *Product) SetTagsArray(tags []string) {
 	p.Tags = string(data)
 }
 
+// GetOptionAxesArray returns the option axes as string array
+func (p *Product) GetOptionAxesArray() []string {
+	if p.OptionAxes == "" {
+		return []string{}
+	}
+	var axes []string
+	json.Unmarshal([]byte(p.OptionAxes), &axes)
+	return axes
+}
+
+// SetOptionAxesArray sets the option axes from string array
+func (p *Product) SetOptionAxesArray(axes []string) {
+	if len(axes) == 0 {
+		p.OptionAxes = ""
+		return
+	}
+	data, _ := json.Marshal(axes)
+	p.OptionAxes = string(data)
+}
+
 // ProductStatus constants
 const (
 	ProductStatusActive   = "active"
@@ -122,6 +154,10 @@ type CreateProductRequest struct {
 	Category    string   `json:"category" binding:"required"`
 	Subcategory string   `json:"subcategory"`
 	Tags        []string `json:"tags"`
+	OptionAxes  []string `json:"option_axes" binding:"max=3,dive,required,max=50"`
+
+	// Variants, when given, are stocked individually and Stock is ignored
+	Variants []VariantRequest `json:"variants" binding:"max=100,dive"`
 }
 
 // UpdateProductRequest represents a product update request
@@ -136,6 +172,7 @@ type UpdateProductRequest struct {
 	Category    string   `json:"category" binding:"omitempty"`
 	Subcategory string   `json:"subcategory"`
 	Tags        []string `json:"tags"`
+	OptionAxes  []string `json:"option_axes" binding:"omitempty,max=3,dive,required,max=50"` // only while the product has no variants
 	Status      string   `json:"status" binding:"omitempty,oneof=active draft archived sold_out"`
 	IsFeatured  bool     `json:"is_featured"`
 }
@@ -144,6 +181,9 @@ type UpdateProductRequest struct {
 type InventoryUpdateRequest struct {
 	Stock    int `json:"stock" binding:"required,min=0"`
 	Reserved int `json:"reserved" binding:"min=0"`
+
+	// VariantID updates one variant of a product with variants
+	VariantID string `json:"variant_id"`
 }
 
 // ProductResponse represents a product response
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProductVariant is one purchasable combination of a product's option axes,
+// such as a T-shirt in size M and colour red. Each variant has its own SKU
+// and stock, and its price overrides the product's when set.
+type ProductVariant struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	VariantID string `gorm:"uniqueIndex;not null" json:"variant_id"`
+	ProductID string `gorm:"not null;uniqueIndex:idx_product_variants_sku" json:"product_id"`
+	SKU       string `gorm:"not null;uniqueIndex:idx_product_variants_sku" json:"sku"`
+	Options   string `gorm:"type:text;not null" json:"options"` // JSON object of option axis to value
+	Price     *int64 `json:"price,omitempty"`                   // Price in cents; nil uses the product's price
+
+	// Inventory
+	Stock     int `gorm:"default:0" json:"stock"`
+	Reserved  int `gorm:"default:0" json:"reserved"`
+	Available int `gorm:"-" json:"available"` // Calculated field
+}
+
+// BeforeCreate hook to generate VariantID
+func (v *ProductVariant) BeforeCreate(tx *gorm.DB) error {
+	if v.VariantID == "" {
+		v.VariantID = uuid.New().String()
+	}
+	return nil
+}
+
+// GetAvailable calculates available stock
+func (v *ProductVariant) GetAvailable() int {
+	return v.Stock - v.Reserved
+}
+
+// GetPrice returns the variant's price, falling back to its product's
+func (v *ProductVariant) GetPrice(product *Product) int64 {
+	if v.Price != nil {
+		return *v.Price
+	}
+	return product.Price
+}
+
+// GetOptionsMap returns options as a map of option axis to value
+func (v *ProductVariant) GetOptionsMap() map[string]string {
+	options := map[string]string{}
+	if v.Options != "" {
+		json.Unmarshal([]byte(v.Options), &options)
+	}
+	return options
+}
+
+// SetOptionsMap sets options from a map of option axis to value
+func (v *ProductVariant) SetOptionsMap(options map[string]string) {
+	data, _ := json.Marshal(options)
+	v.Options = string(data)
+}
+
+// VariantRequest represents a variant on a product creation or variant
+// creation request. Options must give a value for each of the product's
+// option axes.
+type VariantRequest struct {
+	SKU     string            `json:"sku" binding:"required,max=100"`
+	Options map[string]string `json:"options" binding:"required"`
+	Price   *int64            `json:"price" binding:"omitempty,gt=0"`
+	Stock   int               `json:"stock" binding:"min=0"`
+}
+
+// UpdateVariantRequest represents a variant update request. A price of 0
+// removes the override so the variant sells at the product's price.
+type UpdateVariantRequest struct {
+	SKU   string `json:"sku" binding:"omitempty,max=100"`
+	Price *int64 `json:"price" binding:"omitempty,min=0"`
+	Stock *int   `json:"stock" binding:"omitempty,min=0"`
+}
@@ -49,18 +49,35 @@ func (s *ProductService) CreateProduct(ctx context.Context, userID string, req *
 	// Set tags array
 	product.SetTagsArray(req.Tags)
 
+	// Set option axes and variants, which are created along with the product
+	if err := validateOptionAxes(req.OptionAxes); err != nil {
+		return nil, err
+	}
+	product.SetOptionAxesArray(req.OptionAxes)
+	for i := range req.Variants {
+		variant, err := newVariant(product, product.Variants, &req.Variants[i])
+		if err != nil {
+			return nil, err
+		}
+		product.Variants = append(product.Variants, *variant)
+	}
+	if len(product.Variants) > 0 {
+		product.Stock = 0
+	}
+
 	if err := s.db.WithContext(ctx).Create(product).Error; err != nil {
 		s.logger.Error("Failed to create product", zap.Error(err))
 		return nil, shared_errors.ErrInternalServer
 	}
 
+	fillAvailable(product)
 	return product, nil
 }
 
 // GetProduct retrieves a product by ID
 func (s *ProductService) GetProduct(ctx context.Context, productID string) (*models.Product, error) {
 	var product models.Product
-	if err := s.db.WithContext(ctx).Where("product_id = ?", productID).First(&product).Error; err != nil {
+	if err := s.db.WithContext(ctx).Preload("Variants", orderVariants).Where("product_id = ?", productID).First(&product).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, shared_errors.ErrNotFound
 		}
@@ -68,6 +85,7 @@ func (s *ProductService) GetProduct(ctx context.Context, productID string) (*mod
 		return nil, shared_errors.ErrInternalServer
 	}
 
+	fillAvailable(&product)
 	return &product, nil
 }
 
@@ -120,14 +138,14 @@ func (s *ProductService) GetProducts(ctx context.Context, filter *models.Product
 
 	// Apply pagination
 	offset := (page - 1) * pageSize
-	if err := query.Offset(offset).Limit(pageSize).Order("created_at DESC").Find(&products).Error; err != nil {
+	if err := query.Preload("Variants", orderVariants).Offset(offset).Limit(pageSize).Order("created_at DESC").Find(&products).Error; err != nil {
 		s.logger.Error("Failed to get products", zap.Error(err))
 		return nil, shared_errors.ErrInternalServer
 	}
 
 	// Calculate available stock for each product
 	for i := range products {
-		products[i].Available = products[i].GetAvailable()
+		fillAvailable(&products[i])
 	}
 
 	totalPages := int(total)/pageSize + 1
@@ -153,6 +171,7 @@ func (s *ProductService) GetFeaturedProducts(ctx context.Context, limit int) ([]
 	}
 
 	if err := s.db.WithContext(ctx).
+		Preload("Variants", orderVariants).
 		Where("is_featured = ? AND is_active = ? AND status = ?", true, true, models.ProductStatusActive).
 		Limit(limit).
 		Order("created_at DESC").
@@ -163,7 +182,7 @@ func (s *ProductService) GetFeaturedProducts(ctx context.Context, limit int) ([]
 
 	// Calculate available stock for each product
 	for i := range products {
-		products[i].Available = products[i].GetAvailable()
+		fillAvailable(&products[i])
 	}
 
 	return products, nil
@@ -174,7 +193,7 @@ func (s *ProductService) UpdateProduct(ctx context.Context, userID string, produ
 	var product models.Product
 
 	// First, get the product and verify ownership
-	if err := s.db.WithContext(ctx).Where("product_id = ? AND seller_id = ?", productID, userID).First(&product).Error; err != nil {
+	if err := s.db.WithContext(ctx).Preload("Variants", orderVariants).Where("product_id = ? AND seller_id = ?", productID, userID).First(&product).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, shared_errors.ErrNotFound
 		}
@@ -213,6 +232,16 @@ func (s *ProductService) UpdateProduct(ctx context.Context, userID string, produ
 	if req.Tags != nil {
 		product.SetTagsArray(req.Tags)
 	}
+	if req.OptionAxes != nil {
+		// Existing variants are keyed on the current axes
+		if len(product.Variants) > 0 {
+			return nil, shared_errors.ConflictError("PRODUCT_HAS_VARIANTS", "Option axes cannot change once a product has variants")
+		}
+		if err := validateOptionAxes(req.OptionAxes); err != nil {
+			return nil, err
+		}
+		product.SetOptionAxesArray(req.OptionAxes)
+	}
 	if req.Status != "" {
 		product.Status = req.Status
 	}
@@ -220,32 +249,43 @@ func (s *ProductService) UpdateProduct(ctx context.Context, userID string, produ
 
 	product.UpdatedAt = time.Now()
 
-	if err := s.db.WithContext(ctx).Save(&product).Error; err != nil {
+	if err := s.db.WithContext(ctx).Omit("Variants").Save(&product).Error; err != nil {
 		s.logger.Error("Failed to update product", zap.String("product_id", productID), zap.Error(err))
 		return nil, shared_errors.ErrInternalServer
 	}
 
+	fillAvailable(&product)
 	return &product, nil
 }
 
-// DeleteProduct soft deletes a product
+// DeleteProduct soft deletes a product and deletes its variants, which
+// can't be sold without it
 func (s *ProductService) DeleteProduct(ctx context.Context, userID string, productID string) error {
-	result := s.db.WithContext(ctx).Where("product_id = ? AND seller_id = ?", productID, userID).Delete(&models.Product{})
-	if result.Error != nil {
-		s.logger.Error("Failed to delete product", zap.String("product_id", productID), zap.Error(result.Error))
-		return shared_errors.ErrInternalServer
-	}
-	if result.RowsAffected == 0 {
-		return shared_errors.ErrNotFound
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("product_id = ? AND seller_id = ?", productID, userID).Delete(&models.Product{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Where("product_id = ?", productID).Delete(&models.ProductVariant{}).Error
+	})
+	if err != nil {
+		return s.variantError("Failed to delete product", productID, err)
 	}
 
 	return nil
 }
 
-// UpdateInventory updates product inventory
+// UpdateInventory updates product inventory, or one variant's for a product
+// with variants
 func (s *ProductService) UpdateInventory(ctx context.Context, productID string, req *models.InventoryUpdateRequest) error {
-	result := s.db.WithContext(ctx).Model(&models.Product{}).
-		Where("product_id = ?", productID).
+	query, err := s.stockQuery(ctx, productID, req.VariantID)
+	if err != nil {
+		return err
+	}
+	result := query.
 		Updates(map[string]interface{}{
 			"stock":      req.Stock,
 			"reserved":   req.Reserved,
@@ -273,10 +313,15 @@ func (s *ProductService) GetProductsBySeller(ctx context.Context, sellerID strin
 	return s.GetProducts(ctx, filter, page, pageSize)
 }
 
-// ReserveStock reserves stock for a product (used by order service)
-func (s *ProductService) ReserveStock(ctx context.Context, productID string, quantity int) error {
-	result := s.db.WithContext(ctx).Model(&models.Product{}).
-		Where("product_id = ? AND stock - reserved >= ?", productID, quantity).
+// ReserveStock reserves stock for a product (used by order service). Products
+// with variants are stocked per variant, so variantID names the one to reserve.
+func (s *ProductService) ReserveStock(ctx context.Context, productID, variantID string, quantity int) error {
+	query, err := s.stockQuery(ctx, productID, variantID)
+	if err != nil {
+		return err
+	}
+	result := query.
+		Where("stock - reserved >= ?", quantity).
 		Update("reserved", gorm.Expr("reserved + ?", quantity))
 
 	if result.Error != nil {
@@ -290,10 +335,13 @@ func (s *ProductService) ReserveStock(ctx context.Context, productID string, qua
 	return nil
 }
 
-// ReleaseStock releases reserved stock for a product
-func (s *ProductService) ReleaseStock(ctx context.Context, productID string, quantity int) error {
-	result := s.db.WithContext(ctx).Model(&models.Product{}).
-		Where("product_id = ?", productID).
+// ReleaseStock releases reserved stock for a product or one of its variants
+func (s *ProductService) ReleaseStock(ctx context.Context, productID, variantID string, quantity int) error {
+	query, err := s.stockQuery(ctx, productID, variantID)
+	if err != nil {
+		return err
+	}
+	result := query.
 		Update("reserved", gorm.Expr("GREATEST(reserved - ?, 0)", quantity))
 
 	if result.Error != nil {
@@ -307,10 +355,14 @@ func (s *ProductService) ReleaseStock(ctx context.Context, productID string, qua
 	return nil
 }
 
-// ConfirmStockDeduction confirms stock deduction after order completion
-func (s *ProductService) ConfirmStockDeduction(ctx context.Context, productID string, quantity int) error {
-	result := s.db.WithContext(ctx).Model(&models.Product{}).
-		Where("product_id = ?", productID).
+// ConfirmStockDeduction confirms stock deduction after order completion, from
+// the product or the variant that was reserved
+func (s *ProductService) ConfirmStockDeduction(ctx context.Context, productID, variantID string, quantity int) error {
+	query, err := s.stockQuery(ctx, productID, variantID)
+	if err != nil {
+		return err
+	}
+	result := query.
 		Updates(map[string]interface{}{
 			"stock":      gorm.Expr("stock - ?", quantity),
 			"reserved":   gorm.Expr("GREATEST(reserved - ?, 0)", quantity),
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/gmsas95/blytz-mvp/services/product-service/internal/config"
+	"github.com/gmsas95/blytz-mvp/services/product-service/internal/models"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// newTestService returns a ProductService on the database named by
+// PRODUCT_TEST_DATABASE_URL, skipping the test when it isn't set
+func newTestService(t *testing.T) (*ProductService, *gorm.DB) {
+	t.Helper()
+	dsn := os.Getenv("PRODUCT_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("PRODUCT_TEST_DATABASE_URL not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&models.Product{}, &models.ProductVariant{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return NewProductService(db, zap.NewNop(), &config.Config{}), db
+}
+
+// createTestProduct creates a product, with one variant per SKU when skus
+// are given, and removes it when the test ends
+func createTestProduct(t *testing.T, service *ProductService, db *gorm.DB, stock int, skus ...string) *models.Product {
+	t.Helper()
+	req := &models.CreateProductRequest{
+		Name:        "Test shirt",
+		Description: "A shirt for testing stock",
+		Price:       2000,
+		Currency:    "USD",
+		ImageURL:    "https://example.com/shirt.jpg",
+		Stock:       stock,
+		Category:    "clothing",
+	}
+	if len(skus) > 0 {
+		req.OptionAxes = []string{"size"}
+		for _, sku := range skus {
+			req.Variants = append(req.Variants, models.VariantRequest{SKU: sku, Options: map[string]string{"size": sku}, Stock: stock})
+		}
+	}
+	product, err := service.CreateProduct(context.Background(), "seller-1", req)
+	if err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Where("product_id = ?", product.ProductID).Delete(&models.ProductVariant{})
+		db.Unscoped().Where("product_id = ?", product.ProductID).Delete(&models.Product{})
+	})
+	return product
+}
+
+// stockOf reads a product's stock and reserved counts, or one variant's
+func stockOf(t *testing.T, db *gorm.DB, productID, variantID string) (int, int) {
+	t.Helper()
+	var row struct{ Stock, Reserved int }
+	query := db.Model(&models.Product{}).Where("product_id = ?", productID)
+	if variantID != "" {
+		query = db.Model(&models.ProductVariant{}).Where("variant_id = ?", variantID)
+	}
+	if err := query.Select("stock, reserved").Scan(&row).Error; err != nil {
+		t.Fatalf("read stock: %v", err)
+	}
+	return row.Stock, row.Reserved
+}
+
+func TestVariantStock(t *testing.T) {
+	service, db := newTestService(t)
+	ctx := context.Background()
+	shirt := createTestProduct(t, service, db, 5, "S", "M")
+	small, medium := shirt.Variants[0].VariantID, shirt.Variants[1].VariantID
+	plain := createTestProduct(t, service, db, 5)
+
+	if err := service.ReserveStock(ctx, shirt.ProductID, small, 3); err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	if err := service.ReserveStock(ctx, shirt.ProductID, small, 3); !errors.Is(err, shared_errors.ErrInsufficientStock) {
+		t.Errorf("over-reserving a variant: got %v, want insufficient stock", err)
+	}
+	if err := service.ConfirmStockDeduction(ctx, shirt.ProductID, small, 2); err != nil {
+		t.Fatalf("confirm: %v", err)
+	}
+	if err := service.ReleaseStock(ctx, shirt.ProductID, small, 1); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		productID    string
+		variantID    string
+		wantStock    int
+		wantReserved int
+	}{
+		{"reserved variant", shirt.ProductID, small, 3, 0},
+		{"other variant", shirt.ProductID, medium, 5, 0},
+		{"product with variants", shirt.ProductID, "", 0, 0},
+		{"product without variants", plain.ProductID, "", 5, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stock, reserved := stockOf(t, db, tt.productID, tt.variantID)
+			if stock != tt.wantStock || reserved != tt.wantReserved {
+				t.Errorf("stock %d reserved %d, want %d and %d", stock, reserved, tt.wantStock, tt.wantReserved)
+			}
+		})
+	}
+}
+
+func TestStockRequiresVariant(t *testing.T) {
+	service, db := newTestService(t)
+	ctx := context.Background()
+	shirt := createTestProduct(t, service, db, 5, "S", "M")
+	other := createTestProduct(t, service, db, 5, "L")
+
+	// A product stocked per variant can't be reserved as a whole
+	err := service.ReserveStock(ctx, shirt.ProductID, "", 1)
+	if appErr, ok := shared_errors.IsAppError(err); !ok || appErr.Code != "VARIANT_REQUIRED" {
+		t.Errorf("reserving without a variant: got %v, want VARIANT_REQUIRED", err)
+	}
+	// Nor through another product's variant
+	if err := service.ReserveStock(ctx, shirt.ProductID, other.Variants[0].VariantID, 1); !errors.Is(err, shared_errors.ErrInsufficientStock) {
+		t.Errorf("reserving another product's variant: got %v", err)
+	}
+	if _, reserved := stockOf(t, db, other.ProductID, other.Variants[0].VariantID); reserved != 0 {
+		t.Errorf("another product's variant has %d reserved", reserved)
+	}
+}
+
+func TestDeleteProductDeletesVariants(t *testing.T) {
+	service, db := newTestService(t)
+	shirt := createTestProduct(t, service, db, 5, "S", "M")
+
+	if err := service.DeleteProduct(context.Background(), "seller-1", shirt.ProductID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	var variants int64
+	if err := db.Model(&models.ProductVariant{}).Where("product_id = ?", shirt.ProductID).Count(&variants).Error; err != nil {
+		t.Fatalf("count variants: %v", err)
+	}
+	if variants != 0 {
+		t.Errorf("%d variants left after deleting their product", variants)
+	}
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/gmsas95/blytz-mvp/services/product-service/internal/models"
+	shared_errors "github.com/gmsas95/blytz-mvp/shared/pkg/errors"
+)
+
+// errStockReserved aborts adding the first variant to a product whose own
+// stock is still reserved by orders
+var errStockReserved = shared_errors.ConflictError("STOCK_RESERVED", "Variants cannot be added while the product's own stock is reserved")
+
+// orderVariants preloads variants in the order they were added
+func orderVariants(db *gorm.DB) *gorm.DB {
+	return db.Order("id")
+}
+
+// fillAvailable calculates available stock for a product and its variants
+func fillAvailable(product *models.Product) {
+	for i := range product.Variants {
+		product.Variants[i].Available = product.Variants[i].GetAvailable()
+	}
+	product.Available = product.GetAvailable()
+}
+
+// validateOptionAxes checks a product's option axes are distinct
+func validateOptionAxes(axes []string) error {
+	seen := make(map[string]bool, len(axes))
+	for _, axis := range axes {
+		if strings.TrimSpace(axis) == "" || seen[axis] {
+			return shared_errors.ValidationError("INVALID_OPTION_AXES", "Option axes must be distinct and non-empty")
+		}
+		seen[axis] = true
+	}
+	return nil
+}
+
+// newVariant builds a variant of product from a request, checking it sets
+// every option axis and does not repeat the SKU or options of an existing
+// variant
+func newVariant(product *models.Product, existing []models.ProductVariant, req *models.VariantRequest) (*models.ProductVariant, error) {
+	axes := product.GetOptionAxesArray()
+	if len(axes) == 0 {
+		return nil, shared_errors.ValidationError("OPTION_AXES_REQUIRED", "Set the product's option_axes before adding variants")
+	}
+	if len(req.Options) != len(axes) {
+		return nil, invalidVariantOptions(axes)
+	}
+	for _, axis := range axes {
+		if strings.TrimSpace(req.Options[axis]) == "" {
+			return nil, invalidVariantOptions(axes)
+		}
+	}
+
+	for i := range existing {
+		if existing[i].SKU == req.SKU {
+			return nil, duplicateSKU(req.SKU)
+		}
+		if maps.Equal(existing[i].GetOptionsMap(), req.Options) {
+			return nil, shared_errors.ConflictError("DUPLICATE_VARIANT", "The product already has a variant with these options")
+		}
+	}
+
+	variant := &models.ProductVariant{
+		ProductID: product.ProductID,
+		SKU:       req.SKU,
+		Price:     req.Price,
+		Stock:     req.Stock,
+	}
+	variant.SetOptionsMap(req.Options)
+	return variant, nil
+}
+
+func invalidVariantOptions(axes []string) error {
+	return shared_errors.ValidationError("INVALID_VARIANT_OPTIONS", fmt.Sprintf("Variant options must give exactly one value for each of: %s", strings.Join(axes, ", ")))
+}
+
+func duplicateSKU(sku string) error {
+	return shared_errors.ConflictError("DUPLICATE_SKU", fmt.Sprintf("The product already has a variant with SKU %s", sku))
+}
+
+// hasVariants reports whether a product is stocked per variant
+func (s *ProductService) hasVariants(ctx context.Context, productID string) (bool, error) {
+	var variants int64
+	if err := s.db.WithContext(ctx).Model(&models.ProductVariant{}).Where("product_id = ?", productID).Count(&variants).Error; err != nil {
+		s.logger.Error("Failed to count product variants", zap.String("product_id", productID), zap.Error(err))
+		return false, shared_errors.ErrInternalServer
+	}
+	return variants > 0, nil
+}
+
+// stockQuery scopes a stock update to the row that holds the stock: the
+// named variant, or the product itself when it has no variants
+func (s *ProductService) stockQuery(ctx context.Context, productID, variantID string) (*gorm.DB, error) {
+	db := s.db.WithContext(ctx)
+	if variantID != "" {
+		return db.Model(&models.ProductVariant{}).Where("product_id = ? AND variant_id = ?", productID, variantID), nil
+	}
+
+	hasVariants, err := s.hasVariants(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if hasVariants {
+		return nil, shared_errors.ValidationError("VARIANT_REQUIRED", "This product is stocked per variant, so a variant_id is required")
+	}
+	return db.Model(&models.Product{}).Where("product_id = ?", productID), nil
+}
+
+// getSellerProduct retrieves a seller's product with its variants, locking
+// it when db is a transaction so variant changes are serialised
+func getSellerProduct(db *gorm.DB, userID, productID string) (*models.Product, error) {
+	var product models.Product
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Variants", orderVariants).
+		Where("product_id = ? AND seller_id = ?", productID, userID).
+		First(&product).Error
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// AddVariant adds a variant to a seller's product. The first variant moves
+// the product to per-variant stock, so the product's own stock is dropped.
+func (s *ProductService) AddVariant(ctx context.Context, userID, productID string, req *models.VariantRequest) (*models.ProductVariant, error) {
+	var variant *models.ProductVariant
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		product, err := getSellerProduct(tx, userID, productID)
+		if err != nil {
+			return err
+		}
+		if variant, err = newVariant(product, product.Variants, req); err != nil {
+			return err
+		}
+
+		if len(product.Variants) == 0 {
+			if product.Reserved > 0 {
+				return errStockReserved
+			}
+			if err := tx.Model(product).Updates(map[string]interface{}{"stock": 0, "updated_at": time.Now()}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(variant).Error
+	})
+	if err != nil {
+		return nil, s.variantError("Failed to add product variant", productID, err)
+	}
+
+	variant.Available = variant.GetAvailable()
+	return variant, nil
+}
+
+// UpdateVariant changes a variant's SKU, price override or stock
+func (s *ProductService) UpdateVariant(ctx context.Context, userID, productID, variantID string, req *models.UpdateVariantRequest) (*models.ProductVariant, error) {
+	var variant *models.ProductVariant
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		product, err := getSellerProduct(tx, userID, productID)
+		if err != nil {
+			return err
+		}
+		for i := range product.Variants {
+			if product.Variants[i].VariantID == variantID {
+				variant = &product.Variants[i]
+			} else if req.SKU != "" && product.Variants[i].SKU == req.SKU {
+				return duplicateSKU(req.SKU)
+			}
+		}
+		if variant == nil {
+			return gorm.ErrRecordNotFound
+		}
+
+		if req.SKU != "" {
+			variant.SKU = req.SKU
+		}
+		if req.Price != nil {
+			variant.Price = req.Price
+			if *req.Price == 0 {
+				variant.Price = nil
+			}
+		}
+		if req.Stock != nil {
+			variant.Stock = *req.Stock
+		}
+		variant.UpdatedAt = time.Now()
+		return tx.Save(variant).Error
+	})
+	if err != nil {
+		return nil, s.variantError("Failed to update product variant", productID, err)
+	}
+
+	variant.Available = variant.GetAvailable()
+	return variant, nil
+}
+
+// DeleteVariant removes a variant that has no stock reserved by orders
+func (s *ProductService) DeleteVariant(ctx context.Context, userID, productID, variantID string) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := getSellerProduct(tx, userID, productID); err != nil {
+			return err
+		}
+		var variant models.ProductVariant
+		if err := tx.Where("product_id = ? AND variant_id = ?", productID, variantID).First(&variant).Error; err != nil {
+			return err
+		}
+		if variant.Reserved > 0 {
+			return shared_errors.ConflictError("VARIANT_RESERVED", "A variant with reserved stock cannot be deleted")
+		}
+		return tx.Delete(&variant).Error
+	})
+	if err != nil {
+		return s.variantError("Failed to delete product variant", productID, err)
+	}
+	return nil
+}
+
+// variantError maps an error from a variant transaction to the one returned
+// to the seller
+func (s *ProductService) variantError(message, productID string, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return shared_errors.ErrNotFound
+	}
+	if _, ok := shared_errors.IsAppError(err); ok {
+		return err
+	}
+	s.logger.Error(message, zap.String("product_id", productID), zap.Error(err))
+	return shared_errors.ErrInternalServer
+}
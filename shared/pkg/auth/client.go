@@ -3,6 +3,7 @@ package auth
 import (
 	"bytes"
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -189,6 +190,7 @@ type UserInfo struct {
 	PhoneNumber string `json:"phone_number,omitempty"`
 	AvatarURL   string `json:"avatar_url,omitempty"`
 	Role        string `json:"role"`
+	IsVerified  bool   `json:"is_verified"`
 	CreatedAt   string `json:"created_at"`
 	UpdatedAt   string `json:"updated_at"`
 }
@@ -341,3 +343,48 @@ func ContextWithUser(ctx context.Context, userID, userEmail string) context.Cont
 	ctx = context.WithValue(ctx, "userEmail", userEmail)
 	return ctx
 }
+
+// ServiceTokenHeader carries the shared secret on service-to-service calls
+const ServiceTokenHeader = "X-Service-Token"
+
+// GinServiceAuthMiddleware guards internal endpoints that other services call
+// with a shared token rather than a user's JWT. An empty token rejects every
+// request so a missing secret never leaves the endpoints open.
+func GinServiceAuthMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided := c.GetHeader(ServiceTokenHeader)
+		if token == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "Invalid service token",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// GinRequireRole allows only users with one of the given roles. It must run
+// after GinAuthMiddleware, and looks the role up with the caller's own token.
+func GinRequireRole(authClient *AuthClient, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		user, err := authClient.GetUserInfo(c.Request.Context(), token)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "Unable to verify user role",
+			})
+			return
+		}
+
+		for _, role := range roles {
+			if user.Role == role {
+				c.Set("userRole", user.Role)
+				c.Next()
+				return
+			}
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error": "Insufficient permissions",
+		})
+	}
+}
@@ -10,14 +10,22 @@ func SendSuccessResponse(c *gin.Context, statusCode int, data interface{}) {
 	c.JSON(statusCode, gin.H{"status": "success", "data": data})
 }
 
-// SendErrorResponse sends an error response with a status code and error message
+// SendErrorResponse sends an error response with a status code and error
+// message. Application errors also carry their code and any details, so
+// clients can tell rejections apart.
 func SendErrorResponse(c *gin.Context, err error) {
 	appErr, ok := err.(*errors.AppError)
 	if !ok {
 		appErr = errors.ErrInternalServer
+		c.JSON(appErr.StatusCode, gin.H{"status": "error", "message": appErr.Message})
+		return
 	}
 
-	c.JSON(appErr.StatusCode, gin.H{"status": "error", "message": appErr.Message})
+	body := gin.H{"status": "error", "code": appErr.Code, "message": appErr.Message}
+	if len(appErr.Details) > 0 {
+		body["details"] = appErr.Details
+	}
+	c.JSON(appErr.StatusCode, body)
 }
 
 type Response struct {
@@ -70,7 +70,7 @@ func TestAuctionFlow(t *testing.T) {
 	ctx := context.Background()
 
 	// Initialize Firebase client
-	firebaseClient := firebase.NewClient(logger)
+	firebaseClient := firebase.NewClient("http://localhost:5001/demo-blytz-mvp/us-central1", logger)
 
 	t.Run("Complete Auction Lifecycle", func(t *testing.T) {
 		// Step 1: Create a user
@@ -201,7 +201,7 @@ func TestPaymentFlow(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 	ctx := context.Background()
 
-	firebaseClient := firebase.NewClient(logger)
+	firebaseClient := firebase.NewClient("http://localhost:5001/demo-blytz-mvp/us-central1", logger)
 
 	t.Run("Complete Payment Process", func(t *testing.T) {
 		// Step 1: Create payment intent
@@ -233,7 +233,7 @@ func TestErrorHandling(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 	ctx := context.Background()
 
-	firebaseClient := firebase.NewClient(logger)
+	firebaseClient := firebase.NewClient("http://localhost:5001/demo-blytz-mvp/us-central1", logger)
 
 	t.Run("Invalid Auction Creation", func(t *testing.T) {
 		// Try to create auction with invalid data
@@ -268,7 +268,7 @@ func BenchmarkAuctionCreation(b *testing.B) {
 	logger, _ := zap.NewDevelopment()
 	ctx := context.Background()
 
-	firebaseClient := firebase.NewClient(logger)
+	firebaseClient := firebase.NewClient("http://localhost:5001/demo-blytz-mvp/us-central1", logger)
 
 	auctionData := firebase.AuctionData{
 		Title:         "Benchmark Auction",